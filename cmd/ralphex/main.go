@@ -2,14 +2,16 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/jessevdk/go-flags"
 
 	"github.com/umputun/ralphex/pkg/config"
+	"github.com/umputun/ralphex/pkg/executor"
 	"github.com/umputun/ralphex/pkg/git"
 	"github.com/umputun/ralphex/pkg/input"
 	"github.com/umputun/ralphex/pkg/plan"
@@ -31,19 +34,43 @@ type opts struct {
 	Review          bool     `short:"r" long:"review" description:"skip task execution, run full review pipeline"`
 	CodexOnly       bool     `short:"c" long:"codex-only" description:"skip tasks and first review, run only codex loop"`
 	TasksOnly       bool     `short:"t" long:"tasks-only" description:"run only task phase, skip all reviews"`
+	ReviewPaths     []string `long:"review-path" description:"limit reviews to this path (repeatable)"`
 	PlanDescription string   `long:"plan" description:"create plan interactively (enter plan description)"`
 	Debug           bool     `short:"d" long:"debug" description:"enable debug logging"`
 	NoColor         bool     `long:"no-color" description:"disable color output"`
 	Version         bool     `short:"v" long:"version" description:"print version and exit"`
 	Serve           bool     `short:"s" long:"serve" description:"start web dashboard for real-time streaming"`
 	Port            int      `short:"p" long:"port" default:"8080" description:"web dashboard port"`
+	Host            string   `long:"host" description:"web dashboard bind address, e.g. :: for IPv6 (default 127.0.0.1)"`
 	Watch           []string `short:"w" long:"watch" description:"directories to watch for progress files (repeatable)"`
+	Socket          string   `long:"socket" description:"web dashboard unix socket path (used instead of --port)"`
+	Open            bool     `long:"open" description:"open the web dashboard in the default browser"`
+	AuthToken       string   `long:"auth-token" env:"RALPHEX_AUTH_TOKEN" description:"require this token to access the web dashboard"`
 	Reset           bool     `long:"reset" description:"interactively reset global config to embedded defaults"`
-
-	PlanFile string `positional-arg-name:"plan-file" description:"path to plan file (optional, uses fzf if omitted)"`
+	DryRun          bool     `long:"dry-run" description:"print prompts without running claude/codex or changing git state"`
+	ValidatePlan    bool     `long:"validate-plan" description:"lint the plan file and exit (non-zero on errors)"`
+	Force           bool     `long:"force" description:"start even if require_clean_tree is set and the working tree is dirty, overwrite the plan with plan init"`
+	Replay          string   `long:"replay" description:"re-render a finished progress file to the terminal and exit"`
+	Latest          bool     `long:"latest" description:"pick the most recently modified plan with uncompleted tasks when several qualify"`
+	Speed           float64  `long:"speed" description:"replay with original event timing sped up by this factor (0 prints at once)"`
+	LogProgress     string   `long:"log-progress" optional:"yes" optional-value:"info" description:"mirror watched sessions to stdout"`
+	Config          string   `long:"config" description:"config file used instead of .ralphex/config, merged over global config and defaults"`
+
+	PlanFile string `positional-arg-name:"plan-file" description:"path to plan file or directory of plans (optional, uses fzf if omitted)"`
+	InitPlan bool   `no-flag:"true"` // set by "plan init [path]", writes a starter plan to PlanFile instead of running one
 }
 
-var revision = "unknown"
+// build info, set with -ldflags "-X main.revision=... -X main.commit=... -X main.buildDate=..."
+var (
+	revision  = "unknown"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
+// buildInfo returns the build info of the binary, as reported by the dashboard's /api/version.
+func buildInfo() web.BuildInfo {
+	return web.BuildInfo{Version: revision, Commit: commit, BuildDate: buildDate}
+}
 
 // startupInfo holds parameters for printing startup information.
 type startupInfo struct {
@@ -64,6 +91,7 @@ type executePlanRequest struct {
 	Colors        *progress.Colors
 	Selector      *plan.Selector
 	DefaultBranch string
+	Worktree      string // git worktree path the plan runs in, empty if running in the main working tree
 }
 
 func main() {
@@ -71,7 +99,7 @@ func main() {
 
 	var o opts
 	parser := flags.NewParser(&o, flags.Default)
-	parser.Usage = "[OPTIONS] [plan-file]"
+	parser.Usage = "[OPTIONS] [plan-file]\n  ralphex [OPTIONS] plan init [path]"
 
 	args, err := parser.Parse()
 	if err != nil {
@@ -86,8 +114,10 @@ func main() {
 		os.Exit(0)
 	}
 
-	// handle positional argument
-	if len(args) > 0 {
+	// handle positional argument, "plan init [path]" scaffolds a plan instead of naming one to run
+	if path, ok := planInitArgs(args); ok {
+		o.InitPlan, o.PlanFile = true, path
+	} else if len(args) > 0 {
 		o.PlanFile = args[0]
 	}
 
@@ -119,8 +149,13 @@ func run(ctx context.Context, o opts) error {
 		}
 	}
 
+	// handle --validate-plan, lints the plan file without touching config or git
+	if o.ValidatePlan {
+		return runValidatePlan(o.PlanFile, os.Stdout)
+	}
+
 	// load config first to get custom command paths
-	cfg, err := config.Load("") // empty string uses default location
+	cfg, err := loadConfig(o.Config)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -128,13 +163,42 @@ func run(ctx context.Context, o opts) error {
 	// create colors from config (all colors guaranteed populated via fallback)
 	colors := progress.NewColors(cfg.Colors)
 
+	// handle "plan init", writes a starter plan without changing the repository
+	if o.InitPlan {
+		return runPlanInit(o.PlanFile, plansDirOf(cfg.PlansDir, colors), o.Force, os.Stdout)
+	}
+
+	// handle --replay, re-renders a finished progress file without running anything
+	if o.Replay != "" {
+		return runReplay(ctx, o.Replay, o.Speed, progress.NewConsoleLogger(os.Stdout, colors, o.NoColor))
+	}
+
 	// watch-only mode: --serve with watch dirs (CLI or config) and no plan file
 	// runs web dashboard without plan execution, can run from any directory
 	if isWatchOnlyMode(o, cfg.WatchDirs) {
 		dirs := web.ResolveWatchDirs(o.Watch, cfg.WatchDirs)
 		dashboard := web.NewDashboard(web.DashboardConfig{
-			Port:   o.Port,
-			Colors: colors,
+			Port:              o.Port,
+			Host:              bindAddr(o.Host, cfg.BindAddr),
+			Colors:            colors,
+			SessionRetention:  sessionRetention(cfg.SessionRetentionDays),
+			TailPollInterval:  time.Duration(cfg.TailPollIntervalMs) * time.Millisecond,
+			ShutdownTimeout:   time.Duration(cfg.ShutdownTimeoutMs) * time.Millisecond,
+			KeepAliveInterval: time.Duration(cfg.KeepAliveIntervalMs) * time.Millisecond,
+			WatchRecursive:    cfg.WatchRecursive,
+			FollowCompleted:   cfg.FollowCompleted,
+			LazyLoadCompleted: cfg.LazyLoadCompleted,
+			ReadOnly:          cfg.ReadOnly,
+			CodexDisabled:     !cfg.CodexEnabled,
+			RateLimitPerMin:   cfg.RateLimitPerMin,
+			MaxEventTextBytes: cfg.MaxEventTextBytes,
+			AuthToken:         o.AuthToken,
+			Socket:            o.Socket,
+			ProgressLog:       progressLog(o.LogProgress),
+			ProgressLogLevel:  o.LogProgress,
+			OpenBrowser:       o.Open,
+			BuildInfo:         buildInfo(),
+			ConfigSources:     cfg.SourcePaths(),
 		})
 		if watchErr := dashboard.RunWatchOnly(ctx, dirs); watchErr != nil {
 			return fmt.Errorf("run watch-only mode: %w", watchErr)
@@ -142,9 +206,11 @@ func run(ctx context.Context, o opts) error {
 		return nil
 	}
 
-	// check dependencies using configured command (or default "claude")
-	if depErr := checkClaudeDep(cfg); depErr != nil {
-		return depErr
+	// check dependencies using configured command (or default "claude"), not needed for dry-run
+	if !o.DryRun {
+		if depErr := checkClaudeDep(cfg); depErr != nil {
+			return depErr
+		}
 	}
 
 	// require running from repo root
@@ -159,8 +225,10 @@ func run(ctx context.Context, o opts) error {
 	}
 
 	// ensure repository has commits (prompts to create initial commit if empty)
-	if ensureErr := ensureRepoHasCommits(ctx, gitSvc, os.Stdin, os.Stdout); ensureErr != nil {
-		return ensureErr
+	if !o.DryRun {
+		if ensureErr := ensureRepoHasCommits(ctx, gitSvc, os.Stdin, os.Stdout); ensureErr != nil {
+			return ensureErr
+		}
 	}
 
 	// detect default branch for prompt templates
@@ -169,7 +237,8 @@ func run(ctx context.Context, o opts) error {
 	mode := determineMode(o)
 
 	// create plan selector for use by plan selection and plan mode
-	selector := plan.NewSelector(cfg.PlansDir, colors)
+	selector := plan.NewSelector(plan.ResolvePlansDir(cfg.PlansDir, gitSvc.Root()), colors)
+	selector.Latest = o.Latest
 
 	// plan mode has different flow - doesn't require plan file selection
 	if mode == processor.ModePlan {
@@ -202,17 +271,29 @@ func run(ctx context.Context, o opts) error {
 		return fmt.Errorf("select plan: %w", err)
 	}
 
-	// setup git for execution (branch, gitignore)
-	if planFile != "" && modeRequiresBranch(mode) {
-		if err := gitSvc.CreateBranchForPlan(planFile); err != nil {
-			return fmt.Errorf("create branch for plan: %w", err)
+	// setup git for execution (branch or worktree, gitignore), skipped in dry-run to leave the repo untouched
+	mainGitSvc, worktree := gitSvc, ""
+	if !o.DryRun {
+		if cfg.RequireCleanTree && !o.Force {
+			if err := ensureCleanTree(gitSvc, planFile); err != nil {
+				return err
+			}
+		}
+		if planFile != "" && modeRequiresBranch(mode) {
+			if cfg.UseWorktree {
+				if gitSvc, planFile, worktree, err = enterWorktree(gitSvc, planFile, colors); err != nil {
+					return err
+				}
+			} else if err := gitSvc.CreateBranchForPlan(planFile); err != nil {
+				return fmt.Errorf("create branch for plan: %w", err)
+			}
+		}
+		if err := gitSvc.EnsureIgnored("progress*.txt", "progress-test.txt"); err != nil {
+			return fmt.Errorf("ensure gitignore: %w", err)
 		}
-	}
-	if err := gitSvc.EnsureIgnored("progress*.txt", "progress-test.txt"); err != nil {
-		return fmt.Errorf("ensure gitignore: %w", err)
 	}
 
-	return executePlan(ctx, o, executePlanRequest{
+	err = executePlan(ctx, o, executePlanRequest{
 		PlanFile:      planFile,
 		Mode:          mode,
 		GitSvc:        gitSvc,
@@ -220,7 +301,114 @@ func run(ctx context.Context, o opts) error {
 		Colors:        colors,
 		Selector:      selector,
 		DefaultBranch: defaultBranch,
+		Worktree:      worktree,
 	})
+	if worktree != "" {
+		leaveWorktree(mainGitSvc, worktree, errors.Is(err, context.Canceled) && cfg.WorktreePruneOnCancel, colors)
+	}
+	return err
+}
+
+// ensureCleanTree returns an error listing the uncommitted files if the working tree is dirty.
+// the plan file is not counted, ralphex commits it when creating the plan branch.
+func ensureCleanTree(gitSvc *git.Service, planFile string) error {
+	clean, err := gitSvc.IsClean()
+	if err != nil {
+		return fmt.Errorf("check working tree: %w", err)
+	}
+	if clean {
+		return nil
+	}
+
+	files, err := gitSvc.ChangedFiles()
+	if err != nil {
+		return fmt.Errorf("check working tree: %w", err)
+	}
+	relPlan := ""
+	if planFile != "" {
+		if absPlan, absErr := filepath.Abs(planFile); absErr == nil {
+			relPlan, _ = filepath.Rel(gitSvc.Root(), absPlan)
+		}
+	}
+	files = slices.DeleteFunc(files, func(f string) bool { return f == relPlan })
+	if len(files) == 0 {
+		return nil
+	}
+
+	args := ""
+	if planFile != "" {
+		args = " " + planFile
+	}
+	return fmt.Errorf("working tree has uncommitted changes and require_clean_tree is set:\n  %s\n\n"+
+		"options:\n"+
+		"  git stash && ralphex%s && git stash pop   # stash changes temporarily\n"+
+		"  git commit -am \"wip\"                      # commit changes first\n"+
+		"  ralphex --force%s                        # start anyway",
+		strings.Join(files, "\n  "), args, args)
+}
+
+// worktreePath returns the directory for the plan branch worktree, <repo>-worktrees/<branch> next to the repository.
+func worktreePath(repoRoot, branch string) string {
+	return filepath.Join(filepath.Dir(repoRoot), filepath.Base(repoRoot)+"-worktrees", branch)
+}
+
+// enterWorktree creates (or reuses) a worktree for the plan branch and switches the process into it,
+// so executors, progress log and git operations all work there while the main working tree stays untouched.
+// the plan file is copied into the worktree if the branch doesn't have it yet.
+// returns git service for the worktree, plan path relative to the worktree root and the worktree path.
+func enterWorktree(gitSvc *git.Service, planFile string, colors *progress.Colors) (*git.Service, string, string, error) {
+	absPlan, err := filepath.Abs(planFile)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("resolve plan path: %w", err)
+	}
+	relPlan, err := filepath.Rel(gitSvc.Root(), absPlan)
+	if err != nil || strings.HasPrefix(relPlan, "..") {
+		return nil, "", "", fmt.Errorf("plan file %s must be inside the repository to use a worktree", planFile)
+	}
+
+	wtPath := worktreePath(gitSvc.Root(), plan.ExtractBranchName(planFile))
+	if err = gitSvc.AddWorktree(plan.ExtractBranchName(planFile), wtPath); err != nil {
+		return nil, "", "", fmt.Errorf("create worktree: %w", err)
+	}
+
+	wtPlan := filepath.Join(wtPath, relPlan)
+	if _, statErr := os.Stat(wtPlan); os.IsNotExist(statErr) {
+		data, readErr := os.ReadFile(absPlan) //nolint:gosec // plan file selected by user
+		if readErr != nil {
+			return nil, "", "", fmt.Errorf("read plan file: %w", readErr)
+		}
+		if mkErr := os.MkdirAll(filepath.Dir(wtPlan), 0o750); mkErr != nil {
+			return nil, "", "", fmt.Errorf("create plan dir in worktree: %w", mkErr)
+		}
+		if writeErr := os.WriteFile(wtPlan, data, 0o600); writeErr != nil {
+			return nil, "", "", fmt.Errorf("copy plan to worktree: %w", writeErr)
+		}
+	}
+
+	if err = os.Chdir(wtPath); err != nil {
+		return nil, "", "", fmt.Errorf("enter worktree: %w", err)
+	}
+	wtSvc, err := git.NewService(".", colors.Info())
+	if err != nil {
+		return nil, "", "", fmt.Errorf("open worktree repo: %w", err)
+	}
+	return wtSvc, relPlan, wtPath, nil
+}
+
+// leaveWorktree switches back to the main working tree after a worktree run.
+// the worktree is removed if prune is set (canceled run), otherwise it's left in place for review.
+func leaveWorktree(mainGitSvc *git.Service, worktree string, prune bool, colors *progress.Colors) {
+	if err := os.Chdir(mainGitSvc.Root()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to leave worktree: %v\n", err)
+		return
+	}
+	if !prune {
+		colors.Info().Printf("worktree left for review: %s\n", worktree)
+		return
+	}
+	if err := mainGitSvc.RemoveWorktree(worktree); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to remove worktree: %v\n", err)
+	}
 }
 
 // getCurrentBranch returns the current git branch name or "unknown" if unavailable.
@@ -258,13 +446,21 @@ func tryAutoPlanMode(ctx context.Context, err error, o opts, req executePlanRequ
 // handles progress logging, web dashboard, runner execution, and post-execution tasks.
 func executePlan(ctx context.Context, o opts, req executePlanRequest) error {
 	branch := getCurrentBranch(req.GitSvc)
+	startCommit, _ := req.GitSvc.HeadCommit() // recorded for session diffs, empty if unavailable
 
 	// create progress logger
 	baseLog, err := progress.NewLogger(progress.Config{
-		PlanFile: req.PlanFile,
-		Mode:     string(req.Mode),
-		Branch:   branch,
-		NoColor:  o.NoColor,
+		PlanFile:          req.PlanFile,
+		Mode:              string(req.Mode),
+		Branch:            branch,
+		Worktree:          req.Worktree,
+		StartCommit:       startCommit,
+		NoColor:           o.NoColor,
+		Format:            req.Config.ProgressFormat,
+		NameTemplate:      req.Config.ProgressNameTemplate,
+		MaxSizeMB:         req.Config.MaxProgressSizeMB,
+		CompressCompleted: req.Config.CompressCompleted,
+		TimestampFormat:   req.Config.TimestampFormat,
 	}, req.Colors)
 	if err != nil {
 		return fmt.Errorf("create progress logger: %w", err)
@@ -283,13 +479,32 @@ func executePlan(ctx context.Context, o opts, req executePlanRequest) error {
 	var runnerLog processor.Logger = baseLog
 	if o.Serve {
 		dashboard := web.NewDashboard(web.DashboardConfig{
-			BaseLog:         baseLog,
-			Port:            o.Port,
-			PlanFile:        req.PlanFile,
-			Branch:          branch,
-			WatchDirs:       o.Watch,
-			ConfigWatchDirs: req.Config.WatchDirs,
-			Colors:          req.Colors,
+			BaseLog:           baseLog,
+			Port:              o.Port,
+			Host:              bindAddr(o.Host, req.Config.BindAddr),
+			PlanFile:          req.PlanFile,
+			Branch:            branch,
+			WatchDirs:         o.Watch,
+			ConfigWatchDirs:   req.Config.WatchDirs,
+			Colors:            req.Colors,
+			SessionRetention:  sessionRetention(req.Config.SessionRetentionDays),
+			TailPollInterval:  time.Duration(req.Config.TailPollIntervalMs) * time.Millisecond,
+			ShutdownTimeout:   time.Duration(req.Config.ShutdownTimeoutMs) * time.Millisecond,
+			KeepAliveInterval: time.Duration(req.Config.KeepAliveIntervalMs) * time.Millisecond,
+			WatchRecursive:    req.Config.WatchRecursive,
+			FollowCompleted:   req.Config.FollowCompleted,
+			LazyLoadCompleted: req.Config.LazyLoadCompleted,
+			ReadOnly:          req.Config.ReadOnly,
+			CodexDisabled:     !req.Config.CodexEnabled && req.Mode != processor.ModeCodexOnly,
+			RateLimitPerMin:   req.Config.RateLimitPerMin,
+			MaxEventTextBytes: req.Config.MaxEventTextBytes,
+			AuthToken:         o.AuthToken,
+			Socket:            o.Socket,
+			ProgressLog:       progressLog(o.LogProgress),
+			ProgressLogLevel:  o.LogProgress,
+			OpenBrowser:       o.Open,
+			BuildInfo:         buildInfo(),
+			ConfigSources:     req.Config.SourcePaths(),
 		})
 		var dashErr error
 		runnerLog, dashErr = dashboard.Start(ctx)
@@ -309,17 +524,38 @@ func executePlan(ctx context.Context, o opts, req executePlanRequest) error {
 
 	// create and run the runner
 	r := createRunner(req.Config, o, req.PlanFile, req.Mode, runnerLog, req.DefaultBranch)
-	if runErr := r.Run(ctx); runErr != nil {
+	if gate, ok := runnerLog.(processor.PauseGate); ok {
+		r.SetPauseGate(gate) // dashboard can pause the run between iterations
+	}
+	if rec, ok := runnerLog.(processor.PromptRecorder); ok {
+		r.SetPromptRecorder(rec) // dashboard shows the prompt of the current phase
+	}
+	r.SetCommitter(req.GitSvc)
+	r.SetWorkTree(req.GitSvc)
+	runErr := r.Run(ctx)
+	baseLog.SetPhaseTimings(r.PhaseTimings())
+	if req.Config.TokenUsagePattern != "" {
+		baseLog.SetTokenUsage(r.TokenUsage())
+	}
+	if runErr != nil {
+		baseLog.SetOutcome(runOutcome(runErr))
 		return fmt.Errorf("runner: %w", runErr)
 	}
 
 	// move completed plan to completed/ directory
-	if req.PlanFile != "" && modeRequiresBranch(req.Mode) {
+	if req.PlanFile != "" && modeRequiresBranch(req.Mode) && !o.DryRun {
 		if moveErr := req.GitSvc.MovePlanToCompleted(req.PlanFile); moveErr != nil {
 			fmt.Fprintf(os.Stderr, "warning: failed to move plan to completed: %v\n", moveErr)
 		}
 	}
 
+	// push the branch for review, after the plan move so its commit is included
+	if !o.DryRun {
+		if result := autoPush(req.GitSvc, req.Config, getCurrentBranch(req.GitSvc), runnerLog); result != "" {
+			baseLog.SetPushResult(result)
+		}
+	}
+
 	elapsed := baseLog.Elapsed()
 
 	// get diff stats for completion message (optional - errors logged but don't block)
@@ -342,7 +578,8 @@ func executePlan(ctx context.Context, o opts, req executePlanRequest) error {
 			fmt.Fprintf(os.Stderr, "warning: failed to close progress log: %v\n", err)
 		}
 		baseLogClosed = true
-		req.Colors.Info().Printf("web dashboard still running at http://localhost:%d (press Ctrl+C to exit)\n", o.Port)
+		req.Colors.Info().Printf("web dashboard still running at %s (press Ctrl+C to exit)\n",
+			web.DashboardURL(bindAddr(o.Host, req.Config.BindAddr), o.Port))
 		<-ctx.Done()
 	}
 
@@ -351,16 +588,18 @@ func executePlan(ctx context.Context, o opts, req executePlanRequest) error {
 
 // checkClaudeDep checks that the claude command is available in PATH.
 func checkClaudeDep(cfg *config.Config) error {
-	claudeCmd := cfg.ClaudeCommand
-	if claudeCmd == "" {
-		claudeCmd = "claude"
-	}
-	if _, err := exec.LookPath(claudeCmd); err != nil {
-		return fmt.Errorf("%s not found in PATH", claudeCmd)
+	claude := &executor.ClaudeExecutor{Command: cfg.ClaudeCommand}
+	if err := claude.CheckCommand(); err != nil {
+		return fmt.Errorf("check dependencies: %w", err)
 	}
 	return nil
 }
 
+// sessionRetention converts session_retention_days config value into a duration, 0 disables pruning.
+func sessionRetention(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
+
 // isWatchOnlyMode returns true if running in watch-only mode.
 // watch-only mode runs the web dashboard without executing any plan.
 func isWatchOnlyMode(o opts, configWatchDirs []string) bool {
@@ -394,9 +633,26 @@ func validateFlags(o opts) error {
 	if o.PlanDescription != "" && o.PlanFile != "" {
 		return errors.New("--plan flag conflicts with plan file argument; use one or the other")
 	}
+	if o.Speed < 0 {
+		return errors.New("--speed must not be negative")
+	}
+	if err := config.ValidateBindAddr(o.Host); err != nil {
+		return fmt.Errorf("invalid --host: %w", err)
+	}
+	if o.LogProgress != "" && !slices.Contains(web.ProgressLogLevels, o.LogProgress) {
+		return fmt.Errorf("invalid --log-progress %q, want one of %s", o.LogProgress, strings.Join(web.ProgressLogLevels, ", "))
+	}
 	return nil
 }
 
+// bindAddr returns the dashboard bind address, the --host flag takes precedence over the bind_addr config key.
+func bindAddr(flag, configured string) string {
+	if flag != "" {
+		return flag
+	}
+	return configured
+}
+
 // createRunner creates a processor.Runner with the given configuration.
 func createRunner(cfg *config.Config, o opts, planFile string, mode processor.Mode, log processor.Logger, defaultBranch string) *processor.Runner {
 	// --codex-only mode forces codex enabled regardless of config
@@ -405,21 +661,72 @@ func createRunner(cfg *config.Config, o opts, planFile string, mode processor.Mo
 		codexEnabled = true
 	}
 	return processor.New(processor.Config{
-		PlanFile:         planFile,
-		ProgressPath:     log.Path(),
-		Mode:             mode,
-		MaxIterations:    o.MaxIterations,
-		Debug:            o.Debug,
-		NoColor:          o.NoColor,
-		IterationDelayMs: cfg.IterationDelayMs,
-		TaskRetryCount:   cfg.TaskRetryCount,
-		CodexEnabled:     codexEnabled,
-		FinalizeEnabled:  cfg.FinalizeEnabled,
-		DefaultBranch:    defaultBranch,
-		AppConfig:        cfg,
+		PlanFile:          planFile,
+		ProgressPath:      log.Path(),
+		Mode:              mode,
+		MaxIterations:     o.MaxIterations,
+		Debug:             o.Debug,
+		NoColor:           o.NoColor,
+		IterationDelayMs:  cfg.IterationDelayMs,
+		IterationJitterMs: cfg.IterationDelayJitterMs,
+		TaskRetryCount:    cfg.TaskRetryCount,
+		StallLimit:        cfg.StallLimit,
+		TreatProseAsTask:  cfg.TreatProseAsTask,
+		CodexEnabled:      codexEnabled,
+		CodexPasses:       cfg.CodexPasses,
+		CodexMinFindings:  cfg.CodexMinFindings,
+		ReviewOrder:       cfg.ReviewOrder,
+		FinalizeEnabled:   cfg.FinalizeEnabled,
+		AutoCommit:        cfg.AutoCommit,
+		DefaultBranch:     defaultBranch,
+		DryRun:            o.DryRun,
+		ReviewPaths:       o.ReviewPaths,
+		AppConfig:         cfg,
+
+		ContinueOnReviewFailure: cfg.ContinueOnReviewFailure,
+		RetryOnCrash:            cfg.RetryOnCrash,
 	}, log)
 }
 
+// branchPusher pushes branches of successful runs, implemented by git.Service.
+type branchPusher interface {
+	IsMainBranch() (bool, error)
+	PushBranch(remote, branch string) error
+}
+
+// autoPush pushes the branch of a successful run to cfg.PushRemote if auto_push is enabled, reporting the result to log.
+// runs on the default branch or a detached HEAD are not pushed. a failed push doesn't fail the run.
+// returns the result recorded in the progress file footer, empty if auto_push is disabled.
+func autoPush(p branchPusher, cfg *config.Config, branch string, log processor.Logger) string {
+	if !cfg.AutoPush {
+		return ""
+	}
+	if branch == "" {
+		log.Print("warning: auto_push skipped, HEAD is detached")
+		return "skipped: detached HEAD"
+	}
+	if isMain, err := p.IsMainBranch(); err == nil && isMain {
+		log.Print("warning: auto_push skipped, %s is the default branch", branch)
+		return "skipped: " + branch + " is the default branch"
+	}
+
+	remote := cmp.Or(cfg.PushRemote, "origin")
+	if err := p.PushBranch(remote, branch); err != nil {
+		log.LogError(fmt.Errorf("auto_push: %w", err), true)
+		return "failed: " + err.Error()
+	}
+	log.Print("pushed branch %s to %s", branch, remote)
+	return fmt.Sprintf("pushed %s to %s", branch, remote)
+}
+
+// runOutcome maps a runner error to the outcome recorded in the progress file footer.
+func runOutcome(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return progress.OutcomeCanceled
+	}
+	return progress.OutcomeFailed
+}
+
 func printStartupInfo(info startupInfo, colors *progress.Colors) {
 	if info.Mode == processor.ModePlan {
 		colors.Info().Printf("starting interactive plan creation\n")
@@ -447,18 +754,27 @@ func printStartupInfo(info startupInfo, colors *progress.Colors) {
 // after plan creation, prompts user to continue with implementation or exit.
 func runPlanMode(ctx context.Context, o opts, req executePlanRequest) error {
 	// ensure gitignore has progress files
-	if err := req.GitSvc.EnsureIgnored("progress*.txt", "progress-test.txt"); err != nil {
-		return fmt.Errorf("ensure gitignore: %w", err)
+	if !o.DryRun {
+		if err := req.GitSvc.EnsureIgnored("progress*.txt", "progress-test.txt"); err != nil {
+			return fmt.Errorf("ensure gitignore: %w", err)
+		}
 	}
 
 	branch := getCurrentBranch(req.GitSvc)
+	startCommit, _ := req.GitSvc.HeadCommit() // empty if unavailable
 
 	// create progress logger for plan mode
 	baseLog, err := progress.NewLogger(progress.Config{
-		PlanDescription: o.PlanDescription,
-		Mode:            string(processor.ModePlan),
-		Branch:          branch,
-		NoColor:         o.NoColor,
+		PlanDescription:   o.PlanDescription,
+		Mode:              string(processor.ModePlan),
+		Branch:            branch,
+		StartCommit:       startCommit,
+		NoColor:           o.NoColor,
+		Format:            req.Config.ProgressFormat,
+		NameTemplate:      req.Config.ProgressNameTemplate,
+		MaxSizeMB:         req.Config.MaxProgressSizeMB,
+		CompressCompleted: req.Config.CompressCompleted,
+		TimestampFormat:   req.Config.TimestampFormat,
 	}, req.Colors)
 	if err != nil {
 		return fmt.Errorf("create progress logger: %w", err)
@@ -486,20 +802,28 @@ func runPlanMode(ctx context.Context, o opts, req executePlanRequest) error {
 
 	// create and configure runner
 	r := processor.New(processor.Config{
-		PlanDescription:  o.PlanDescription,
-		ProgressPath:     baseLog.Path(),
-		Mode:             processor.ModePlan,
-		MaxIterations:    o.MaxIterations,
-		Debug:            o.Debug,
-		NoColor:          o.NoColor,
-		IterationDelayMs: req.Config.IterationDelayMs,
-		DefaultBranch:    req.DefaultBranch,
-		AppConfig:        req.Config,
+		PlanDescription:   o.PlanDescription,
+		ProgressPath:      baseLog.Path(),
+		Mode:              processor.ModePlan,
+		MaxIterations:     o.MaxIterations,
+		Debug:             o.Debug,
+		NoColor:           o.NoColor,
+		IterationDelayMs:  req.Config.IterationDelayMs,
+		IterationJitterMs: req.Config.IterationDelayJitterMs,
+		DefaultBranch:     req.DefaultBranch,
+		DryRun:            o.DryRun,
+		AppConfig:         req.Config,
 	}, baseLog)
 	r.SetInputCollector(collector)
 
 	// run the plan creation loop
-	if runErr := r.Run(ctx); runErr != nil {
+	runErr := r.Run(ctx)
+	baseLog.SetPhaseTimings(r.PhaseTimings())
+	if req.Config.TokenUsagePattern != "" {
+		baseLog.SetTokenUsage(r.TokenUsage())
+	}
+	if runErr != nil {
+		baseLog.SetOutcome(runOutcome(runErr))
 		return fmt.Errorf("plan creation: %w", runErr)
 	}
 
@@ -507,19 +831,20 @@ func runPlanMode(ctx context.Context, o opts, req executePlanRequest) error {
 	planFile := req.Selector.FindRecent(startTime)
 	elapsed := baseLog.Elapsed()
 
-	// print completion message with plan file path if found
+	// print completion message with plan file path if found, recording it for automation
 	if planFile != "" {
-		relPath, relErr := filepath.Rel(".", planFile)
+		relPath, relErr := filepath.Rel(req.GitSvc.Root(), planFile)
 		if relErr != nil {
 			relPath = planFile
 		}
+		baseLog.LogPlanReady(relPath)
 		req.Colors.Info().Printf("\nplan creation completed in %s, created %s\n", elapsed, relPath)
 	} else {
 		req.Colors.Info().Printf("\nplan creation completed in %s\n", elapsed)
 	}
 
-	// if no plan file found, can't continue to implementation
-	if planFile == "" {
+	// if no plan file found, can't continue to implementation; dry-run never continues
+	if planFile == "" || o.DryRun {
 		return nil
 	}
 
@@ -557,6 +882,136 @@ func runPlanMode(ctx context.Context, o opts, req executePlanRequest) error {
 	})
 }
 
+// runValidatePlan lints the plan file and prints found issues.
+// returns error if the plan can't be read or has error-level issues.
+func runValidatePlan(planFile string, w io.Writer) error {
+	if planFile == "" {
+		return errors.New("--validate-plan requires a plan file argument")
+	}
+	issues, err := plan.ValidatePlan(planFile)
+	if err != nil {
+		return fmt.Errorf("validate plan: %w", err)
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s: %s\n", planFile, issue)
+	}
+	if plan.HasErrors(issues) {
+		return fmt.Errorf("plan %s has errors", planFile)
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "%s: ok\n", planFile)
+	}
+	return nil
+}
+
+// planInitArgs reports whether positional args are "plan init [path]", returning the path if given.
+func planInitArgs(args []string) (string, bool) {
+	if len(args) < 2 || len(args) > 3 || args[0] != "plan" || args[1] != "init" {
+		return "", false
+	}
+	if len(args) == 3 {
+		return args[2], true
+	}
+	return "", true
+}
+
+// plansDirOf resolves the configured plans directory against the git root, the same way the plan selector does.
+// outside a git repository it is resolved against the current directory.
+func plansDirOf(plansDir string, colors *progress.Colors) string {
+	gitSvc, err := git.NewService(".", colors.Info())
+	if err != nil {
+		return plan.ResolvePlansDir(plansDir, ".")
+	}
+	return plan.ResolvePlansDir(plansDir, gitSvc.Root())
+}
+
+// runPlanInit writes a starter plan to path, by default a dated new-plan.md in the resolved plans directory.
+// an existing plan is overwritten only with force.
+func runPlanInit(path, plansDir string, force bool, w io.Writer) error {
+	if path == "" {
+		path = filepath.Join(plansDir, time.Now().Format("2006-01-02")+"-new-plan.md")
+	}
+	if err := plan.WriteTemplate(path, force); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("plan %s already exists, use --force to overwrite", path)
+		}
+		return fmt.Errorf("init plan: %w", err)
+	}
+	fmt.Fprintf(w, "created plan %s, edit it and run: ralphex %s\n", path, path)
+	return nil
+}
+
+// maxReplayDelay caps the pause between replayed events, so long idle gaps don't stall the replay.
+const maxReplayDelay = 5 * time.Second
+
+// runReplay re-renders a finished progress file through the progress logger, keeping original timestamps.
+// speed > 0 waits between events for their original interval divided by speed. interrupting the replay is not an error.
+func runReplay(ctx context.Context, path string, speed float64, log *progress.Logger) error {
+	readAt := time.Now()
+	events, err := web.ReadProgressEvents(path)
+	if err != nil {
+		return fmt.Errorf("read progress file: %w", err)
+	}
+
+	var at time.Time
+	log.SetClock(func() time.Time { return at })
+	for _, e := range events {
+		ts := e.Timestamp
+		if !ts.Before(readAt) && !at.IsZero() {
+			ts = at // lines without a timestamp in the file are stamped when read, keep the previous time
+		}
+		if speed > 0 && !at.IsZero() && ts.After(at) {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(min(time.Duration(float64(ts.Sub(at))/speed), maxReplayDelay)):
+			}
+		}
+		at = ts
+		replayEvent(log, e)
+	}
+	return nil
+}
+
+// replayEvent prints a single progress event the way it was printed by the running session.
+func replayEvent(log *progress.Logger, e web.Event) {
+	log.SetPhase(e.Phase)
+	switch e.Type {
+	case web.EventTypeSection:
+		log.PrintSection(processor.Section{Label: e.Section})
+	case web.EventTypeTaskStart, web.EventTypeTaskEnd, web.EventTypeIterationStart:
+		// dashboard markers, the section event following them prints the header
+	case web.EventTypeError:
+		log.LogError(errors.New(strings.TrimPrefix(e.Text, "ERROR: ")), e.Recoverable)
+	case web.EventTypeWarn:
+		log.Warn("%s", strings.TrimPrefix(e.Text, "WARN: "))
+	case web.EventTypeHumanAction:
+		if _, action, ok := strings.Cut(e.Text, "): "); ok {
+			log.LogHumanAction(e.Actor, action)
+			return
+		}
+		log.PrintAligned(e.Text)
+	default:
+		log.PrintAligned(e.Text)
+	}
+}
+
+// progressLog returns the writer watched sessions are mirrored to, nil if mirroring is disabled.
+func progressLog(level string) io.Writer {
+	if level == "" {
+		return nil
+	}
+	return os.Stdout
+}
+
+// loadConfig loads the config from the default locations, or with the given file as the local layer.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		return config.Load("") // empty string uses default location
+	}
+	return config.LoadFile("", path) //nolint:wrapcheck // LoadFile errors name the file
+}
+
 // runReset runs the interactive config reset flow.
 func runReset() error {
 	configDir := config.DefaultConfigDir()
@@ -571,7 +1026,8 @@ func runReset() error {
 // this allows reset to work standalone (exit after reset) while also supporting
 // combined usage like "ralphex --reset docs/plans/feature.md".
 func isResetOnly(o opts) bool {
-	return o.PlanFile == "" && !o.Review && !o.CodexOnly && !o.TasksOnly && !o.Serve && o.PlanDescription == "" && len(o.Watch) == 0
+	return o.PlanFile == "" && !o.Review && !o.CodexOnly && !o.TasksOnly && !o.Serve && o.PlanDescription == "" &&
+		len(o.Watch) == 0 && o.Replay == ""
 }
 
 // ensureRepoHasCommits checks that the repository has at least one commit.
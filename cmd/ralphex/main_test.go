@@ -3,6 +3,9 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +22,7 @@ import (
 	"github.com/umputun/ralphex/pkg/git"
 	"github.com/umputun/ralphex/pkg/plan"
 	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/processor/mocks"
 	"github.com/umputun/ralphex/pkg/progress"
 )
 
@@ -121,6 +125,81 @@ func TestDetermineMode(t *testing.T) {
 	}
 }
 
+func TestRunOutcome(t *testing.T) {
+	assert.Equal(t, progress.OutcomeCanceled, runOutcome(context.Canceled))
+	assert.Equal(t, progress.OutcomeCanceled, runOutcome(fmt.Errorf("task phase: %w", context.Canceled)))
+	assert.Equal(t, progress.OutcomeFailed, runOutcome(errors.New("max iterations reached")))
+}
+
+// mockPusher records pushed branches, implements branchPusher
+type mockPusher struct {
+	isMain bool
+	err    error
+	pushed []string
+}
+
+func (m *mockPusher) IsMainBranch() (bool, error) { return m.isMain, nil }
+
+func (m *mockPusher) PushBranch(remote, branch string) error {
+	m.pushed = append(m.pushed, remote+"/"+branch)
+	return m.err
+}
+
+func TestAutoPush(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.Config
+		branch     string
+		pusher     mockPusher
+		wantPushed []string
+		wantResult string
+		wantLog    string
+		wantErr    string
+	}{
+		{name: "disabled", cfg: config.Config{PushRemote: "origin"}, branch: "feature", wantResult: ""},
+		{name: "pushes branch", cfg: config.Config{AutoPush: true, PushRemote: "upstream"}, branch: "feature",
+			wantPushed: []string{"upstream/feature"}, wantResult: "pushed feature to upstream",
+			wantLog: "pushed branch feature to upstream"},
+		{name: "default remote", cfg: config.Config{AutoPush: true}, branch: "feature",
+			wantPushed: []string{"origin/feature"}, wantResult: "pushed feature to origin"},
+		{name: "default branch", cfg: config.Config{AutoPush: true}, branch: "master", pusher: mockPusher{isMain: true},
+			wantResult: "skipped: master is the default branch", wantLog: "warning: auto_push skipped, master is the default branch"},
+		{name: "detached head", cfg: config.Config{AutoPush: true}, branch: "",
+			wantResult: "skipped: detached HEAD", wantLog: "warning: auto_push skipped, HEAD is detached"},
+		{name: "push fails", cfg: config.Config{AutoPush: true}, branch: "feature",
+			pusher:     mockPusher{err: errors.New("push branch: git push: exit status 128: rejected")},
+			wantPushed: []string{"origin/feature"}, wantResult: "failed: push branch: git push: exit status 128: rejected",
+			wantErr: "auto_push: push branch: git push: exit status 128: rejected"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs []string
+			var errs []string
+			log := &mocks.LoggerMock{
+				PrintFunc: func(format string, args ...any) { logs = append(logs, fmt.Sprintf(format, args...)) },
+				LogErrorFunc: func(err error, recoverable bool) {
+					assert.True(t, recoverable, "a failed push doesn't fail the run")
+					errs = append(errs, err.Error())
+				},
+			}
+			pusher := tc.pusher
+			result := autoPush(&pusher, &tc.cfg, tc.branch, log)
+
+			assert.Equal(t, tc.wantResult, result)
+			assert.Equal(t, tc.wantPushed, pusher.pushed)
+			if tc.wantLog != "" {
+				assert.Equal(t, []string{tc.wantLog}, logs)
+			}
+			if tc.wantErr != "" {
+				assert.Equal(t, []string{tc.wantErr}, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
 func TestIsWatchOnlyMode(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -420,6 +499,11 @@ func TestValidateFlags(t *testing.T) {
 		{name: "no_flags_is_valid", opts: opts{}, wantErr: false},
 		{name: "plan_flag_only_is_valid", opts: opts{PlanDescription: "add feature"}, wantErr: false},
 		{name: "plan_file_only_is_valid", opts: opts{PlanFile: "docs/plans/test.md"}, wantErr: false},
+		{name: "negative_speed", opts: opts{Replay: "progress.txt", Speed: -1}, wantErr: true, errMsg: "--speed must not be negative"},
+		{name: "ipv6_host_is_valid", opts: opts{Host: "::"}, wantErr: false},
+		{name: "invalid_host", opts: opts{Host: "not-an-ip"}, wantErr: true, errMsg: "invalid --host"},
+		{name: "log_progress_level_is_valid", opts: opts{LogProgress: "warn"}, wantErr: false},
+		{name: "invalid_log_progress_level", opts: opts{LogProgress: "debug"}, wantErr: true, errMsg: "invalid --log-progress"},
 		{name: "both_plan_and_planfile_conflicts", opts: opts{PlanDescription: "add feature", PlanFile: "docs/plans/test.md"}, wantErr: true, errMsg: "conflicts"},
 	}
 
@@ -436,6 +520,212 @@ func TestValidateFlags(t *testing.T) {
 	}
 }
 
+func TestBindAddr(t *testing.T) {
+	assert.Empty(t, bindAddr("", ""))
+	assert.Equal(t, "0.0.0.0", bindAddr("", "0.0.0.0"))
+	assert.Equal(t, "::1", bindAddr("::1", "0.0.0.0"), "flag takes precedence over config")
+}
+
+func TestRunValidatePlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	goodPlan := filepath.Join(tmpDir, "good.md")
+	require.NoError(t, os.WriteFile(goodPlan, []byte("# Plan\n### Task 1: setup\n- [ ] do it\n"), 0o600))
+	warnPlan := filepath.Join(tmpDir, "warn.md")
+	require.NoError(t, os.WriteFile(warnPlan, []byte("### Task 1: setup\n- [ ] one\n* [ ] two\n"), 0o600))
+	badPlan := filepath.Join(tmpDir, "bad.md")
+	require.NoError(t, os.WriteFile(badPlan, []byte("# Plan\n- [ ] no task header\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		plan    string
+		wantErr string
+		wantOut string
+	}{
+		{name: "no plan file", plan: "", wantErr: "requires a plan file"},
+		{name: "missing file", plan: filepath.Join(tmpDir, "missing.md"), wantErr: "validate plan"},
+		{name: "valid plan", plan: goodPlan, wantOut: goodPlan + ": ok"},
+		{name: "warnings only", plan: warnPlan, wantOut: `line 3: warning: "* [ ]" is not a task checkbox`},
+		{name: "errors", plan: badPlan, wantErr: "has errors", wantOut: "error: no tasks found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runValidatePlan(tc.plan, &buf)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, buf.String(), tc.wantOut)
+		})
+	}
+}
+
+func TestPlanInitArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantPath string
+		wantOK   bool
+	}{
+		{args: nil},
+		{args: []string{"docs/plans/feature.md"}},
+		{args: []string{"plan"}},
+		{args: []string{"plan", "init"}, wantOK: true},
+		{args: []string{"plan", "init", "docs/plans/feature.md"}, wantPath: "docs/plans/feature.md", wantOK: true},
+		{args: []string{"plan", "init", "a.md", "b.md"}},
+		{args: []string{"plan", "create", "a.md"}},
+	}
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			path, ok := planInitArgs(tc.args)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantPath, path)
+		})
+	}
+}
+
+func TestRunPlanInit(t *testing.T) {
+	t.Run("writes plan to given path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "feature.md")
+		var buf bytes.Buffer
+		require.NoError(t, runPlanInit(path, "docs/plans", false, &buf))
+		assert.Contains(t, buf.String(), "created plan "+path)
+
+		var out bytes.Buffer
+		require.NoError(t, runValidatePlan(path, &out))
+		assert.Contains(t, out.String(), path+": ok")
+	})
+
+	t.Run("defaults to dated plan in plans dir", func(t *testing.T) {
+		plansDir := filepath.Join(t.TempDir(), "plans")
+		var buf bytes.Buffer
+		require.NoError(t, runPlanInit("", plansDir, false, &buf))
+		want := filepath.Join(plansDir, time.Now().Format("2006-01-02")+"-new-plan.md")
+		assert.FileExists(t, want)
+		assert.Contains(t, buf.String(), want)
+	})
+
+	t.Run("existing plan needs force", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "feature.md")
+		require.NoError(t, os.WriteFile(path, []byte("mine\n"), 0o600))
+
+		err := runPlanInit(path, "docs/plans", false, io.Discard)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --force to overwrite")
+
+		require.NoError(t, runPlanInit(path, "docs/plans", true, io.Discard))
+		content, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "### Task 1:")
+	})
+}
+
+func TestPlansDirOf(t *testing.T) {
+	colors := testColors()
+
+	t.Run("relative dir resolves against git root", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		t.Chdir(dir)
+		root, err := filepath.EvalSymlinks(dir)
+		require.NoError(t, err)
+		got, err := filepath.EvalSymlinks(filepath.Dir(plansDirOf("docs", colors)))
+		require.NoError(t, err)
+		assert.Equal(t, root, got)
+	})
+
+	t.Run("absolute dir kept", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Chdir(setupTestRepo(t))
+		assert.Equal(t, dir, plansDirOf(dir, colors))
+	})
+
+	t.Run("outside git resolves against current dir", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		assert.Equal(t, filepath.Join("docs", "plans"), plansDirOf("docs/plans", colors))
+	})
+}
+
+func TestRunReplay(t *testing.T) {
+	tmpDir := t.TempDir()
+	rule := strings.Repeat("-", 60)
+	textFile := filepath.Join(tmpDir, "progress-text.txt")
+	require.NoError(t, os.WriteFile(textFile, []byte("# Ralphex Progress Log\nPlan: plan.md\nMode: full\n"+rule+"\n\n"+
+		"--- task iteration 1 ---\n[26-01-22 10:00:01] working on task\n[26-01-22 10:00:02] WARN: slow test\n"+
+		"[26-01-22 10:00:03] <<<RALPHEX:ALL_TASKS_DONE>>>\n\n--- claude review 0: all findings ---\n"+
+		"[26-01-22 10:01:00] ERROR: review failed\n[26-01-22 10:01:01] HUMAN ACTION (web): pause\n"+
+		"\n"+rule+"\nCompleted: 2026-01-22 10:02:00 (2 minutes)\n"), 0o600))
+
+	t.Run("text progress", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runReplay(context.Background(), textFile, 0, progress.NewConsoleLogger(&buf, testColors(), true)))
+
+		var lines []string
+		for line := range strings.SplitSeq(buf.String(), "\n") {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		assert.Equal(t, []string{
+			"--- task iteration 1 ---",
+			"[26-01-22 10:00:01] working on task",
+			"[26-01-22 10:00:02] WARN: slow test",
+			"[26-01-22 10:00:03] ALL_TASKS_DONE",
+			"--- claude review 0: all findings ---",
+			"[26-01-22 10:01:00] ERROR: review failed",
+			"[26-01-22 10:01:01] HUMAN ACTION (web): pause",
+			"[26-01-22 10:01:01] Completed: 2026-01-22 10:02:00 (2 minutes)",
+		}, lines)
+	})
+
+	t.Run("jsonl progress", func(t *testing.T) {
+		jsonlFile := filepath.Join(tmpDir, "progress-jsonl.txt")
+		require.NoError(t, os.WriteFile(jsonlFile, []byte(
+			`{"timestamp":"2026-01-22T10:00:00Z","type":"header","header":{"plan":"plan.md","mode":"full"}}`+"\n"+
+				`{"timestamp":"2026-01-22T10:00:01Z","phase":"review","type":"section","section":"claude review 0: all findings"}`+"\n"+
+				`{"timestamp":"2026-01-22T10:00:02Z","phase":"review","type":"output","text":"looks good"}`+"\n"+
+				`{"timestamp":"2026-01-22T10:00:03Z","phase":"review","type":"signal","signal":"REVIEW_DONE","text":"<<<RALPHEX:REVIEW_DONE>>>"}`+"\n"),
+			0o600))
+
+		var buf bytes.Buffer
+		require.NoError(t, runReplay(context.Background(), jsonlFile, 0, progress.NewConsoleLogger(&buf, testColors(), true)))
+		out := buf.String()
+		section := strings.Index(out, "--- claude review 0: all findings ---")
+		output := strings.Index(out, "looks good")
+		signal := strings.Index(out, "REVIEW_DONE")
+		require.NotEqual(t, -1, section)
+		assert.Less(t, section, output)
+		assert.Less(t, output, signal)
+		assert.NotContains(t, out, "<<<RALPHEX:")
+	})
+
+	t.Run("original timing", func(t *testing.T) {
+		var buf bytes.Buffer
+		start := time.Now()
+		// 62 seconds of recorded gaps replayed 1000 times faster
+		require.NoError(t, runReplay(context.Background(), textFile, 1000, progress.NewConsoleLogger(&buf, testColors(), true)))
+		assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+		assert.Contains(t, buf.String(), "ALL_TASKS_DONE")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var buf bytes.Buffer
+		require.NoError(t, runReplay(ctx, textFile, 1, progress.NewConsoleLogger(&buf, testColors(), true)))
+		assert.Contains(t, buf.String(), "working on task")
+		assert.NotContains(t, buf.String(), "slow test", "replay stops waiting for the next event")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := runReplay(context.Background(), filepath.Join(tmpDir, "missing.txt"), 0,
+			progress.NewConsoleLogger(&bytes.Buffer{}, testColors(), true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read progress file")
+	})
+}
+
 func TestPrintStartupInfo(t *testing.T) {
 	colors := testColors()
 
@@ -573,6 +863,40 @@ func TestEnsureRepoHasCommits(t *testing.T) {
 	})
 }
 
+func TestEnsureCleanTree(t *testing.T) {
+	t.Run("clean tree", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		gitSvc, err := git.NewService(dir, noopLogger{})
+		require.NoError(t, err)
+		require.NoError(t, ensureCleanTree(gitSvc, filepath.Join(dir, "plan.md")))
+	})
+
+	t.Run("uncommitted plan file is allowed", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		planPath := filepath.Join(dir, "docs", "plans", "feature.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(planPath), 0o750))
+		require.NoError(t, os.WriteFile(planPath, []byte("# Plan\n"), 0o600))
+
+		gitSvc, err := git.NewService(dir, noopLogger{})
+		require.NoError(t, err)
+		require.NoError(t, ensureCleanTree(gitSvc, planPath))
+	})
+
+	t.Run("dirty tree lists files", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("wip"), 0o600))
+
+		gitSvc, err := git.NewService(dir, noopLogger{})
+		require.NoError(t, err)
+		err = ensureCleanTree(gitSvc, filepath.Join(dir, "plan.md"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "working tree has uncommitted changes")
+		assert.Contains(t, err.Error(), "\n  README.md\n  notes.txt\n")
+		assert.Contains(t, err.Error(), "ralphex --force "+filepath.Join(dir, "plan.md"))
+	})
+}
+
 func TestTasksOnlyModeBranchCreation(t *testing.T) {
 	t.Run("tasks_only_creates_branch_for_plan", func(t *testing.T) {
 		skipIfClaudeNotAvailable(t)
@@ -731,6 +1055,66 @@ func TestModeRequiresBranch(t *testing.T) {
 	}
 }
 
+func TestWorktreePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("/src", "proj-worktrees", "add-auth"), worktreePath("/src/proj", "add-auth"))
+}
+
+func TestEnterWorktree(t *testing.T) {
+	t.Run("creates worktree and copies untracked plan", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		origDir, err := os.Getwd()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+		planPath := filepath.Join(dir, "docs", "plans", "add-auth.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(planPath), 0o750))
+		require.NoError(t, os.WriteFile(planPath, []byte("# Plan\n\n- [ ] task 1\n"), 0o600))
+
+		gitSvc, err := git.NewService(dir, noopLogger{})
+		require.NoError(t, err)
+
+		wtSvc, relPlan, wtPath, err := enterWorktree(gitSvc, planPath, testColors())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(wtPath)) })
+
+		assert.Equal(t, worktreePath(gitSvc.Root(), "add-auth"), wtPath)
+		assert.Equal(t, filepath.Join("docs", "plans", "add-auth.md"), relPlan)
+		assert.FileExists(t, filepath.Join(wtPath, relPlan))
+
+		branch, err := wtSvc.CurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "add-auth", branch)
+
+		// main working tree stays on its branch
+		mainBranch, err := gitSvc.CurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "master", mainBranch)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, wtPath, cwd)
+
+		leaveWorktree(gitSvc, wtPath, true, testColors())
+		assert.NoDirExists(t, wtPath)
+		cwd, err = os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, gitSvc.Root(), cwd)
+	})
+
+	t.Run("plan outside repository", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		planPath := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planPath, []byte("# Plan\n"), 0o600))
+
+		gitSvc, err := git.NewService(dir, noopLogger{})
+		require.NoError(t, err)
+
+		_, _, _, err = enterWorktree(gitSvc, planPath, testColors())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be inside the repository")
+	})
+}
+
 // setupTestRepo creates a test git repository with an initial commit.
 func setupTestRepo(t *testing.T) string {
 	t.Helper()
@@ -0,0 +1,63 @@
+// Package checkbox finds the task checkboxes of a plan file, the "- [ ]" and "- [x]" items the runner works through.
+// plan selection, plan validation and the runner all use it, so they agree on what counts as a task.
+package checkbox
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BOM is the UTF-8 byte order mark some editors and export tools put at the start of text files.
+// a plan starting with it would hide a checkbox on its first line.
+const BOM = "\ufeff"
+
+// itemRe matches a checkbox list item, capturing its indentation and check mark.
+// only "-" bullets are task checkboxes, "*" and "+" items are left alone by the runner and the dashboard.
+var itemRe = regexp.MustCompile(`^(\s*)-\s+\[([ xX])\]`)
+
+// Item is a task checkbox of a plan.
+type Item struct {
+	Line   int    // 1-based line number
+	Indent int    // leading whitespace of the line, in bytes
+	Mark   string // check mark: " " for an open item, "x" or "X" for a completed one
+	Text   string // the line without surrounding whitespace
+}
+
+// Checked reports whether the item is completed.
+func (i Item) Checked() bool {
+	return i.Mark != " "
+}
+
+// Scan returns the task checkboxes of plan content in file order.
+// a leading byte order mark and the \r of CRLF line endings are ignored,
+// checkboxes in fenced code blocks are examples, not tasks, and are skipped.
+func Scan(content string) []Item {
+	var items []Item
+	inFence := false
+	for i, line := range strings.Split(strings.TrimPrefix(content, BOM), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		m := itemRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		items = append(items, Item{Line: i + 1, Indent: len(m[1]), Mark: m[2], Text: strings.TrimSpace(line)})
+	}
+	return items
+}
+
+// HasUnchecked reports whether plan content has any open task checkbox.
+func HasUnchecked(content string) bool {
+	for _, item := range Scan(content) {
+		if !item.Checked() {
+			return true
+		}
+	}
+	return false
+}
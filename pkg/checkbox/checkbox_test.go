@@ -0,0 +1,48 @@
+package checkbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Item
+	}{
+		{name: "empty", content: "", want: nil},
+		{name: "open and completed items",
+			content: "# Plan\n- [ ] todo\n  - [x] nested done\n- [X] done\n",
+			want: []Item{
+				{Line: 2, Indent: 0, Mark: " ", Text: "- [ ] todo"},
+				{Line: 3, Indent: 2, Mark: "x", Text: "- [x] nested done"},
+				{Line: 4, Indent: 0, Mark: "X", Text: "- [X] done"},
+			}},
+		{name: "other bullets are not tasks", content: "* [ ] star\n+ [ ] plus\n-[ ] no space\n", want: nil},
+		{name: "fenced code blocks skipped",
+			content: "```\n- [ ] example\n```\n- [ ] real\n",
+			want:    []Item{{Line: 4, Mark: " ", Text: "- [ ] real"}}},
+		{name: "bom and crlf",
+			content: BOM + "- [ ] first\r\n- [x] second\r\n",
+			want: []Item{
+				{Line: 1, Mark: " ", Text: "- [ ] first"},
+				{Line: 2, Mark: "x", Text: "- [x] second"},
+			}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Scan(tc.content))
+		})
+	}
+}
+
+func TestHasUnchecked(t *testing.T) {
+	assert.True(t, HasUnchecked("- [x] done\n- [ ] todo\n"))
+	assert.True(t, HasUnchecked(BOM+"- [ ] first line"))
+	assert.False(t, HasUnchecked("- [x] done\n- [X] done\n"))
+	assert.False(t, HasUnchecked("* [ ] not a task\n```\n- [ ] example\n```\n"))
+	assert.False(t, HasUnchecked("no checkboxes"))
+}
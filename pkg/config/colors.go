@@ -4,13 +4,44 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"gopkg.in/ini.v1"
 )
 
+// colorThemes are the color presets selected with the theme key, dark matches the embedded defaults.
+var colorThemes = map[string]ColorConfig{
+	"dark": {Task: "0,255,0", Review: "0,255,255", Codex: "208,150,217", ClaudeEval: "189,214,255",
+		Warn: "255,197,109", Error: "255,0,0", Signal: "210,82,82", Timestamp: "138,138,138", Info: "180,180,180"},
+	"light": {Task: "0,135,0", Review: "0,120,150", Codex: "140,60,150", ClaudeEval: "40,90,170",
+		Warn: "175,95,0", Error: "200,0,0", Signal: "170,40,40", Timestamp: "110,110,110", Info: "80,80,80"},
+	"solarized": {Task: "133,153,0", Review: "42,161,152", Codex: "211,54,130", ClaudeEval: "38,139,210",
+		Warn: "181,137,0", Error: "220,50,47", Signal: "203,75,22", Timestamp: "88,110,117", Info: "147,161,161"},
+}
+
+// ColorThemes returns the sorted names of available color themes.
+func ColorThemes() []string {
+	return slices.Sorted(maps.Keys(colorThemes))
+}
+
+// ApplyTheme returns the colors of the selected theme preset, overridden by the colors set in c.
+// c is returned as is if no theme is selected.
+func (c ColorConfig) ApplyTheme() (ColorConfig, error) {
+	if c.Theme == "" {
+		return c, nil
+	}
+	preset, ok := colorThemes[c.Theme]
+	if !ok {
+		return ColorConfig{}, fmt.Errorf("unknown theme %q, must be one of: %s", c.Theme, strings.Join(ColorThemes(), ", "))
+	}
+	preset.mergeFrom(&c)
+	return preset, nil
+}
+
 // colorLoader implements ColorLoader with embedded filesystem fallback.
 type colorLoader struct {
 	embedFS embed.FS
@@ -45,9 +76,14 @@ func (cl *colorLoader) Load(localConfigPath, globalConfigPath string) (ColorConf
 	}
 
 	// merge: embedded → global → local (local wins)
+	// a theme takes the place of embedded colors, its preset is applied by ApplyTheme
+	user := global
+	user.mergeFrom(&local)
+	if user.Theme != "" {
+		return user, nil
+	}
 	result := embedded
-	result.mergeFrom(&global)
-	result.mergeFrom(&local)
+	result.mergeFrom(&user)
 
 	return result, nil
 }
@@ -67,7 +103,11 @@ func (cl *colorLoader) parseColorsFromFile(path string) (ColorConfig, error) {
 		return ColorConfig{}, fmt.Errorf("read config %s: %w", path, err)
 	}
 
-	return cl.parseColorsFromBytes(data)
+	cfg, err := loadConfigSource(path, data)
+	if err != nil {
+		return ColorConfig{}, err
+	}
+	return cl.parseColorsFromINI(cfg)
 }
 
 // parseColorsFromEmbedded parses colors from the embedded defaults/config file.
@@ -85,7 +125,11 @@ func (cl *colorLoader) parseColorsFromBytes(data []byte) (ColorConfig, error) {
 	if err != nil {
 		return ColorConfig{}, fmt.Errorf("parse config: %w", err)
 	}
+	return cl.parseColorsFromINI(cfg)
+}
 
+// parseColorsFromINI extracts color configuration from a parsed config.
+func (cl *colorLoader) parseColorsFromINI(cfg *ini.File) (ColorConfig, error) {
 	var colors ColorConfig
 	section := cfg.Section("")
 	colorKeys := []struct {
@@ -119,6 +163,14 @@ func (cl *colorLoader) parseColorsFromBytes(data []byte) (ColorConfig, error) {
 		*ck.field = fmt.Sprintf("%d,%d,%d", r, g, b)
 	}
 
+	if key, err := section.GetKey("theme"); err == nil {
+		theme := strings.ToLower(strings.TrimSpace(key.String()))
+		if _, ok := colorThemes[theme]; theme != "" && !ok {
+			return ColorConfig{}, fmt.Errorf("invalid theme %q, must be one of: %s", theme, strings.Join(ColorThemes(), ", "))
+		}
+		colors.Theme = theme
+	}
+
 	return colors, nil
 }
 
@@ -174,4 +226,7 @@ func (dst *ColorConfig) mergeFrom(src *ColorConfig) {
 	if src.Info != "" {
 		dst.Info = src.Info
 	}
+	if src.Theme != "" {
+		dst.Theme = src.Theme
+	}
 }
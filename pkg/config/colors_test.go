@@ -310,6 +310,7 @@ func TestColorConfig_mergeFrom(t *testing.T) {
 			Signal:     "19,20,21",
 			Timestamp:  "22,23,24",
 			Info:       "25,26,27",
+			Theme:      "light",
 		}
 		dst.mergeFrom(src)
 
@@ -322,6 +323,78 @@ func TestColorConfig_mergeFrom(t *testing.T) {
 		assert.Equal(t, "19,20,21", dst.Signal)
 		assert.Equal(t, "22,23,24", dst.Timestamp)
 		assert.Equal(t, "25,26,27", dst.Info)
+		assert.Equal(t, "light", dst.Theme)
+	})
+}
+
+func TestColorLoader_Load_Theme(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global-config")
+	localConfig := filepath.Join(tmpDir, "local-config")
+	require.NoError(t, os.WriteFile(globalConfig, []byte("theme = Solarized\ncolor_task = #ff0000\n"), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte("color_error = #0000ff\n"), 0o600))
+
+	loader := newColorLoader(defaultsFS)
+	colors, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+	assert.Equal(t, ColorConfig{Theme: "solarized", Task: "255,0,0", Error: "0,0,255"}, colors,
+		"embedded colors are not merged when a theme is selected")
+
+	t.Run("local theme overrides global", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(localConfig, []byte("theme = light\n"), 0o600))
+		colors, err := loader.Load(localConfig, globalConfig)
+		require.NoError(t, err)
+		assert.Equal(t, "light", colors.Theme)
+		assert.Equal(t, "255,0,0", colors.Task, "global color still overrides the preset")
+	})
+
+	t.Run("invalid theme", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(localConfig, []byte("theme = neon\n"), 0o600))
+		_, err := loader.Load(localConfig, globalConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid theme "neon", must be one of: dark, light, solarized`)
+	})
+}
+
+func TestColorConfig_ApplyTheme(t *testing.T) {
+	t.Run("no theme", func(t *testing.T) {
+		cfg := ColorConfig{Task: "1,2,3"}
+		got, err := cfg.ApplyTheme()
+		require.NoError(t, err)
+		assert.Equal(t, cfg, got)
+	})
+
+	t.Run("preset with overrides", func(t *testing.T) {
+		got, err := ColorConfig{Theme: "solarized", Task: "1,2,3", Info: "4,5,6"}.ApplyTheme()
+		require.NoError(t, err)
+		want := colorThemes["solarized"]
+		want.Task, want.Info, want.Theme = "1,2,3", "4,5,6", "solarized"
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("dark matches embedded defaults", func(t *testing.T) {
+		embedded, err := newColorLoader(defaultsFS).Load("", "")
+		require.NoError(t, err)
+		got, err := ColorConfig{Theme: "dark"}.ApplyTheme()
+		require.NoError(t, err)
+		got.Theme = ""
+		assert.Equal(t, embedded, got)
+	})
+
+	t.Run("all presets are complete", func(t *testing.T) {
+		for _, name := range ColorThemes() {
+			preset := colorThemes[name]
+			for _, v := range []string{preset.Task, preset.Review, preset.Codex, preset.ClaudeEval, preset.Warn,
+				preset.Error, preset.Signal, preset.Timestamp, preset.Info} {
+				assert.NotEmpty(t, v, "theme %s", name)
+			}
+		}
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		_, err := ColorConfig{Theme: "neon"}.ApplyTheme()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown theme "neon"`)
 	})
 }
 
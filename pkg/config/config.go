@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
 //go:embed defaults/config defaults/prompts/* defaults/agents/*
@@ -29,9 +30,28 @@ const (
 // *Set fields:
 //   - CodexEnabledSet: tracks if codex_enabled was explicitly set
 //   - CodexTimeoutMsSet: tracks if codex_timeout_ms was explicitly set
+//   - CodexPassesSet: tracks if codex_passes was explicitly set
 //   - IterationDelayMsSet: tracks if iteration_delay_ms was explicitly set
 //   - TaskRetryCountSet: tracks if task_retry_count was explicitly set
+//   - RetryOnCrashSet: tracks if retry_on_crash was explicitly set
 //   - FinalizeEnabledSet: tracks if finalize_enabled was explicitly set
+//   - UseWorktreeSet: tracks if use_worktree was explicitly set
+//   - WorktreePruneOnCancelSet: tracks if worktree_prune_on_cancel was explicitly set
+//   - AutoCommitSet: tracks if auto_commit was explicitly set
+//   - RequireCleanTreeSet: tracks if require_clean_tree was explicitly set
+//   - TreatProseAsTaskSet: tracks if treat_prose_as_task was explicitly set
+//   - AutoPushSet: tracks if auto_push was explicitly set
+//   - WatchRecursiveSet: tracks if watch_recursive was explicitly set
+//   - FollowCompletedSet: tracks if follow_completed was explicitly set
+//   - LazyLoadCompletedSet: tracks if lazy_load_completed was explicitly set
+//   - ReadOnlySet: tracks if read_only was explicitly set
+//   - CompressCompletedSet: tracks if compress_completed was explicitly set
+//   - ContinueOnReviewFailureSet: tracks if continue_on_review_failure was explicitly set
+//   - MaxEventTextBytesSet: tracks if max_event_text_bytes was explicitly set
+//   - MaxProgressSizeMBSet: tracks if max_progress_size_mb was explicitly set
+//   - CodexMinFindingsSet: tracks if codex_min_findings was explicitly set
+//   - StallLimitSet: tracks if stall_limit was explicitly set
+//   - SessionRetentionDaysSet: tracks if session_retention_days was explicitly set
 type Config struct {
 	ClaudeCommand string `json:"claude_command"`
 	ClaudeArgs    string `json:"claude_args"`
@@ -44,22 +64,99 @@ type Config struct {
 	CodexTimeoutMs       int    `json:"codex_timeout_ms"`
 	CodexTimeoutMsSet    bool   `json:"-"` // tracks if codex_timeout_ms was explicitly set in config
 	CodexSandbox         string `json:"codex_sandbox"`
+	CodexArgs            string `json:"codex_args"`
+	CodexPasses          int    `json:"codex_passes"`       // max codex review passes, 0 derives it from max iterations
+	CodexPassesSet       bool   `json:"-"`                  // tracks if codex_passes was explicitly set in config
+	CodexMinFindings     int    `json:"codex_min_findings"` // min file:line findings to run the codex fix loop, 0 disables
+	CodexMinFindingsSet  bool   `json:"-"`                  // tracks if codex_min_findings was explicitly set in config
+	ReviewOrder          string `json:"review_order"`       // order of review phases: claude-first (default) or codex-first
 
-	IterationDelayMs    int  `json:"iteration_delay_ms"`
-	IterationDelayMsSet bool `json:"-"` // tracks if iteration_delay_ms was explicitly set in config
-	TaskRetryCount      int  `json:"task_retry_count"`
-	TaskRetryCountSet   bool `json:"-"` // tracks if task_retry_count was explicitly set in config
+	ContinueOnReviewFailure    bool `json:"continue_on_review_failure"` // log a failed review phase and go on with the next one
+	ContinueOnReviewFailureSet bool `json:"-"`                          // tracks if continue_on_review_failure was explicitly set in config
+
+	IterationDelayMs       int  `json:"iteration_delay_ms"`
+	IterationDelayMsSet    bool `json:"-"`                         // tracks if iteration_delay_ms was explicitly set in config
+	IterationDelayJitterMs int  `json:"iteration_delay_jitter_ms"` // random +/- variation of the iteration delay, 0 disables
+	TaskRetryCount         int  `json:"task_retry_count"`
+	TaskRetryCountSet      bool `json:"-"` // tracks if task_retry_count was explicitly set in config
+
+	RetryOnCrash    bool `json:"retry_on_crash"` // retry a task iteration whose executor crashed, like a FAILED one
+	RetryOnCrashSet bool `json:"-"`              // tracks if retry_on_crash was explicitly set in config
+
+	StallLimit    int  `json:"stall_limit"` // abort after this many task iterations without progress, 0 disables
+	StallLimitSet bool `json:"-"`           // tracks if stall_limit was explicitly set in config
 
 	FinalizeEnabled    bool `json:"finalize_enabled"`
 	FinalizeEnabledSet bool `json:"-"` // tracks if finalize_enabled was explicitly set in config
 
-	PlansDir  string   `json:"plans_dir"`
-	WatchDirs []string `json:"watch_dirs"` // directories to watch for progress files
+	UseWorktree              bool `json:"use_worktree"`
+	UseWorktreeSet           bool `json:"-"` // tracks if use_worktree was explicitly set in config
+	WorktreePruneOnCancel    bool `json:"worktree_prune_on_cancel"`
+	WorktreePruneOnCancelSet bool `json:"-"` // tracks if worktree_prune_on_cancel was explicitly set in config
+
+	AutoCommit    bool `json:"auto_commit"`
+	AutoCommitSet bool `json:"-"` // tracks if auto_commit was explicitly set in config
+
+	RequireCleanTree    bool `json:"require_clean_tree"`
+	RequireCleanTreeSet bool `json:"-"` // tracks if require_clean_tree was explicitly set in config
+
+	// run a plan without task checkboxes as a single task instead of refusing it
+	TreatProseAsTask    bool `json:"treat_prose_as_task"`
+	TreatProseAsTaskSet bool `json:"-"` // tracks if treat_prose_as_task was explicitly set in config
+
+	AutoPush    bool   `json:"auto_push"`   // push the branch to PushRemote after a successful run
+	AutoPushSet bool   `json:"-"`           // tracks if auto_push was explicitly set in config
+	PushRemote  string `json:"push_remote"` // remote used by auto_push
+
+	PlansDir          string   `json:"plans_dir"`
+	WatchDirs         []string `json:"watch_dirs"`      // directories or glob patterns to watch for progress files
+	WatchRecursive    bool     `json:"watch_recursive"` // scan subdirectories of watch dirs
+	WatchRecursiveSet bool     `json:"-"`               // tracks if watch_recursive was explicitly set in config
+
+	FollowCompleted    bool `json:"follow_completed"` // keep tailing progress files of finished sessions
+	FollowCompletedSet bool `json:"-"`                // tracks if follow_completed was explicitly set in config
+
+	LazyLoadCompleted    bool `json:"lazy_load_completed"` // load content of completed sessions on first access
+	LazyLoadCompletedSet bool `json:"-"`                   // tracks if lazy_load_completed was explicitly set in config
+
+	SessionRetentionDays    int  `json:"session_retention_days"` // days to keep completed sessions, 0 keeps forever
+	SessionRetentionDaysSet bool `json:"-"`                      // tracks if session_retention_days was explicitly set in config
+	TailPollIntervalMs      int  `json:"tail_poll_interval_ms"`  // dashboard progress file poll interval, 0 uses default
+	ShutdownTimeoutMs       int  `json:"shutdown_timeout_ms"`    // dashboard shutdown grace period, 0 uses default
+	KeepAliveIntervalMs     int  `json:"keepalive_interval_ms"`  // idle time before SSE keep-alive comments, 0 uses default
+
+	ReadOnly    bool `json:"read_only"` // dashboard rejects requests changing session state, e.g. pause
+	ReadOnlySet bool `json:"-"`         // tracks if read_only was explicitly set in config
+
+	BindAddr        string `json:"bind_addr"`          // dashboard listen address, empty uses localhost
+	RateLimitPerMin int    `json:"rate_limit_per_min"` // max dashboard requests per minute changing session state, 0 disables
+
+	MaxEventTextBytes    int  `json:"max_event_text_bytes"` // dashboard event text size to truncate at, 0 disables truncation
+	MaxEventTextBytesSet bool `json:"-"`                    // tracks if max_event_text_bytes was explicitly set in config
+
+	ProgressFormat string `json:"progress_format"` // progress file format: text (default) or jsonl
+
+	// progress file name template with ProgressNamePlaceholders, empty uses default names
+	ProgressNameTemplate string `json:"progress_name_template"`
+
+	TimestampFormat string `json:"timestamp_format"` // timestamp format of text progress files: legacy (default) or iso8601
+
+	MaxProgressSizeMB    int  `json:"max_progress_size_mb"` // progress file size in MB to rotate at, 0 disables rotation
+	MaxProgressSizeMBSet bool `json:"-"`                    // tracks if max_progress_size_mb was explicitly set in config
+
+	CompressCompleted    bool `json:"compress_completed"` // gzip progress files of completed runs
+	CompressCompletedSet bool `json:"-"`                  // tracks if compress_completed was explicitly set in config
+
+	// regex matching token usage lines in provider CLI output, first capture group is the count
+	TokenUsagePattern string `json:"token_usage_pattern"`
 
 	// error patterns to detect in executor output (e.g., rate limit messages)
 	ClaudeErrorPatterns []string `json:"claude_error_patterns"`
 	CodexErrorPatterns  []string `json:"codex_error_patterns"`
 
+	// extra KEY=VALUE environment variables of claude and codex processes, values may be secrets
+	ExecutorEnv []string `json:"-"`
+
 	// output colors (RGB values as comma-separated strings)
 	Colors ColorConfig `json:"-"`
 
@@ -74,8 +171,9 @@ type Config struct {
 	// custom agents (loaded separately from files)
 	CustomAgents []CustomAgent `json:"-"`
 
-	configDir string // private, global config directory set by Load()
-	localDir  string // private, local project config directory (.ralphex/) if found
+	configDir   string   // private, global config directory set by Load()
+	localDir    string   // private, local project config directory (.ralphex/) if found
+	sourcePaths []string // private, config files the values were loaded from, see SourcePaths
 }
 
 // CustomAgent represents a user-defined review agent.
@@ -96,6 +194,7 @@ type ColorConfig struct {
 	Signal     string // completion/failure signals
 	Timestamp  string // timestamp prefix
 	Info       string // informational messages
+	Theme      string // named preset the colors above override, see ApplyTheme
 }
 
 // Load loads all configuration from the specified directory.
@@ -135,6 +234,54 @@ func loadWithLocal(globalDir, localDir string) (*Config, error) {
 	return loadConfigFromDirs(globalDir, localDir)
 }
 
+// LoadFile loads configuration like Load, with the given config file as the local layer
+// instead of .ralphex/config, e.g. for CI runs that pin an exact config.
+// If configDir is empty, uses the default location (~/.config/ralphex/) for the global layer.
+// local prompts and agents are still taken from .ralphex/ in the current directory if present.
+// returns an error if the file doesn't exist or can't be read.
+func LoadFile(configDir, path string) (*Config, error) {
+	if err := checkConfigFile(path); err != nil {
+		return nil, err
+	}
+
+	globalDir := configDir
+	if globalDir == "" {
+		globalDir = DefaultConfigDir()
+	}
+	installer := newDefaultsInstaller(defaultsFS)
+	if err := installer.Install(globalDir); err != nil {
+		return nil, fmt.Errorf("install defaults: %w", err)
+	}
+
+	var localDir string
+	if cwd, err := os.Getwd(); err == nil {
+		candidate := filepath.Join(cwd, ".ralphex")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			localDir = candidate
+		}
+	}
+	return loadConfigFromPaths(globalDir, localDir, path)
+}
+
+// checkConfigFile verifies that path is a readable regular file.
+func checkConfigFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("config file %s not found", path)
+		}
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("config file %s is a directory", path)
+	}
+	f, err := os.Open(path) //nolint:gosec // path given by the user
+	if err != nil {
+		return fmt.Errorf("config file %s is not readable: %w", path, err)
+	}
+	return f.Close()
+}
+
 // LoadReadOnly loads configuration without installing defaults.
 // use this in tests or tools that should not modify user's config directory.
 // if config files don't exist, embedded defaults are used.
@@ -159,14 +306,20 @@ func LoadReadOnly(configDir string) (*Config, error) {
 // loadConfigFromDirs loads configuration from specified directories without installing defaults.
 // shared by loadWithLocal (after installing) and LoadReadOnly (without installing).
 func loadConfigFromDirs(globalDir, localDir string) (*Config, error) {
-	embedFS := defaultsFS
-
-	// build config file paths
-	var localConfigPath, globalConfigPath string
+	var localConfigPath string
 	if localDir != "" {
-		localConfigPath = filepath.Join(localDir, "config")
+		localConfigPath = resolveConfigFile(localDir)
 	}
-	globalConfigPath = filepath.Join(globalDir, "config")
+	return loadConfigFromPaths(globalDir, localDir, localConfigPath)
+}
+
+// loadConfigFromPaths loads configuration with an explicit local config file, which may live outside localDir.
+// localDir provides local prompts and agents, either may be empty.
+func loadConfigFromPaths(globalDir, localDir, localConfigPath string) (*Config, error) {
+	embedFS := defaultsFS
+
+	// format of config files is detected from the extension (ini, toml, yaml)
+	globalConfigPath := resolveConfigFile(globalDir)
 
 	// load values (scalars) - falls back to embedded if files don't exist
 	vl := newValuesLoader(embedFS)
@@ -208,36 +361,89 @@ func loadConfigFromDirs(globalDir, localDir string) (*Config, error) {
 
 	// assemble config
 	c := &Config{
-		ClaudeCommand:        values.ClaudeCommand,
-		ClaudeArgs:           values.ClaudeArgs,
-		CodexEnabled:         values.CodexEnabled,
-		CodexEnabledSet:      values.CodexEnabledSet,
-		CodexCommand:         values.CodexCommand,
-		CodexModel:           values.CodexModel,
-		CodexReasoningEffort: values.CodexReasoningEffort,
-		CodexTimeoutMs:       values.CodexTimeoutMs,
-		CodexTimeoutMsSet:    values.CodexTimeoutMsSet,
-		CodexSandbox:         values.CodexSandbox,
-		IterationDelayMs:     values.IterationDelayMs,
-		IterationDelayMsSet:  values.IterationDelayMsSet,
-		TaskRetryCount:       values.TaskRetryCount,
-		TaskRetryCountSet:    values.TaskRetryCountSet,
-		FinalizeEnabled:      values.FinalizeEnabled,
-		FinalizeEnabledSet:   values.FinalizeEnabledSet,
-		PlansDir:             values.PlansDir,
-		WatchDirs:            values.WatchDirs,
-		ClaudeErrorPatterns:  values.ClaudeErrorPatterns,
-		CodexErrorPatterns:   values.CodexErrorPatterns,
-		Colors:               colors,
-		TaskPrompt:           prompts.Task,
-		ReviewFirstPrompt:    prompts.ReviewFirst,
-		ReviewSecondPrompt:   prompts.ReviewSecond,
-		CodexPrompt:          prompts.Codex,
-		MakePlanPrompt:       prompts.MakePlan,
-		FinalizePrompt:       prompts.Finalize,
-		CustomAgents:         agents,
-		configDir:            globalDir,
-		localDir:             localDir,
+		ClaudeCommand:              values.ClaudeCommand,
+		ClaudeArgs:                 values.ClaudeArgs,
+		CodexEnabled:               values.CodexEnabled,
+		CodexEnabledSet:            values.CodexEnabledSet,
+		CodexCommand:               values.CodexCommand,
+		CodexModel:                 values.CodexModel,
+		CodexReasoningEffort:       values.CodexReasoningEffort,
+		CodexTimeoutMs:             values.CodexTimeoutMs,
+		CodexTimeoutMsSet:          values.CodexTimeoutMsSet,
+		CodexSandbox:               values.CodexSandbox,
+		CodexArgs:                  values.CodexArgs,
+		CodexPasses:                values.CodexPasses,
+		CodexPassesSet:             values.CodexPassesSet,
+		CodexMinFindings:           values.CodexMinFindings,
+		CodexMinFindingsSet:        values.CodexMinFindingsSet,
+		ReviewOrder:                values.ReviewOrder,
+		ContinueOnReviewFailure:    values.ContinueOnReviewFailure,
+		ContinueOnReviewFailureSet: values.ContinueOnReviewFailureSet,
+		IterationDelayMs:           values.IterationDelayMs,
+		IterationDelayMsSet:        values.IterationDelayMsSet,
+		IterationDelayJitterMs:     values.IterationDelayJitterMs,
+		TaskRetryCount:             values.TaskRetryCount,
+		TaskRetryCountSet:          values.TaskRetryCountSet,
+		RetryOnCrash:               values.RetryOnCrash,
+		RetryOnCrashSet:            values.RetryOnCrashSet,
+		StallLimit:                 values.StallLimit,
+		StallLimitSet:              values.StallLimitSet,
+		FinalizeEnabled:            values.FinalizeEnabled,
+		FinalizeEnabledSet:         values.FinalizeEnabledSet,
+		UseWorktree:                values.UseWorktree,
+		UseWorktreeSet:             values.UseWorktreeSet,
+		WorktreePruneOnCancel:      values.WorktreePruneOnCancel,
+		WorktreePruneOnCancelSet:   values.WorktreePruneOnCancelSet,
+		AutoCommit:                 values.AutoCommit,
+		AutoCommitSet:              values.AutoCommitSet,
+		RequireCleanTree:           values.RequireCleanTree,
+		RequireCleanTreeSet:        values.RequireCleanTreeSet,
+		TreatProseAsTask:           values.TreatProseAsTask,
+		TreatProseAsTaskSet:        values.TreatProseAsTaskSet,
+		AutoPush:                   values.AutoPush,
+		AutoPushSet:                values.AutoPushSet,
+		PushRemote:                 values.PushRemote,
+		PlansDir:                   values.PlansDir,
+		WatchDirs:                  values.WatchDirs,
+		WatchRecursive:             values.WatchRecursive,
+		WatchRecursiveSet:          values.WatchRecursiveSet,
+		FollowCompleted:            values.FollowCompleted,
+		FollowCompletedSet:         values.FollowCompletedSet,
+		LazyLoadCompleted:          values.LazyLoadCompleted,
+		LazyLoadCompletedSet:       values.LazyLoadCompletedSet,
+		SessionRetentionDays:       values.SessionRetentionDays,
+		SessionRetentionDaysSet:    values.SessionRetentionDaysSet,
+		TailPollIntervalMs:         values.TailPollIntervalMs,
+		ShutdownTimeoutMs:          values.ShutdownTimeoutMs,
+		KeepAliveIntervalMs:        values.KeepAliveIntervalMs,
+		ReadOnly:                   values.ReadOnly,
+		ReadOnlySet:                values.ReadOnlySet,
+		BindAddr:                   values.BindAddr,
+		RateLimitPerMin:            values.RateLimitPerMin,
+		MaxEventTextBytes:          values.MaxEventTextBytes,
+		MaxEventTextBytesSet:       values.MaxEventTextBytesSet,
+		ProgressFormat:             values.ProgressFormat,
+		ProgressNameTemplate:       values.ProgressNameTemplate,
+		TimestampFormat:            values.TimestampFormat,
+		MaxProgressSizeMB:          values.MaxProgressSizeMB,
+		MaxProgressSizeMBSet:       values.MaxProgressSizeMBSet,
+		CompressCompleted:          values.CompressCompleted,
+		CompressCompletedSet:       values.CompressCompletedSet,
+		TokenUsagePattern:          values.TokenUsagePattern,
+		ClaudeErrorPatterns:        values.ClaudeErrorPatterns,
+		CodexErrorPatterns:         values.CodexErrorPatterns,
+		ExecutorEnv:                values.ExecutorEnv,
+		Colors:                     colors,
+		TaskPrompt:                 prompts.Task,
+		ReviewFirstPrompt:          prompts.ReviewFirst,
+		ReviewSecondPrompt:         prompts.ReviewSecond,
+		CodexPrompt:                prompts.Codex,
+		MakePlanPrompt:             prompts.MakePlan,
+		FinalizePrompt:             prompts.Finalize,
+		CustomAgents:               agents,
+		configDir:                  globalDir,
+		localDir:                   localDir,
+		sourcePaths:                values.Sources,
 	}
 
 	return c, nil
@@ -255,6 +461,12 @@ func DefaultConfigDir() string {
 	return filepath.Join(home, ".config", "ralphex")
 }
 
+// SourcePaths returns the config files the values were loaded from, global first, then local.
+// returns nil if neither exists and only embedded defaults were used.
+func (c *Config) SourcePaths() []string {
+	return slices.Clone(c.sourcePaths)
+}
+
 // LocalDir returns the local project config directory if one was detected.
 // returns empty string if no local config was used.
 func (c *Config) LocalDir() string {
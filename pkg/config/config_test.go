@@ -201,6 +201,57 @@ func TestLoad_PartialConfig(t *testing.T) {
 	assert.Equal(t, 3600000, cfg.CodexTimeoutMs)
 	assert.True(t, cfg.CodexEnabled)
 	assert.Equal(t, 1, cfg.TaskRetryCount)
+	assert.Equal(t, "origin", cfg.PushRemote)
+	assert.False(t, cfg.AutoPush)
+}
+
+func TestLoadFile(t *testing.T) {
+	globalDir := filepath.Join(t.TempDir(), "ralphex")
+	require.NoError(t, os.MkdirAll(globalDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(globalDir, "config"),
+		[]byte("plans_dir = global/plans\ncodex_model = global-model\n"), 0o600))
+
+	t.Run("file overrides global config and defaults", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ci.ini")
+		require.NoError(t, os.WriteFile(path, []byte("plans_dir = ci/plans\niteration_delay_ms = 10\n"), 0o600))
+
+		cfg, err := LoadFile(globalDir, path)
+		require.NoError(t, err)
+		assert.Equal(t, "ci/plans", cfg.PlansDir, "file wins over global config")
+		assert.Equal(t, 10, cfg.IterationDelayMs, "file wins over embedded defaults")
+		assert.Equal(t, "global-model", cfg.CodexModel, "global config applies to keys not in the file")
+		assert.Equal(t, "claude", cfg.ClaudeCommand, "embedded defaults fill the rest")
+		assert.NotEmpty(t, cfg.TaskPrompt)
+	})
+
+	t.Run("format from extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ci.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("plans_dir: yaml/plans\n"), 0o600))
+
+		cfg, err := LoadFile(globalDir, path)
+		require.NoError(t, err)
+		assert.Equal(t, "yaml/plans", cfg.PlansDir)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.ini")
+		_, err := LoadFile(globalDir, path)
+		require.Error(t, err)
+		assert.Equal(t, "config file "+path+" not found", err.Error())
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := LoadFile(globalDir, dir)
+		require.EqualError(t, err, "config file "+dir+" is a directory")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ci.ini")
+		require.NoError(t, os.WriteFile(path, []byte("task_retry_count = many\n"), 0o600))
+		_, err := LoadFile(globalDir, path)
+		require.ErrorContains(t, err, "task_retry_count")
+	})
 }
 
 func TestLoad_EmptyConfig(t *testing.T) {
@@ -321,9 +372,38 @@ codex_command = /custom/codex
 codex_model = custom-model
 codex_reasoning_effort = low
 codex_timeout_ms = 1000
-codex_sandbox = none
+codex_sandbox = workspace-write
+codex_args = --profile review
+codex_passes = 2
+codex_min_findings = 3
+review_order = codex-first
+continue_on_review_failure = true
+executor_env = CLAUDE_MODEL=opus, API_TOKEN=a=b
+tail_poll_interval_ms = 500
+shutdown_timeout_ms = 2000
+keepalive_interval_ms = 10000
+watch_recursive = false
+follow_completed = true
+lazy_load_completed = true
+read_only = true
+bind_addr = ::1
+rate_limit_per_min = 30
+max_event_text_bytes = 4096
+require_clean_tree = true
+treat_prose_as_task = true
+auto_push = true
+push_remote = upstream
+token_usage_pattern = tokens used: ([\d,]+)
+progress_format = jsonl
+progress_name_template = {date}-{slug}
+timestamp_format = iso8601
+max_progress_size_mb = 50
+compress_completed = true
 iteration_delay_ms = 500
+iteration_delay_jitter_ms = 100
 task_retry_count = 5
+retry_on_crash = true
+stall_limit = 4
 plans_dir = my/plans
 `
 	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config"), []byte(configContent), 0o600))
@@ -339,9 +419,48 @@ plans_dir = my/plans
 	assert.Equal(t, "custom-model", cfg.CodexModel)
 	assert.Equal(t, "low", cfg.CodexReasoningEffort)
 	assert.Equal(t, 1000, cfg.CodexTimeoutMs)
-	assert.Equal(t, "none", cfg.CodexSandbox)
+	assert.Equal(t, "workspace-write", cfg.CodexSandbox)
+	assert.Equal(t, "--profile review", cfg.CodexArgs)
+	assert.Equal(t, 2, cfg.CodexPasses)
+	assert.Equal(t, 3, cfg.CodexMinFindings)
+	assert.Equal(t, "codex-first", cfg.ReviewOrder)
+	assert.True(t, cfg.ContinueOnReviewFailure)
+	assert.True(t, cfg.ContinueOnReviewFailureSet)
+	assert.Equal(t, []string{"CLAUDE_MODEL=opus", "API_TOKEN=a=b"}, cfg.ExecutorEnv)
+	assert.Equal(t, 500, cfg.TailPollIntervalMs)
+	assert.Equal(t, 2000, cfg.ShutdownTimeoutMs)
+	assert.Equal(t, 10000, cfg.KeepAliveIntervalMs)
+	assert.False(t, cfg.WatchRecursive)
+	assert.True(t, cfg.WatchRecursiveSet)
+	assert.True(t, cfg.FollowCompleted)
+	assert.True(t, cfg.FollowCompletedSet)
+	assert.True(t, cfg.LazyLoadCompleted)
+	assert.True(t, cfg.LazyLoadCompletedSet)
+	assert.True(t, cfg.ReadOnly)
+	assert.True(t, cfg.ReadOnlySet)
+	assert.Equal(t, "::1", cfg.BindAddr)
+	assert.Equal(t, 30, cfg.RateLimitPerMin)
+	assert.Equal(t, 4096, cfg.MaxEventTextBytes)
+	assert.True(t, cfg.RequireCleanTree)
+	assert.True(t, cfg.RequireCleanTreeSet)
+	assert.True(t, cfg.TreatProseAsTask)
+	assert.True(t, cfg.TreatProseAsTaskSet)
+	assert.True(t, cfg.AutoPush)
+	assert.True(t, cfg.AutoPushSet)
+	assert.Equal(t, "upstream", cfg.PushRemote)
+	assert.Equal(t, `tokens used: ([\d,]+)`, cfg.TokenUsagePattern)
+	assert.Equal(t, "jsonl", cfg.ProgressFormat)
+	assert.Equal(t, "{date}-{slug}", cfg.ProgressNameTemplate)
+	assert.Equal(t, "iso8601", cfg.TimestampFormat)
+	assert.Equal(t, 50, cfg.MaxProgressSizeMB)
+	assert.True(t, cfg.CompressCompleted)
+	assert.True(t, cfg.CompressCompletedSet)
 	assert.Equal(t, 500, cfg.IterationDelayMs)
+	assert.Equal(t, 100, cfg.IterationDelayJitterMs)
 	assert.Equal(t, 5, cfg.TaskRetryCount)
+	assert.True(t, cfg.RetryOnCrash)
+	assert.True(t, cfg.RetryOnCrashSet)
+	assert.Equal(t, 4, cfg.StallLimit)
 	assert.Equal(t, "my/plans", cfg.PlansDir)
 }
 
@@ -371,6 +490,38 @@ func TestLocalConfig_WithLocalDir(t *testing.T) {
 	assert.Equal(t, localDir, cfg.LocalDir())
 }
 
+func TestConfig_SourcePaths(t *testing.T) {
+	t.Run("only embedded defaults", func(t *testing.T) {
+		cfg, err := loadConfigFromDirs(filepath.Join(t.TempDir(), "global"), "")
+		require.NoError(t, err)
+		assert.Empty(t, cfg.SourcePaths())
+	})
+
+	t.Run("global and local config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		globalDir := filepath.Join(tmpDir, "global")
+		localDir := filepath.Join(tmpDir, ".ralphex")
+		require.NoError(t, os.MkdirAll(localDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, "config.toml"), []byte("plans_dir = \"plans\"\n"), 0o600))
+
+		cfg, err := loadWithLocal(globalDir, localDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(globalDir, "config"), filepath.Join(localDir, "config.toml")},
+			cfg.SourcePaths(), "installed global config first, then the local one")
+	})
+
+	t.Run("local dir without config file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		globalDir := filepath.Join(tmpDir, "global")
+		localDir := filepath.Join(tmpDir, ".ralphex")
+		require.NoError(t, os.MkdirAll(localDir, 0o700))
+
+		cfg, err := loadWithLocal(globalDir, localDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(globalDir, "config")}, cfg.SourcePaths())
+	})
+}
+
 func TestLocalConfig_LocalOverridesGlobal(t *testing.T) {
 	tmpDir := t.TempDir()
 	globalDir := filepath.Join(tmpDir, "global")
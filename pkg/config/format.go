@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames lists supported config file names in lookup order.
+// the extensionless "config" file is INI and takes precedence to keep existing setups unchanged.
+var configFileNames = []string{"config", "config.ini", "config.toml", "config.yaml", "config.yml"}
+
+// resolveConfigFile returns the path of the config file in dir.
+// checks supported names in order and returns the first existing one,
+// falling back to the default INI "config" file if none exists.
+func resolveConfigFile(dir string) string {
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, "config")
+}
+
+// loadConfigSource parses config data into an ini.File based on the file extension.
+// .toml and .yaml/.yml files are converted to the default INI section so all loaders
+// share the same key lookup and validation; anything else is parsed as INI.
+func loadConfigSource(path string, data []byte) (*ini.File, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return tomlToINI(data)
+	case ".yaml", ".yml":
+		return yamlToINI(data)
+	}
+
+	// ignoreInlineComment: true prevents # from being treated as inline comment marker
+	cfg, err := ini.LoadSources(ini.LoadOptions{IgnoreInlineComment: true}, data)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// yamlToINI converts a flat YAML mapping into an ini.File with all keys in the default section.
+// sequences are joined with commas to match the INI list format.
+func yamlToINI(data []byte) (*ini.File, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+
+	cfg := ini.Empty()
+	section := cfg.Section("")
+	for key, val := range raw {
+		str, err := scalarString(val)
+		if err != nil {
+			return nil, fmt.Errorf("parse yaml: key %s: %w", key, err)
+		}
+		if _, err := section.NewKey(key, str); err != nil {
+			return nil, fmt.Errorf("parse yaml: key %s: %w", key, err)
+		}
+	}
+	return cfg, nil
+}
+
+// scalarString converts a decoded YAML or TOML value into its INI string form.
+func scalarString(val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := scalarString(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any, []map[string]any:
+		return "", errors.New("nested mappings are not supported")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// tomlToINI decodes TOML into an ini.File with all keys in the default section.
+// arrays are joined with commas to match the INI list format. ralphex config is flat,
+// so tables are rejected instead of being silently ignored.
+func tomlToINI(data []byte) (*ini.File, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, fmt.Errorf("parse toml: %w", err)
+	}
+
+	cfg := ini.Empty()
+	section := cfg.Section("")
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		switch raw[key].(type) {
+		case map[string]any, []map[string]any:
+			return nil, fmt.Errorf("parse toml: unknown table [%s], config keys must be top-level", key)
+		}
+		str, err := scalarString(raw[key])
+		if err != nil {
+			return nil, fmt.Errorf("parse toml: key %s: %w", key, err)
+		}
+		if _, err := section.NewKey(key, str); err != nil {
+			return nil, fmt.Errorf("parse toml: key %s: %w", key, err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValuesLoader_Load_AllFormatsEquivalent(t *testing.T) {
+	globalINI := `
+claude_command = /global/claude
+codex_enabled = true
+iteration_delay_ms = 5000
+`
+	localFiles := map[string]string{
+		"config": `
+# local overrides
+codex_enabled = false
+codex_model = "gpt-test"
+codex_timeout_ms = 0
+task_retry_count = 3
+watch_dirs = /a, /b
+claude_error_patterns = rate limit,quota # exceeded
+`,
+		"config.toml": `
+# local overrides
+codex_enabled = false
+codex_model = "gpt-test"
+codex_timeout_ms = 0 # disable timeout
+task_retry_count = 3
+watch_dirs = ["/a", '/b']
+claude_error_patterns = ["rate limit", "quota # exceeded"]
+`,
+		"config.yaml": `
+# local overrides
+codex_enabled: false
+codex_model: "gpt-test"
+codex_timeout_ms: 0
+task_retry_count: 3
+watch_dirs:
+  - /a
+  - /b
+claude_error_patterns: ["rate limit", "quota # exceeded"]
+`,
+	}
+
+	var results []Values
+	for name, content := range localFiles {
+		t.Run(name, func(t *testing.T) {
+			globalDir, localDir := t.TempDir(), t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(globalDir, "config"), []byte(globalINI), 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(localDir, name), []byte(content), 0o600))
+
+			localPath := resolveConfigFile(localDir)
+			assert.Equal(t, filepath.Join(localDir, name), localPath)
+
+			values, err := newValuesLoader(defaultsFS).Load(localPath, resolveConfigFile(globalDir))
+			require.NoError(t, err)
+
+			assert.Equal(t, "/global/claude", values.ClaudeCommand)
+			assert.Equal(t, 5000, values.IterationDelayMs)
+			assert.False(t, values.CodexEnabled)
+			assert.True(t, values.CodexEnabledSet)
+			assert.Equal(t, "gpt-test", values.CodexModel)
+			assert.Equal(t, 0, values.CodexTimeoutMs)
+			assert.True(t, values.CodexTimeoutMsSet)
+			assert.Equal(t, 3, values.TaskRetryCount)
+			assert.Equal(t, []string{"/a", "/b"}, values.WatchDirs)
+			assert.Equal(t, []string{"rate limit", "quota # exceeded"}, values.ClaudeErrorPatterns)
+			assert.Equal(t, []string{filepath.Join(globalDir, "config"), localPath}, values.Sources)
+			values.Sources = nil // temp dirs differ between formats
+			results = append(results, values)
+		})
+	}
+
+	require.Len(t, results, len(localFiles))
+	for _, v := range results[1:] {
+		assert.Equal(t, results[0], v)
+	}
+}
+
+func TestColorLoader_Load_YAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("color_task: \"#ff0000\"\n"), 0o600))
+
+	colors, err := newColorLoader(defaultsFS).Load(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "255,0,0", colors.Task)
+}
+
+func TestResolveConfigFile(t *testing.T) {
+	t.Run("defaults to extensionless config", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Equal(t, filepath.Join(dir, "config"), resolveConfigFile(dir))
+	})
+
+	t.Run("extensionless config takes precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), nil, 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), nil, 0o600))
+		assert.Equal(t, filepath.Join(dir, "config"), resolveConfigFile(dir))
+	})
+
+	t.Run("finds toml", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.toml"), nil, 0o600))
+		assert.Equal(t, filepath.Join(dir, "config.toml"), resolveConfigFile(dir))
+	})
+}
+
+func TestLoadConfigSource_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		data    string
+		wantErr string
+	}{
+		{name: "toml unterminated string", path: "config.toml", data: `claude_command = "claude`, wantErr: "parse toml"},
+		{name: "toml missing value", path: "config.toml", data: "claude_command", wantErr: "parse toml"},
+		{name: "toml trailing garbage", path: "config.toml", data: `claude_command = "a" b`, wantErr: "parse toml"},
+		{name: "toml table", path: "config.toml", data: "[codex]\nmodel = \"x\"\n", wantErr: "unknown table [codex]"},
+		{name: "toml dotted key", path: "config.toml", data: "codex.model = \"x\"\n", wantErr: "unknown table [codex]"},
+		{name: "toml array of tables", path: "config.toml", data: "[[dirs]]\npath = \"/a\"\n", wantErr: "unknown table [dirs]"},
+		{name: "toml inline table in array", path: "config.toml", data: "dirs = [{path = \"/a\"}]\n", wantErr: "nested mappings"},
+		{name: "yaml invalid", path: "config.yaml", data: "key: [unclosed", wantErr: "parse yaml"},
+		{name: "yaml nested mapping", path: "config.yml", data: "codex:\n  model: x\n", wantErr: "nested mappings"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadConfigSource(tc.path, []byte(tc.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestTomlToINI(t *testing.T) {
+	data := []byte(`
+# comment
+plain = 42
+flag = true # trailing comment
+escaped = "say \"hi\" \\ bye"
+literal = 'C:\path'
+list = ["a", "b,c"]
+multiline = [
+  "x",
+  "y",
+]
+`)
+	cfg, err := loadConfigSource("config.toml", data)
+	require.NoError(t, err)
+
+	section := cfg.Section("")
+	assert.Equal(t, "42", section.Key("plain").String())
+	assert.Equal(t, "true", section.Key("flag").String())
+	assert.Equal(t, `say "hi" \ bye`, section.Key("escaped").String())
+	assert.Equal(t, `C:\path`, section.Key("literal").String())
+	assert.Equal(t, "a,b,c", section.Key("list").String())
+	assert.Equal(t, "x,y", section.Key("multiline").String())
+}
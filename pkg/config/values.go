@@ -3,7 +3,10 @@ package config
 import (
 	"embed"
 	"fmt"
+	"net"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	"gopkg.in/ini.v1"
@@ -14,28 +17,97 @@ import (
 // set in config. This allows distinguishing explicit false/0 from "not set", enabling
 // proper merge behavior where local config can override global config with zero values.
 type Values struct {
-	ClaudeCommand        string
-	ClaudeArgs           string
-	ClaudeErrorPatterns  []string // patterns to detect in claude output (e.g., rate limit messages)
-	CodexEnabled         bool
-	CodexEnabledSet      bool // tracks if codex_enabled was explicitly set
-	CodexCommand         string
-	CodexModel           string
-	CodexReasoningEffort string
-	CodexTimeoutMs       int
-	CodexTimeoutMsSet    bool // tracks if codex_timeout_ms was explicitly set
-	CodexSandbox         string
-	CodexErrorPatterns   []string // patterns to detect in codex output (e.g., rate limit messages)
-	IterationDelayMs     int
-	IterationDelayMsSet  bool // tracks if iteration_delay_ms was explicitly set
-	TaskRetryCount       int
-	TaskRetryCountSet    bool // tracks if task_retry_count was explicitly set
-	FinalizeEnabled      bool
-	FinalizeEnabledSet   bool // tracks if finalize_enabled was explicitly set
-	PlansDir             string
-	WatchDirs            []string // directories to watch for progress files
+	ClaudeCommand              string
+	ClaudeArgs                 string
+	ClaudeErrorPatterns        []string // patterns to detect in claude output (e.g., rate limit messages)
+	CodexEnabled               bool
+	CodexEnabledSet            bool // tracks if codex_enabled was explicitly set
+	CodexCommand               string
+	CodexModel                 string
+	CodexReasoningEffort       string
+	CodexTimeoutMs             int
+	CodexTimeoutMsSet          bool // tracks if codex_timeout_ms was explicitly set
+	CodexSandbox               string
+	CodexArgs                  string   // extra arguments appended to the codex command line
+	CodexPasses                int      // max codex review passes, 0 derives it from max iterations
+	CodexPassesSet             bool     // tracks if codex_passes was explicitly set
+	CodexMinFindings           int      // min file:line findings in codex output to run the fix loop, 0 disables
+	CodexMinFindingsSet        bool     // tracks if codex_min_findings was explicitly set
+	ReviewOrder                string   // order of review phases: claude-first or codex-first, empty uses claude-first
+	ContinueOnReviewFailure    bool     // log a failed review phase and go on with the next one
+	ContinueOnReviewFailureSet bool     // tracks if continue_on_review_failure was explicitly set
+	CodexErrorPatterns         []string // patterns to detect in codex output (e.g., rate limit messages)
+	ExecutorEnv                []string // extra KEY=VALUE environment variables of claude and codex processes
+	IterationDelayMs           int
+	IterationDelayMsSet        bool // tracks if iteration_delay_ms was explicitly set
+	IterationDelayJitterMs     int  // random +/- variation of the iteration delay, 0 disables
+	TaskRetryCount             int
+	TaskRetryCountSet          bool // tracks if task_retry_count was explicitly set
+	RetryOnCrash               bool // retry a task iteration whose executor crashed, using the task_retry_count budget
+	RetryOnCrashSet            bool // tracks if retry_on_crash was explicitly set
+	StallLimit                 int  // abort after this many task iterations without progress, 0 disables
+	StallLimitSet              bool // tracks if stall_limit was explicitly set
+	FinalizeEnabled            bool
+	FinalizeEnabledSet         bool // tracks if finalize_enabled was explicitly set
+	UseWorktree                bool
+	UseWorktreeSet             bool // tracks if use_worktree was explicitly set
+	WorktreePruneOnCancel      bool
+	WorktreePruneOnCancelSet   bool // tracks if worktree_prune_on_cancel was explicitly set
+	AutoCommit                 bool
+	AutoCommitSet              bool // tracks if auto_commit was explicitly set
+	RequireCleanTree           bool
+	RequireCleanTreeSet        bool // tracks if require_clean_tree was explicitly set
+	TreatProseAsTask           bool // run a plan without task checkboxes as a single task instead of refusing it
+	TreatProseAsTaskSet        bool // tracks if treat_prose_as_task was explicitly set
+	AutoPush                   bool // push the branch after a successful run
+	AutoPushSet                bool // tracks if auto_push was explicitly set
+	PushRemote                 string
+	PlansDir                   string
+	WatchDirs                  []string // directories or glob patterns to watch for progress files
+	WatchRecursive             bool
+	WatchRecursiveSet          bool   // tracks if watch_recursive was explicitly set
+	FollowCompleted            bool   // keep tailing progress files of finished sessions
+	FollowCompletedSet         bool   // tracks if follow_completed was explicitly set
+	LazyLoadCompleted          bool   // load content of completed sessions on first access instead of on discovery
+	LazyLoadCompletedSet       bool   // tracks if lazy_load_completed was explicitly set
+	SessionRetentionDays       int    // days to keep completed sessions in watched dirs, 0 keeps forever
+	SessionRetentionDaysSet    bool   // tracks if session_retention_days was explicitly set
+	TailPollIntervalMs         int    // dashboard progress file poll interval, 0 uses default
+	ShutdownTimeoutMs          int    // dashboard shutdown grace period, 0 uses default
+	KeepAliveIntervalMs        int    // idle time before the dashboard sends SSE keep-alive comments, 0 uses default
+	ReadOnly                   bool   // dashboard rejects requests changing session state
+	ReadOnlySet                bool   // tracks if read_only was explicitly set
+	BindAddr                   string // dashboard listen address, empty uses localhost
+	RateLimitPerMin            int    // max dashboard requests per minute changing session state, 0 disables
+	MaxEventTextBytes          int    // dashboard event text size to truncate at, 0 disables truncation
+	MaxEventTextBytesSet       bool   // tracks if max_event_text_bytes was explicitly set
+	ProgressFormat             string // progress file format: text or jsonl, empty uses text
+	ProgressNameTemplate       string // progress file name template, empty uses default names
+	TimestampFormat            string // timestamp format of text progress files: legacy or iso8601, empty uses legacy
+	MaxProgressSizeMB          int    // progress file size in MB to rotate at, 0 disables rotation
+	MaxProgressSizeMBSet       bool   // tracks if max_progress_size_mb was explicitly set
+	CompressCompleted          bool   // gzip progress files of completed runs
+	CompressCompletedSet       bool   // tracks if compress_completed was explicitly set
+	TokenUsagePattern          string // regex matching token usage lines in provider CLI output, empty disables
+
+	Sources []string // config files the values were read from, in merge order
 }
 
+// allowed values for codex settings passed through to the codex CLI
+var (
+	codexReasoningEfforts = []string{"minimal", "low", "medium", "high", "xhigh"}
+	codexSandboxModes     = []string{"read-only", "workspace-write", "danger-full-access"}
+)
+
+// allowed orders of review phases, see processor.Config.ReviewOrder
+var reviewOrders = []string{"claude-first", "codex-first"}
+
+// allowed progress file formats, see progress.Config.Format
+var progressFormats = []string{"text", "jsonl"}
+
+// allowed timestamp formats of progress files, see progress.Config.TimestampFormat
+var timestampFormats = []string{"legacy", "iso8601"}
+
 // valuesLoader implements ValuesLoader with embedded filesystem fallback.
 type valuesLoader struct {
 	embedFS embed.FS
@@ -92,15 +164,25 @@ func (vl *valuesLoader) parseValuesFromFile(path string) (Values, error) {
 		}
 		return Values{}, fmt.Errorf("read config %s: %w", path, err)
 	}
+	sources := []string{path}
 
 	// strip comments and check if anything remains
 	// if only comments/whitespace, return empty Values to fall back to embedded defaults
 	stripped := stripComments(string(data))
 	if strings.TrimSpace(stripped) == "" {
-		return Values{}, nil
+		return Values{Sources: sources}, nil
 	}
 
-	return vl.parseValuesFromBytes(data)
+	cfg, err := loadConfigSource(path, data)
+	if err != nil {
+		return Values{}, err
+	}
+	values, err := vl.parseValuesFromINI(cfg)
+	if err != nil {
+		return Values{}, err
+	}
+	values.Sources = sources
+	return values, nil
 }
 
 // parseValuesFromEmbedded parses values from the embedded defaults/config file.
@@ -112,16 +194,20 @@ func (vl *valuesLoader) parseValuesFromEmbedded() (Values, error) {
 	return vl.parseValuesFromBytes(data)
 }
 
-// parseValuesFromBytes parses configuration from a byte slice into Values.
-//
-//nolint:gocyclo // adding watch_dirs pushed complexity over threshold; splitting would hurt readability
+// parseValuesFromBytes parses INI configuration from a byte slice into Values.
 func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 	// ignoreInlineComment: true prevents # from being treated as inline comment marker
 	cfg, err := ini.LoadSources(ini.LoadOptions{IgnoreInlineComment: true}, data)
 	if err != nil {
 		return Values{}, fmt.Errorf("parse config: %w", err)
 	}
+	return vl.parseValuesFromINI(cfg)
+}
 
+// parseValuesFromINI extracts Values from a parsed config, regardless of the source file format.
+//
+//nolint:gocyclo // adding watch_dirs pushed complexity over threshold; splitting would hurt readability
+func (vl *valuesLoader) parseValuesFromINI(cfg *ini.File) (Values, error) {
 	var values Values
 	section := cfg.Section("") // default section (no section header)
 
@@ -149,6 +235,9 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 		values.CodexModel = key.String()
 	}
 	if key, err := section.GetKey("codex_reasoning_effort"); err == nil {
+		if err := validateOneOf("codex_reasoning_effort", key.String(), codexReasoningEfforts); err != nil {
+			return Values{}, err
+		}
 		values.CodexReasoningEffort = key.String()
 	}
 	if key, err := section.GetKey("codex_timeout_ms"); err == nil {
@@ -163,8 +252,50 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 		values.CodexTimeoutMsSet = true
 	}
 	if key, err := section.GetKey("codex_sandbox"); err == nil {
+		if err := validateOneOf("codex_sandbox", key.String(), codexSandboxModes); err != nil {
+			return Values{}, err
+		}
 		values.CodexSandbox = key.String()
 	}
+	if key, err := section.GetKey("codex_args"); err == nil {
+		values.CodexArgs = key.String()
+	}
+	if key, err := section.GetKey("codex_passes"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid codex_passes: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid codex_passes: must be non-negative, got %d", val)
+		}
+		values.CodexPasses = val
+		values.CodexPassesSet = true
+	}
+	if key, err := section.GetKey("codex_min_findings"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid codex_min_findings: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid codex_min_findings: must be non-negative, got %d", val)
+		}
+		values.CodexMinFindings = val
+		values.CodexMinFindingsSet = true
+	}
+	if key, err := section.GetKey("review_order"); err == nil {
+		if err := validateOneOf("review_order", key.String(), reviewOrders); err != nil {
+			return Values{}, err
+		}
+		values.ReviewOrder = key.String()
+	}
+	if key, err := section.GetKey("continue_on_review_failure"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid continue_on_review_failure: %w", boolErr)
+		}
+		values.ContinueOnReviewFailure = val
+		values.ContinueOnReviewFailureSet = true
+	}
 
 	// timing settings
 	if key, err := section.GetKey("iteration_delay_ms"); err == nil {
@@ -178,6 +309,16 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 		values.IterationDelayMs = val
 		values.IterationDelayMsSet = true
 	}
+	if key, err := section.GetKey("iteration_delay_jitter_ms"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid iteration_delay_jitter_ms: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid iteration_delay_jitter_ms: must be non-negative, got %d", val)
+		}
+		values.IterationDelayJitterMs = val
+	}
 	if key, err := section.GetKey("task_retry_count"); err == nil {
 		val, intErr := key.Int()
 		if intErr != nil {
@@ -189,6 +330,25 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 		values.TaskRetryCount = val
 		values.TaskRetryCountSet = true
 	}
+	if key, err := section.GetKey("retry_on_crash"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid retry_on_crash: %w", boolErr)
+		}
+		values.RetryOnCrash = val
+		values.RetryOnCrashSet = true
+	}
+	if key, err := section.GetKey("stall_limit"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid stall_limit: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid stall_limit: must be non-negative, got %d", val)
+		}
+		values.StallLimit = val
+		values.StallLimitSet = true
+	}
 
 	// finalize settings
 	if key, err := section.GetKey("finalize_enabled"); err == nil {
@@ -200,6 +360,61 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 		values.FinalizeEnabledSet = true
 	}
 
+	// worktree settings
+	if key, err := section.GetKey("use_worktree"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid use_worktree: %w", boolErr)
+		}
+		values.UseWorktree = val
+		values.UseWorktreeSet = true
+	}
+	if key, err := section.GetKey("worktree_prune_on_cancel"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid worktree_prune_on_cancel: %w", boolErr)
+		}
+		values.WorktreePruneOnCancel = val
+		values.WorktreePruneOnCancelSet = true
+	}
+
+	// auto-commit settings
+	if key, err := section.GetKey("auto_commit"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid auto_commit: %w", boolErr)
+		}
+		values.AutoCommit = val
+		values.AutoCommitSet = true
+	}
+	if key, err := section.GetKey("require_clean_tree"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid require_clean_tree: %w", boolErr)
+		}
+		values.RequireCleanTree = val
+		values.RequireCleanTreeSet = true
+	}
+	if key, err := section.GetKey("treat_prose_as_task"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid treat_prose_as_task: %w", boolErr)
+		}
+		values.TreatProseAsTask = val
+		values.TreatProseAsTaskSet = true
+	}
+	if key, err := section.GetKey("auto_push"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid auto_push: %w", boolErr)
+		}
+		values.AutoPush = val
+		values.AutoPushSet = true
+	}
+	if key, err := section.GetKey("push_remote"); err == nil {
+		values.PushRemote = strings.TrimSpace(key.String())
+	}
+
 	// paths
 	if key, err := section.GetKey("plans_dir"); err == nil {
 		values.PlansDir = key.String()
@@ -216,8 +431,167 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 			}
 		}
 	}
+	if key, err := section.GetKey("watch_recursive"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid watch_recursive: %w", boolErr)
+		}
+		values.WatchRecursive = val
+		values.WatchRecursiveSet = true
+	}
+	if key, err := section.GetKey("follow_completed"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid follow_completed: %w", boolErr)
+		}
+		values.FollowCompleted = val
+		values.FollowCompletedSet = true
+	}
+	if key, err := section.GetKey("lazy_load_completed"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid lazy_load_completed: %w", boolErr)
+		}
+		values.LazyLoadCompleted = val
+		values.LazyLoadCompletedSet = true
+	}
+
+	if key, err := section.GetKey("session_retention_days"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid session_retention_days: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid session_retention_days: must be non-negative, got %d", val)
+		}
+		values.SessionRetentionDays = val
+		values.SessionRetentionDaysSet = true
+	}
+	if key, err := section.GetKey("tail_poll_interval_ms"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid tail_poll_interval_ms: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid tail_poll_interval_ms: must be non-negative, got %d", val)
+		}
+		values.TailPollIntervalMs = val
+	}
+	if key, err := section.GetKey("shutdown_timeout_ms"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid shutdown_timeout_ms: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid shutdown_timeout_ms: must be non-negative, got %d", val)
+		}
+		values.ShutdownTimeoutMs = val
+	}
+	if key, err := section.GetKey("keepalive_interval_ms"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid keepalive_interval_ms: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid keepalive_interval_ms: must be non-negative, got %d", val)
+		}
+		values.KeepAliveIntervalMs = val
+	}
+	if key, err := section.GetKey("bind_addr"); err == nil {
+		val := strings.TrimSpace(key.String())
+		if err := ValidateBindAddr(val); err != nil {
+			return Values{}, fmt.Errorf("invalid bind_addr: %w", err)
+		}
+		values.BindAddr = val
+	}
+	if key, err := section.GetKey("rate_limit_per_min"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid rate_limit_per_min: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid rate_limit_per_min: must be non-negative, got %d", val)
+		}
+		values.RateLimitPerMin = val
+	}
+	if key, err := section.GetKey("max_event_text_bytes"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid max_event_text_bytes: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid max_event_text_bytes: must be non-negative, got %d", val)
+		}
+		values.MaxEventTextBytes = val
+		values.MaxEventTextBytesSet = true
+	}
+	if key, err := section.GetKey("read_only"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid read_only: %w", boolErr)
+		}
+		values.ReadOnly = val
+		values.ReadOnlySet = true
+	}
 
 	// error patterns (comma-separated)
+	if key, err := section.GetKey("progress_format"); err == nil {
+		if err := validateOneOf("progress_format", key.String(), progressFormats); err != nil {
+			return Values{}, err
+		}
+		values.ProgressFormat = key.String()
+	}
+
+	if key, err := section.GetKey("progress_name_template"); err == nil {
+		val := strings.TrimSpace(key.String())
+		if err := validateProgressNameTemplate(val); err != nil {
+			return Values{}, err
+		}
+		values.ProgressNameTemplate = val
+	}
+
+	if key, err := section.GetKey("timestamp_format"); err == nil {
+		if err := validateOneOf("timestamp_format", key.String(), timestampFormats); err != nil {
+			return Values{}, err
+		}
+		values.TimestampFormat = key.String()
+	}
+
+	if key, err := section.GetKey("max_progress_size_mb"); err == nil {
+		val, intErr := key.Int()
+		if intErr != nil {
+			return Values{}, fmt.Errorf("invalid max_progress_size_mb: %w", intErr)
+		}
+		if val < 0 {
+			return Values{}, fmt.Errorf("invalid max_progress_size_mb: must be non-negative, got %d", val)
+		}
+		values.MaxProgressSizeMB = val
+		values.MaxProgressSizeMBSet = true
+	}
+
+	if key, err := section.GetKey("compress_completed"); err == nil {
+		val, boolErr := key.Bool()
+		if boolErr != nil {
+			return Values{}, fmt.Errorf("invalid compress_completed: %w", boolErr)
+		}
+		values.CompressCompleted = val
+		values.CompressCompletedSet = true
+	}
+
+	if key, err := section.GetKey("token_usage_pattern"); err == nil {
+		val := strings.TrimSpace(key.String())
+		if val != "" {
+			re, reErr := regexp.Compile(val)
+			if reErr != nil {
+				return Values{}, fmt.Errorf("invalid token_usage_pattern: %w", reErr)
+			}
+			if re.NumSubexp() < 1 {
+				return Values{}, fmt.Errorf("invalid token_usage_pattern: must have a capture group for the token count, got %q", val)
+			}
+		}
+		values.TokenUsagePattern = val
+	}
+
 	if key, err := section.GetKey("claude_error_patterns"); err == nil {
 		val := strings.TrimSpace(key.String())
 		if val != "" {
@@ -238,10 +612,80 @@ func (vl *valuesLoader) parseValuesFromBytes(data []byte) (Values, error) {
 			}
 		}
 	}
+	if key, err := section.GetKey("executor_env"); err == nil {
+		for p := range strings.SplitSeq(key.String(), ",") {
+			t := strings.TrimSpace(p)
+			if t == "" {
+				continue
+			}
+			// the value may be a secret, so only the key is reported
+			if k, _, ok := strings.Cut(t, "="); !ok || k == "" || strings.ContainsAny(k, " \t") {
+				return Values{}, fmt.Errorf("invalid executor_env: want KEY=VALUE entries, got %q", k)
+			}
+			values.ExecutorEnv = append(values.ExecutorEnv, t)
+		}
+	}
 
 	return values, nil
 }
 
+// validateOneOf checks that a non-empty value is one of the allowed values.
+// empty value is valid and means "use default".
+func validateOneOf(name, val string, allowed []string) error {
+	if val == "" || slices.Contains(allowed, val) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s: got %q, want one of %s", name, val, strings.Join(allowed, ", "))
+}
+
+// ProgressNamePlaceholders are the placeholders allowed in progress_name_template.
+var ProgressNamePlaceholders = []string{"{date}", "{branch}", "{mode}", "{slug}"}
+
+// progressNamePlaceholderRe matches a placeholder in progress_name_template
+var progressNamePlaceholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// progressNameRotatedRe matches the end of a template rendering to a rotated part name, e.g. {slug}.2,
+// see progress.RotatedPath. a branch can be a plain number, so it can't follow the dot either.
+var progressNameRotatedRe = regexp.MustCompile(`\.(\d+|\{branch\})$`)
+
+// validateProgressNameTemplate checks that the template only uses known placeholders and doesn't contain path separators,
+// progress files are always created in the working directory. empty template is valid and means "use default names".
+// the template must contain {slug}, otherwise runs of different plans get the same file and the logger truncates
+// the other run's log, and it must not render to a name the dashboard takes for a rotated part.
+func validateProgressNameTemplate(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	if strings.ContainsAny(tmpl, `/\`) {
+		return fmt.Errorf("invalid progress_name_template: %q contains a path separator", tmpl)
+	}
+	for _, p := range progressNamePlaceholderRe.FindAllString(tmpl, -1) {
+		if !slices.Contains(ProgressNamePlaceholders, p) {
+			return fmt.Errorf("invalid progress_name_template: unknown placeholder %s, want one of %s",
+				p, strings.Join(ProgressNamePlaceholders, ", "))
+		}
+	}
+	if !strings.Contains(tmpl, "{slug}") {
+		return fmt.Errorf("invalid progress_name_template: %q has no {slug}, runs of different plans would share a file", tmpl)
+	}
+	if progressNameRotatedRe.MatchString(strings.TrimRight(tmpl, "-_. ")) {
+		return fmt.Errorf("invalid progress_name_template: %q ends like a rotated part name, e.g. progress-<name>.1.txt", tmpl)
+	}
+	return nil
+}
+
+// ValidateBindAddr checks that addr is an IPv4 or IPv6 address or "localhost", the address the dashboard binds to.
+// empty addr is valid and means "use default".
+func ValidateBindAddr(addr string) error {
+	if addr == "" || addr == "localhost" || net.ParseIP(addr) != nil {
+		return nil
+	}
+	if strings.HasPrefix(addr, "[") || strings.Count(addr, ":") == 1 {
+		return fmt.Errorf("%q must be an address without brackets or port", addr)
+	}
+	return fmt.Errorf("%q is not an IP address", addr)
+}
+
 // mergeFrom merges non-empty values from src into dst.
 func (dst *Values) mergeFrom(src *Values) {
 	if src.ClaudeCommand != "" {
@@ -270,28 +714,147 @@ func (dst *Values) mergeFrom(src *Values) {
 	if src.CodexSandbox != "" {
 		dst.CodexSandbox = src.CodexSandbox
 	}
+	if src.CodexArgs != "" {
+		dst.CodexArgs = src.CodexArgs
+	}
+	if src.CodexPassesSet {
+		dst.CodexPasses = src.CodexPasses
+		dst.CodexPassesSet = true
+	}
+	if src.CodexMinFindingsSet {
+		dst.CodexMinFindings = src.CodexMinFindings
+		dst.CodexMinFindingsSet = true
+	}
+	if src.ReviewOrder != "" {
+		dst.ReviewOrder = src.ReviewOrder
+	}
+	if src.ContinueOnReviewFailureSet {
+		dst.ContinueOnReviewFailure = src.ContinueOnReviewFailure
+		dst.ContinueOnReviewFailureSet = true
+	}
 	if src.IterationDelayMsSet {
 		dst.IterationDelayMs = src.IterationDelayMs
 		dst.IterationDelayMsSet = true
 	}
+	if src.IterationDelayJitterMs > 0 {
+		dst.IterationDelayJitterMs = src.IterationDelayJitterMs
+	}
 	if src.TaskRetryCountSet {
 		dst.TaskRetryCount = src.TaskRetryCount
 		dst.TaskRetryCountSet = true
 	}
+	if src.RetryOnCrashSet {
+		dst.RetryOnCrash = src.RetryOnCrash
+		dst.RetryOnCrashSet = true
+	}
+	if src.StallLimitSet {
+		dst.StallLimit = src.StallLimit
+		dst.StallLimitSet = true
+	}
 	if src.FinalizeEnabledSet {
 		dst.FinalizeEnabled = src.FinalizeEnabled
 		dst.FinalizeEnabledSet = true
 	}
+	if src.UseWorktreeSet {
+		dst.UseWorktree = src.UseWorktree
+		dst.UseWorktreeSet = true
+	}
+	if src.WorktreePruneOnCancelSet {
+		dst.WorktreePruneOnCancel = src.WorktreePruneOnCancel
+		dst.WorktreePruneOnCancelSet = true
+	}
+	if src.AutoCommitSet {
+		dst.AutoCommit = src.AutoCommit
+		dst.AutoCommitSet = true
+	}
+	if src.RequireCleanTreeSet {
+		dst.RequireCleanTree = src.RequireCleanTree
+		dst.RequireCleanTreeSet = true
+	}
+	if src.TreatProseAsTaskSet {
+		dst.TreatProseAsTask = src.TreatProseAsTask
+		dst.TreatProseAsTaskSet = true
+	}
+	if src.AutoPushSet {
+		dst.AutoPush = src.AutoPush
+		dst.AutoPushSet = true
+	}
+	if src.PushRemote != "" {
+		dst.PushRemote = src.PushRemote
+	}
 	if src.PlansDir != "" {
 		dst.PlansDir = src.PlansDir
 	}
 	if len(src.WatchDirs) > 0 {
 		dst.WatchDirs = src.WatchDirs
 	}
+	if src.WatchRecursiveSet {
+		dst.WatchRecursive = src.WatchRecursive
+		dst.WatchRecursiveSet = true
+	}
+	if src.FollowCompletedSet {
+		dst.FollowCompleted = src.FollowCompleted
+		dst.FollowCompletedSet = true
+	}
+	if src.LazyLoadCompletedSet {
+		dst.LazyLoadCompleted = src.LazyLoadCompleted
+		dst.LazyLoadCompletedSet = true
+	}
+	if src.SessionRetentionDaysSet {
+		dst.SessionRetentionDays = src.SessionRetentionDays
+		dst.SessionRetentionDaysSet = true
+	}
+	if src.TailPollIntervalMs > 0 {
+		dst.TailPollIntervalMs = src.TailPollIntervalMs
+	}
+	if src.ShutdownTimeoutMs > 0 {
+		dst.ShutdownTimeoutMs = src.ShutdownTimeoutMs
+	}
+	if src.KeepAliveIntervalMs > 0 {
+		dst.KeepAliveIntervalMs = src.KeepAliveIntervalMs
+	}
+	if src.BindAddr != "" {
+		dst.BindAddr = src.BindAddr
+	}
+	if src.RateLimitPerMin > 0 {
+		dst.RateLimitPerMin = src.RateLimitPerMin
+	}
+	if src.MaxEventTextBytesSet {
+		dst.MaxEventTextBytes = src.MaxEventTextBytes
+		dst.MaxEventTextBytesSet = true
+	}
+	if src.ReadOnlySet {
+		dst.ReadOnly = src.ReadOnly
+		dst.ReadOnlySet = true
+	}
+	if src.ProgressFormat != "" {
+		dst.ProgressFormat = src.ProgressFormat
+	}
+	if src.ProgressNameTemplate != "" {
+		dst.ProgressNameTemplate = src.ProgressNameTemplate
+	}
+	if src.TimestampFormat != "" {
+		dst.TimestampFormat = src.TimestampFormat
+	}
+	if src.MaxProgressSizeMBSet {
+		dst.MaxProgressSizeMB = src.MaxProgressSizeMB
+		dst.MaxProgressSizeMBSet = true
+	}
+	if src.CompressCompletedSet {
+		dst.CompressCompleted = src.CompressCompleted
+		dst.CompressCompletedSet = true
+	}
+	if src.TokenUsagePattern != "" {
+		dst.TokenUsagePattern = src.TokenUsagePattern
+	}
 	if len(src.ClaudeErrorPatterns) > 0 {
 		dst.ClaudeErrorPatterns = src.ClaudeErrorPatterns
 	}
 	if len(src.CodexErrorPatterns) > 0 {
 		dst.CodexErrorPatterns = src.CodexErrorPatterns
 	}
+	if len(src.ExecutorEnv) > 0 {
+		dst.ExecutorEnv = src.ExecutorEnv
+	}
+	dst.Sources = append(dst.Sources, src.Sources...)
 }
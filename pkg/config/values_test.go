@@ -29,6 +29,8 @@ func TestValuesLoader_Load_EmbeddedOnly(t *testing.T) {
 	assert.Equal(t, "xhigh", values.CodexReasoningEffort)
 	assert.Equal(t, 3600000, values.CodexTimeoutMs)
 	assert.Equal(t, "read-only", values.CodexSandbox)
+	assert.Equal(t, 1, values.CodexPasses)
+	assert.True(t, values.CodexPassesSet)
 	assert.Equal(t, 2000, values.IterationDelayMs)
 	assert.Equal(t, 1, values.TaskRetryCount)
 	assert.True(t, values.TaskRetryCountSet)
@@ -74,6 +76,7 @@ claude_command = /global/claude
 claude_args = --global-args
 iteration_delay_ms = 5000
 plans_dir = global/plans
+session_retention_days = 30
 `
 	require.NoError(t, os.WriteFile(globalConfig, []byte(globalContent), 0o600))
 
@@ -94,6 +97,7 @@ plans_dir = local/plans
 	// global values preserved when not overridden
 	assert.Equal(t, "--global-args", values.ClaudeArgs)
 	assert.Equal(t, 5000, values.IterationDelayMs)
+	assert.Equal(t, 30, values.SessionRetentionDays)
 }
 
 func TestValuesLoader_Load_PartialConfigs(t *testing.T) {
@@ -128,9 +132,51 @@ func TestValuesLoader_Load_InvalidConfig(t *testing.T) {
 		{name: "invalid codex_timeout_ms", config: "codex_timeout_ms = abc", errPart: "codex_timeout_ms"},
 		{name: "invalid codex_enabled", config: "codex_enabled = maybe", errPart: "codex_enabled"},
 		{name: "invalid finalize_enabled", config: "finalize_enabled = maybe", errPart: "finalize_enabled"},
+		{name: "invalid codex_passes", config: "codex_passes = many", errPart: "codex_passes"},
+		{name: "negative codex_passes", config: "codex_passes = -2", errPart: "codex_passes"},
+		{name: "invalid codex_min_findings", config: "codex_min_findings = some", errPart: "codex_min_findings"},
+		{name: "negative codex_min_findings", config: "codex_min_findings = -1", errPart: "codex_min_findings"},
+		{name: "invalid max_progress_size_mb", config: "max_progress_size_mb = big", errPart: "max_progress_size_mb"},
+		{name: "negative max_progress_size_mb", config: "max_progress_size_mb = -5", errPart: "max_progress_size_mb"},
+		{name: "invalid compress_completed", config: "compress_completed = maybe", errPart: "compress_completed"},
+		{name: "invalid continue_on_review_failure", config: "continue_on_review_failure = sometimes",
+			errPart: "continue_on_review_failure"},
+		{name: "executor_env without value", config: "executor_env = MODEL", errPart: "executor_env"},
+		{name: "executor_env without key", config: "executor_env = =secret", errPart: "executor_env"},
+		{name: "invalid auto_commit", config: "auto_commit = maybe", errPart: "auto_commit"},
+		{name: "invalid require_clean_tree", config: "require_clean_tree = yes please", errPart: "require_clean_tree"},
+		{name: "invalid treat_prose_as_task", config: "treat_prose_as_task = maybe", errPart: "treat_prose_as_task"},
+		{name: "invalid auto_push", config: "auto_push = sometimes", errPart: "auto_push"},
+		{name: "invalid token_usage_pattern regex", config: "token_usage_pattern = tokens: ([0-9]+", errPart: "token_usage_pattern"},
+		{name: "token_usage_pattern without group", config: `token_usage_pattern = tokens: \d+`, errPart: "capture group"},
+		{name: "invalid use_worktree", config: "use_worktree = maybe", errPart: "use_worktree"},
+		{name: "invalid worktree_prune_on_cancel", config: "worktree_prune_on_cancel = maybe", errPart: "worktree_prune_on_cancel"},
 		{name: "negative task_retry_count", config: "task_retry_count = -1", errPart: "task_retry_count"},
+		{name: "invalid retry_on_crash", config: "retry_on_crash = often", errPart: "retry_on_crash"},
 		{name: "negative codex_timeout_ms", config: "codex_timeout_ms = -100", errPart: "codex_timeout_ms"},
 		{name: "negative iteration_delay_ms", config: "iteration_delay_ms = -50", errPart: "iteration_delay_ms"},
+		{name: "invalid iteration_delay_jitter_ms", config: "iteration_delay_jitter_ms = some", errPart: "iteration_delay_jitter_ms"},
+		{name: "negative iteration_delay_jitter_ms", config: "iteration_delay_jitter_ms = -5", errPart: "iteration_delay_jitter_ms"},
+		{name: "invalid session_retention_days", config: "session_retention_days = week", errPart: "session_retention_days"},
+		{name: "invalid tail_poll_interval_ms", config: "tail_poll_interval_ms = fast", errPart: "tail_poll_interval_ms"},
+		{name: "negative tail_poll_interval_ms", config: "tail_poll_interval_ms = -1", errPart: "tail_poll_interval_ms"},
+		{name: "invalid shutdown_timeout_ms", config: "shutdown_timeout_ms = soon", errPart: "shutdown_timeout_ms"},
+		{name: "invalid keepalive_interval_ms", config: "keepalive_interval_ms = often", errPart: "keepalive_interval_ms"},
+		{name: "invalid watch_recursive", config: "watch_recursive = deep", errPart: "watch_recursive"},
+		{name: "invalid follow_completed", config: "follow_completed = always", errPart: "follow_completed"},
+		{name: "invalid lazy_load_completed", config: "lazy_load_completed = later", errPart: "lazy_load_completed"},
+		{name: "invalid read_only", config: "read_only = maybe", errPart: "read_only"},
+		{name: "invalid bind_addr", config: "bind_addr = example.com", errPart: "bind_addr"},
+		{name: "bind_addr with port", config: "bind_addr = 127.0.0.1:8080", errPart: "bind_addr"},
+		{name: "invalid stall_limit", config: "stall_limit = never", errPart: "stall_limit"},
+		{name: "negative stall_limit", config: "stall_limit = -2", errPart: "stall_limit"},
+		{name: "invalid rate_limit_per_min", config: "rate_limit_per_min = lots", errPart: "rate_limit_per_min"},
+		{name: "negative rate_limit_per_min", config: "rate_limit_per_min = -1", errPart: "rate_limit_per_min"},
+		{name: "invalid max_event_text_bytes", config: "max_event_text_bytes = huge", errPart: "max_event_text_bytes"},
+		{name: "negative max_event_text_bytes", config: "max_event_text_bytes = -1", errPart: "max_event_text_bytes"},
+		{name: "negative shutdown_timeout_ms", config: "shutdown_timeout_ms = -1", errPart: "shutdown_timeout_ms"},
+		{name: "negative keepalive_interval_ms", config: "keepalive_interval_ms = -1", errPart: "keepalive_interval_ms"},
+		{name: "negative session_retention_days", config: "session_retention_days = -1", errPart: "session_retention_days"},
 	}
 
 	for _, tc := range tests {
@@ -221,6 +267,21 @@ func TestValuesLoader_Load_ExplicitZeroIterationDelayMs(t *testing.T) {
 	assert.True(t, values.IterationDelayMsSet)
 }
 
+func TestValuesLoader_Load_ExplicitZeroCodexPasses(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`codex_passes = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load("", configPath)
+	require.NoError(t, err)
+
+	// explicit zero opts into auto passes, not overwritten by embedded default 1
+	assert.Equal(t, 0, values.CodexPasses)
+	assert.True(t, values.CodexPassesSet)
+}
+
 func TestValuesLoader_Load_LocalOverridesCodexEnabled(t *testing.T) {
 	tmpDir := t.TempDir()
 	globalConfig := filepath.Join(tmpDir, "global")
@@ -253,6 +314,91 @@ func TestValuesLoader_Load_LocalOverridesTaskRetryCount(t *testing.T) {
 	assert.True(t, values.TaskRetryCountSet)
 }
 
+func TestValuesLoader_Load_LocalOverridesSessionRetentionDays(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`session_retention_days = 30`), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`session_retention_days = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	// explicit zero in local config disables what global config enabled
+	assert.Equal(t, 0, values.SessionRetentionDays)
+	assert.True(t, values.SessionRetentionDaysSet)
+}
+
+func TestValuesLoader_Load_LocalOverridesStallLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`stall_limit = 3`), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`stall_limit = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	// explicit zero in local config disables what global config enabled
+	assert.Equal(t, 0, values.StallLimit)
+	assert.True(t, values.StallLimitSet)
+}
+
+func TestValuesLoader_Load_LocalOverridesCodexMinFindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`codex_min_findings = 2`), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`codex_min_findings = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	// explicit zero in local config disables what global config enabled
+	assert.Equal(t, 0, values.CodexMinFindings)
+	assert.True(t, values.CodexMinFindingsSet)
+}
+
+func TestValuesLoader_Load_LocalOverridesMaxProgressSizeMB(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`max_progress_size_mb = 10`), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`max_progress_size_mb = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	// explicit zero in local config disables what global config enabled
+	assert.Equal(t, 0, values.MaxProgressSizeMB)
+	assert.True(t, values.MaxProgressSizeMBSet)
+}
+
+func TestValuesLoader_Load_LocalOverridesMaxEventTextBytes(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`max_event_text_bytes = 4096`), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`max_event_text_bytes = 0`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	// explicit zero in local config disables what global config enabled
+	assert.Equal(t, 0, values.MaxEventTextBytes)
+	assert.True(t, values.MaxEventTextBytesSet)
+}
+
 func TestValuesLoader_Load_LocalOverridesFinalizeEnabled(t *testing.T) {
 	tmpDir := t.TempDir()
 	globalConfig := filepath.Join(tmpDir, "global")
@@ -269,6 +415,69 @@ func TestValuesLoader_Load_LocalOverridesFinalizeEnabled(t *testing.T) {
 	assert.True(t, values.FinalizeEnabledSet)
 }
 
+func TestValuesLoader_Load_CodexArgs(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load("", "")
+	require.NoError(t, err)
+	assert.Empty(t, values.CodexArgs, "no codex args by default")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`codex_args = --profile global`), 0o600))
+	values, err = loader.Load("", globalConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "--profile global", values.CodexArgs)
+
+	require.NoError(t, os.WriteFile(localConfig, []byte(`codex_args = -c model_verbosity="low"`), 0o600))
+	values, err = loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+	assert.Equal(t, `-c model_verbosity="low"`, values.CodexArgs)
+	assert.Equal(t, "gpt-5.2-codex", values.CodexModel, "other codex settings are unaffected")
+}
+
+func TestValuesLoader_Load_WorktreeOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte("use_worktree = true\nworktree_prune_on_cancel = true"), 0o600))
+	require.NoError(t, os.WriteFile(localConfig, []byte(`worktree_prune_on_cancel = false`), 0o600))
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+
+	assert.True(t, values.UseWorktree)
+	assert.True(t, values.UseWorktreeSet)
+	assert.False(t, values.WorktreePruneOnCancel)
+	assert.True(t, values.WorktreePruneOnCancelSet)
+}
+
+func TestValuesLoader_Load_WatchRecursive(t *testing.T) {
+	tmpDir := t.TempDir()
+	globalConfig := filepath.Join(tmpDir, "global")
+	localConfig := filepath.Join(tmpDir, "local")
+
+	loader := newValuesLoader(defaultsFS)
+	values, err := loader.Load("", "")
+	require.NoError(t, err)
+	assert.True(t, values.WatchRecursive, "recursive by default")
+
+	require.NoError(t, os.WriteFile(globalConfig, []byte("watch_dirs = ~/projects/*\nwatch_recursive = false"), 0o600))
+	values, err = loader.Load("", globalConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"~/projects/*"}, values.WatchDirs, "patterns are kept for the dashboard to expand")
+	assert.False(t, values.WatchRecursive)
+
+	require.NoError(t, os.WriteFile(localConfig, []byte("watch_recursive = true"), 0o600))
+	values, err = loader.Load(localConfig, globalConfig)
+	require.NoError(t, err)
+	assert.True(t, values.WatchRecursive)
+	assert.True(t, values.WatchRecursiveSet)
+}
+
 func TestValuesLoader_Load_AllValuesFromUserConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config")
@@ -281,7 +490,7 @@ codex_command = /custom/codex
 codex_model = custom-model
 codex_reasoning_effort = low
 codex_timeout_ms = 1000
-codex_sandbox = none
+codex_sandbox = workspace-write
 iteration_delay_ms = 500
 task_retry_count = 5
 plans_dir = my/plans
@@ -300,7 +509,7 @@ plans_dir = my/plans
 	assert.Equal(t, "custom-model", values.CodexModel)
 	assert.Equal(t, "low", values.CodexReasoningEffort)
 	assert.Equal(t, 1000, values.CodexTimeoutMs)
-	assert.Equal(t, "none", values.CodexSandbox)
+	assert.Equal(t, "workspace-write", values.CodexSandbox)
 	assert.Equal(t, 500, values.IterationDelayMs)
 	assert.Equal(t, 5, values.TaskRetryCount)
 	assert.True(t, values.TaskRetryCountSet)
@@ -409,7 +618,7 @@ codex_command = /custom/codex
 codex_model = gpt-5
 codex_reasoning_effort = high
 codex_timeout_ms = 7200000
-codex_sandbox = none
+codex_sandbox = workspace-write
 iteration_delay_ms = 5000
 task_retry_count = 3
 plans_dir = custom/plans
@@ -425,7 +634,7 @@ plans_dir = custom/plans
 		assert.Equal(t, "gpt-5", values.CodexModel)
 		assert.Equal(t, "high", values.CodexReasoningEffort)
 		assert.Equal(t, 7200000, values.CodexTimeoutMs)
-		assert.Equal(t, "none", values.CodexSandbox)
+		assert.Equal(t, "workspace-write", values.CodexSandbox)
 		assert.Equal(t, 5000, values.IterationDelayMs)
 		assert.Equal(t, 3, values.TaskRetryCount)
 		assert.True(t, values.TaskRetryCountSet)
@@ -492,6 +701,62 @@ func TestValuesLoader_parseValuesFromBytes_InvalidINI(t *testing.T) {
 	assert.Contains(t, err.Error(), "parse config")
 }
 
+func TestValuesLoader_parseValuesFromBytes_EnumValues(t *testing.T) {
+	vl := &valuesLoader{embedFS: defaultsFS}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "valid reasoning effort", input: "codex_reasoning_effort = medium"},
+		{name: "valid xhigh reasoning effort", input: "codex_reasoning_effort = xhigh"},
+		{name: "empty reasoning effort", input: "codex_reasoning_effort ="},
+		{name: "invalid reasoning effort", input: "codex_reasoning_effort = hgh",
+			wantErr: `invalid codex_reasoning_effort: got "hgh", want one of minimal, low, medium, high, xhigh`},
+		{name: "valid sandbox", input: "codex_sandbox = danger-full-access"},
+		{name: "empty sandbox", input: "codex_sandbox ="},
+		{name: "invalid sandbox", input: "codex_sandbox = none",
+			wantErr: `invalid codex_sandbox: got "none", want one of read-only, workspace-write, danger-full-access`},
+		{name: "sandbox is case sensitive", input: "codex_sandbox = Read-Only", wantErr: "invalid codex_sandbox"},
+		{name: "valid review order", input: "review_order = codex-first"},
+		{name: "invalid review order", input: "review_order = codex",
+			wantErr: `invalid review_order: got "codex", want one of claude-first, codex-first`},
+		{name: "valid progress format", input: "progress_format = jsonl"},
+		{name: "empty progress format", input: "progress_format ="},
+		{name: "invalid progress format", input: "progress_format = json",
+			wantErr: `invalid progress_format: got "json", want one of text, jsonl`},
+		{name: "valid progress name template", input: "progress_name_template = {date}_{slug}-{mode}-{branch}"},
+		{name: "empty progress name template", input: "progress_name_template ="},
+		{name: "unknown progress name placeholder", input: "progress_name_template = {date}-{project}",
+			wantErr: "invalid progress_name_template: unknown placeholder {project}, want one of {date}, {branch}, {mode}, {slug}"},
+		{name: "progress name template with path", input: "progress_name_template = logs/{slug}",
+			wantErr: "invalid progress_name_template: \"logs/{slug}\" contains a path separator"},
+		{name: "progress name template without slug", input: "progress_name_template = {date}-{mode}",
+			wantErr: "invalid progress_name_template: \"{date}-{mode}\" has no {slug}, runs of different plans would share a file"},
+		{name: "progress name template like a rotated part", input: "progress_name_template = {slug}.2",
+			wantErr: "invalid progress_name_template: \"{slug}.2\" ends like a rotated part name"},
+		{name: "progress name template ending with branch after dot", input: "progress_name_template = {slug}.{branch}",
+			wantErr: "ends like a rotated part name"},
+		{name: "progress name template with dotted date", input: "progress_name_template = {slug}.{date}"},
+		{name: "valid timestamp format", input: "timestamp_format = iso8601"},
+		{name: "invalid timestamp format", input: "timestamp_format = rfc3339",
+			wantErr: `invalid timestamp_format: got "rfc3339", want one of legacy, iso8601`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := vl.parseValuesFromBytes([]byte(tc.input))
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
 func TestValuesLoader_parseValuesFromBytes_ErrorPatterns(t *testing.T) {
 	vl := &valuesLoader{embedFS: defaultsFS}
 
@@ -704,3 +969,33 @@ func TestValuesLoader_Load_BothAllCommentedFallsBackToEmbedded(t *testing.T) {
 	assert.Equal(t, "docs/plans", values.PlansDir)
 	assert.True(t, values.CodexEnabled)
 }
+
+func TestValidateBindAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		wantErr string
+	}{
+		{addr: ""},
+		{addr: "localhost"},
+		{addr: "127.0.0.1"},
+		{addr: "0.0.0.0"},
+		{addr: "::"},
+		{addr: "::1"},
+		{addr: "fe80::1"},
+		{addr: "example.com", wantErr: "not an IP address"},
+		{addr: "127.0.0.1:8080", wantErr: "without brackets or port"},
+		{addr: "[::1]", wantErr: "without brackets or port"},
+		{addr: "256.0.0.1", wantErr: "not an IP address"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.addr, func(t *testing.T) {
+			err := ValidateBindAddr(tc.addr)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
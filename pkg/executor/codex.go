@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 )
 
@@ -25,7 +26,9 @@ type CodexRunner interface {
 
 // execCodexRunner is the default command runner using os/exec for codex.
 // codex outputs streaming progress to stderr, final response to stdout.
-type execCodexRunner struct{}
+type execCodexRunner struct {
+	env []string // extra KEY=VALUE environment variables, see withEnv
+}
 
 func (r *execCodexRunner) Run(ctx context.Context, name string, args ...string) (CodexStreams, func() error, error) {
 	// check context before starting to avoid spawning a process that will be immediately killed
@@ -36,6 +39,9 @@ func (r *execCodexRunner) Run(ctx context.Context, name string, args ...string)
 	// use exec.Command (not CommandContext) because we handle cancellation ourselves
 	// to ensure the entire process group is killed, not just the direct child
 	cmd := exec.Command(name, args...) //nolint:noctx // intentional: we handle context cancellation via process group kill
+	if len(r.env) > 0 {
+		cmd.Env = withEnv(os.Environ(), r.env)
+	}
 
 	// create new process group so we can kill all descendants on cleanup
 	setupProcessGroup(cmd)
@@ -67,10 +73,13 @@ type CodexExecutor struct {
 	ReasoningEffort string            // reasoning effort level, defaults to "xhigh"
 	TimeoutMs       int               // stream idle timeout in ms, defaults to 3600000
 	Sandbox         string            // sandbox mode, defaults to "read-only"
+	Args            string            // extra arguments (space-separated), appended after built-in settings
 	ProjectDoc      string            // path to project documentation file
 	OutputHandler   func(text string) // called for each filtered output line in real-time
 	Debug           bool              // enable debug output
 	ErrorPatterns   []string          // patterns to detect in output (e.g., rate limit messages)
+	TokenPattern    *regexp.Regexp    // matches token usage in stderr lines, first group is the count
+	Env             []string          // extra KEY=VALUE environment variables of the codex process, not logged
 	runner          CodexRunner       // for testing, nil uses default
 }
 
@@ -80,14 +89,24 @@ type codexFilterState struct {
 	seen        map[string]bool // track all shown lines for deduplication
 }
 
+// CheckCommand verifies that the codex command is available.
+func (e *CodexExecutor) CheckCommand() error {
+	return CheckCommand("codex", e.command())
+}
+
+// command returns the configured codex command or the default.
+func (e *CodexExecutor) command() string {
+	if e.Command == "" {
+		return "codex"
+	}
+	return e.Command
+}
+
 // Run executes codex CLI with the given prompt and returns filtered output.
 // stderr is streamed line-by-line to OutputHandler for progress indication.
 // stdout is captured entirely as the final response (returned in Result.Output).
 func (e *CodexExecutor) Run(ctx context.Context, prompt string) Result {
-	cmd := e.Command
-	if cmd == "" {
-		cmd = "codex"
-	}
+	cmd := e.command()
 
 	model := e.Model
 	if model == "" {
@@ -125,22 +144,31 @@ func (e *CodexExecutor) Run(ctx context.Context, prompt string) Result {
 		args = append(args, "-c", fmt.Sprintf("project_doc=%q", e.ProjectDoc))
 	}
 
+	// user args go last so their -c overrides take precedence over the built-in settings
+	if e.Args != "" {
+		args = append(args, splitArgs(e.Args)...)
+	}
+
 	args = append(args, prompt)
 
 	runner := e.runner
 	if runner == nil {
-		runner = &execCodexRunner{}
+		runner = &execCodexRunner{env: e.Env}
 	}
 
 	streams, wait, err := runner.Run(ctx, cmd, args...)
 	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return Result{Error: &CommandNotFoundError{Tool: "codex", Command: cmd}}
+		}
 		return Result{Error: fmt.Errorf("start codex: %w", err)}
 	}
 
-	// process stderr for progress display (header block + bold summaries)
+	// process stderr for progress display (header block + bold summaries) and token usage
+	var usage TokenUsage
 	stderrDone := make(chan error, 1)
 	go func() {
-		stderrDone <- e.processStderr(ctx, streams.Stderr)
+		stderrDone <- e.processStderr(ctx, streams.Stderr, &usage)
 	}()
 
 	// read stdout entirely as final response
@@ -176,16 +204,18 @@ func (e *CodexExecutor) Run(ctx context.Context, prompt string) Result {
 			Output: stdoutContent,
 			Signal: signal,
 			Error:  &PatternMatchError{Pattern: pattern, HelpCmd: "codex /status"},
+			Usage:  usage,
 		}
 	}
 
 	// return stdout content as the result (the actual answer from codex)
-	return Result{Output: stdoutContent, Signal: signal, Error: finalErr}
+	return Result{Output: stdoutContent, Signal: signal, Error: finalErr, Usage: usage}
 }
 
 // processStderr reads stderr line-by-line, filters for progress display.
 // shows header block (between first two "--------" separators) and bold summaries.
-func (e *CodexExecutor) processStderr(ctx context.Context, r io.Reader) error {
+// token usage matching TokenPattern is counted on all lines, including hidden ones.
+func (e *CodexExecutor) processStderr(ctx context.Context, r io.Reader, usage *TokenUsage) error {
 	state := &codexFilterState{}
 	scanner := bufio.NewScanner(r)
 	// increase buffer size for large output lines
@@ -200,6 +230,7 @@ func (e *CodexExecutor) processStderr(ctx context.Context, r io.Reader) error {
 		}
 
 		line := scanner.Text()
+		usage.add(e.TokenPattern, line)
 		if show, filtered := e.shouldDisplay(line, state); show {
 			if e.OutputHandler != nil {
 				e.OutputHandler(filtered + "\n")
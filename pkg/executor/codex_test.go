@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"slices"
 	"strings"
 	"testing"
@@ -149,6 +150,17 @@ func TestCodexExecutor_Run_StartError(t *testing.T) {
 	assert.Contains(t, result.Error.Error(), "command not found")
 }
 
+func TestCodexExecutor_Run_CommandNotFound(t *testing.T) {
+	e := &CodexExecutor{Command: "nonexistent-codex-12345"}
+
+	result := e.Run(context.Background(), "test")
+
+	var notFound *CommandNotFoundError
+	require.ErrorAs(t, result.Error, &notFound)
+	assert.Equal(t, `codex command "nonexistent-codex-12345" not found in PATH`, result.Error.Error())
+	assert.Error(t, e.CheckCommand())
+}
+
 func TestCodexExecutor_Run_WaitError(t *testing.T) {
 	mock := &mockCodexRunner{
 		runFunc: func(_ context.Context, _ string, _ ...string) (CodexStreams, func() error, error) {
@@ -244,6 +256,39 @@ func TestCodexExecutor_Run_CustomSettings(t *testing.T) {
 	assert.Contains(t, argsStr, `project_doc="/path/to/doc.md"`)
 }
 
+func TestCodexExecutor_Run_ExtraArgs(t *testing.T) {
+	t.Setenv("RALPHEX_DOCKER", "")
+
+	var capturedArgs []string
+	mock := &mockCodexRunner{
+		runFunc: func(_ context.Context, _ string, args ...string) (CodexStreams, func() error, error) {
+			capturedArgs = args
+			return mockStreams("", "result"), mockWait(), nil
+		},
+	}
+	e := &CodexExecutor{
+		runner:          mock,
+		Model:           "gpt-4o",
+		ReasoningEffort: "medium",
+		Sandbox:         "workspace-write",
+		Args:            `--profile review -c model="o3"`,
+	}
+
+	result := e.Run(context.Background(), "the prompt")
+	require.NoError(t, result.Error)
+
+	assert.Equal(t, []string{
+		"exec",
+		"--sandbox", "workspace-write",
+		"-c", `model="gpt-4o"`,
+		"-c", "model_reasoning_effort=medium",
+		"-c", "stream_idle_timeout_ms=3600000",
+		"--profile", "review",
+		"-c", "model=o3",
+		"the prompt",
+	}, capturedArgs, "extra args go after built-in settings and before the prompt")
+}
+
 func TestCodexExecutor_shouldDisplay_headerBlock(t *testing.T) {
 	e := &CodexExecutor{}
 
@@ -428,7 +473,7 @@ func TestCodexExecutor_processStderr_contextCancellation(t *testing.T) {
 	}()
 
 	e := &CodexExecutor{}
-	err := e.processStderr(ctx, pr)
+	err := e.processStderr(ctx, pr, &TokenUsage{})
 
 	// should return context.Canceled or nil (depending on timing)
 	if err != nil {
@@ -498,7 +543,7 @@ func TestCodexExecutor_processStderr_readError(t *testing.T) {
 	e := &CodexExecutor{}
 	errReader := &failingReader{err: errors.New("read failed")}
 
-	err := e.processStderr(context.Background(), errReader)
+	err := e.processStderr(context.Background(), errReader, &TokenUsage{})
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "read stderr")
@@ -641,7 +686,7 @@ func TestCodexExecutor_processStderr_largeLines(t *testing.T) {
 				},
 			}
 
-			err := e.processStderr(context.Background(), strings.NewReader(stderr))
+			err := e.processStderr(context.Background(), strings.NewReader(stderr), &TokenUsage{})
 
 			require.NoError(t, err, "should handle %d byte line without error", tc.size)
 			assert.Contains(t, shown, largeContent, "large content should be captured")
@@ -764,6 +809,34 @@ func TestCodexExecutor_Run_ErrorPattern(t *testing.T) {
 	}
 }
 
+func TestCodexExecutor_Run_TokenUsage(t *testing.T) {
+	stderr := "--------\nmodel: gpt-5\n--------\n**Reviewing**\ntokens used: 12,000\n**Done**\ntokens used: 345\n"
+	tests := []struct {
+		name    string
+		pattern *regexp.Regexp
+		want    TokenUsage
+	}{
+		{name: "usage lines accumulated", pattern: regexp.MustCompile(`tokens used: ([\d,]+)`),
+			want: TokenUsage{Tokens: 12345, Reported: true}},
+		{name: "no matching line", pattern: regexp.MustCompile(`total tokens: (\d+)`), want: TokenUsage{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockCodexRunner{
+				runFunc: func(_ context.Context, _ string, _ ...string) (CodexStreams, func() error, error) {
+					return mockStreams(stderr, "no issues found"), mockWait(), nil
+				},
+			}
+			e := &CodexExecutor{runner: mock, TokenPattern: tc.pattern}
+
+			result := e.Run(context.Background(), "analyze code")
+			require.NoError(t, result.Error)
+			assert.Equal(t, tc.want, result.Usage)
+		})
+	}
+}
+
 func TestCodexExecutor_Run_ErrorPattern_WithSignal(t *testing.T) {
 	// error pattern should still be detected even when output contains a signal
 	mock := &mockCodexRunner{
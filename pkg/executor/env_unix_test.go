@@ -0,0 +1,46 @@
+//go:build unix
+
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script to a temp dir and returns its path.
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fake-cli")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700)) //nolint:gosec // test script must be executable
+	return path
+}
+
+func TestClaudeExecutor_Env(t *testing.T) {
+	t.Setenv("RALPHEX_TEST_MODEL", "inherited")
+	script := writeScript(t, `printf '{"type":"content_block_delta","delta":{"type":"text_delta","text":"model=%s key=%s"}}\n' `+
+		`"$RALPHEX_TEST_MODEL" "$RALPHEX_TEST_KEY"`)
+
+	e := &ClaudeExecutor{Command: script, Env: []string{"RALPHEX_TEST_MODEL=opus", "RALPHEX_TEST_KEY=a=b"}}
+	result := e.Run(context.Background(), "prompt")
+	require.NoError(t, result.Error)
+	assert.Equal(t, "model=opus key=a=b", result.Output)
+}
+
+func TestCodexExecutor_Env(t *testing.T) {
+	script := writeScript(t, `echo "model=$RALPHEX_TEST_MODEL"`)
+
+	e := &CodexExecutor{Command: script, Env: []string{"RALPHEX_TEST_MODEL=gpt"}}
+	result := e.Run(context.Background(), "prompt")
+	require.NoError(t, result.Error)
+	assert.Contains(t, result.Output, "model=gpt")
+
+	e = &CodexExecutor{Command: script}
+	result = e.Run(context.Background(), "prompt")
+	require.NoError(t, result.Error)
+	assert.Contains(t, result.Output, "model=\n", "nothing is injected without Env")
+}
@@ -5,10 +5,13 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -20,9 +23,32 @@ const maxScannerBuffer = 64 * 1024 * 1024
 
 // Result holds execution result with output and detected signal.
 type Result struct {
-	Output string // accumulated text output
-	Signal string // detected signal (COMPLETED, FAILED, etc.) or empty
-	Error  error  // execution error if any
+	Output string     // accumulated text output
+	Signal string     // detected signal (COMPLETED, FAILED, etc.) or empty
+	Error  error      // execution error if any
+	Usage  TokenUsage // token usage reported in the CLI output, see TokenPattern of the executors
+}
+
+// TokenUsage is the token count reported by a CLI run.
+// Reported is false if no output line matched the token pattern, Tokens is unavailable then.
+type TokenUsage struct {
+	Tokens   int64
+	Reported bool
+}
+
+// add counts the tokens captured by pattern in line, ignoring thousands separators in the count.
+func (u *TokenUsage) add(pattern *regexp.Regexp, line string) {
+	if pattern == nil {
+		return
+	}
+	for _, m := range pattern.FindAllStringSubmatch(line, -1) {
+		n, err := strconv.ParseInt(strings.NewReplacer(",", "", "_", "", " ", "").Replace(m[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		u.Tokens += n
+		u.Reported = true
+	}
 }
 
 // PatternMatchError is returned when a configured error pattern is detected in output.
@@ -35,6 +61,25 @@ func (e *PatternMatchError) Error() string {
 	return fmt.Sprintf("detected error pattern: %q", e.Pattern)
 }
 
+// CommandNotFoundError is returned when an executor's CLI command can't be found.
+type CommandNotFoundError struct {
+	Tool    string // executor name, e.g. "claude"
+	Command string // configured command
+}
+
+func (e *CommandNotFoundError) Error() string {
+	return fmt.Sprintf("%s command %q not found in PATH", e.Tool, e.Command)
+}
+
+// CheckCommand verifies that command resolves to an executable, via PATH or as a file path.
+// returns *CommandNotFoundError if it doesn't.
+func CheckCommand(tool, command string) error {
+	if _, err := exec.LookPath(command); err != nil {
+		return &CommandNotFoundError{Tool: tool, Command: command}
+	}
+	return nil
+}
+
 // CommandRunner abstracts command execution for testing.
 // Returns an io.Reader for streaming output and a wait function for completion.
 type CommandRunner interface {
@@ -42,7 +87,9 @@ type CommandRunner interface {
 }
 
 // execClaudeRunner is the default command runner using os/exec.
-type execClaudeRunner struct{}
+type execClaudeRunner struct {
+	env []string // extra KEY=VALUE environment variables, see withEnv
+}
 
 func (r *execClaudeRunner) Run(ctx context.Context, name string, args ...string) (io.Reader, func() error, error) {
 	// check context before starting to avoid spawning a process that will be immediately killed
@@ -54,8 +101,8 @@ func (r *execClaudeRunner) Run(ctx context.Context, name string, args ...string)
 	// to ensure the entire process group is killed, not just the direct child
 	cmd := exec.Command(name, args...) //nolint:noctx // intentional: we handle context cancellation via process group kill
 
-	// filter out ANTHROPIC_API_KEY from environment (claude uses different auth)
-	cmd.Env = filterEnv(os.Environ(), "ANTHROPIC_API_KEY")
+	// filter out ANTHROPIC_API_KEY from environment (claude uses different auth), unless set explicitly in r.env
+	cmd.Env = withEnv(filterEnv(os.Environ(), "ANTHROPIC_API_KEY"), r.env)
 
 	// create new process group so we can kill all descendants on cleanup
 	setupProcessGroup(cmd)
@@ -144,6 +191,19 @@ func filterEnv(env []string, keysToRemove ...string) []string {
 	return result
 }
 
+// withEnv returns a copy of env with the KEY=VALUE entries of extra added, replacing entries of the same keys.
+func withEnv(env, extra []string) []string {
+	if len(extra) == 0 {
+		return env
+	}
+	keys := make([]string, 0, len(extra))
+	for _, e := range extra {
+		key, _, _ := strings.Cut(e, "=")
+		keys = append(keys, key)
+	}
+	return append(filterEnv(env, keys...), extra...)
+}
+
 // streamEvent represents a JSON event from claude CLI stream output.
 type streamEvent struct {
 	Type    string `json:"type"`
@@ -169,17 +229,39 @@ type ClaudeExecutor struct {
 	Command       string            // command to execute, defaults to "claude"
 	Args          string            // additional arguments (space-separated), defaults to standard args
 	OutputHandler func(text string) // called for each text chunk, can be nil
-	Debug         bool              // enable debug output
+	Debug         bool              // enable debug output, written to OutputHandler with a "[debug]" prefix
 	ErrorPatterns []string          // patterns to detect in output (e.g., rate limit messages)
+	TokenPattern  *regexp.Regexp    // matches token usage in raw stream lines, first group is the count
+	Env           []string          // extra KEY=VALUE environment variables of the claude process, not logged
 	cmdRunner     CommandRunner     // for testing, nil uses default
 }
 
+// debug writes a debug message prefixed with "[debug]" to OutputHandler, so it reaches the progress log
+// and the dashboard, or to stdout if there is no handler.
+func (e *ClaudeExecutor) debug(msg string) {
+	if e.OutputHandler != nil {
+		e.OutputHandler("[debug] " + msg + "\n")
+		return
+	}
+	fmt.Printf("[debug] %s\n", msg)
+}
+
+// CheckCommand verifies that the claude command is available.
+func (e *ClaudeExecutor) CheckCommand() error {
+	return CheckCommand("claude", e.command())
+}
+
+// command returns the configured claude command or the default.
+func (e *ClaudeExecutor) command() string {
+	if e.Command == "" {
+		return "claude"
+	}
+	return e.Command
+}
+
 // Run executes claude CLI with the given prompt and parses streaming JSON output.
 func (e *ClaudeExecutor) Run(ctx context.Context, prompt string) Result {
-	cmd := e.Command
-	if cmd == "" {
-		cmd = "claude"
-	}
+	cmd := e.command()
 
 	// build args from configured string or use defaults
 	var args []string
@@ -196,11 +278,14 @@ func (e *ClaudeExecutor) Run(ctx context.Context, prompt string) Result {
 
 	runner := e.cmdRunner
 	if runner == nil {
-		runner = &execClaudeRunner{}
+		runner = &execClaudeRunner{env: e.Env}
 	}
 
 	stdout, wait, err := runner.Run(ctx, cmd, args...)
 	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return Result{Error: &CommandNotFoundError{Tool: "claude", Command: cmd}}
+		}
 		return Result{Error: err}
 	}
 
@@ -209,7 +294,7 @@ func (e *ClaudeExecutor) Run(ctx context.Context, prompt string) Result {
 	if err := wait(); err != nil {
 		// check if it was context cancellation
 		if ctx.Err() != nil {
-			return Result{Output: result.Output, Signal: result.Signal, Error: ctx.Err()}
+			return Result{Output: result.Output, Signal: result.Signal, Error: ctx.Err(), Usage: result.Usage}
 		}
 		// non-zero exit might still have useful output
 		if result.Output == "" {
@@ -223,6 +308,7 @@ func (e *ClaudeExecutor) Run(ctx context.Context, prompt string) Result {
 			Output: result.Output,
 			Signal: result.Signal,
 			Error:  &PatternMatchError{Pattern: pattern, HelpCmd: "claude /usage"},
+			Usage:  result.Usage,
 		}
 	}
 
@@ -233,6 +319,7 @@ func (e *ClaudeExecutor) Run(ctx context.Context, prompt string) Result {
 func (e *ClaudeExecutor) parseStream(r io.Reader) Result {
 	var output strings.Builder
 	var signal string
+	var usage TokenUsage
 
 	scanner := bufio.NewScanner(r)
 	// increase buffer size for large JSON lines (large diffs with parallel agents)
@@ -244,12 +331,13 @@ func (e *ClaudeExecutor) parseStream(r io.Reader) Result {
 		if line == "" {
 			continue
 		}
+		usage.add(e.TokenPattern, line)
 
 		var event streamEvent
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
 			// print non-JSON lines as-is
 			if e.Debug {
-				fmt.Printf("[debug] non-JSON line: %s\n", line)
+				e.debug("non-JSON line: " + line)
 			}
 			output.WriteString(line)
 			output.WriteString("\n")
@@ -274,10 +362,10 @@ func (e *ClaudeExecutor) parseStream(r io.Reader) Result {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return Result{Output: output.String(), Signal: signal, Error: fmt.Errorf("stream read: %w", err)}
+		return Result{Output: output.String(), Signal: signal, Error: fmt.Errorf("stream read: %w", err), Usage: usage}
 	}
 
-	return Result{Output: output.String(), Signal: signal}
+	return Result{Output: output.String(), Signal: signal, Usage: usage}
 }
 
 // extractText extracts text content from various event types.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -44,6 +45,28 @@ func TestClaudeExecutor_Run_StartError(t *testing.T) {
 	assert.Contains(t, result.Error.Error(), "command not found")
 }
 
+func TestClaudeExecutor_Run_CommandNotFound(t *testing.T) {
+	e := &ClaudeExecutor{Command: "nonexistent-claude-12345"}
+
+	result := e.Run(context.Background(), "test prompt")
+
+	var notFound *CommandNotFoundError
+	require.ErrorAs(t, result.Error, &notFound)
+	assert.Equal(t, `claude command "nonexistent-claude-12345" not found in PATH`, result.Error.Error())
+}
+
+func TestClaudeExecutor_CheckCommand(t *testing.T) {
+	e := &ClaudeExecutor{Command: "nonexistent-claude-12345"}
+	err := e.CheckCommand()
+	var notFound *CommandNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "claude", notFound.Tool)
+	assert.Equal(t, "nonexistent-claude-12345", notFound.Command)
+
+	e = &ClaudeExecutor{Command: "sh"}
+	assert.NoError(t, e.CheckCommand())
+}
+
 func TestClaudeExecutor_Run_WaitError_WithOutput(t *testing.T) {
 	jsonStream := `{"type":"content_block_delta","delta":{"type":"text_delta","text":"partial output"}}`
 
@@ -231,6 +254,15 @@ func TestClaudeExecutor_parseStream_withDebug(t *testing.T) {
 	result := e.parseStream(strings.NewReader(input))
 
 	assert.Equal(t, "not json\nvalid", result.Output)
+
+	t.Run("debug lines go to output handler", func(t *testing.T) {
+		var chunks []string
+		e := &ClaudeExecutor{Debug: true, OutputHandler: func(text string) { chunks = append(chunks, text) }}
+		result := e.parseStream(strings.NewReader(input))
+
+		assert.Equal(t, "not json\nvalid", result.Output, "debug lines are not part of the output")
+		assert.Equal(t, []string{"[debug] non-JSON line: not json\n", "not json\n", "valid"}, chunks)
+	})
 }
 
 func TestClaudeExecutor_extractText(t *testing.T) {
@@ -431,6 +463,25 @@ func TestSplitArgs(t *testing.T) {
 	}
 }
 
+func TestWithEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   []string
+		extra []string
+		want  []string
+	}{
+		{name: "no extra", env: []string{"A=1"}, want: []string{"A=1"}},
+		{name: "adds new keys", env: []string{"A=1"}, extra: []string{"B=2"}, want: []string{"A=1", "B=2"}},
+		{name: "replaces existing keys", env: []string{"A=1", "B=2", "AB=3"}, extra: []string{"A=x=y"},
+			want: []string{"B=2", "AB=3", "A=x=y"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, withEnv(tc.env, tc.extra))
+		})
+	}
+}
+
 func TestFilterEnv(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -531,6 +582,29 @@ func TestPatternMatchError_Error(t *testing.T) {
 	assert.Equal(t, `detected error pattern: "rate limit exceeded"`, err.Error())
 }
 
+func TestCheckCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		wantErr string
+	}{
+		{name: "found in PATH", command: "sh"},
+		{name: "missing binary", command: "nonexistent-cmd-12345", wantErr: `tool command "nonexistent-cmd-12345" not found in PATH`},
+		{name: "missing path", command: "/nonexistent/bin/tool", wantErr: `tool command "/nonexistent/bin/tool" not found in PATH`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckCommand("tool", tc.command)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
 func TestCheckErrorPatterns(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -643,6 +717,53 @@ func TestClaudeExecutor_Run_ErrorPattern(t *testing.T) {
 	}
 }
 
+func TestClaudeExecutor_Run_TokenUsage(t *testing.T) {
+	stream := `{"type":"assistant","message":{"content":[{"type":"text","text":"working"}]},"usage":{"output_tokens":1200}}
+{"type":"assistant","message":{"content":[{"type":"text","text":" done"}]},"usage":{"output_tokens":300}}
+{"type":"result","result":"summary"}
+`
+	tests := []struct {
+		name    string
+		pattern *regexp.Regexp
+		want    TokenUsage
+	}{
+		{name: "usage lines accumulated", pattern: regexp.MustCompile(`"output_tokens":(\d+)`),
+			want: TokenUsage{Tokens: 1500, Reported: true}},
+		{name: "no matching line", pattern: regexp.MustCompile(`"input_tokens":(\d+)`), want: TokenUsage{}},
+		{name: "no pattern", want: TokenUsage{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mocks.CommandRunnerMock{
+				RunFunc: func(_ context.Context, _ string, _ ...string) (io.Reader, func() error, error) {
+					return strings.NewReader(stream), func() error { return nil }, nil
+				},
+			}
+			e := &ClaudeExecutor{cmdRunner: mock, TokenPattern: tc.pattern}
+
+			result := e.Run(context.Background(), "test prompt")
+			require.NoError(t, result.Error)
+			assert.Equal(t, "working done", result.Output)
+			assert.Equal(t, tc.want, result.Usage)
+		})
+	}
+}
+
+func TestTokenUsage_Add(t *testing.T) {
+	pattern := regexp.MustCompile(`tokens used:\s*([\d,_ ]+\d)`)
+	var u TokenUsage
+	u.add(pattern, "no usage here")
+	assert.Equal(t, TokenUsage{}, u)
+
+	u.add(pattern, "tokens used: 12,345")
+	u.add(pattern, "tokens used: 1_000 and tokens used: 5")
+	assert.Equal(t, TokenUsage{Tokens: 13350, Reported: true}, u)
+
+	u.add(nil, "tokens used: 100")
+	assert.Equal(t, int64(13350), u.Tokens, "nil pattern is ignored")
+}
+
 func TestClaudeExecutor_Run_ErrorPattern_WithSignal(t *testing.T) {
 	// error pattern should still be detected even when output contains a signal
 	jsonStream := `{"type":"content_block_delta","delta":{"type":"text_delta","text":"You've hit your limit <<<RALPHEX:ALL_TASKS_DONE>>>"}}`
@@ -2,10 +2,13 @@
 package git
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -221,6 +224,35 @@ func (r *repo) CheckoutBranch(name string) error {
 	return nil
 }
 
+// AddWorktree creates a linked worktree at path checked out on branch.
+// the branch is created from HEAD if it doesn't exist yet.
+// uses git CLI because go-git doesn't support linked worktree creation.
+func (r *repo) AddWorktree(branch, path string) error {
+	args := []string{"worktree", "add", path, branch}
+	if !r.BranchExists(branch) {
+		args = []string{"worktree", "add", "-b", branch, path}
+	}
+	return r.runGit(args...)
+}
+
+// RemoveWorktree removes a linked worktree, discarding its uncommitted changes, and prunes stale worktree records.
+// the branch checked out in the worktree is kept.
+func (r *repo) RemoveWorktree(path string) error {
+	if err := r.runGit("worktree", "remove", "--force", path); err != nil {
+		return err
+	}
+	return r.runGit("worktree", "prune")
+}
+
+// runGit runs a git CLI command in the repository root.
+func (r *repo) runGit(args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", r.path}, args...)...) //nolint:gosec,noctx // args are built internally
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // MoveFile moves a file using git (equivalent to git mv).
 // Paths can be absolute or relative to the repository root.
 // The destination directory must already exist.
@@ -306,6 +338,66 @@ func (r *repo) Commit(msg string) error {
 	return nil
 }
 
+// CommitAll stages all changes (modified, deleted and non-ignored untracked files) and commits them.
+// returns the new commit hash, or empty string if there was nothing to commit.
+func (r *repo) CommitAll(msg string) (string, error) {
+	wt, err := r.gitRepo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("get worktree: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return "", fmt.Errorf("get status: %w", err)
+	}
+
+	// sort for deterministic staging order
+	paths := make([]string, 0, len(status))
+	for path := range status {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	staged := 0
+	for _, path := range paths {
+		s := status[path]
+		switch {
+		case s.Worktree == git.Untracked:
+			ignored, ignoreErr := r.IsIgnored(path)
+			if ignoreErr != nil {
+				return "", fmt.Errorf("check ignored %s: %w", path, ignoreErr)
+			}
+			if ignored {
+				continue
+			}
+			if _, addErr := wt.Add(path); addErr != nil {
+				return "", fmt.Errorf("stage %s: %w", path, addErr)
+			}
+		case s.Worktree == git.Deleted:
+			if _, rmErr := wt.Remove(path); rmErr != nil {
+				return "", fmt.Errorf("stage removal of %s: %w", path, rmErr)
+			}
+		case s.Worktree != git.Unmodified:
+			if _, addErr := wt.Add(path); addErr != nil {
+				return "", fmt.Errorf("stage %s: %w", path, addErr)
+			}
+		case s.Staging == git.Unmodified:
+			continue // nothing staged or changed for this path
+		}
+		staged++
+	}
+
+	if staged == 0 {
+		return "", nil
+	}
+
+	hash, err := wt.Commit(msg, &git.CommitOptions{Author: r.getAuthor()})
+	if err != nil {
+		return "", fmt.Errorf("commit: %w", err)
+	}
+	return hash.String(), nil
+}
+
 // getAuthor returns the commit author from git config or a fallback.
 // checks repository config first (.git/config), then falls back to global config,
 // and finally to default values.
@@ -478,6 +570,78 @@ func (r *repo) HasChangesOtherThan(filePath string) (bool, error) {
 	return false, nil
 }
 
+// ChangedFiles returns the paths of files with uncommitted changes, relative to the repository root and sorted.
+// this includes modified/deleted tracked files, staged changes, and untracked files (excluding gitignored).
+func (r *repo) ChangedFiles() ([]string, error) {
+	statuses, err := r.Status()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, s := range statuses {
+		files = append(files, s.Path)
+	}
+	return files, nil
+}
+
+// ChangesHash returns a hash of the uncommitted changes: the path, status and current content of each changed file.
+// unlike ChangedFiles, it changes when an already changed file is edited again.
+// files that can't be read, e.g. deleted ones, are covered by their status only.
+func (r *repo) ChangesHash() (string, error) {
+	files, err := r.Status()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, f := range files {
+		_, _ = fmt.Fprintf(h, "%s %s\n", f.Status, f.Path)
+		if data, err := os.ReadFile(filepath.Join(r.path, f.Path)); err == nil { //nolint:gosec // path from git status
+			sum := sha256.Sum256(data)
+			_, _ = h.Write(sum[:])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileStatus is the status of a file with uncommitted changes.
+type FileStatus struct {
+	Path   string // path relative to the repository root
+	Status string // two-letter status as in git status --porcelain, staging then worktree, e.g. " M", "A ", "??"
+}
+
+// Status returns the files with uncommitted changes sorted by path, like git status --porcelain.
+// this includes modified/deleted tracked files, staged changes, and untracked files (excluding gitignored).
+func (r *repo) Status() ([]FileStatus, error) {
+	wt, err := r.gitRepo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("get worktree: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("get status: %w", err)
+	}
+
+	var files []FileStatus
+	for path, s := range status {
+		if !r.fileHasChanges(s) {
+			continue
+		}
+		if s.Worktree == git.Untracked {
+			ignored, err := r.IsIgnored(path)
+			if err != nil {
+				return nil, fmt.Errorf("check ignored: %w", err)
+			}
+			if ignored {
+				continue // skip gitignored untracked files
+			}
+		}
+		files = append(files, FileStatus{Path: path, Status: string([]byte{byte(s.Staging), byte(s.Worktree)})})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
 // FileHasChanges returns true if the given file has uncommitted changes.
 // this includes untracked, modified, deleted, or staged states.
 func (r *repo) FileHasChanges(filePath string) (bool, error) {
@@ -642,6 +806,47 @@ func (r *repo) diffStats(baseBranch string) (DiffStats, error) {
 	return result, nil
 }
 
+// headHash returns the hash of the commit HEAD points to.
+func (r *repo) headHash() (string, error) {
+	head, err := r.gitRepo.Head()
+	if err != nil {
+		return "", fmt.Errorf("get HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// diffSince returns the unified diff between ref (commit hash or branch) and HEAD.
+// returns empty string if ref and HEAD point to the same commit.
+func (r *repo) diffSince(ref string) (string, error) {
+	hash, err := r.gitRepo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	baseCommit, err := r.gitRepo.CommitObject(*hash)
+	if err != nil {
+		return "", fmt.Errorf("get commit %s: %w", ref, err)
+	}
+
+	headRef, err := r.gitRepo.Head()
+	if err != nil {
+		return "", fmt.Errorf("get HEAD: %w", err)
+	}
+	if headRef.Hash() == baseCommit.Hash {
+		return "", nil
+	}
+	headCommit, err := r.gitRepo.CommitObject(headRef.Hash())
+	if err != nil {
+		return "", fmt.Errorf("get HEAD commit: %w", err)
+	}
+
+	// binary files are rendered as "Binary files ... differ" by the patch encoder
+	patch, err := baseCommit.Patch(headCommit)
+	if err != nil {
+		return "", fmt.Errorf("get patch: %w", err)
+	}
+	return patch.String(), nil
+}
+
 // resolveToCommit resolves a branch name to a commit object.
 // tries local branch first, then remote tracking branch (origin/name).
 func (r *repo) resolveToCommit(branchName string) (*object.Commit, error) {
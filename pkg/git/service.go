@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/umputun/ralphex/pkg/plan"
 )
 
+// maxDiffSize limits the size of diffs returned by DiffSince, larger diffs are truncated.
+const maxDiffSize = 1 << 20
+
 // Logger provides logging for git operations output.
 // Compatible with *color.Color and standard log.Logger.
 // The return values from Printf are ignored by Service methods.
@@ -60,6 +64,34 @@ func (s *Service) HasCommits() (bool, error) {
 	return s.repo.HasCommits()
 }
 
+// IsClean returns true if the working tree has no uncommitted changes.
+// untracked files count as changes unless they are gitignored.
+func (s *Service) IsClean() (bool, error) {
+	files, err := s.repo.ChangedFiles()
+	if err != nil {
+		return false, err
+	}
+	return len(files) == 0, nil
+}
+
+// ChangedFiles returns the paths of files with uncommitted changes, relative to the repository root and sorted.
+// untracked files are included unless they are gitignored.
+func (s *Service) ChangedFiles() ([]string, error) {
+	return s.repo.ChangedFiles()
+}
+
+// ChangesHash returns a hash of the uncommitted changes, covering the content of changed files,
+// so it moves whenever the working tree is edited. untracked files are included unless they are gitignored.
+func (s *Service) ChangesHash() (string, error) {
+	return s.repo.ChangesHash()
+}
+
+// Status returns the files with uncommitted changes and their two-letter git status, sorted by path.
+// untracked files are included unless they are gitignored.
+func (s *Service) Status() ([]FileStatus, error) {
+	return s.repo.Status()
+}
+
 // CreateBranch creates a new branch and switches to it.
 func (s *Service) CreateBranch(name string) error {
 	return s.repo.CreateBranch(name)
@@ -135,6 +167,52 @@ func (s *Service) CreateBranchForPlan(planFile string) error {
 	return nil
 }
 
+// AddWorktree creates a linked worktree at path on branch, creating the branch from HEAD if needed.
+// if path is already a worktree of this repository it is reused as-is.
+func (s *Service) AddWorktree(branch, path string) error {
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		s.log.Printf("using existing worktree: %s\n", path)
+		return nil
+	}
+	s.log.Printf("creating worktree for branch %s: %s\n", branch, path)
+	if err := s.repo.AddWorktree(branch, path); err != nil {
+		return fmt.Errorf("add worktree: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorktree removes a linked worktree created by AddWorktree. the branch is kept.
+func (s *Service) RemoveWorktree(path string) error {
+	if err := s.repo.RemoveWorktree(path); err != nil {
+		return fmt.Errorf("remove worktree: %w", err)
+	}
+	s.log.Printf("removed worktree: %s\n", path)
+	return nil
+}
+
+// PushBranch pushes the branch to the remote, setting it as the branch's upstream.
+// uses git CLI so the user's credentials and remote configuration apply.
+func (s *Service) PushBranch(remote, branch string) error {
+	if remote == "" || branch == "" {
+		return errors.New("push branch: remote and branch are required")
+	}
+	s.log.Printf("pushing branch %s to %s\n", branch, remote)
+	if err := s.repo.runGit("push", "--set-upstream", remote, branch); err != nil {
+		return fmt.Errorf("push branch: %w", err)
+	}
+	return nil
+}
+
+// CommitAll stages and commits all changes in the working tree, respecting gitignore.
+// returns the commit hash, or empty string if the tree was clean and nothing was committed.
+func (s *Service) CommitAll(message string) (string, error) {
+	hash, err := s.repo.CommitAll(message)
+	if err != nil {
+		return "", fmt.Errorf("commit all: %w", err)
+	}
+	return hash, nil
+}
+
 // MovePlanToCompleted moves a plan file to the completed/ subdirectory and commits.
 // Creates the completed/ directory if it doesn't exist.
 // Uses git mv if the file is tracked, falls back to os.Rename for untracked files.
@@ -204,6 +282,29 @@ func (s *Service) EnsureHasCommits(promptFn func() bool) error {
 	return nil
 }
 
+// HeadCommit returns the hash of the current HEAD commit.
+func (s *Service) HeadCommit() (string, error) {
+	return s.repo.headHash()
+}
+
+// DiffSince returns the unified diff between ref (commit hash or branch name) and HEAD.
+// diffs larger than 1MB are cut at a line boundary and end with a truncation notice.
+// returns empty string if there are no changes.
+func (s *Service) DiffSince(ref string) (string, error) {
+	diff, err := s.repo.diffSince(ref)
+	if err != nil {
+		return "", fmt.Errorf("diff since %s: %w", ref, err)
+	}
+	if len(diff) <= maxDiffSize {
+		return diff, nil
+	}
+	cut := diff[:maxDiffSize]
+	if idx := strings.LastIndexByte(cut, '\n'); idx > 0 {
+		cut = cut[:idx+1]
+	}
+	return cut + fmt.Sprintf("\n... diff truncated, showing %d of %d bytes\n", len(cut), len(diff)), nil
+}
+
 // DiffStats returns change statistics between baseBranch and HEAD.
 // returns zero stats if baseBranch doesn't exist or HEAD equals baseBranch.
 func (s *Service) DiffStats(baseBranch string) (DiffStats, error) {
@@ -3,10 +3,13 @@ package git
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -213,6 +216,286 @@ func TestService_CreateBranchForPlan(t *testing.T) {
 	})
 }
 
+func TestService_AddWorktree(t *testing.T) {
+	t.Run("creates worktree on new branch", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		wtPath := filepath.Join(t.TempDir(), "wt")
+		require.NoError(t, svc.AddWorktree("feature-x", wtPath))
+		assert.FileExists(t, filepath.Join(wtPath, "README.md"))
+
+		wtSvc, err := NewService(wtPath, noopServiceLogger())
+		require.NoError(t, err)
+		branch, err := wtSvc.CurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "feature-x", branch)
+
+		// main working tree stays on its branch
+		mainBranch, err := svc.CurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "master", mainBranch)
+	})
+
+	t.Run("uses existing branch", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+		require.NoError(t, svc.CreateBranch("existing"))
+		require.NoError(t, svc.repo.CheckoutBranch("master"))
+
+		wtPath := filepath.Join(t.TempDir(), "wt")
+		require.NoError(t, svc.AddWorktree("existing", wtPath))
+
+		wtSvc, err := NewService(wtPath, noopServiceLogger())
+		require.NoError(t, err)
+		branch, err := wtSvc.CurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "existing", branch)
+	})
+
+	t.Run("reuses existing worktree", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		log := &mockLogger{}
+		svc, err := NewService(dir, log)
+		require.NoError(t, err)
+
+		wtPath := filepath.Join(t.TempDir(), "wt")
+		require.NoError(t, svc.AddWorktree("feature-y", wtPath))
+		require.NoError(t, svc.AddWorktree("feature-y", wtPath))
+		assert.Contains(t, log.logs[len(log.logs)-1], "using existing worktree")
+	})
+
+	t.Run("fails when branch is checked out elsewhere", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		err = svc.AddWorktree("master", filepath.Join(t.TempDir(), "wt"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "add worktree")
+	})
+}
+
+func TestService_RemoveWorktree(t *testing.T) {
+	dir := setupTestRepo(t)
+	svc, err := NewService(dir, noopServiceLogger())
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	require.NoError(t, svc.AddWorktree("feature-z", wtPath))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "dirty.txt"), []byte("x"), 0o600))
+
+	require.NoError(t, svc.RemoveWorktree(wtPath))
+	assert.NoDirExists(t, wtPath)
+	assert.True(t, svc.repo.BranchExists("feature-z"), "branch is kept")
+
+	// worktree can be recreated for the same branch after removal
+	require.NoError(t, svc.AddWorktree("feature-z", wtPath))
+}
+
+func TestService_PushBranch(t *testing.T) {
+	dir := setupTestRepo(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	out, err := exec.Command("git", "init", "--bare", remote).CombinedOutput()
+	require.NoError(t, err, string(out))
+	out, err = exec.Command("git", "-C", dir, "remote", "add", "origin", remote).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	log := &mockLogger{}
+	svc, err := NewService(dir, log)
+	require.NoError(t, err)
+	require.NoError(t, svc.CreateBranch("feature-push"))
+	head, err := svc.HeadCommit()
+	require.NoError(t, err)
+
+	t.Run("pushes branch", func(t *testing.T) {
+		require.NoError(t, svc.PushBranch("origin", "feature-push"))
+		out, err := exec.Command("git", "-C", remote, "rev-parse", "refs/heads/feature-push").CombinedOutput()
+		require.NoError(t, err, string(out))
+		assert.Equal(t, head, strings.TrimSpace(string(out)))
+		assert.Contains(t, log.logs[len(log.logs)-1], "pushing branch feature-push to origin")
+	})
+
+	t.Run("unknown remote", func(t *testing.T) {
+		err := svc.PushBranch("nowhere", "feature-push")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "push branch: git push")
+	})
+
+	t.Run("missing arguments", func(t *testing.T) {
+		require.EqualError(t, svc.PushBranch("", "feature-push"), "push branch: remote and branch are required")
+		require.EqualError(t, svc.PushBranch("origin", ""), "push branch: remote and branch are required")
+	})
+}
+
+func TestService_CommitAll(t *testing.T) {
+	t.Run("clean tree creates no commit", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		headBefore, err := svc.repo.gitRepo.Head()
+		require.NoError(t, err)
+
+		hash, err := svc.CommitAll("ralphex: task iteration 1 (plan.md)")
+		require.NoError(t, err)
+		assert.Empty(t, hash)
+
+		headAfter, err := svc.repo.gitRepo.Head()
+		require.NoError(t, err)
+		assert.Equal(t, headBefore.Hash(), headAfter.Hash())
+	})
+
+	t.Run("dirty tree is committed", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "new.go"), []byte("package main\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "debug.log"), []byte("noise"), 0o600))
+
+		hash, err := svc.CommitAll("ralphex: task iteration 2 (plan.md)")
+		require.NoError(t, err)
+		require.NotEmpty(t, hash)
+
+		head, err := svc.repo.gitRepo.Head()
+		require.NoError(t, err)
+		assert.Equal(t, head.Hash().String(), hash)
+
+		commit, err := svc.repo.gitRepo.CommitObject(head.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, "ralphex: task iteration 2 (plan.md)", commit.Message)
+
+		tree, err := commit.Tree()
+		require.NoError(t, err)
+		_, err = tree.File("new.go")
+		require.NoError(t, err)
+		_, err = tree.File("debug.log")
+		require.Error(t, err, "ignored file should not be committed")
+
+		dirty, err := svc.repo.IsDirty()
+		require.NoError(t, err)
+		assert.False(t, dirty)
+	})
+
+	t.Run("deleted file is committed", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, os.Remove(filepath.Join(dir, "README.md")))
+
+		hash, err := svc.CommitAll("remove readme")
+		require.NoError(t, err)
+		require.NotEmpty(t, hash)
+
+		commit, err := svc.repo.gitRepo.CommitObject(plumbing.NewHash(hash))
+		require.NoError(t, err)
+		tree, err := commit.Tree()
+		require.NoError(t, err)
+		_, err = tree.File("README.md")
+		require.Error(t, err)
+	})
+}
+
+func TestService_IsClean(t *testing.T) {
+	t.Run("clean tree", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		clean, err := svc.IsClean()
+		require.NoError(t, err)
+		assert.True(t, clean)
+
+		files, err := svc.ChangedFiles()
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("uncommitted changes", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "new.go"), []byte("package main\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "staged.go"), []byte("package main\n"), 0o600))
+		require.NoError(t, svc.repo.Add("staged.go"))
+
+		clean, err := svc.IsClean()
+		require.NoError(t, err)
+		assert.False(t, clean)
+
+		files, err := svc.ChangedFiles()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"README.md", "new.go", "staged.go"}, files)
+	})
+
+	t.Run("changes hash follows file content", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		clean, err := svc.ChangesHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+		first, err := svc.ChangesHash()
+		require.NoError(t, err)
+		assert.NotEqual(t, clean, first)
+
+		// editing the same file again keeps the changed file list but moves the hash
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed again\n"), 0o600))
+		second, err := svc.ChangesHash()
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		again, err := svc.ChangesHash()
+		require.NoError(t, err)
+		assert.Equal(t, second, again)
+	})
+
+	t.Run("ignored files don't count", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o600))
+		_, err = svc.CommitAll("add gitignore")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "debug.log"), []byte("noise"), 0o600))
+
+		clean, err := svc.IsClean()
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+}
+
+func TestService_Status(t *testing.T) {
+	dir := setupTestRepo(t)
+	svc, err := NewService(dir, noopServiceLogger())
+	require.NoError(t, err)
+
+	status, err := svc.Status()
+	require.NoError(t, err)
+	assert.Empty(t, status, "clean tree")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.go"), []byte("package main\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "staged.go"), []byte("package main\n"), 0o600))
+	require.NoError(t, svc.repo.Add("staged.go"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "debug.log"), []byte("noise\n"), 0o600))
+
+	status, err = svc.Status()
+	require.NoError(t, err)
+	assert.Equal(t, []FileStatus{{Path: ".gitignore", Status: "??"}, {Path: "README.md", Status: " M"},
+		{Path: "new.go", Status: "??"}, {Path: "staged.go", Status: "A "}}, status)
+}
+
 func TestService_MovePlanToCompleted(t *testing.T) {
 	t.Run("moves tracked file", func(t *testing.T) {
 		dir := setupTestRepo(t)
@@ -516,6 +799,81 @@ func TestService_GetDefaultBranch(t *testing.T) {
 	})
 }
 
+func TestService_DiffSince(t *testing.T) {
+	// commitFile writes and commits a file in the service repo
+	commitFile := func(t *testing.T, svc *Service, name string, content []byte) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(svc.Root(), name), content, 0o600))
+		require.NoError(t, svc.repo.Add(name))
+		require.NoError(t, svc.repo.Commit("add "+name))
+	}
+
+	t.Run("returns diff of commits after ref", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		start, err := svc.HeadCommit()
+		require.NoError(t, err)
+		commitFile(t, svc, "new.txt", []byte("line1\nline2\n"))
+
+		diff, err := svc.DiffSince(start)
+		require.NoError(t, err)
+		assert.Contains(t, diff, "diff --git a/new.txt b/new.txt")
+		assert.Contains(t, diff, "+line1\n+line2\n")
+	})
+
+	t.Run("empty when nothing changed", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		start, err := svc.HeadCommit()
+		require.NoError(t, err)
+		diff, err := svc.DiffSince(start)
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("binary files", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		start, err := svc.HeadCommit()
+		require.NoError(t, err)
+		commitFile(t, svc, "image.bin", []byte{0x89, 'P', 'N', 'G', 0x00, 0x01, 0x02})
+
+		diff, err := svc.DiffSince(start)
+		require.NoError(t, err)
+		assert.Contains(t, diff, "Binary files")
+	})
+
+	t.Run("large diff is truncated", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		start, err := svc.HeadCommit()
+		require.NoError(t, err)
+		commitFile(t, svc, "big.txt", []byte(strings.Repeat("some long line of generated content\n", 40000)))
+
+		diff, err := svc.DiffSince(start)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(diff), maxDiffSize+100)
+		assert.Contains(t, diff, "... diff truncated, showing")
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		svc, err := NewService(dir, noopServiceLogger())
+		require.NoError(t, err)
+
+		_, err = svc.DiffSince("0123456789abcdef0123456789abcdef01234567")
+		require.Error(t, err)
+	})
+}
+
 func TestService_DiffStats(t *testing.T) {
 	t.Run("returns zero stats when on same branch", func(t *testing.T) {
 		dir := setupTestRepo(t)
@@ -12,6 +12,8 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/term"
+
 	"github.com/umputun/ralphex/pkg/render"
 )
 
@@ -58,10 +60,12 @@ type Collector interface {
 }
 
 // TerminalCollector implements Collector using fzf (if available) or numbered selection fallback.
+// when stdin is not a terminal, questions are answered with their first option instead of waiting for input.
 type TerminalCollector struct {
-	stdin   io.Reader // for testing, nil uses os.Stdin
-	stdout  io.Writer // for testing, nil uses os.Stdout
-	noColor bool      // if true, skip glamour rendering
+	stdin      io.Reader   // for testing, nil uses os.Stdin
+	stdout     io.Writer   // for testing, nil uses os.Stdout
+	noColor    bool        // if true, skip glamour rendering
+	isTerminal func() bool // for testing, nil checks whether os.Stdin is a terminal
 }
 
 // NewTerminalCollector creates a new TerminalCollector with specified options.
@@ -83,12 +87,25 @@ func (c *TerminalCollector) getStdout() io.Writer {
 	return os.Stdout
 }
 
+func (c *TerminalCollector) stdinIsTerminal() bool {
+	if c.isTerminal != nil {
+		return c.isTerminal()
+	}
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
 // AskQuestion presents options using fzf if available, otherwise falls back to numbered selection.
+// if stdin is not a terminal, the first option is selected as the default answer.
 func (c *TerminalCollector) AskQuestion(ctx context.Context, question string, options []string) (string, error) {
 	if len(options) == 0 {
 		return "", errors.New("no options provided")
 	}
 
+	if !c.stdinIsTerminal() {
+		_, _ = fmt.Fprintf(c.getStdout(), "\n%s\nstdin is not a terminal, using default answer: %s\n", question, options[0])
+		return options[0], nil
+	}
+
 	// try fzf first
 	if hasFzf() {
 		return c.selectWithFzf(ctx, question, options)
@@ -141,7 +158,7 @@ func (c *TerminalCollector) selectWithNumbers(ctx context.Context, question stri
 	for i, opt := range options {
 		_, _ = fmt.Fprintf(stdout, "  %d) %s\n", i+1, opt)
 	}
-	_, _ = fmt.Fprintf(stdout, "Enter number (1-%d): ", len(options))
+	_, _ = fmt.Fprintf(stdout, "Enter number (1-%d) or option text: ", len(options))
 
 	// read selection
 	reader := bufio.NewReader(stdin)
@@ -150,10 +167,15 @@ func (c *TerminalCollector) selectWithNumbers(ctx context.Context, question stri
 		return "", fmt.Errorf("read input: %w", err)
 	}
 
-	// parse selection
+	// parse selection, the answer must be one of the options, given by number or text
 	line = strings.TrimSpace(line)
 	num, err := strconv.Atoi(line)
 	if err != nil {
+		for _, opt := range options {
+			if line != "" && strings.EqualFold(line, opt) {
+				return opt, nil
+			}
+		}
 		return "", fmt.Errorf("invalid number: %s", line)
 	}
 
@@ -30,6 +30,10 @@ func TestTerminalCollector_selectWithNumbers(t *testing.T) {
 		{name: "invalid input", question: "Pick one", options: []string{"A", "B"}, input: "abc\n", wantErr: "invalid number"},
 		{name: "empty input", question: "Pick one", options: []string{"A", "B"}, input: "\n", wantErr: "invalid number"},
 		{name: "single option", question: "Only one", options: []string{"OnlyOption"}, input: "1\n", want: "OnlyOption"},
+		{name: "option text", question: "Pick one", options: []string{"Redis", "In-memory"}, input: "In-memory\n", want: "In-memory"},
+		{name: "option text any case", question: "Pick one", options: []string{"Redis", "In-memory"}, input: " redis \n", want: "Redis"},
+		{name: "text not in options", question: "Pick one", options: []string{"Redis", "In-memory"}, input: "Memcached\n",
+			wantErr: "invalid number"},
 	}
 
 	for _, tc := range tests {
@@ -77,6 +81,19 @@ func TestTerminalCollector_AskQuestion_emptyOptionsSlice(t *testing.T) {
 	assert.Contains(t, err.Error(), "no options provided")
 }
 
+func TestTerminalCollector_AskQuestion_notTerminal(t *testing.T) {
+	var stdout bytes.Buffer
+	stdin := strings.NewReader("2\n")
+	c := &TerminalCollector{stdin: stdin, stdout: &stdout, isTerminal: func() bool { return false }}
+
+	got, err := c.AskQuestion(context.Background(), "Which cache backend?", []string{"Redis", "In-memory"})
+	require.NoError(t, err)
+	assert.Equal(t, "Redis", got, "first option is the default")
+	assert.Contains(t, stdout.String(), "Which cache backend?")
+	assert.Contains(t, stdout.String(), "stdin is not a terminal, using default answer: Redis")
+	assert.Equal(t, 2, stdin.Len(), "stdin is not read")
+}
+
 func TestTerminalCollector_selectWithNumbers_outputFormat(t *testing.T) {
 	var stdout bytes.Buffer
 	c := &TerminalCollector{stdin: strings.NewReader("2\n"), stdout: &stdout}
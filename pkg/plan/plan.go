@@ -11,9 +11,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/umputun/ralphex/pkg/checkbox"
 	"github.com/umputun/ralphex/pkg/input"
 	"github.com/umputun/ralphex/pkg/progress"
 )
@@ -28,6 +30,7 @@ var ErrNoPlansFound = errors.New("no plans found")
 type Selector struct {
 	PlansDir string
 	Colors   *progress.Colors
+	Latest   bool // discovery picks the most recently modified plan instead of failing when several qualify
 }
 
 // NewSelector creates a new Selector with the given plans directory and colors.
@@ -40,8 +43,9 @@ func NewSelector(plansDir string, colors *progress.Colors) *Selector {
 
 // Select selects and prepares a plan file.
 // if planFile is provided, validates it exists and returns absolute path.
+// if planFile is a directory, discovers the plan with uncompleted tasks in it, see Discover.
 // if planFile is empty and optional is true, returns empty string without error.
-// if planFile is empty and optional is false, uses fzf for selection.
+// if planFile is empty and optional is false, discovers the plan in PlansDir if Latest is set, otherwise uses fzf.
 func (s *Selector) Select(ctx context.Context, planFile string, optional bool) (string, error) {
 	selected, err := s.selectPlan(ctx, planFile, optional)
 	if err != nil {
@@ -64,9 +68,13 @@ func (s *Selector) Select(ctx context.Context, planFile string, optional bool) (
 // selectPlan handles the logic for selecting a plan file.
 func (s *Selector) selectPlan(ctx context.Context, planFile string, optional bool) (string, error) {
 	if planFile != "" {
-		if _, err := os.Stat(planFile); err != nil {
+		info, err := os.Stat(planFile)
+		if err != nil {
 			return "", fmt.Errorf("plan file not found: %s", planFile)
 		}
+		if info.IsDir() {
+			return s.Discover(planFile)
+		}
 		return planFile, nil
 	}
 
@@ -75,6 +83,10 @@ func (s *Selector) selectPlan(ctx context.Context, planFile string, optional boo
 		return "", nil
 	}
 
+	if s.Latest {
+		return s.Discover(s.PlansDir)
+	}
+
 	// use fzf to select plan
 	return s.selectWithFzf(ctx)
 }
@@ -122,6 +134,66 @@ func (s *Selector) selectWithFzf(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// Discover picks the plan to run from dir: the *.md file with uncompleted tasks ("- [ ]").
+// if several plans have uncompleted tasks, the most recently modified one is picked when Latest is set,
+// otherwise the choice is ambiguous and an error listing the candidates is returned.
+func (s *Selector) Discover(dir string) (string, error) {
+	plans, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return "", fmt.Errorf("find plans in %s: %w", dir, err)
+	}
+
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	for _, p := range plans {
+		info, statErr := os.Stat(p)
+		if statErr != nil || info.IsDir() || !hasUncompletedTasks(p) {
+			continue
+		}
+		candidates = append(candidates, candidate{path: p, modTime: info.ModTime()})
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: no plan with uncompleted tasks in %s", ErrNoPlansFound, dir)
+	}
+
+	// most recent first, ties broken by name for a stable order
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].modTime.Equal(candidates[j].modTime) {
+			return candidates[i].modTime.After(candidates[j].modTime)
+		}
+		return candidates[i].path < candidates[j].path
+	})
+
+	if len(candidates) > 1 && !s.Latest {
+		names := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			names = append(names, filepath.Base(c.path))
+		}
+		return "", fmt.Errorf("%d plans with uncompleted tasks in %s (%s), pass the plan file or use --latest to pick the most recent",
+			len(candidates), dir, strings.Join(names, ", "))
+	}
+
+	chosen := candidates[0].path
+	if len(candidates) > 1 {
+		s.Colors.Info().Printf("selected most recently modified plan with uncompleted tasks: %s\n", chosen)
+	} else {
+		s.Colors.Info().Printf("selected plan with uncompleted tasks: %s\n", chosen)
+	}
+	return chosen, nil
+}
+
+// hasUncompletedTasks reports whether the plan file has any unchecked "- [ ]" items.
+func hasUncompletedTasks(path string) bool {
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from globbing the plans directory
+	if err != nil {
+		return false
+	}
+	return checkbox.HasUnchecked(string(content))
+}
+
 // FindRecent finds the most recently modified plan file in the plans directory
 // that was modified after the given start time.
 func (s *Selector) FindRecent(startTime time.Time) string {
@@ -154,6 +226,16 @@ func (s *Selector) FindRecent(startTime time.Time) string {
 	return recentPlan
 }
 
+// ResolvePlansDir returns the plans directory of a project.
+// an absolute plansDir is returned as is, a relative one is resolved against projectDir,
+// so each project keeps its plans in its own tree, e.g. <project>/docs/plans.
+func ResolvePlansDir(plansDir, projectDir string) string {
+	if filepath.IsAbs(plansDir) {
+		return filepath.Clean(plansDir)
+	}
+	return filepath.Join(projectDir, plansDir)
+}
+
 // ExtractBranchName derives a branch name from a plan file path.
 // removes the .md extension and strips any leading date prefix (e.g., "2024-01-15-").
 func ExtractBranchName(planFile string) string {
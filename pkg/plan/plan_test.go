@@ -154,6 +154,138 @@ func TestSelector_FindRecent(t *testing.T) {
 	})
 }
 
+func TestSelector_Discover(t *testing.T) {
+	colors := progress.NewColors(config.ColorConfig{
+		Task: "0,255,0", Review: "255,255,0", Codex: "255,165,0",
+		ClaudeEval: "0,255,255", Warn: "255,165,0", Error: "255,0,0",
+		Signal: "255,0,255", Timestamp: "128,128,128", Info: "255,255,255",
+	})
+	base := time.Now().Add(-time.Hour)
+	// writePlan creates a plan modified age minutes after base
+	writePlan := func(t *testing.T, dir, name, content string, age int) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		mtime := base.Add(time.Duration(age) * time.Minute)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+		return path
+	}
+
+	t.Run("no candidates", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlan(t, dir, "done.md", "# Done\n- [x] Task 1", 1)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("- [ ] not a plan"), 0o600))
+
+		_, err := NewSelector(dir, colors).Discover(dir)
+		require.ErrorIs(t, err, ErrNoPlansFound)
+		assert.Contains(t, err.Error(), "no plan with uncompleted tasks in "+dir)
+	})
+
+	t.Run("single candidate", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlan(t, dir, "done.md", "# Done\n- [x] Task 1", 5)
+		todo := writePlan(t, dir, "todo.md", "# Todo\n- [x] Task 1\n  - [ ] Task 2", 1)
+
+		got, err := NewSelector(dir, colors).Discover(dir)
+		require.NoError(t, err)
+		assert.Equal(t, todo, got)
+	})
+
+	t.Run("multiple candidates are ambiguous", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlan(t, dir, "a.md", "- [ ] Task", 1)
+		writePlan(t, dir, "b.md", "- [ ] Task", 3)
+		writePlan(t, dir, "c.md", "- [ ] Task", 2)
+
+		_, err := NewSelector(dir, colors).Discover(dir)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNoPlansFound)
+		assert.Contains(t, err.Error(), "3 plans with uncompleted tasks")
+		assert.Contains(t, err.Error(), "(b.md, c.md, a.md)", "most recent first")
+		assert.Contains(t, err.Error(), "--latest")
+	})
+
+	t.Run("latest picks the most recent candidate", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlan(t, dir, "a.md", "- [ ] Task", 1)
+		b := writePlan(t, dir, "b.md", "- [ ] Task", 3)
+		writePlan(t, dir, "newest-done.md", "- [x] Task", 10)
+
+		sel := NewSelector(dir, colors)
+		sel.Latest = true
+		got, err := sel.Discover(dir)
+		require.NoError(t, err)
+		assert.Equal(t, b, got)
+	})
+
+	t.Run("select with a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		todo := writePlan(t, dir, "todo.md", "- [ ] Task", 1)
+
+		got, err := NewSelector("/nonexistent", colors).Select(context.Background(), dir, false)
+		require.NoError(t, err)
+		assert.Equal(t, todo, got)
+	})
+
+	t.Run("select without plan file discovers in plans dir with latest", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlan(t, dir, "a.md", "- [ ] Task", 1)
+		b := writePlan(t, dir, "b.md", "- [ ] Task", 2)
+
+		sel := NewSelector(dir, colors)
+		sel.Latest = true
+		got, err := sel.Select(context.Background(), "", false)
+		require.NoError(t, err)
+		assert.Equal(t, b, got)
+	})
+}
+
+func TestResolvePlansDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		plansDir   string
+		projectDir string
+		want       string
+	}{
+		{name: "relative resolved against project", plansDir: "docs/plans", projectDir: "/work/app", want: "/work/app/docs/plans"},
+		{name: "relative with dot prefix", plansDir: "./plans", projectDir: "/work/app", want: "/work/app/plans"},
+		{name: "relative escaping project", plansDir: "../shared/plans", projectDir: "/work/app", want: "/work/shared/plans"},
+		{name: "absolute kept as is", plansDir: "/srv/plans", projectDir: "/work/app", want: "/srv/plans"},
+		{name: "absolute cleaned", plansDir: "/srv/plans/", projectDir: "/work/app", want: "/srv/plans"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ResolvePlansDir(tc.plansDir, tc.projectDir))
+		})
+	}
+
+	t.Run("selector finds plans in resolved dir", func(t *testing.T) {
+		colors := progress.NewColors(config.ColorConfig{
+			Task: "0,255,0", Review: "255,255,0", Codex: "255,165,0",
+			ClaudeEval: "0,255,255", Warn: "255,165,0", Error: "255,0,0",
+			Signal: "255,0,255", Timestamp: "128,128,128", Info: "255,255,255",
+		})
+		projectDir, sharedDir := t.TempDir(), t.TempDir()
+		startTime := time.Now()
+		writePlan := func(dir string) string {
+			require.NoError(t, os.MkdirAll(dir, 0o750))
+			planFile := filepath.Join(dir, "feature.md")
+			require.NoError(t, os.WriteFile(planFile, []byte("# Feature"), 0o600))
+			later := startTime.Add(time.Second)
+			require.NoError(t, os.Chtimes(planFile, later, later))
+			return planFile
+		}
+
+		relPlan := writePlan(filepath.Join(projectDir, "docs", "plans"))
+		sel := NewSelector(ResolvePlansDir("docs/plans", projectDir), colors)
+		assert.Equal(t, relPlan, sel.FindRecent(startTime))
+
+		absPlan := writePlan(sharedDir)
+		sel = NewSelector(ResolvePlansDir(sharedDir, projectDir), colors)
+		assert.Equal(t, absPlan, sel.FindRecent(startTime))
+	})
+}
+
 func TestExtractBranchName(t *testing.T) {
 	tests := []struct {
 		name     string
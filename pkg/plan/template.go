@@ -0,0 +1,37 @@
+package plan
+
+import (
+	_ "embed" // embeds the starter plan
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// starterPlan is the plan written by WriteTemplate, in the format the runner and ValidatePlan expect.
+//
+//go:embed template.md
+var starterPlan []byte
+
+// WriteTemplate writes a starter plan with example tasks to path, creating missing parent directories.
+// an existing file is overwritten only if force is set, otherwise the returned error wraps fs.ErrExist.
+func WriteTemplate(path string, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create plan directory: %w", err)
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o600) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return fmt.Errorf("create plan: %w", err)
+	}
+	if _, err := f.Write(starterPlan); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write plan: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close plan: %w", err)
+	}
+	return nil
+}
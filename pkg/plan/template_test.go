@@ -0,0 +1,49 @@
+package plan
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	t.Run("writes a valid plan with uncompleted tasks", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "docs", "plans", "new-plan.md")
+		require.NoError(t, WriteTemplate(path, false))
+
+		issues, err := ValidatePlan(path)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+		assert.True(t, hasUncompletedTasks(path))
+
+		content, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "### Task 1:")
+		assert.Contains(t, string(content), "### Task 2:")
+	})
+
+	t.Run("refuses to overwrite existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte("my plan\n"), 0o600))
+
+		err := WriteTemplate(path, false)
+		require.ErrorIs(t, err, fs.ErrExist)
+		content, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Equal(t, "my plan\n", string(content))
+	})
+
+	t.Run("force overwrites existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte("my plan\n"), 0o600))
+
+		require.NoError(t, WriteTemplate(path, true))
+		content, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Equal(t, starterPlan, content)
+	})
+}
@@ -0,0 +1,182 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/umputun/ralphex/pkg/checkbox"
+)
+
+// Severity is the level of a plan validation issue.
+type Severity string
+
+// severity levels for plan issues.
+const (
+	SeverityError   Severity = "error"   // plan will not execute correctly
+	SeverityWarning Severity = "warning" // plan executes but likely wastes iterations
+)
+
+// PlanIssue describes a problem found in a plan file.
+type PlanIssue struct {
+	Line     int      // 1-based line number, 0 for file-level issues
+	Severity Severity // error or warning
+	Message  string   // human-readable description
+}
+
+// String formats the issue as "line N: severity: message".
+func (i PlanIssue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Severity, i.Message)
+}
+
+// patterns for plan validation, task headers match the ones used by the dashboard plan parser.
+// task checkboxes are found by checkbox.Scan, validateOtherBulletRe catches the "*" and "+" look-alikes it skips.
+var (
+	validateTaskHeaderRe  = regexp.MustCompile(`^###\s+(?:Task|Iteration)\s+(\d+):?\s*(.*)$`)
+	validateOtherBulletRe = regexp.MustCompile(`^\s*([*+])\s+\[([ xX])\]`)
+	validateSectionRe     = regexp.MustCompile(`^#{1,2}\s+`)
+)
+
+// HasErrors returns true if any issue has error severity.
+func HasErrors(issues []PlanIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePlan lints a plan file and returns the issues found, ordered by line.
+// checkboxes are detected by checkbox.Scan, the same way the runner checks for uncompleted tasks,
+// so anything reported here is something the runner would trip over.
+// returns error only if the file can't be read.
+func ValidatePlan(path string) ([]PlanIssue, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("open plan: %w", err)
+	}
+	content := string(data)
+	items := map[int]checkbox.Item{} // line -> task checkbox
+	for _, item := range checkbox.Scan(content) {
+		items[item.Line] = item
+	}
+
+	var issues []PlanIssue
+	taskLines := map[int]int{} // task number -> line of first header
+	taskCount := 0
+	inTask, inFence := false, false
+	styleLine, style := 0, "" // first checkbox style seen, e.g. "-x"
+
+	// parent tracking for nested checkboxes: indent and checked state of the closest less-indented checkbox
+	type parent struct {
+		indent  int
+		checked bool
+		line    int
+	}
+	var parents []parent
+
+	for i, line := range strings.Split(strings.TrimPrefix(content, checkbox.BOM), "\n") {
+		lineNum := i + 1
+		line = strings.TrimSuffix(line, "\r")
+
+		// skip fenced code blocks, headers and checkboxes there are examples
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if m := validateTaskHeaderRe.FindStringSubmatch(line); m != nil {
+			inTask = true
+			taskCount++
+			parents = nil
+			num, _ := strconv.Atoi(m[1])
+			if prev, ok := taskLines[num]; ok {
+				issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityError,
+					Message: fmt.Sprintf("duplicate task %d (first defined at line %d)", num, prev)})
+			} else {
+				taskLines[num] = lineNum
+			}
+			if strings.TrimSpace(m[2]) == "" {
+				issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityError,
+					Message: fmt.Sprintf("task %d has no title", num)})
+			}
+			continue
+		}
+
+		// a new top-level or second-level section ends the current task
+		if validateSectionRe.MatchString(line) {
+			inTask = false
+			parents = nil
+			continue
+		}
+
+		// "*" and "+" checkboxes look like tasks but the runner never sees them
+		if m := validateOtherBulletRe.FindStringSubmatch(line); m != nil {
+			issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityWarning,
+				Message: fmt.Sprintf("%q is not a task checkbox, only \"- [ ]\" items are tracked", m[1]+" ["+m[2]+"]")})
+			continue
+		}
+
+		item, ok := items[lineNum]
+		if !ok {
+			continue
+		}
+		indent, checked := item.Indent, item.Checked()
+
+		// checkbox style: case of the check mark must be consistent
+		itemStyle := "-"
+		if item.Mark == "X" {
+			itemStyle += "X"
+		}
+		switch {
+		case style == "":
+			style, styleLine = itemStyle, lineNum
+		case itemStyle != style:
+			issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityWarning,
+				Message: fmt.Sprintf("mixed checkbox styles: %q differs from %q used at line %d",
+					"- ["+item.Mark+"]", checkboxExample(style), styleLine)})
+		}
+
+		if !inTask {
+			if !checked {
+				issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityWarning,
+					Message: "unchecked checkbox outside of a task section keeps the plan incomplete"})
+			}
+			continue
+		}
+
+		// nested item under a checked parent can't be reached: the parent is done, but the runner still sees [ ]
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) > 0 && parents[len(parents)-1].checked && !checked {
+			issues = append(issues, PlanIssue{Line: lineNum, Severity: SeverityError,
+				Message: fmt.Sprintf("unreachable nested item: parent at line %d is already checked", parents[len(parents)-1].line)})
+		}
+		parents = append(parents, parent{indent: indent, checked: checked, line: lineNum})
+	}
+
+	if taskCount == 0 {
+		issues = append([]PlanIssue{{Severity: SeverityError,
+			Message: "no tasks found, expected '### Task N: title' headers"}}, issues...)
+	}
+
+	return issues, nil
+}
+
+// checkboxExample renders a checkbox style key back into its markdown form for messages.
+func checkboxExample(style string) string {
+	if strings.HasSuffix(style, "X") {
+		return style[:1] + " [X]"
+	}
+	return style + " [ ]"
+}
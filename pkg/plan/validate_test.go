@@ -0,0 +1,164 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+func TestValidatePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []PlanIssue
+	}{
+		{
+			name: "valid plan",
+			content: `# Plan
+
+## Tasks
+
+### Task 1: first
+- [x] done
+  - [x] nested done
+
+### Task 2: second
+- [ ] todo
+  - [ ] nested todo
+
+` + "```" + `
+### Task 1: example in code block
+* [X] ignored
+` + "```",
+			want: nil,
+		},
+		{
+			name:    "no tasks",
+			content: "# Plan\n\nsome text\n",
+			want:    []PlanIssue{{Severity: SeverityError, Message: "no tasks found, expected '### Task N: title' headers"}},
+		},
+		{
+			name:    "task without title",
+			content: "### Task 1:\n- [ ] item\n### Task 2\n- [ ] item\n",
+			want: []PlanIssue{
+				{Line: 1, Severity: SeverityError, Message: "task 1 has no title"},
+				{Line: 3, Severity: SeverityError, Message: "task 2 has no title"},
+			},
+		},
+		{
+			name:    "duplicate task",
+			content: "### Task 1: a\n- [ ] item\n### Task 1: b\n- [ ] item\n",
+			want:    []PlanIssue{{Line: 3, Severity: SeverityError, Message: "duplicate task 1 (first defined at line 1)"}},
+		},
+		{
+			name:    "mixed checkbox styles",
+			content: "### Task 1: a\n- [ ] one\n- [x] two\n- [X] three\n",
+			want: []PlanIssue{
+				{Line: 4, Severity: SeverityWarning, Message: `mixed checkbox styles: "- [X]" differs from "- [ ]" used at line 2`},
+			},
+		},
+		{
+			name:    "checkbox with other bullet",
+			content: "### Task 1: a\n- [ ] one\n* [ ] two\n  + [x] three\n",
+			want: []PlanIssue{
+				{Line: 3, Severity: SeverityWarning, Message: `"* [ ]" is not a task checkbox, only "- [ ]" items are tracked`},
+				{Line: 4, Severity: SeverityWarning, Message: `"+ [x]" is not a task checkbox, only "- [ ]" items are tracked`},
+			},
+		},
+		{
+			name:    "unreachable nested item",
+			content: "### Task 1: a\n- [x] parent\n  - [x] child done\n  - [ ] child todo\n- [ ] sibling\n  - [ ] child of unchecked\n",
+			want: []PlanIssue{
+				{Line: 4, Severity: SeverityError, Message: "unreachable nested item: parent at line 2 is already checked"},
+			},
+		},
+		{
+			name:    "checkbox outside task section",
+			content: "### Task 1: a\n- [x] item\n\n## Success criteria\n- [ ] works\n- [x] checked is fine\n",
+			want: []PlanIssue{
+				{Line: 5, Severity: SeverityWarning, Message: "unchecked checkbox outside of a task section keeps the plan incomplete"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plan.md")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			issues, err := ValidatePlan(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, issues)
+		})
+	}
+}
+
+func TestValidatePlan_MalformedFixture(t *testing.T) {
+	issues, err := ValidatePlan(filepath.Join("..", "..", "e2e", "testdata", "test-plan-malformed.md"))
+	require.NoError(t, err)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+	assert.Contains(t, issues[0].Message, "no tasks found")
+	assert.Equal(t, 14, issues[1].Line)
+	assert.Equal(t, SeverityWarning, issues[1].Severity)
+	assert.True(t, HasErrors(issues))
+
+	// the well-formed fixture passes cleanly
+	issues, err = ValidatePlan(filepath.Join("..", "..", "e2e", "testdata", "test-plan.md"))
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	assert.False(t, HasErrors(issues))
+}
+
+func TestValidatePlan_LineEndingsAndBOM(t *testing.T) {
+	for _, fixture := range []string{"test-plan.md", "test-plan-malformed.md"} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "e2e", "testdata", fixture)) //nolint:gosec // test fixture
+		require.NoError(t, err)
+		want, err := ValidatePlan(filepath.Join("..", "..", "e2e", "testdata", fixture))
+		require.NoError(t, err)
+
+		variants := map[string]string{
+			"crlf":     strings.ReplaceAll(string(content), "\n", "\r\n"),
+			"bom":      progress.BOM + string(content),
+			"bom crlf": progress.BOM + strings.ReplaceAll(string(content), "\n", "\r\n"),
+		}
+		for name, variant := range variants {
+			t.Run(fixture+" "+name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "plan.md")
+				require.NoError(t, os.WriteFile(path, []byte(variant), 0o600))
+				issues, err := ValidatePlan(path)
+				require.NoError(t, err)
+				assert.Equal(t, want, issues)
+			})
+		}
+	}
+
+	t.Run("checkbox on the first line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte(progress.BOM+"- [ ] first\r\n### Task 1: a\r\n- [ ] item\r\n"), 0o600))
+		issues, err := ValidatePlan(path)
+		require.NoError(t, err)
+		assert.Equal(t, []PlanIssue{{Line: 1, Severity: SeverityWarning,
+			Message: "unchecked checkbox outside of a task section keeps the plan incomplete"}}, issues)
+		assert.True(t, hasUncompletedTasks(path))
+	})
+}
+
+func TestValidatePlan_MissingFile(t *testing.T) {
+	_, err := ValidatePlan(filepath.Join(t.TempDir(), "missing.md"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "open plan")
+}
+
+func TestPlanIssue_String(t *testing.T) {
+	assert.Equal(t, "line 3: error: task 1 has no title",
+		PlanIssue{Line: 3, Severity: SeverityError, Message: "task 1 has no title"}.String())
+	assert.Equal(t, "error: no tasks found", PlanIssue{Severity: SeverityError, Message: "no tasks found"}.String())
+}
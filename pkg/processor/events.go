@@ -0,0 +1,186 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventType is the kind of a runner event, see Runner.Events.
+type EventType string
+
+// event types emitted by Runner.Events, matching the events streamed by the web dashboard.
+const (
+	EventOutput         EventType = "output"          // output line
+	EventSection        EventType = "section"         // section header
+	EventError          EventType = "error"           // error logged by the runner
+	EventSignal         EventType = "signal"          // executor reported a signal, see Signal constants
+	EventTaskStart      EventType = "task_start"      // task iteration started
+	EventTaskEnd        EventType = "task_end"        // task iteration ended
+	EventIterationStart EventType = "iteration_start" // review/codex iteration started
+	EventHumanAction    EventType = "human_action"    // human intervention (answer, pause, cancel)
+)
+
+// eventsBuffer is the capacity of the channel returned by Runner.Events.
+const eventsBuffer = 256
+
+// Event is a structured record of the run's progress, emitted on the channel returned by Runner.Events.
+type Event struct {
+	Type        EventType `json:"type"`
+	Phase       Phase     `json:"phase"`
+	Text        string    `json:"text"`
+	Section     string    `json:"section,omitempty"`     // section label, set for section events
+	Signal      string    `json:"signal,omitempty"`      // raw signal, e.g. SignalCompleted, set for signal events
+	TaskNum     int       `json:"task_num,omitempty"`    // task number, set for task_start and task_end events
+	Iteration   int       `json:"iteration,omitempty"`   // number of the enclosing section, 0 outside numbered sections
+	Actor       string    `json:"actor,omitempty"`       // who performed a human action
+	Recoverable bool      `json:"recoverable,omitempty"` // error events only, the error didn't stop the run
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Events returns a channel receiving the run's events, so embedders can follow a run without implementing Logger.
+// the channel is created on the first call, which must happen before Run, and is closed when Run returns.
+// events are sent synchronously, the consumer has to keep draining the channel or the run blocks.
+func (r *Runner) Events() <-chan Event {
+	if r.events == nil {
+		r.events = make(chan Event, eventsBuffer)
+		r.log = &eventLogger{inner: r.log, emit: r.emit}
+	}
+	return r.events
+}
+
+// emit sends an event to the events channel, if Events was called. the timestamp is set from the runner clock.
+func (r *Runner) emit(e Event) {
+	if r.events == nil {
+		return
+	}
+	if e.Phase == "" {
+		e.Phase = r.phase
+	}
+	e.Timestamp = r.clock.Now()
+	r.events <- e
+}
+
+// emitSignal sends a signal event for an executor result's signal, if any.
+// signals are taken from results rather than output text, so they are reported once even if echoed in the output.
+func (r *Runner) emitSignal(signal string) {
+	if signal == "" {
+		return
+	}
+	r.emit(Event{Type: EventSignal, Text: signal, Signal: signal})
+}
+
+// eventLogger forwards all calls to the wrapped logger and converts them to events,
+// the same way the web dashboard's BroadcastLogger does.
+type eventLogger struct {
+	inner       Logger
+	emit        func(Event)
+	phase       Phase
+	currentTask int // task number of the active task iteration, 0 if none
+	iteration   int // number of the current section, stamped on all events
+}
+
+// SetPhase emits task_end when leaving the task phase with an active task.
+func (l *eventLogger) SetPhase(phase Phase) {
+	if l.phase == PhaseTask && phase != PhaseTask && l.currentTask > 0 {
+		l.send(Event{Type: EventTaskEnd, TaskNum: l.currentTask, Text: fmt.Sprintf("task %d completed", l.currentTask)})
+		l.currentTask = 0
+	}
+	l.phase = phase
+	l.inner.SetPhase(phase)
+}
+
+// Print emits an output event.
+func (l *eventLogger) Print(format string, args ...any) {
+	l.inner.Print(format, args...)
+	l.send(Event{Type: EventOutput, Text: formatEventText(format, args...)})
+}
+
+// PrintRaw emits an output event.
+func (l *eventLogger) PrintRaw(format string, args ...any) {
+	l.inner.PrintRaw(format, args...)
+	l.send(Event{Type: EventOutput, Text: formatEventText(format, args...)})
+}
+
+// PrintSection emits the section event, preceded by task/iteration boundary events based on section type.
+func (l *eventLogger) PrintSection(section Section) {
+	l.inner.PrintSection(section)
+	switch section.Type {
+	case SectionTaskIteration:
+		if l.currentTask > 0 {
+			l.send(Event{Type: EventTaskEnd, TaskNum: l.currentTask, Iteration: l.currentTask,
+				Text: fmt.Sprintf("task %d completed", l.currentTask)})
+		}
+		l.currentTask = section.Iteration
+		l.iteration = section.Iteration
+		l.send(Event{Type: EventTaskStart, TaskNum: section.Iteration, Text: section.Label})
+	case SectionClaudeReview, SectionCodexIteration:
+		l.iteration = section.Iteration
+		l.send(Event{Type: EventIterationStart, Text: section.Label})
+	default:
+		l.iteration = section.Iteration
+	}
+	l.send(Event{Type: EventSection, Section: section.Label, Text: section.Label})
+}
+
+// PrintAligned emits an output event.
+func (l *eventLogger) PrintAligned(text string) {
+	l.inner.PrintAligned(text)
+	l.send(Event{Type: EventOutput, Text: text})
+}
+
+// LogQuestion emits the question and its options as output events.
+func (l *eventLogger) LogQuestion(question string, options []string) {
+	l.inner.LogQuestion(question, options)
+	l.send(Event{Type: EventOutput, Text: "QUESTION: " + question})
+	l.send(Event{Type: EventOutput, Text: "OPTIONS: " + strings.Join(options, ", ")})
+}
+
+// LogAnswer emits the answer as an output event.
+func (l *eventLogger) LogAnswer(answer string) {
+	l.inner.LogAnswer(answer)
+	l.send(Event{Type: EventOutput, Text: "ANSWER: " + answer})
+}
+
+// LogDraftReview emits the draft review action and feedback as output events.
+func (l *eventLogger) LogDraftReview(action, feedback string) {
+	l.inner.LogDraftReview(action, feedback)
+	l.send(Event{Type: EventOutput, Text: "DRAFT REVIEW: " + action})
+	if feedback != "" {
+		l.send(Event{Type: EventOutput, Text: "FEEDBACK: " + feedback})
+	}
+}
+
+// LogHumanAction emits a human action event.
+func (l *eventLogger) LogHumanAction(actor, action string) {
+	l.inner.LogHumanAction(actor, action)
+	l.send(Event{Type: EventHumanAction, Actor: actor, Text: fmt.Sprintf("HUMAN ACTION (%s): %s", actor, action)})
+}
+
+// LogError emits an error event.
+func (l *eventLogger) LogError(err error, recoverable bool) {
+	l.inner.LogError(err, recoverable)
+	l.send(Event{Type: EventError, Text: "ERROR: " + err.Error(), Recoverable: recoverable})
+}
+
+// Path returns the wrapped logger's progress file path.
+func (l *eventLogger) Path() string {
+	return l.inner.Path()
+}
+
+// send stamps the logger's phase and current iteration on the event and emits it.
+func (l *eventLogger) send(e Event) {
+	e.Phase = l.phase
+	if e.Iteration == 0 {
+		e.Iteration = l.iteration
+	}
+	l.emit(e)
+}
+
+// formatEventText formats a string with args, like fmt.Sprintf.
+func formatEventText(format string, args ...any) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,147 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/executor"
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// collectEvents runs r in the background and returns all events received until the channel is closed, with Run's error.
+func collectEvents(t *testing.T, r *processor.Runner) ([]processor.Event, error) {
+	t.Helper()
+	events := r.Events()
+	errCh := make(chan error, 1)
+	go func() { errCh <- r.Run(context.Background()) }()
+
+	var res []processor.Event
+	for e := range events {
+		res = append(res, e)
+	}
+	return res, <-errCh
+}
+
+// eventsOfType returns events of the given type.
+func eventsOfType(events []processor.Event, typ processor.EventType) []processor.Event {
+	var res []processor.Event
+	for _, e := range events {
+		if e.Type == typ {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func TestRunner_Events(t *testing.T) {
+	t.Run("full run", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Output: "task done", Signal: processor.SignalCompleted},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "done", Signal: processor.SignalCodexDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+		})
+		codex := newMockExecutor([]executor.Result{{Output: "found issue in foo.go"}})
+
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true,
+			AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codex)
+		r.SetClock(newFakeClock())
+		events, err := collectEvents(t, r)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+
+		// phases are reported in run order
+		var phases []processor.Phase
+		for _, e := range events {
+			if len(phases) == 0 || phases[len(phases)-1] != e.Phase {
+				phases = append(phases, e.Phase)
+			}
+		}
+		assert.Equal(t, []processor.Phase{processor.PhaseTask, processor.PhaseReview, processor.PhaseCodex,
+			processor.PhaseClaudeEval, processor.PhaseCodex, processor.PhaseReview}, phases)
+
+		// signals come from executor results, tagged with the phase that produced them
+		var signals []processor.Event
+		for _, e := range eventsOfType(events, processor.EventSignal) {
+			signals = append(signals, processor.Event{Signal: e.Signal, Phase: e.Phase})
+		}
+		assert.Equal(t, []processor.Event{
+			{Signal: processor.SignalCompleted, Phase: processor.PhaseTask},
+			{Signal: processor.SignalReviewDone, Phase: processor.PhaseReview},
+			{Signal: processor.SignalReviewDone, Phase: processor.PhaseReview},
+			{Signal: processor.SignalCodexDone, Phase: processor.PhaseClaudeEval},
+			{Signal: processor.SignalReviewDone, Phase: processor.PhaseReview},
+		}, signals)
+
+		taskStarts := eventsOfType(events, processor.EventTaskStart)
+		require.Len(t, taskStarts, 1)
+		assert.Equal(t, 1, taskStarts[0].TaskNum)
+		assert.Equal(t, 1, taskStarts[0].Iteration)
+		taskEnds := eventsOfType(events, processor.EventTaskEnd)
+		require.Len(t, taskEnds, 1, "task ends when leaving the task phase")
+		assert.Equal(t, 1, taskEnds[0].TaskNum)
+		assert.Equal(t, processor.PhaseTask, taskEnds[0].Phase)
+
+		assert.NotEmpty(t, eventsOfType(events, processor.EventSection))
+		assert.NotEmpty(t, eventsOfType(events, processor.EventIterationStart))
+		assert.Empty(t, eventsOfType(events, processor.EventError))
+		for _, e := range events {
+			assert.False(t, e.Timestamp.IsZero(), "event %+v has no timestamp", e)
+		}
+	})
+
+	t.Run("failed run reports the error", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{{Output: "failed", Signal: processor.SignalFailed}})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 50,
+			AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		events, err := collectEvents(t, r)
+		require.Error(t, err)
+
+		signals := eventsOfType(events, processor.EventSignal)
+		require.NotEmpty(t, signals)
+		assert.Equal(t, processor.SignalFailed, signals[0].Signal)
+		errs := eventsOfType(events, processor.EventError)
+		require.Len(t, errs, 1)
+		assert.False(t, errs[0].Recoverable)
+		assert.Contains(t, errs[0].Text, err.Error())
+	})
+
+	t.Run("logger still receives all calls", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{{Error: errors.New("boom")}})
+		cfg := processor.Config{Mode: processor.ModeReview, MaxIterations: 50, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		events, err := collectEvents(t, r)
+		require.Error(t, err)
+
+		assert.Len(t, eventsOfType(events, processor.EventSection), len(log.PrintSectionCalls()))
+		assert.Len(t, eventsOfType(events, processor.EventError), len(log.LogErrorCalls()))
+		assert.NotEmpty(t, log.SetPhaseCalls())
+	})
+
+	t.Run("events are not required", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+		claude := newMockExecutor([]executor.Result{{Output: "task done", Signal: processor.SignalCompleted}})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 50, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		require.NoError(t, r.Run(context.Background()))
+	})
+}
@@ -6,6 +6,7 @@ import "time"
 // this file is only compiled during test builds (`go test`).
 type TestRunnerConfig struct {
 	IterationDelay time.Duration
+	DelayJitter    time.Duration
 	TaskRetryCount int
 }
 
@@ -13,6 +14,7 @@ type TestRunnerConfig struct {
 func (r *Runner) TestConfig() TestRunnerConfig {
 	return TestRunnerConfig{
 		IterationDelay: r.iterationDelay,
+		DelayJitter:    r.delayJitter,
 		TaskRetryCount: r.taskRetryCount,
 	}
 }
@@ -21,6 +21,12 @@ import (
 //			LogDraftReviewFunc: func(action string, feedback string)  {
 //				panic("mock out the LogDraftReview method")
 //			},
+//			LogErrorFunc: func(err error, recoverable bool)  {
+//				panic("mock out the LogError method")
+//			},
+//			LogHumanActionFunc: func(actor string, action string)  {
+//				panic("mock out the LogHumanAction method")
+//			},
 //			LogQuestionFunc: func(question string, options []string)  {
 //				panic("mock out the LogQuestion method")
 //			},
@@ -55,6 +61,12 @@ type LoggerMock struct {
 	// LogDraftReviewFunc mocks the LogDraftReview method.
 	LogDraftReviewFunc func(action string, feedback string)
 
+	// LogErrorFunc mocks the LogError method.
+	LogErrorFunc func(err error, recoverable bool)
+
+	// LogHumanActionFunc mocks the LogHumanAction method.
+	LogHumanActionFunc func(actor string, action string)
+
 	// LogQuestionFunc mocks the LogQuestion method.
 	LogQuestionFunc func(question string, options []string)
 
@@ -90,6 +102,20 @@ type LoggerMock struct {
 			// Feedback is the feedback argument value.
 			Feedback string
 		}
+		// LogError holds details about calls to the LogError method.
+		LogError []struct {
+			// Err is the err argument value.
+			Err error
+			// Recoverable is the recoverable argument value.
+			Recoverable bool
+		}
+		// LogHumanAction holds details about calls to the LogHumanAction method.
+		LogHumanAction []struct {
+			// Actor is the actor argument value.
+			Actor string
+			// Action is the action argument value.
+			Action string
+		}
 		// LogQuestion holds details about calls to the LogQuestion method.
 		LogQuestion []struct {
 			// Question is the question argument value.
@@ -132,6 +158,8 @@ type LoggerMock struct {
 	}
 	lockLogAnswer      sync.RWMutex
 	lockLogDraftReview sync.RWMutex
+	lockLogError       sync.RWMutex
+	lockLogHumanAction sync.RWMutex
 	lockLogQuestion    sync.RWMutex
 	lockPath           sync.RWMutex
 	lockPrint          sync.RWMutex
@@ -209,6 +237,78 @@ func (mock *LoggerMock) LogDraftReviewCalls() []struct {
 	return calls
 }
 
+// LogError calls LogErrorFunc.
+func (mock *LoggerMock) LogError(err error, recoverable bool) {
+	if mock.LogErrorFunc == nil {
+		panic("LoggerMock.LogErrorFunc: method is nil but Logger.LogError was just called")
+	}
+	callInfo := struct {
+		Err         error
+		Recoverable bool
+	}{
+		Err:         err,
+		Recoverable: recoverable,
+	}
+	mock.lockLogError.Lock()
+	mock.calls.LogError = append(mock.calls.LogError, callInfo)
+	mock.lockLogError.Unlock()
+	mock.LogErrorFunc(err, recoverable)
+}
+
+// LogErrorCalls gets all the calls that were made to LogError.
+// Check the length with:
+//
+//	len(mockedLogger.LogErrorCalls())
+func (mock *LoggerMock) LogErrorCalls() []struct {
+	Err         error
+	Recoverable bool
+} {
+	var calls []struct {
+		Err         error
+		Recoverable bool
+	}
+	mock.lockLogError.RLock()
+	calls = mock.calls.LogError
+	mock.lockLogError.RUnlock()
+	return calls
+}
+
+// LogHumanAction calls LogHumanActionFunc.
+func (mock *LoggerMock) LogHumanAction(actor string, action string) {
+	if mock.LogHumanActionFunc == nil {
+		panic("LoggerMock.LogHumanActionFunc: method is nil but Logger.LogHumanAction was just called")
+	}
+	callInfo := struct {
+		Actor  string
+		Action string
+	}{
+		Actor:  actor,
+		Action: action,
+	}
+	mock.lockLogHumanAction.Lock()
+	mock.calls.LogHumanAction = append(mock.calls.LogHumanAction, callInfo)
+	mock.lockLogHumanAction.Unlock()
+	mock.LogHumanActionFunc(actor, action)
+}
+
+// LogHumanActionCalls gets all the calls that were made to LogHumanAction.
+// Check the length with:
+//
+//	len(mockedLogger.LogHumanActionCalls())
+func (mock *LoggerMock) LogHumanActionCalls() []struct {
+	Actor  string
+	Action string
+} {
+	var calls []struct {
+		Actor  string
+		Action string
+	}
+	mock.lockLogHumanAction.RLock()
+	calls = mock.calls.LogHumanAction
+	mock.lockLogHumanAction.RUnlock()
+	return calls
+}
+
 // LogQuestion calls LogQuestionFunc.
 func (mock *LoggerMock) LogQuestion(question string, options []string) {
 	if mock.LogQuestionFunc == nil {
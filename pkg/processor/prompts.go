@@ -124,6 +124,24 @@ func (r *Runner) replacePromptVariables(prompt string) string {
 	return result
 }
 
+// buildReviewPrompt renders a claude review prompt, appending the review scope when ReviewPaths is set.
+func (r *Runner) buildReviewPrompt(prompt string) string {
+	result := r.replacePromptVariables(prompt)
+	if len(r.cfg.ReviewPaths) == 0 {
+		return result
+	}
+
+	var sb strings.Builder
+	sb.WriteString(result)
+	sb.WriteString("\n\n## Review Scope\n\nLimit the review to changes in these paths and ignore changes elsewhere:\n")
+	for _, p := range r.cfg.ReviewPaths {
+		sb.WriteString("- " + p + "\n")
+	}
+	fmt.Fprintf(&sb, "\nPass the paths to git commands, e.g. `git diff %s...HEAD -- %s`.\n",
+		r.getDefaultBranch(), strings.Join(r.cfg.ReviewPaths, " "))
+	return sb.String()
+}
+
 // getDefaultBranch returns the default branch name or "master" as fallback.
 func (r *Runner) getDefaultBranch() string {
 	if r.cfg.DefaultBranch == "" {
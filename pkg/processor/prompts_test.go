@@ -95,6 +95,31 @@ func TestRunner_replacePromptVariables_ReviewSecondPrompt(t *testing.T) {
 	})
 }
 
+func TestRunner_buildReviewPrompt(t *testing.T) {
+	appCfg := testAppConfig(t)
+
+	t.Run("no review paths keeps prompt unchanged", func(t *testing.T) {
+		r := &Runner{cfg: Config{ProgressPath: "progress.txt", DefaultBranch: "main", AppConfig: appCfg}, log: newMockLogger("")}
+		for _, tmpl := range []string{appCfg.ReviewFirstPrompt, appCfg.ReviewSecondPrompt} {
+			prompt := r.buildReviewPrompt(tmpl)
+			assert.Equal(t, r.replacePromptVariables(tmpl), prompt)
+			assert.NotContains(t, prompt, "## Review Scope")
+		}
+	})
+
+	t.Run("review paths are appended as scope", func(t *testing.T) {
+		r := &Runner{cfg: Config{ProgressPath: "progress.txt", DefaultBranch: "main", ReviewPaths: []string{"pkg/web", "cmd"},
+			AppConfig: appCfg}, log: newMockLogger("")}
+		for _, tmpl := range []string{appCfg.ReviewFirstPrompt, appCfg.ReviewSecondPrompt} {
+			prompt := r.buildReviewPrompt(tmpl)
+			assert.True(t, strings.HasPrefix(prompt, r.replacePromptVariables(tmpl)), "scope is appended to the rendered prompt")
+			assert.Contains(t, prompt, "## Review Scope")
+			assert.Contains(t, prompt, "- pkg/web\n- cmd\n")
+			assert.Contains(t, prompt, "`git diff main...HEAD -- pkg/web cmd`")
+		}
+	})
+}
+
 func TestRunner_buildCodexEvaluationPrompt(t *testing.T) {
 	findings := "Issue 1: Missing error check in foo.go:42"
 
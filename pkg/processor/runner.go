@@ -5,11 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/umputun/ralphex/pkg/checkbox"
 	"github.com/umputun/ralphex/pkg/config"
 	"github.com/umputun/ralphex/pkg/executor"
 )
@@ -17,6 +21,9 @@ import (
 // DefaultIterationDelay is the pause between iterations to allow system to settle.
 const DefaultIterationDelay = 2 * time.Second
 
+// humanActor is the actor recorded for actions performed by the local user.
+const humanActor = "user"
+
 // Mode represents the execution mode.
 type Mode string
 
@@ -28,26 +35,54 @@ const (
 	ModePlan      Mode = "plan"       // interactive plan creation mode
 )
 
+// review phase orders, see Config.ReviewOrder
+const (
+	ReviewOrderClaudeFirst = "claude-first" // claude review, claude review loop, codex loop, claude review loop
+	ReviewOrderCodexFirst  = "codex-first"  // codex loop, claude review, claude review loop
+)
+
 // Config holds runner configuration.
 type Config struct {
-	PlanFile         string         // path to plan file (required for full mode)
-	PlanDescription  string         // plan description for interactive plan creation mode
-	ProgressPath     string         // path to progress file
-	Mode             Mode           // execution mode
-	MaxIterations    int            // maximum iterations for task phase
-	Debug            bool           // enable debug output
-	NoColor          bool           // disable color output
-	IterationDelayMs int            // delay between iterations in milliseconds
-	TaskRetryCount   int            // number of times to retry failed tasks
-	CodexEnabled     bool           // whether codex review is enabled
-	FinalizeEnabled  bool           // whether finalize step is enabled
-	DefaultBranch    string         // default branch name (detected from repo)
-	AppConfig        *config.Config // full application config (for executors and prompts)
+	PlanFile          string         // path to plan file (required for full mode)
+	PlanDescription   string         // plan description for interactive plan creation mode
+	ProgressPath      string         // path to progress file
+	Mode              Mode           // execution mode
+	MaxIterations     int            // maximum iterations for task phase
+	Debug             bool           // enable debug output
+	NoColor           bool           // disable color output
+	IterationDelayMs  int            // delay between iterations in milliseconds
+	IterationJitterMs int            // random +/- variation applied to the iteration delay in milliseconds
+	TaskRetryCount    int            // number of times to retry failed tasks
+	StallLimit        int            // abort the task phase after this many iterations without progress, 0 disables
+	TreatProseAsTask  bool           // run a plan without task checkboxes as a single task instead of refusing it
+	CodexEnabled      bool           // whether codex review is enabled
+	CodexPasses       int            // max codex review passes, 0 derives it from MaxIterations
+	CodexMinFindings  int            // min file:line findings in codex output to run the fix loop, 0 disables
+	ReviewOrder       string         // order of review phases, ReviewOrderClaudeFirst (default) or ReviewOrderCodexFirst
+	FinalizeEnabled   bool           // whether finalize step is enabled
+	AutoCommit        bool           // commit changes after each successful task iteration
+	DefaultBranch     string         // default branch name (detected from repo)
+	DryRun            bool           // log rendered prompts instead of running executors
+	ReviewPaths       []string       // limit review phases to these paths, empty reviews all changes
+	AppConfig         *config.Config // full application config (for executors and prompts)
+
+	// ContinueOnReviewFailure logs a FAILED review phase as a recoverable error and goes on with the next phase,
+	// task phase failures still stop the run
+	ContinueOnReviewFailure bool
+
+	// RetryOnCrash retries a task iteration whose claude process failed (Result.Error) like one that sent
+	// the FAILED signal, sharing the TaskRetryCount budget. error patterns and cancellation still stop the run
+	RetryOnCrash bool
 }
 
 //go:generate moq -out mocks/executor.go -pkg mocks -skip-ensure -fmt goimports . Executor
 //go:generate moq -out mocks/logger.go -pkg mocks -skip-ensure -fmt goimports . Logger
 //go:generate moq -out mocks/input_collector.go -pkg mocks -skip-ensure -fmt goimports . InputCollector
+//go:generate moq -out mocks/pause_gate.go -pkg mocks -skip-ensure -fmt goimports . PauseGate
+//go:generate moq -out mocks/committer.go -pkg mocks -skip-ensure -fmt goimports . Committer
+//go:generate moq -out mocks/clock.go -pkg mocks -skip-ensure -fmt goimports . Clock
+//go:generate moq -out mocks/work_tree.go -pkg mocks -skip-ensure -fmt goimports . WorkTree
+//go:generate moq -out mocks/prompt_recorder.go -pkg mocks -skip-ensure -fmt goimports . PromptRecorder
 
 // Executor runs CLI commands and returns results.
 type Executor interface {
@@ -64,6 +99,8 @@ type Logger interface {
 	LogQuestion(question string, options []string)
 	LogAnswer(answer string)
 	LogDraftReview(action string, feedback string)
+	LogHumanAction(actor string, action string)
+	LogError(err error, recoverable bool) // recoverable errors don't stop the run
 	Path() string
 }
 
@@ -73,6 +110,59 @@ type InputCollector interface {
 	AskDraftReview(ctx context.Context, question string, planContent string) (action string, feedback string, err error)
 }
 
+// PauseGate lets an external controller (e.g. the web dashboard) hold the runner between iterations.
+// PauseActions returns the pause and resume requests made since the previous call, oldest first,
+// so a pause resumed before the runner reached its next gate check is still recorded.
+type PauseGate interface {
+	IsPaused() bool
+	WaitResume(ctx context.Context) error
+	PauseActions() []PauseAction
+}
+
+// PauseAction is a pause or resume request received by a PauseGate.
+type PauseAction struct {
+	Actor  string    // who made the request, e.g. "web"
+	Action string    // "paused run" or "resumed run"
+	Time   time.Time // when the request was made
+}
+
+// Committer commits working tree changes, returning the commit hash or empty string if nothing changed.
+type Committer interface {
+	CommitAll(message string) (string, error)
+}
+
+// WorkTree reports the state of the repository, used to detect task iterations that changed nothing.
+type WorkTree interface {
+	HeadCommit() (string, error)
+	ChangesHash() (string, error)
+}
+
+// PromptRecorder receives every prompt right before it is sent to an executor,
+// e.g. for the web dashboard to show the literal prompt of the current phase.
+type PromptRecorder interface {
+	RecordPrompt(phase Phase, prompt string)
+}
+
+// Clock provides the time source for phase timings and the waits between iterations and retries,
+// tests replace it to control timing.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock implements Clock with the time package.
+type realClock struct{}
+
+// Now returns the current time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns a channel receiving the current time once d elapses.
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
 // Runner orchestrates the execution loop.
 type Runner struct {
 	cfg            Config
@@ -80,17 +170,39 @@ type Runner struct {
 	claude         Executor
 	codex          Executor
 	inputCollector InputCollector
+	pauseGate      PauseGate
+	committer      Committer
+	workTree       WorkTree
+	promptRecorder PromptRecorder
+	setupErr       error // executor setup problem (e.g. missing claude binary), reported by Run before any phase
 	iterationDelay time.Duration
+	delayJitter    time.Duration
+	codexTimeout   time.Duration // limit of a single codex run, 0 means no limit
+	clock          Clock
 	taskRetryCount int
+
+	// phase timing, accumulated by setPhase and reported by PhaseTimings
+	phase      Phase
+	phaseStart time.Time
+	timings    map[Phase]time.Duration
+
+	// token usage reported by executor runs, per phase, see TokenUsage
+	tokens map[Phase]int64
+
+	// structured events channel, created by Events
+	events chan Event
 }
 
 // New creates a new Runner with the given configuration.
 // If codex is enabled but the binary is not found in PATH, it is automatically disabled with a warning.
+// A missing claude binary is reported by Run before any phase starts (not checked in dry-run).
 func New(cfg Config, log Logger) *Runner {
+	var r *Runner // set below, referenced by output handlers at run time
+
 	// build claude executor with config values
 	claudeExec := &executor.ClaudeExecutor{
 		OutputHandler: func(text string) {
-			log.PrintAligned(text)
+			r.log.PrintAligned(text) // r.log, so output reaches the events logger installed by Events
 		},
 		Debug: cfg.Debug,
 	}
@@ -98,12 +210,13 @@ func New(cfg Config, log Logger) *Runner {
 		claudeExec.Command = cfg.AppConfig.ClaudeCommand
 		claudeExec.Args = cfg.AppConfig.ClaudeArgs
 		claudeExec.ErrorPatterns = cfg.AppConfig.ClaudeErrorPatterns
+		claudeExec.Env = cfg.AppConfig.ExecutorEnv
 	}
 
 	// build codex executor with config values
 	codexExec := &executor.CodexExecutor{
 		OutputHandler: func(text string) {
-			log.PrintAligned(text)
+			r.log.PrintAligned(text) // r.log, so output reaches the events logger installed by Events
 		},
 		Debug: cfg.Debug,
 	}
@@ -113,22 +226,33 @@ func New(cfg Config, log Logger) *Runner {
 		codexExec.ReasoningEffort = cfg.AppConfig.CodexReasoningEffort
 		codexExec.TimeoutMs = cfg.AppConfig.CodexTimeoutMs
 		codexExec.Sandbox = cfg.AppConfig.CodexSandbox
+		codexExec.Args = cfg.AppConfig.CodexArgs
 		codexExec.ErrorPatterns = cfg.AppConfig.CodexErrorPatterns
+		codexExec.Env = cfg.AppConfig.ExecutorEnv
+	}
+
+	// token usage pattern is validated on config load, a broken one only disables counting
+	if cfg.AppConfig != nil && cfg.AppConfig.TokenUsagePattern != "" {
+		if re, err := regexp.Compile(cfg.AppConfig.TokenUsagePattern); err == nil {
+			claudeExec.TokenPattern, codexExec.TokenPattern = re, re
+		} else {
+			log.Print("warning: invalid token_usage_pattern (%v), token usage is not counted", err)
+		}
 	}
 
 	// auto-disable codex if the binary is not installed
 	if cfg.CodexEnabled {
-		codexCmd := codexExec.Command
-		if codexCmd == "" {
-			codexCmd = "codex"
-		}
-		if _, err := exec.LookPath(codexCmd); err != nil {
-			log.Print("warning: codex not found (%s: %v), disabling codex review phase", codexCmd, err)
+		if err := codexExec.CheckCommand(); err != nil {
+			log.Print("warning: codex not found (%v), disabling codex review phase", err)
 			cfg.CodexEnabled = false
 		}
 	}
 
-	return NewWithExecutors(cfg, log, claudeExec, codexExec)
+	r = NewWithExecutors(cfg, log, claudeExec, codexExec)
+	if !cfg.DryRun {
+		r.setupErr = claudeExec.CheckCommand()
+	}
+	return r
 }
 
 // NewWithExecutors creates a new Runner with custom executors (for testing).
@@ -138,6 +262,10 @@ func NewWithExecutors(cfg Config, log Logger, claude, codex Executor) *Runner {
 	if cfg.IterationDelayMs > 0 {
 		iterDelay = time.Duration(cfg.IterationDelayMs) * time.Millisecond
 	}
+	iterJitter := time.Duration(max(cfg.IterationJitterMs, 0)) * time.Millisecond
+	if cfg.DryRun {
+		iterDelay, iterJitter = 0, 0 // nothing to settle when executors are not invoked
+	}
 
 	// determine task retry count from config
 	// appConfig.TaskRetryCountSet means user explicitly set it (even to 0 for no retries)
@@ -148,13 +276,23 @@ func NewWithExecutors(cfg Config, log Logger, claude, codex Executor) *Runner {
 		retryCount = cfg.TaskRetryCount
 	}
 
+	var codexTimeout time.Duration
+	if cfg.AppConfig != nil && cfg.AppConfig.CodexTimeoutMs > 0 {
+		codexTimeout = time.Duration(cfg.AppConfig.CodexTimeoutMs) * time.Millisecond
+	}
+
 	return &Runner{
 		cfg:            cfg,
 		log:            log,
 		claude:         claude,
 		codex:          codex,
 		iterationDelay: iterDelay,
+		delayJitter:    iterJitter,
+		codexTimeout:   codexTimeout,
+		clock:          realClock{},
 		taskRetryCount: retryCount,
+		timings:        make(map[Phase]time.Duration),
+		tokens:         make(map[Phase]int64),
 	}
 }
 
@@ -163,8 +301,55 @@ func (r *Runner) SetInputCollector(c InputCollector) {
 	r.inputCollector = c
 }
 
+// SetPauseGate sets the gate checked before every executor call.
+func (r *Runner) SetPauseGate(g PauseGate) {
+	r.pauseGate = g
+}
+
+// SetCommitter sets the committer used for per-iteration commits when AutoCommit is enabled.
+func (r *Runner) SetCommitter(c Committer) {
+	r.committer = c
+}
+
+// SetWorkTree sets the repository state used by stall detection, without it only plan checkboxes count as progress.
+func (r *Runner) SetWorkTree(w WorkTree) {
+	r.workTree = w
+}
+
+// SetPromptRecorder sets the recorder receiving the prompts sent to executors.
+func (r *Runner) SetPromptRecorder(p PromptRecorder) {
+	r.promptRecorder = p
+}
+
+// SetClock sets the clock used for iteration delays, the real clock is used by default.
+func (r *Runner) SetClock(c Clock) {
+	r.clock = c
+}
+
 // Run executes the main loop based on configured mode.
+// cancellation of ctx (e.g. ctrl+c) is recorded as a human action, any other error stopping the run is logged via LogError.
 func (r *Runner) Run(ctx context.Context) error {
+	if r.events != nil {
+		defer close(r.events)
+	}
+	if r.setupErr != nil {
+		err := fmt.Errorf("setup: %w", r.setupErr)
+		r.log.LogError(err, false)
+		return err
+	}
+	err := r.runMode(ctx)
+	r.stopPhaseClock()
+	switch {
+	case errors.Is(err, context.Canceled):
+		r.log.LogHumanAction(humanActor, "canceled run")
+	case err != nil:
+		r.log.LogError(err, false)
+	}
+	return err
+}
+
+// runMode dispatches to the run function for the configured mode.
+func (r *Runner) runMode(ctx context.Context) error {
 	switch r.cfg.Mode {
 	case ModeFull:
 		return r.runFull(ctx)
@@ -181,46 +366,38 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 }
 
-// runFull executes the complete pipeline: tasks → review → codex → review.
+// runFull executes the complete pipeline: tasks, then the review phases, see runReviewPhases.
 func (r *Runner) runFull(ctx context.Context) error {
 	if r.cfg.PlanFile == "" {
 		return errors.New("plan file required for full mode")
 	}
 
 	// phase 1: task execution
-	r.log.SetPhase(PhaseTask)
+	r.setPhase(PhaseTask)
 	r.log.PrintRaw("starting task execution phase\n")
 
 	if err := r.runTaskPhase(ctx); err != nil {
 		return fmt.Errorf("task phase: %w", err)
 	}
 
-	// phase 2: first review pass - address ALL findings
-	r.log.SetPhase(PhaseReview)
-	r.log.PrintSection(NewGenericSection("claude review 0: all findings"))
-
-	if err := r.runClaudeReview(ctx, r.replacePromptVariables(r.cfg.AppConfig.ReviewFirstPrompt)); err != nil {
-		return fmt.Errorf("first review: %w", err)
-	}
-
-	// phase 2.1: claude review loop (critical/major) before codex
-	if err := r.runClaudeReviewLoop(ctx); err != nil {
-		return fmt.Errorf("pre-codex review loop: %w", err)
+	// phase 2: review pipeline
+	if err := r.runReviewPhases(ctx); err != nil {
+		return err
 	}
 
-	// phase 2.5: codex external review loop
-	r.log.SetPhase(PhaseCodex)
-	r.log.PrintSection(NewGenericSection("codex external review"))
-
-	if err := r.runCodexLoop(ctx); err != nil {
-		return fmt.Errorf("codex loop: %w", err)
+	// optional finalize step (best-effort, but propagates context cancellation)
+	if err := r.runFinalize(ctx); err != nil {
+		return err
 	}
 
-	// phase 3: claude review loop (critical/major) after codex
-	r.log.SetPhase(PhaseReview)
+	r.log.Print("all phases completed successfully")
+	return nil
+}
 
-	if err := r.runClaudeReviewLoop(ctx); err != nil {
-		return fmt.Errorf("post-codex review loop: %w", err)
+// runReviewOnly executes only the review pipeline, see runReviewPhases.
+func (r *Runner) runReviewOnly(ctx context.Context) error {
+	if err := r.runReviewPhases(ctx); err != nil {
+		return err
 	}
 
 	// optional finalize step (best-effort, but propagates context cancellation)
@@ -228,53 +405,89 @@ func (r *Runner) runFull(ctx context.Context) error {
 		return err
 	}
 
-	r.log.Print("all phases completed successfully")
+	r.log.Print("review phases completed successfully")
 	return nil
 }
 
-// runReviewOnly executes only the review pipeline: review → codex → review.
-func (r *Runner) runReviewOnly(ctx context.Context) error {
-	// phase 1: first review
-	r.log.SetPhase(PhaseReview)
-	r.log.PrintSection(NewGenericSection("claude review 0: all findings"))
-
-	if err := r.runClaudeReview(ctx, r.replacePromptVariables(r.cfg.AppConfig.ReviewFirstPrompt)); err != nil {
-		return fmt.Errorf("first review: %w", err)
+// runReviewPhases runs the claude and codex review phases in the configured order:
+// review → codex → review by default, codex → review with ReviewOrderCodexFirst.
+// each phase keeps its own signals and iteration caps whatever the order.
+func (r *Runner) runReviewPhases(ctx context.Context) error {
+	if r.cfg.ReviewOrder == ReviewOrderCodexFirst {
+		if err := r.runCodexPhase(ctx); err != nil {
+			return err
+		}
+		if err := r.skipReviewFailure(r.runFirstReview(ctx)); err != nil {
+			return err
+		}
+		// claude review loop (critical/major) after codex
+		if err := r.runClaudeReviewLoop(ctx); err != nil {
+			return r.skipReviewFailure(fmt.Errorf("post-codex review loop: %w", err))
+		}
+		return nil
 	}
 
-	// phase 1.1: claude review loop (critical/major) before codex
+	if err := r.skipReviewFailure(r.runFirstReview(ctx)); err != nil {
+		return err
+	}
+	// claude review loop (critical/major) before codex
 	if err := r.runClaudeReviewLoop(ctx); err != nil {
-		return fmt.Errorf("pre-codex review loop: %w", err)
+		if err = r.skipReviewFailure(fmt.Errorf("pre-codex review loop: %w", err)); err != nil {
+			return err
+		}
 	}
-
-	// phase 2: codex external review loop
-	r.log.SetPhase(PhaseCodex)
-	r.log.PrintSection(NewGenericSection("codex external review"))
-
-	if err := r.runCodexLoop(ctx); err != nil {
-		return fmt.Errorf("codex loop: %w", err)
+	if err := r.runCodexPhase(ctx); err != nil {
+		return err
 	}
 
-	// phase 3: claude review loop (critical/major) after codex
-	r.log.SetPhase(PhaseReview)
-
+	// claude review loop (critical/major) after codex
+	r.setPhase(PhaseReview)
 	if err := r.runClaudeReviewLoop(ctx); err != nil {
-		return fmt.Errorf("post-codex review loop: %w", err)
+		return r.skipReviewFailure(fmt.Errorf("post-codex review loop: %w", err))
 	}
+	return nil
+}
 
-	// optional finalize step (best-effort, but propagates context cancellation)
-	if err := r.runFinalize(ctx); err != nil {
+// skipReviewFailure returns err unless it is a review phase failure and ContinueOnReviewFailure is set,
+// then the failure is logged as a recoverable error and the run goes on with the next phase.
+func (r *Runner) skipReviewFailure(err error) error {
+	if err == nil || !r.cfg.ContinueOnReviewFailure || !errors.Is(err, ErrReviewFailed) {
 		return err
 	}
+	r.log.LogError(err, true)
+	r.log.Print("review phase failed, continuing with the next phase (continue_on_review_failure)")
+	return nil
+}
+
+// runFirstReview runs the first claude review pass, addressing all findings.
+func (r *Runner) runFirstReview(ctx context.Context) error {
+	r.setPhase(PhaseReview)
+	r.log.PrintSection(NewGenericSection("claude review 0: all findings"))
+	if err := r.runClaudeReview(ctx, r.buildReviewPrompt(r.cfg.AppConfig.ReviewFirstPrompt)); err != nil {
+		return fmt.Errorf("first review: %w", err)
+	}
+	return nil
+}
 
-	r.log.Print("review phases completed successfully")
+// runCodexPhase runs the codex external review loop.
+// a codex timeout is logged as a recoverable error and the run continues with the claude review phases.
+func (r *Runner) runCodexPhase(ctx context.Context) error {
+	r.setPhase(PhaseCodex)
+	r.log.PrintSection(NewGenericSection("codex external review"))
+	if err := r.runCodexLoop(ctx); err != nil {
+		if errors.Is(err, ErrCodexTimeout) {
+			r.log.LogError(fmt.Errorf("codex loop: %w", err), true)
+			return nil
+		}
+		return fmt.Errorf("codex loop: %w", err)
+	}
 	return nil
 }
 
 // runCodexOnly executes only the codex pipeline: codex → review.
 func (r *Runner) runCodexOnly(ctx context.Context) error {
 	// phase 1: codex external review loop
-	r.log.SetPhase(PhaseCodex)
+	r.setPhase(PhaseCodex)
 	r.log.PrintSection(NewGenericSection("codex external review"))
 
 	if err := r.runCodexLoop(ctx); err != nil {
@@ -282,10 +495,12 @@ func (r *Runner) runCodexOnly(ctx context.Context) error {
 	}
 
 	// phase 2: claude review loop (critical/major) after codex
-	r.log.SetPhase(PhaseReview)
+	r.setPhase(PhaseReview)
 
 	if err := r.runClaudeReviewLoop(ctx); err != nil {
-		return fmt.Errorf("post-codex review loop: %w", err)
+		if err = r.skipReviewFailure(fmt.Errorf("post-codex review loop: %w", err)); err != nil {
+			return err
+		}
 	}
 
 	// optional finalize step (best-effort, but propagates context cancellation)
@@ -303,7 +518,7 @@ func (r *Runner) runTasksOnly(ctx context.Context) error {
 		return errors.New("plan file required for tasks-only mode")
 	}
 
-	r.log.SetPhase(PhaseTask)
+	r.setPhase(PhaseTask)
 	r.log.PrintRaw("starting task execution phase\n")
 
 	if err := r.runTaskPhase(ctx); err != nil {
@@ -317,8 +532,21 @@ func (r *Runner) runTasksOnly(ctx context.Context) error {
 // runTaskPhase executes tasks until completion or max iterations.
 // executes ONE Task section per iteration.
 func (r *Runner) runTaskPhase(ctx context.Context) error {
+	// without checkboxes completion can't be verified, a prose plan is either one task or a mistake
+	if !r.hasTaskCheckboxes() {
+		r.log.Print("warning: plan has no task checkboxes")
+		if !r.cfg.TreatProseAsTask {
+			return ErrNoTaskCheckboxes
+		}
+		r.log.Print("treating the whole plan as a single task (treat_prose_as_task)")
+	}
+
 	prompt := r.replacePromptVariables(r.cfg.AppConfig.TaskPrompt)
 	retryCount := 0
+	var stall stallTracker
+	if r.stallDetection() {
+		stall.last = r.progressSnapshot()
+	}
 
 	for i := 1; i <= r.cfg.MaxIterations; i++ {
 		select {
@@ -327,31 +555,56 @@ func (r *Runner) runTaskPhase(ctx context.Context) error {
 		default:
 		}
 
+		if err := r.checkPlanFile(); err != nil {
+			return err
+		}
+
 		r.log.PrintSection(NewTaskIterationSection(i))
 
-		result := r.claude.Run(ctx, prompt)
+		result := r.runExecutor(ctx, r.claude, "claude", prompt, executor.Result{Signal: SignalCompleted})
 		if result.Error != nil {
 			if err := r.handlePatternMatchError(result.Error, "claude"); err != nil {
 				return err
 			}
+			if r.cfg.RetryOnCrash && ctx.Err() == nil && retryCount < r.taskRetryCount {
+				r.log.Print("claude crashed: %v, retrying...", result.Error)
+				retryCount++
+				r.waitIteration(ctx)
+				continue
+			}
 			return fmt.Errorf("claude execution: %w", result.Error)
 		}
+		// a plan removed during the iteration can't confirm completion, and must not be taken for an unfinished one
+		if err := r.checkPlanFile(); err != nil {
+			return err
+		}
+
+		if result.Signal != SignalFailed {
+			r.commitIteration(i)
+		}
+		stallErr := r.trackStall(&stall)
 
 		if result.Signal == SignalCompleted {
-			// verify plan actually has no uncompleted checkboxes
-			if r.hasUncompletedTasks() {
+			// verify plan actually has no uncompleted checkboxes (plan is not modified in dry-run)
+			if !r.cfg.DryRun && r.hasUncompletedTasks() {
+				if stallErr != nil {
+					return stallErr
+				}
 				r.log.Print("warning: completion signal received but plan still has [ ] items, continuing...")
 				continue
 			}
 			r.log.PrintRaw("\nall tasks completed, starting code review...\n")
 			return nil
 		}
+		if stallErr != nil {
+			return stallErr
+		}
 
 		if result.Signal == SignalFailed {
 			if retryCount < r.taskRetryCount {
 				r.log.Print("task failed, retrying...")
 				retryCount++
-				time.Sleep(r.iterationDelay)
+				r.waitIteration(ctx)
 				continue
 			}
 			return errors.New("task execution failed after retry (FAILED signal received)")
@@ -359,7 +612,7 @@ func (r *Runner) runTaskPhase(ctx context.Context) error {
 
 		retryCount = 0
 		// continue with same prompt - it reads from plan file each time
-		time.Sleep(r.iterationDelay)
+		r.waitIteration(ctx)
 	}
 
 	return fmt.Errorf("max iterations (%d) reached without completion", r.cfg.MaxIterations)
@@ -367,7 +620,7 @@ func (r *Runner) runTaskPhase(ctx context.Context) error {
 
 // runClaudeReview runs Claude review with the given prompt until REVIEW_DONE.
 func (r *Runner) runClaudeReview(ctx context.Context, prompt string) error {
-	result := r.claude.Run(ctx, prompt)
+	result := r.runExecutor(ctx, r.claude, "claude", prompt, executor.Result{Signal: SignalReviewDone})
 	if result.Error != nil {
 		if err := r.handlePatternMatchError(result.Error, "claude"); err != nil {
 			return err
@@ -376,7 +629,7 @@ func (r *Runner) runClaudeReview(ctx context.Context, prompt string) error {
 	}
 
 	if result.Signal == SignalFailed {
-		return errors.New("review failed (FAILED signal received)")
+		return ErrReviewFailed
 	}
 
 	if !IsReviewDone(result.Signal) {
@@ -400,7 +653,8 @@ func (r *Runner) runClaudeReviewLoop(ctx context.Context) error {
 
 		r.log.PrintSection(NewClaudeReviewSection(i, ": critical/major"))
 
-		result := r.claude.Run(ctx, r.replacePromptVariables(r.cfg.AppConfig.ReviewSecondPrompt))
+		result := r.runExecutor(ctx, r.claude, "claude", r.buildReviewPrompt(r.cfg.AppConfig.ReviewSecondPrompt),
+			executor.Result{Signal: SignalReviewDone})
 		if result.Error != nil {
 			if err := r.handlePatternMatchError(result.Error, "claude"); err != nil {
 				return err
@@ -409,7 +663,7 @@ func (r *Runner) runClaudeReviewLoop(ctx context.Context) error {
 		}
 
 		if result.Signal == SignalFailed {
-			return errors.New("review failed (FAILED signal received)")
+			return ErrReviewFailed
 		}
 
 		if IsReviewDone(result.Signal) {
@@ -418,7 +672,7 @@ func (r *Runner) runClaudeReviewLoop(ctx context.Context) error {
 		}
 
 		r.log.Print("issues fixed, running another review iteration...")
-		time.Sleep(r.iterationDelay)
+		r.waitIteration(ctx)
 	}
 
 	r.log.Print("max claude review iterations reached, continuing...")
@@ -433,8 +687,11 @@ func (r *Runner) runCodexLoop(ctx context.Context) error {
 		return nil
 	}
 
-	// codex iterations = 20% of max_iterations (min 3)
+	// codex iterations = codex_passes if set, otherwise 20% of max_iterations (min 3)
 	maxCodexIterations := max(3, r.cfg.MaxIterations/5)
+	if r.cfg.CodexPasses > 0 {
+		maxCodexIterations = min(r.cfg.CodexPasses, max(r.cfg.MaxIterations, 1)) // never exceed the iteration cap
+	}
 
 	var claudeResponse string // first iteration has no prior response
 
@@ -448,8 +705,11 @@ func (r *Runner) runCodexLoop(ctx context.Context) error {
 		r.log.PrintSection(NewCodexIterationSection(i))
 
 		// run codex analysis
-		codexResult := r.codex.Run(ctx, r.buildCodexPrompt(i == 1, claudeResponse))
+		codexResult := r.runCodex(ctx, r.buildCodexPrompt(i == 1, claudeResponse))
 		if codexResult.Error != nil {
+			if errors.Is(codexResult.Error, ErrCodexTimeout) {
+				return codexResult.Error
+			}
 			if err := r.handlePatternMatchError(codexResult.Error, "codex"); err != nil {
 				return err
 			}
@@ -461,16 +721,25 @@ func (r *Runner) runCodexLoop(ctx context.Context) error {
 			break
 		}
 
+		// trivial notes from codex are not worth a claude fix iteration
+		if r.cfg.CodexMinFindings > 0 {
+			if found := countCodexFindings(codexResult.Output); found < r.cfg.CodexMinFindings {
+				r.log.Print("codex findings below threshold, skipping fix loop (found %d, need %d)", found, r.cfg.CodexMinFindings)
+				break
+			}
+		}
+
 		// show codex findings summary before Claude evaluation
 		r.showCodexSummary(codexResult.Output)
 
 		// pass codex output to claude for evaluation and fixing
-		r.log.SetPhase(PhaseClaudeEval)
+		r.setPhase(PhaseClaudeEval)
 		r.log.PrintSection(NewClaudeEvalSection())
-		claudeResult := r.claude.Run(ctx, r.buildCodexEvaluationPrompt(codexResult.Output))
+		claudeResult := r.runExecutor(ctx, r.claude, "claude", r.buildCodexEvaluationPrompt(codexResult.Output),
+			executor.Result{Signal: SignalCodexDone})
 
 		// restore codex phase for next iteration
-		r.log.SetPhase(PhaseCodex)
+		r.setPhase(PhaseCodex)
 		if claudeResult.Error != nil {
 			if err := r.handlePatternMatchError(claudeResult.Error, "claude"); err != nil {
 				return err
@@ -486,13 +755,33 @@ func (r *Runner) runCodexLoop(ctx context.Context) error {
 			return nil
 		}
 
-		time.Sleep(r.iterationDelay)
+		r.waitIteration(ctx)
 	}
 
 	r.log.Print("max codex iterations reached, continuing to next phase...")
 	return nil
 }
 
+// runCodex runs a codex review pass, limited to the configured codex timeout.
+// a pass exceeding the timeout returns ErrCodexTimeout in the result error. time spent paused doesn't count.
+func (r *Runner) runCodex(ctx context.Context, prompt string) executor.Result {
+	dryResult := executor.Result{Output: "dry-run: codex findings placeholder"}
+	if r.codexTimeout <= 0 {
+		return r.runExecutor(ctx, r.codex, "codex", prompt, dryResult)
+	}
+	if err := r.waitIfPaused(ctx); err != nil {
+		return executor.Result{Error: err}
+	}
+
+	codexCtx, cancel := context.WithTimeout(ctx, r.codexTimeout)
+	defer cancel()
+	result := r.runExecutor(codexCtx, r.codex, "codex", prompt, dryResult)
+	if result.Error != nil && ctx.Err() == nil && errors.Is(codexCtx.Err(), context.DeadlineExceeded) {
+		result.Error = fmt.Errorf("%w after %s, raise codex_timeout_ms for longer reviews", ErrCodexTimeout, r.codexTimeout)
+	}
+	return result
+}
+
 // buildCodexPrompt creates the prompt for codex review.
 func (r *Runner) buildCodexPrompt(isFirst bool, claudeResponse string) string {
 	// build plan context if available
@@ -517,6 +806,12 @@ The code implements the plan at: %s
 		diffDescription = "uncommitted changes (Claude's fixes from previous iteration)"
 	}
 
+	// scoped review limits the diff to the requested paths
+	if len(r.cfg.ReviewPaths) > 0 {
+		diffInstruction += " -- " + strings.Join(r.cfg.ReviewPaths, " ")
+		diffDescription += " in " + strings.Join(r.cfg.ReviewPaths, ", ")
+	}
+
 	basePrompt := fmt.Sprintf(`%sReview the %s.
 
 %s
@@ -546,21 +841,123 @@ If Claude's arguments are invalid, explain why the issues still exist.`, basePro
 	return basePrompt
 }
 
+// ErrNoTaskCheckboxes is returned by the task phase for a plan without any task checkboxes,
+// unless TreatProseAsTask is set.
+var ErrNoTaskCheckboxes = errors.New("plan has no task checkboxes, add \"- [ ]\" items for its tasks " +
+	"or set treat_prose_as_task = true to run the whole plan as a single task")
+
+// ErrStalled is returned when the task phase made no progress for StallLimit consecutive iterations.
+var ErrStalled = errors.New("stalled")
+
+// ErrReviewFailed is returned when a claude review pass sends the FAILED signal.
+var ErrReviewFailed = errors.New("review failed (FAILED signal received)")
+
+// ErrCodexTimeout is returned when a codex run exceeds the configured codex_timeout_ms.
+var ErrCodexTimeout = errors.New("codex timed out")
+
+// ErrPlanFileMissing is returned when the plan file was deleted while the task phase was running.
+var ErrPlanFileMissing = errors.New("plan file disappeared")
+
+// checkPlanFile returns ErrPlanFileMissing if the plan file no longer exists, neither where it was
+// nor moved to the completed directory. other stat errors are left to the plan readers.
+func (r *Runner) checkPlanFile() error {
+	if r.cfg.PlanFile == "" {
+		return nil
+	}
+	path := r.resolvePlanFilePath()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrPlanFileMissing, path)
+	}
+	return nil
+}
+
+// progressSnapshot captures what a task iteration is expected to change: plan checkboxes and repository files.
+type progressSnapshot struct {
+	checkboxes string // checkbox lines of the plan file
+	head       string // HEAD commit, moves when iterations are auto-committed
+	changes    string // hash of uncommitted changes, moves when any changed file is edited
+}
+
+// stallTracker counts consecutive task iterations that left the progress snapshot unchanged.
+type stallTracker struct {
+	last  progressSnapshot
+	count int
+}
+
+// progressSnapshot reads the current plan checkboxes and repository state.
+// repository errors leave the related fields empty, so they don't count as progress.
+func (r *Runner) progressSnapshot() progressSnapshot {
+	var snap progressSnapshot
+	if content, err := os.ReadFile(r.resolvePlanFilePath()); err == nil {
+		var boxes []string
+		for _, item := range checkbox.Scan(string(content)) {
+			boxes = append(boxes, item.Text)
+		}
+		snap.checkboxes = strings.Join(boxes, "\n")
+	}
+	if r.workTree != nil {
+		snap.head, _ = r.workTree.HeadCommit()
+		if hash, err := r.workTree.ChangesHash(); err == nil {
+			snap.changes = hash
+		}
+	}
+	return snap
+}
+
+// stallDetection reports whether stall detection is enabled. it is off in dry-run, where executors don't change anything.
+func (r *Runner) stallDetection() bool {
+	return r.cfg.StallLimit > 0 && !r.cfg.DryRun
+}
+
+// trackStall compares the progress snapshot with the previous iteration's one and returns ErrStalled
+// once StallLimit iterations in a row changed nothing.
+func (r *Runner) trackStall(t *stallTracker) error {
+	if !r.stallDetection() {
+		return nil
+	}
+	snap := r.progressSnapshot()
+	if snap != t.last {
+		t.last, t.count = snap, 0
+		return nil
+	}
+	t.count++
+	if t.count >= r.cfg.StallLimit {
+		return fmt.Errorf("%w: no progress for %d iterations", ErrStalled, t.count)
+	}
+	return nil
+}
+
+// hasTaskCheckboxes checks if plan file has any checkboxes, completed or not.
+func (r *Runner) hasTaskCheckboxes() bool {
+	content, err := os.ReadFile(r.resolvePlanFilePath())
+	if err != nil {
+		return true // can't tell, let the task phase deal with the plan
+	}
+	return len(checkbox.Scan(string(content))) > 0
+}
+
 // hasUncompletedTasks checks if plan file has any uncompleted checkboxes.
 func (r *Runner) hasUncompletedTasks() bool {
 	content, err := os.ReadFile(r.resolvePlanFilePath())
 	if err != nil {
 		return true // assume incomplete if can't read
 	}
+	return checkbox.HasUnchecked(string(content))
+}
 
-	// look for uncompleted checkbox pattern: [ ] (not [x])
-	for line := range strings.SplitSeq(string(content), "\n") {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "- [ ]") {
-			return true
+// codexFindingRe matches a file:line reference, e.g. "pkg/foo/bar.go:42".
+var codexFindingRe = regexp.MustCompile(`[\w./-]+\.\w+:\d+`)
+
+// countCodexFindings returns the number of lines in codex output referencing a file:line location.
+// the codex prompt asks for findings with file:line references, so this approximates the number of findings.
+func countCodexFindings(output string) int {
+	count := 0
+	for line := range strings.SplitSeq(output, "\n") {
+		if codexFindingRe.MatchString(line) {
+			count++
 		}
 	}
-	return false
+	return count
 }
 
 // showCodexSummary displays a condensed summary of codex output before Claude evaluation.
@@ -623,6 +1020,7 @@ func (r *Runner) handlePlanDraft(ctx context.Context, output string) draftReview
 
 	// log the draft review action and feedback to progress file
 	r.log.LogDraftReview(action, feedback)
+	r.log.LogHumanAction(humanActor, "draft review: "+action)
 
 	switch action {
 	case "accept":
@@ -645,12 +1043,15 @@ func (r *Runner) handlePlanDraft(ctx context.Context, output string) draftReview
 func (r *Runner) handlePlanQuestion(ctx context.Context, output string) (bool, error) {
 	question, err := ParseQuestionPayload(output)
 	if err != nil {
-		// log malformed signals (but not "no signal" which is expected)
+		// report malformed signals (but not "no signal" which is expected), so the dropped question is visible
 		if !errors.Is(err, ErrNoQuestionSignal) {
-			r.log.Print("warning: %v", err)
+			r.log.LogError(err, true)
 		}
 		return false, nil
 	}
+	if question.Repaired {
+		r.log.Print("warning: question signal had malformed JSON, recovered question %q", question.Question)
+	}
 
 	r.log.LogQuestion(question.Question, question.Options)
 
@@ -660,6 +1061,7 @@ func (r *Runner) handlePlanQuestion(ctx context.Context, output string) (bool, e
 	}
 
 	r.log.LogAnswer(answer)
+	r.log.LogHumanAction(humanActor, "answered question: "+answer)
 	return true, nil
 }
 
@@ -674,7 +1076,7 @@ func (r *Runner) runPlanCreation(ctx context.Context) error {
 		return errors.New("input collector required for plan mode")
 	}
 
-	r.log.SetPhase(PhasePlan)
+	r.setPhase(PhasePlan)
 	r.log.PrintRaw("starting interactive plan creation\n")
 	r.log.Print("plan request: %s", r.cfg.PlanDescription)
 
@@ -700,7 +1102,7 @@ func (r *Runner) runPlanCreation(ctx context.Context) error {
 			lastRevisionFeedback = "" // clear after use
 		}
 
-		result := r.claude.Run(ctx, prompt)
+		result := r.runExecutor(ctx, r.claude, "claude", prompt, executor.Result{Signal: SignalPlanReady})
 		if result.Error != nil {
 			if err := r.handlePatternMatchError(result.Error, "claude"); err != nil {
 				return err
@@ -725,7 +1127,7 @@ func (r *Runner) runPlanCreation(ctx context.Context) error {
 		}
 		if draftResult.handled {
 			lastRevisionFeedback = draftResult.feedback
-			time.Sleep(r.iterationDelay)
+			r.waitIteration(ctx)
 			continue
 		}
 
@@ -735,17 +1137,84 @@ func (r *Runner) runPlanCreation(ctx context.Context) error {
 			return err
 		}
 		if handled {
-			time.Sleep(r.iterationDelay)
+			r.waitIteration(ctx)
 			continue
 		}
 
 		// no question, no draft, and no completion - continue
-		time.Sleep(r.iterationDelay)
+		r.waitIteration(ctx)
 	}
 
 	return fmt.Errorf("max plan iterations (%d) reached without completion", maxPlanIterations)
 }
 
+// runExecutor runs the prompt with the given executor.
+// in dry-run mode the rendered prompt is logged instead and dryResult is returned as if the executor succeeded.
+// the prompt is written raw, so signal markers inside it are not mistaken for real signals.
+func (r *Runner) runExecutor(ctx context.Context, exec Executor, name, prompt string, dryResult executor.Result) executor.Result {
+	if err := r.waitIfPaused(ctx); err != nil {
+		return executor.Result{Error: err}
+	}
+	if r.promptRecorder != nil {
+		r.promptRecorder.RecordPrompt(r.phase, prompt)
+	}
+	if !r.cfg.DryRun {
+		result := exec.Run(ctx, prompt)
+		if result.Usage.Reported {
+			r.tokens[r.phase] += result.Usage.Tokens
+		}
+		r.emitSignal(result.Signal)
+		return result
+	}
+	r.log.Print("dry-run: %s prompt:", name)
+	r.log.PrintRaw("%s\n", prompt)
+	return dryResult
+}
+
+// waitIfPaused blocks while the pause gate is paused, recording the gate's pause and resume requests
+// as human actions of whoever made them. returns ctx error if canceled while paused.
+func (r *Runner) waitIfPaused(ctx context.Context) error {
+	if r.pauseGate == nil {
+		return nil
+	}
+	r.logPauseActions()
+	if !r.pauseGate.IsPaused() {
+		return nil
+	}
+	err := r.pauseGate.WaitResume(ctx)
+	r.logPauseActions()
+	if err != nil {
+		return fmt.Errorf("wait for resume: %w", err)
+	}
+	return nil
+}
+
+// logPauseActions records the pause and resume requests received by the pause gate since the last check.
+// the request time is part of the action text, the log entry itself is written when the runner gets to it.
+func (r *Runner) logPauseActions() {
+	for _, a := range r.pauseGate.PauseActions() {
+		r.log.LogHumanAction(a.Actor, fmt.Sprintf("%s (requested at %s)", a.Action, a.Time.Format("15:04:05")))
+	}
+}
+
+// commitIteration commits changes made by a successful task iteration if auto-commit is enabled.
+// best-effort: failures are logged but don't stop the task phase.
+func (r *Runner) commitIteration(iteration int) {
+	if !r.cfg.AutoCommit || r.committer == nil || r.cfg.DryRun {
+		return
+	}
+	msg := fmt.Sprintf("ralphex: task iteration %d (%s)", iteration, filepath.Base(r.cfg.PlanFile))
+	hash, err := r.committer.CommitAll(msg)
+	if err != nil {
+		r.log.Print("warning: auto-commit failed: %v", err)
+		return
+	}
+	if hash == "" {
+		return // nothing changed in this iteration
+	}
+	r.log.Print("committed task iteration %d: %s", iteration, hash)
+}
+
 // handlePatternMatchError checks if err is a PatternMatchError and logs appropriate messages.
 // Returns the error if it's a pattern match (to trigger graceful exit), nil otherwise.
 func (r *Runner) handlePatternMatchError(err error, tool string) error {
@@ -766,11 +1235,11 @@ func (r *Runner) runFinalize(ctx context.Context) error {
 		return nil
 	}
 
-	r.log.SetPhase(PhaseFinalize)
+	r.setPhase(PhaseFinalize)
 	r.log.PrintSection(NewGenericSection("finalize step"))
 
 	prompt := r.replacePromptVariables(r.cfg.AppConfig.FinalizePrompt)
-	result := r.claude.Run(ctx, prompt)
+	result := r.runExecutor(ctx, r.claude, "claude", prompt, executor.Result{})
 
 	if result.Error != nil {
 		// propagate context cancellation - user wants to abort
@@ -782,7 +1251,7 @@ func (r *Runner) runFinalize(ctx context.Context) error {
 			return nil //nolint:nilerr // intentional: best-effort semantics, log but don't propagate
 		}
 		// best-effort: log error but don't fail
-		r.log.Print("finalize step failed: %v", result.Error)
+		r.log.LogError(fmt.Errorf("finalize step failed: %w", result.Error), true)
 		return nil
 	}
 
@@ -794,3 +1263,47 @@ func (r *Runner) runFinalize(ctx context.Context) error {
 	r.log.Print("finalize step completed")
 	return nil
 }
+
+// setPhase switches the logger to phase and starts timing it, adding the time spent in the previous phase.
+func (r *Runner) setPhase(phase Phase) {
+	r.stopPhaseClock()
+	r.phase, r.phaseStart = phase, r.clock.Now()
+	r.log.SetPhase(phase)
+}
+
+// stopPhaseClock adds the time spent in the current phase to its total.
+func (r *Runner) stopPhaseClock() {
+	if r.phase != "" {
+		r.timings[r.phase] += r.clock.Now().Sub(r.phaseStart)
+		r.phase = ""
+	}
+}
+
+// PhaseTimings returns the total time spent in each phase entered during Run.
+// phases that were never entered are not included.
+func (r *Runner) PhaseTimings() map[Phase]time.Duration {
+	return maps.Clone(r.timings)
+}
+
+// TokenUsage returns the tokens reported by executor runs in each phase.
+// phases without any reported usage are not included, an empty map means usage was unavailable.
+func (r *Runner) TokenUsage() map[Phase]int64 {
+	return maps.Clone(r.tokens)
+}
+
+// waitIteration pauses between iterations for the iteration delay with a random jitter of up to
+// +/- delayJitter, so sessions started together don't hit providers in lockstep.
+// returns early when ctx is canceled, the caller's loop then reports the cancellation.
+func (r *Runner) waitIteration(ctx context.Context) {
+	delay := r.iterationDelay
+	if r.delayJitter > 0 {
+		delay += time.Duration(rand.Int64N(2*int64(r.delayJitter)+1)) - r.delayJitter //nolint:gosec // not security sensitive
+	}
+	if delay <= 0 {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-r.clock.After(delay):
+	}
+}
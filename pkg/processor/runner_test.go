@@ -3,9 +3,13 @@ package processor_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -41,6 +45,20 @@ func newMockExecutor(results []executor.Result) *mocks.ExecutorMock {
 	}
 }
 
+// newFakeClock creates a clock whose waits complete immediately, so iteration delays take no wall-clock time.
+// requested durations are recorded in AfterCalls.
+func newFakeClock() *mocks.ClockMock {
+	now := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+	return &mocks.ClockMock{
+		NowFunc: func() time.Time { return now },
+		AfterFunc: func(d time.Duration) <-chan time.Time {
+			ch := make(chan time.Time, 1)
+			ch <- now.Add(d)
+			return ch
+		},
+	}
+}
+
 // newMockLogger creates a mock logger with no-op implementations.
 func newMockLogger(path string) *mocks.LoggerMock {
 	return &mocks.LoggerMock{
@@ -52,6 +70,8 @@ func newMockLogger(path string) *mocks.LoggerMock {
 		LogQuestionFunc:    func(_ string, _ []string) {},
 		LogAnswerFunc:      func(_ string) {},
 		LogDraftReviewFunc: func(_, _ string) {},
+		LogHumanActionFunc: func(_, _ string) {},
+		LogErrorFunc:       func(_ error, _ bool) {},
 		PathFunc:           func() string { return path },
 	}
 }
@@ -106,6 +126,338 @@ func TestRunner_RunFull_Success(t *testing.T) {
 	assert.Len(t, codex.RunCalls(), 1)
 }
 
+func TestRunner_CodexPasses(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	// claude results for the given mode, with codex evaluations in between review phases
+	claudeResults := func(mode processor.Mode, evals ...executor.Result) []executor.Result {
+		var res []executor.Result
+		if mode == processor.ModeFull {
+			res = append(res, executor.Result{Output: "task done", Signal: processor.SignalCompleted})
+		}
+		res = append(res,
+			executor.Result{Output: "review done", Signal: processor.SignalReviewDone}, // first review
+			executor.Result{Output: "review done", Signal: processor.SignalReviewDone}, // pre-codex review loop
+		)
+		res = append(res, evals...)
+		return append(res, executor.Result{Output: "review done", Signal: processor.SignalReviewDone}) // post-codex loop
+	}
+
+	tests := []struct {
+		name          string
+		mode          processor.Mode
+		passes        int
+		maxIterations int
+		codex         []executor.Result
+		evals         []executor.Result
+		wantCodex     int
+	}{
+		{name: "full mode, findings then clean", mode: processor.ModeFull, passes: 2, maxIterations: 50,
+			codex:     []executor.Result{{Output: "found issue in foo.go"}, {Output: "no issues found"}},
+			evals:     []executor.Result{{Output: "fixed foo.go"}, {Output: "done", Signal: processor.SignalCodexDone}},
+			wantCodex: 2},
+		{name: "review mode, findings then clean", mode: processor.ModeReview, passes: 2, maxIterations: 50,
+			codex:     []executor.Result{{Output: "found issue in foo.go"}, {Output: "no issues found"}},
+			evals:     []executor.Result{{Output: "fixed foo.go"}, {Output: "done", Signal: processor.SignalCodexDone}},
+			wantCodex: 2},
+		{name: "single pass stops with findings", mode: processor.ModeFull, passes: 1, maxIterations: 50,
+			codex:     []executor.Result{{Output: "found issue in foo.go"}},
+			evals:     []executor.Result{{Output: "fixed foo.go"}},
+			wantCodex: 1},
+		{name: "clean first pass stops early", mode: processor.ModeReview, passes: 3, maxIterations: 50,
+			codex:     []executor.Result{{Output: "no issues found"}},
+			evals:     []executor.Result{{Output: "done", Signal: processor.SignalCodexDone}},
+			wantCodex: 1},
+		{name: "capped by max iterations", mode: processor.ModeReview, passes: 5, maxIterations: 2,
+			codex:     []executor.Result{{Output: "issue 1"}, {Output: "issue 2"}},
+			evals:     []executor.Result{{Output: "fixed 1"}, {Output: "fixed 2"}},
+			wantCodex: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claude := newMockExecutor(claudeResults(tc.mode, tc.evals...))
+			codex := newMockExecutor(tc.codex)
+
+			cfg := processor.Config{Mode: tc.mode, PlanFile: planFile, MaxIterations: tc.maxIterations, CodexEnabled: true,
+				CodexPasses: tc.passes, AppConfig: testAppConfig(t)}
+			r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codex)
+			r.SetClock(newFakeClock())
+
+			require.NoError(t, r.Run(context.Background()))
+			assert.Len(t, codex.RunCalls(), tc.wantCodex)
+		})
+	}
+}
+
+func TestRunner_CodexMinFindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	tests := []struct {
+		name        string
+		minFindings int
+		codexOutput string
+		wantFixLoop bool
+	}{
+		{name: "noise below threshold", minFindings: 2, codexOutput: "looks fine overall, maybe add a comment",
+			wantFixLoop: false},
+		{name: "single finding below threshold", minFindings: 2, codexOutput: "- pkg/foo/foo.go:12 unchecked error",
+			wantFixLoop: false},
+		{name: "substantive findings", minFindings: 2,
+			codexOutput: "- pkg/foo/foo.go:12 unchecked error\n- pkg/bar/bar.go:40 data race on counter", wantFixLoop: true},
+		{name: "threshold disabled", minFindings: 0, codexOutput: "looks fine overall", wantFixLoop: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claudeRes := []executor.Result{
+				{Output: "task done", Signal: processor.SignalCompleted},
+				{Output: "review done", Signal: processor.SignalReviewDone}, // first review
+				{Output: "review done", Signal: processor.SignalReviewDone}, // pre-codex review loop
+			}
+			if tc.wantFixLoop {
+				claudeRes = append(claudeRes, executor.Result{Output: "fixed", Signal: processor.SignalCodexDone})
+			}
+			claudeRes = append(claudeRes, executor.Result{Output: "review done", Signal: processor.SignalReviewDone})
+			claude := newMockExecutor(claudeRes)
+			codex := newMockExecutor([]executor.Result{{Output: tc.codexOutput}})
+
+			var messages []string
+			log := newMockLogger("progress.txt")
+			log.PrintFunc = func(format string, args ...any) { messages = append(messages, fmt.Sprintf(format, args...)) }
+
+			cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true,
+				CodexMinFindings: tc.minFindings, AppConfig: testAppConfig(t)}
+			r := processor.NewWithExecutors(cfg, log, claude, codex)
+			r.SetClock(newFakeClock())
+
+			require.NoError(t, r.Run(context.Background()))
+			assert.Len(t, codex.RunCalls(), 1)
+			assert.Len(t, claude.RunCalls(), len(claudeRes), "all claude results consumed")
+			skipped := slices.ContainsFunc(messages, func(m string) bool {
+				return strings.HasPrefix(m, "codex findings below threshold, skipping fix loop")
+			})
+			assert.Equal(t, !tc.wantFixLoop, skipped)
+		})
+	}
+}
+
+func TestRunner_PlanFileDeleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		signal string // signal of the iteration deleting the plan
+	}{
+		{name: "deleted during iteration", signal: ""},
+		{name: "deleted with completion signal", signal: processor.SignalCompleted},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			planFile := filepath.Join(t.TempDir(), "plan.md")
+			require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1\n- [ ] Task 2"), 0o600))
+
+			iteration := 0
+			claude := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+				iteration++
+				if iteration == 2 {
+					require.NoError(t, os.Remove(planFile))
+					return executor.Result{Output: "task 2 done", Signal: tc.signal}
+				}
+				return executor.Result{Output: "task 1 done"}
+			}}
+			log := newMockLogger("progress.txt")
+
+			cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 10,
+				AppConfig: testAppConfig(t)}
+			r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+			r.SetClock(newFakeClock())
+			err := r.Run(context.Background())
+
+			require.ErrorIs(t, err, processor.ErrPlanFileMissing)
+			assert.EqualError(t, err, "task phase: plan file disappeared: "+planFile)
+			assert.Len(t, claude.RunCalls(), 2, "no iterations after the plan is gone, review not started")
+			require.Len(t, log.LogErrorCalls(), 1)
+			assert.ErrorIs(t, log.LogErrorCalls()[0].Err, processor.ErrPlanFileMissing)
+			assert.False(t, log.LogErrorCalls()[0].Recoverable)
+		})
+	}
+}
+
+func TestRunner_ReviewOrder(t *testing.T) {
+	planFile := filepath.Join(t.TempDir(), "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	const (
+		task   = processor.PhaseTask
+		review = processor.PhaseReview
+		codex  = processor.PhaseCodex
+		eval   = processor.PhaseClaudeEval
+	)
+	tests := []struct {
+		name        string
+		mode        processor.Mode
+		order       string
+		codexPasses int
+		codexDone   bool // claude evaluation of codex findings signals codex done
+		want        []processor.Phase
+	}{
+		{name: "default order, review mode", mode: processor.ModeReview, codexDone: true,
+			want: []processor.Phase{review, review, codex, eval, review}},
+		{name: "claude first, full mode", mode: processor.ModeFull, order: processor.ReviewOrderClaudeFirst, codexDone: true,
+			want: []processor.Phase{task, review, review, codex, eval, review}},
+		{name: "codex first, review mode", mode: processor.ModeReview, order: processor.ReviewOrderCodexFirst, codexDone: true,
+			want: []processor.Phase{codex, eval, review, review}},
+		{name: "codex first, full mode", mode: processor.ModeFull, order: processor.ReviewOrderCodexFirst, codexDone: true,
+			want: []processor.Phase{task, codex, eval, review, review}},
+		{name: "codex first, codex passes cap", mode: processor.ModeReview, order: processor.ReviewOrderCodexFirst,
+			codexPasses: 2, want: []processor.Phase{codex, eval, codex, eval, review, review}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &mocks.PromptRecorderMock{RecordPromptFunc: func(processor.Phase, string) {}}
+			// claude answers depending on the phase the runner recorded the prompt in
+			claude := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+				calls := recorder.RecordPromptCalls()
+				switch calls[len(calls)-1].Phase {
+				case processor.PhaseTask:
+					return executor.Result{Output: "done", Signal: processor.SignalCompleted}
+				case processor.PhaseClaudeEval:
+					if tc.codexDone {
+						return executor.Result{Output: "fixed", Signal: processor.SignalCodexDone}
+					}
+					return executor.Result{Output: "fixed some"}
+				default:
+					return executor.Result{Output: "review done", Signal: processor.SignalReviewDone}
+				}
+			}}
+			codexExec := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+				return executor.Result{Output: "- main.go:1 unused import"}
+			}}
+
+			cfg := processor.Config{Mode: tc.mode, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true,
+				CodexPasses: tc.codexPasses, ReviewOrder: tc.order, AppConfig: testAppConfig(t)}
+			r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codexExec)
+			r.SetClock(newFakeClock())
+			r.SetPromptRecorder(recorder)
+			require.NoError(t, r.Run(context.Background()))
+
+			var got []processor.Phase
+			for _, c := range recorder.RecordPromptCalls() {
+				got = append(got, c.Phase)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRunner_PromptRecorder(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	claude := newMockExecutor([]executor.Result{
+		{Output: "review done", Signal: processor.SignalReviewDone}, // first review
+		{Output: "review done", Signal: processor.SignalReviewDone}, // pre-codex review loop
+		{Output: "fixed", Signal: processor.SignalCodexDone},        // codex evaluation
+		{Output: "review done", Signal: processor.SignalReviewDone}, // post-codex review loop
+	})
+	codex := newMockExecutor([]executor.Result{{Output: "- main.go:1 unused import"}})
+	recorder := &mocks.PromptRecorderMock{RecordPromptFunc: func(processor.Phase, string) {}}
+
+	cfg := processor.Config{Mode: processor.ModeReview, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true,
+		AppConfig: testAppConfig(t)}
+	r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codex)
+	r.SetClock(newFakeClock())
+	r.SetPromptRecorder(recorder)
+	require.NoError(t, r.Run(context.Background()))
+
+	calls := recorder.RecordPromptCalls()
+	require.Len(t, calls, 5)
+	wantPhases := []processor.Phase{processor.PhaseReview, processor.PhaseReview, processor.PhaseCodex,
+		processor.PhaseClaudeEval, processor.PhaseReview}
+	var gotPhases []processor.Phase
+	for _, c := range calls {
+		gotPhases = append(gotPhases, c.Phase)
+	}
+	assert.Equal(t, wantPhases, gotPhases)
+
+	// recorded prompts are exactly the ones executors received
+	claudeCalls := claude.RunCalls()
+	assert.Equal(t, claudeCalls[0].Prompt, calls[0].Prompt)
+	assert.Equal(t, claudeCalls[1].Prompt, calls[1].Prompt)
+	assert.Equal(t, codex.RunCalls()[0].Prompt, calls[2].Prompt)
+	assert.Equal(t, claudeCalls[2].Prompt, calls[3].Prompt)
+	assert.Equal(t, claudeCalls[3].Prompt, calls[4].Prompt)
+}
+
+func TestRunner_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	// uncompleted tasks must not block dry-run, plan file is never edited
+	planContent := "# Plan\n- [ ] Task 1"
+	require.NoError(t, os.WriteFile(planFile, []byte(planContent), 0o600))
+
+	sectionLabels := func(log *mocks.LoggerMock) []string {
+		var labels []string
+		for _, c := range log.PrintSectionCalls() {
+			labels = append(labels, c.Section.Label)
+		}
+		return labels
+	}
+
+	t.Run("full mode", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor(nil)
+		codex := newMockExecutor(nil)
+
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true,
+			FinalizeEnabled: true, DryRun: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, codex)
+		require.NoError(t, r.Run(context.Background()))
+
+		assert.Empty(t, claude.RunCalls())
+		assert.Empty(t, codex.RunCalls())
+		assert.Equal(t, []string{"task iteration 1", "claude review 0: all findings", "claude review 1: critical/major",
+			"codex external review", "codex iteration 1", "claude evaluating codex findings", "claude review 1: critical/major", "finalize step"},
+			sectionLabels(log))
+
+		// rendered prompts are logged raw, one per executor call that was skipped
+		var prompts int
+		for _, c := range log.PrintCalls() {
+			if strings.HasPrefix(c.Format, "dry-run:") {
+				prompts++
+			}
+		}
+		assert.Equal(t, 7, prompts)
+
+		content, err := os.ReadFile(planFile) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Equal(t, planContent, string(content))
+	})
+
+	t.Run("plan mode", func(t *testing.T) {
+		log := newMockLogger("progress-plan.txt")
+		claude := newMockExecutor(nil)
+		codex := newMockExecutor(nil)
+		inputCollector := newMockInputCollector(nil)
+
+		cfg := processor.Config{Mode: processor.ModePlan, PlanDescription: "add caching", MaxIterations: 50,
+			DryRun: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, codex)
+		r.SetInputCollector(inputCollector)
+		require.NoError(t, r.Run(context.Background()))
+
+		assert.Empty(t, claude.RunCalls())
+		assert.Empty(t, inputCollector.AskQuestionCalls())
+		assert.Equal(t, []string{"plan iteration 1"}, sectionLabels(log))
+	})
+}
+
 func TestRunner_RunFull_NoCodexFindings(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
@@ -183,6 +535,82 @@ func TestRunner_RunCodexOnly_NoFindings(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunner_CodexTimeout(t *testing.T) {
+	// blockingCodex blocks until its context is done, like a hung codex process
+	blockingCodex := func() *mocks.ExecutorMock {
+		return &mocks.ExecutorMock{RunFunc: func(ctx context.Context, _ string) executor.Result {
+			<-ctx.Done()
+			return executor.Result{Error: fmt.Errorf("context error: %w", ctx.Err())}
+		}}
+	}
+	appConfig := func(t *testing.T) *config.Config {
+		t.Helper()
+		cfg := testAppConfig(t)
+		cfg.CodexTimeoutMs = 50
+		return cfg
+	}
+
+	t.Run("codex-only mode fails the run", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor(nil)
+		codex := blockingCodex()
+
+		cfg := processor.Config{Mode: processor.ModeCodexOnly, MaxIterations: 50, CodexEnabled: true, AppConfig: appConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, codex)
+		start := time.Now()
+		err := r.Run(context.Background())
+
+		require.ErrorIs(t, err, processor.ErrCodexTimeout)
+		assert.Contains(t, err.Error(), "codex timed out after 50ms")
+		assert.NotContains(t, err.Error(), "codex execution", "timeout is not reported as a generic codex error")
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Len(t, codex.RunCalls(), 1)
+		assert.Empty(t, claude.RunCalls(), "claude review doesn't run after the timeout")
+		require.Len(t, log.LogErrorCalls(), 1)
+		assert.ErrorIs(t, log.LogErrorCalls()[0].Err, processor.ErrCodexTimeout)
+		assert.False(t, log.LogErrorCalls()[0].Recoverable)
+	})
+
+	t.Run("review mode continues with claude review", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "review done", Signal: processor.SignalReviewDone}, // first review
+			{Output: "review done", Signal: processor.SignalReviewDone}, // pre-codex review loop
+			{Output: "review done", Signal: processor.SignalReviewDone}, // post-codex review loop
+		})
+		codex := blockingCodex()
+
+		cfg := processor.Config{Mode: processor.ModeReview, MaxIterations: 50, CodexEnabled: true, AppConfig: appConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, codex)
+		err := r.Run(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, codex.RunCalls(), 1)
+		assert.Len(t, claude.RunCalls(), 3)
+		require.Len(t, log.LogErrorCalls(), 1)
+		assert.ErrorIs(t, log.LogErrorCalls()[0].Err, processor.ErrCodexTimeout)
+		assert.True(t, log.LogErrorCalls()[0].Recoverable)
+	})
+
+	t.Run("parent cancellation is not a timeout", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		codex := blockingCodex()
+		ctx, cancel := context.WithCancel(context.Background())
+		codex.RunFunc = func(ctx context.Context, _ string) executor.Result {
+			cancel()
+			<-ctx.Done()
+			return executor.Result{Error: ctx.Err()}
+		}
+
+		cfg := processor.Config{Mode: processor.ModeCodexOnly, MaxIterations: 50, CodexEnabled: true, AppConfig: appConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, newMockExecutor(nil), codex)
+		err := r.Run(ctx)
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, processor.ErrCodexTimeout)
+	})
+}
+
 func TestRunner_CodexDisabled_SkipsCodexPhase(t *testing.T) {
 	log := newMockLogger("progress.txt")
 	claude := newMockExecutor([]executor.Result{
@@ -281,7 +709,7 @@ func TestRunner_RunTasksOnly_NoReviews(t *testing.T) {
 func TestRunner_TaskPhase_FailedSignal(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
-	require.NoError(t, os.WriteFile(planFile, []byte("# Plan"), 0o600))
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
 
 	log := newMockLogger("progress.txt")
 	claude := newMockExecutor([]executor.Result{
@@ -298,6 +726,48 @@ func TestRunner_TaskPhase_FailedSignal(t *testing.T) {
 	assert.Contains(t, err.Error(), "FAILED signal")
 }
 
+func TestRunner_TaskPhase_ProsePlan(t *testing.T) {
+	newProsePlan := func(t *testing.T) string {
+		t.Helper()
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n\nAdd a cache in front of the user lookup.\n"), 0o600))
+		return planFile
+	}
+	warned := func(log *mocks.LoggerMock) bool {
+		for _, call := range log.PrintCalls() {
+			if call.Format == "warning: plan has no task checkboxes" {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("refused by default", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor(nil)
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: newProsePlan(t), MaxIterations: 50, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		err := r.Run(context.Background())
+
+		require.ErrorIs(t, err, processor.ErrNoTaskCheckboxes)
+		assert.Contains(t, err.Error(), "treat_prose_as_task")
+		assert.True(t, warned(log))
+		assert.Empty(t, claude.RunCalls(), "nothing is executed")
+	})
+
+	t.Run("single task with treat_prose_as_task", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{{Output: "done", Signal: processor.SignalCompleted}})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: newProsePlan(t), MaxIterations: 50,
+			TreatProseAsTask: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		require.NoError(t, r.Run(context.Background()))
+
+		assert.True(t, warned(log))
+		assert.Len(t, claude.RunCalls(), 1, "completion of the single task ends the task phase")
+	})
+}
+
 func TestRunner_TaskPhase_MaxIterations(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
@@ -313,16 +783,447 @@ func TestRunner_TaskPhase_MaxIterations(t *testing.T) {
 
 	cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 3, AppConfig: testAppConfig(t)}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	clock := newFakeClock()
+	r.SetClock(clock)
 	err := r.Run(context.Background())
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "max iterations")
+	require.Len(t, clock.AfterCalls(), 3, "waits after every iteration")
+	for _, c := range clock.AfterCalls() {
+		assert.Equal(t, processor.DefaultIterationDelay, c.D)
+	}
+}
+
+func TestRunner_PhaseTimings(t *testing.T) {
+	// clock advances a minute on every reading, so each phase gets a predictable duration
+	tickingClock := func() *mocks.ClockMock {
+		clock := newFakeClock()
+		now := clock.Now()
+		clock.NowFunc = func() time.Time {
+			now = now.Add(time.Minute)
+			return now
+		}
+		return clock
+	}
+
+	t.Run("full run", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Output: "task done", Signal: processor.SignalCompleted},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "done", Signal: processor.SignalCodexDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+		})
+		codex := newMockExecutor([]executor.Result{{Output: "found issue in foo.go"}})
+
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codex)
+		r.SetClock(tickingClock())
+		require.NoError(t, r.Run(context.Background()))
+
+		timings := r.PhaseTimings()
+		for _, phase := range []processor.Phase{processor.PhaseTask, processor.PhaseReview, processor.PhaseCodex} {
+			assert.Positive(t, timings[phase], "phase %s", phase)
+		}
+		assert.NotContains(t, timings, processor.PhasePlan)
+	})
+
+	t.Run("failed task phase never enters codex", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{{Output: "working..."}})
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 1, CodexEnabled: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		r.SetClock(tickingClock())
+		require.Error(t, r.Run(context.Background()))
+
+		timings := r.PhaseTimings()
+		assert.Len(t, timings, 1)
+		assert.Equal(t, time.Minute, timings[processor.PhaseTask], "one clock tick between entering and leaving the phase")
+		assert.NotContains(t, timings, processor.PhaseCodex)
+	})
+}
+
+func TestRunner_TokenUsage(t *testing.T) {
+	usage := func(n int64) executor.TokenUsage { return executor.TokenUsage{Tokens: n, Reported: true} }
+
+	t.Run("sums reported usage per phase", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Output: "task done", Signal: processor.SignalCompleted, Usage: usage(1000)},
+			{Output: "review done", Signal: processor.SignalReviewDone, Usage: usage(200)},
+			{Output: "review done", Signal: processor.SignalReviewDone, Usage: usage(50)},
+			{Output: "done", Signal: processor.SignalCodexDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+		})
+		codex := newMockExecutor([]executor.Result{{Output: "found issue in foo.go", Usage: usage(7)}})
+
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, CodexEnabled: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, codex)
+		require.NoError(t, r.Run(context.Background()))
+
+		tokens := r.TokenUsage()
+		assert.Equal(t, int64(1000), tokens[processor.PhaseTask])
+		assert.Equal(t, int64(250), tokens[processor.PhaseReview])
+		assert.Equal(t, int64(7), tokens[processor.PhaseCodex])
+	})
+
+	t.Run("nothing reported", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		planFile := filepath.Join(tmpDir, "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Output: "task done", Signal: processor.SignalCompleted},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+			{Output: "review done", Signal: processor.SignalReviewDone},
+		})
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		require.NoError(t, r.Run(context.Background()))
+
+		assert.Empty(t, r.TokenUsage())
+	})
+}
+
+func TestRunner_PauseGate(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	pausedAt := time.Date(2026, 1, 22, 9, 30, 15, 0, time.UTC)
+	resumedAt := pausedAt.Add(5 * time.Minute)
+
+	// testGate is a pause gate controlled by the test, pause and resume record their requests like the dashboard does
+	type testGate struct {
+		*mocks.PauseGateMock
+		paused  atomic.Bool
+		mu      sync.Mutex
+		actions []processor.PauseAction
+	}
+	request := func(g *testGate, paused bool, action string, at time.Time) {
+		g.mu.Lock()
+		g.actions = append(g.actions, processor.PauseAction{Actor: "web", Action: action, Time: at})
+		g.mu.Unlock()
+		g.paused.Store(paused)
+	}
+
+	// newGate returns a gate that reports waiting on the returned channel and blocks until resume is closed
+	newGate := func(resume <-chan struct{}) (*testGate, <-chan struct{}) {
+		waiting := make(chan struct{})
+		g := &testGate{}
+		g.PauseGateMock = &mocks.PauseGateMock{
+			IsPausedFunc: g.paused.Load,
+			WaitResumeFunc: func(ctx context.Context) error {
+				close(waiting)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-resume:
+					return nil
+				}
+			},
+			PauseActionsFunc: func() []processor.PauseAction {
+				g.mu.Lock()
+				defer g.mu.Unlock()
+				actions := g.actions
+				g.actions = nil
+				return actions
+			},
+		}
+		return g, waiting
+	}
+
+	// claude calls onFirst during its first call, completes on the second
+	newClaude := func(onFirst func(), calls *atomic.Int32) *mocks.ExecutorMock {
+		return &mocks.ExecutorMock{
+			RunFunc: func(_ context.Context, _ string) executor.Result {
+				if calls.Add(1) == 1 {
+					onFirst()
+					return executor.Result{Output: "task 1 in progress"}
+				}
+				return executor.Result{Signal: processor.SignalCompleted}
+			},
+		}
+	}
+
+	humanActions := func(log *mocks.LoggerMock) []string {
+		var actions []string
+		for _, c := range log.LogHumanActionCalls() {
+			actions = append(actions, c.Actor+": "+c.Action)
+		}
+		return actions
+	}
+
+	cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 5,
+		AppConfig: testAppConfig(t)}
+
+	t.Run("blocks until resumed", func(t *testing.T) {
+		var calls atomic.Int32
+		resume := make(chan struct{})
+		gate, waiting := newGate(resume)
+		log := newMockLogger("progress.txt")
+
+		claude := newClaude(func() { request(gate, true, "paused run", pausedAt) }, &calls)
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetPauseGate(gate)
+
+		done := make(chan error, 1)
+		go func() { done <- r.Run(context.Background()) }()
+
+		select {
+		case <-waiting:
+		case <-time.After(time.Second):
+			t.Fatal("runner did not wait on pause gate")
+		}
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int32(1), calls.Load(), "no executor calls while paused")
+
+		request(gate, false, "resumed run", resumedAt)
+		close(resume)
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("runner did not continue after resume")
+		}
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, []string{"web: paused run (requested at 09:30:15)", "web: resumed run (requested at 09:35:15)"},
+			humanActions(log))
+	})
+
+	t.Run("pause and resume between gate checks", func(t *testing.T) {
+		var calls atomic.Int32
+		gate, _ := newGate(make(chan struct{}))
+		log := newMockLogger("progress.txt")
+
+		claude := newClaude(func() {
+			request(gate, true, "paused run", pausedAt)
+			request(gate, false, "resumed run", resumedAt)
+		}, &calls)
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetPauseGate(gate)
+
+		require.NoError(t, r.Run(context.Background()))
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Empty(t, gate.WaitResumeCalls(), "not paused at the gate, no wait")
+		assert.Equal(t, []string{"web: paused run (requested at 09:30:15)", "web: resumed run (requested at 09:35:15)"},
+			humanActions(log))
+	})
+
+	t.Run("cancel while paused", func(t *testing.T) {
+		var calls atomic.Int32
+		gate, waiting := newGate(make(chan struct{}))
+		log := newMockLogger("progress.txt")
+
+		claude := newClaude(func() { request(gate, true, "paused run", pausedAt) }, &calls)
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetPauseGate(gate)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- r.Run(ctx) }()
+
+		<-waiting
+		cancel()
+		err := <-done
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRunner_AutoCommit(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+	// first iteration leaves a clean tree, second one produces a commit
+	newCommitter := func() *mocks.CommitterMock {
+		hashes := []string{"", "abc123"}
+		return &mocks.CommitterMock{
+			CommitAllFunc: func(_ string) (string, error) {
+				h := hashes[0]
+				hashes = hashes[1:]
+				return h, nil
+			},
+		}
+	}
+	results := []executor.Result{{Output: "task 1 done"}, {Signal: processor.SignalCompleted}}
+
+	t.Run("commits successful iterations", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		committer := newCommitter()
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 5,
+			AutoCommit: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, newMockExecutor(results), newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetCommitter(committer)
+
+		require.NoError(t, r.Run(context.Background()))
+
+		calls := committer.CommitAllCalls()
+		require.Len(t, calls, 2)
+		assert.Equal(t, "ralphex: task iteration 1 (plan.md)", calls[0].Message)
+		assert.Equal(t, "ralphex: task iteration 2 (plan.md)", calls[1].Message)
+
+		var printed []string
+		for _, c := range log.PrintCalls() {
+			printed = append(printed, fmt.Sprintf(c.Format, c.Args...))
+		}
+		assert.Contains(t, printed, "committed task iteration 2: abc123")
+		assert.NotContains(t, printed, "committed task iteration 1: ")
+	})
+
+	t.Run("failed iteration is not committed", func(t *testing.T) {
+		committer := newCommitter()
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 5,
+			TaskRetryCount: 1, AutoCommit: true, AppConfig: testAppConfig(t)}
+		claude := newMockExecutor([]executor.Result{{Signal: processor.SignalFailed}, {Signal: processor.SignalCompleted}})
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetCommitter(committer)
+
+		require.NoError(t, r.Run(context.Background()))
+		require.Len(t, committer.CommitAllCalls(), 1)
+		assert.Equal(t, "ralphex: task iteration 2 (plan.md)", committer.CommitAllCalls()[0].Message)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		committer := newCommitter()
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 5,
+			AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), newMockExecutor(results), newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetCommitter(committer)
+
+		require.NoError(t, r.Run(context.Background()))
+		assert.Empty(t, committer.CommitAllCalls())
+	})
+}
+
+func TestRunner_TaskPhase_StallLimit(t *testing.T) {
+	writePlan := func(t *testing.T, content string) string {
+		t.Helper()
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte(content), 0o600))
+		return planFile
+	}
+	// claude keeps working without checking off anything
+	idle := func() *mocks.ExecutorMock {
+		return &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+			return executor.Result{Output: "working..."}
+		}}
+	}
+	cleanTree := func() *mocks.WorkTreeMock {
+		return &mocks.WorkTreeMock{
+			HeadCommitFunc:  func() (string, error) { return "abc123", nil },
+			ChangesHashFunc: func() (string, error) { return "", nil },
+		}
+	}
+	run := func(t *testing.T, cfg processor.Config, claude processor.Executor, wt processor.WorkTree) error {
+		t.Helper()
+		cfg.Mode, cfg.AppConfig = processor.ModeTasksOnly, testAppConfig(t)
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		if wt != nil {
+			r.SetWorkTree(wt)
+		}
+		return r.Run(context.Background())
+	}
+
+	t.Run("aborts at the stall limit", func(t *testing.T) {
+		claude := idle()
+		err := run(t, processor.Config{PlanFile: writePlan(t, "# Plan\n- [ ] Task 1\n- [ ] Task 2"), MaxIterations: 10,
+			StallLimit: 3}, claude, cleanTree())
+		require.ErrorIs(t, err, processor.ErrStalled)
+		assert.Contains(t, err.Error(), "stalled: no progress for 3 iterations")
+		assert.Len(t, claude.RunCalls(), 3, "aborts before using up max iterations")
+	})
+
+	t.Run("checked task resets the count", func(t *testing.T) {
+		planFile := writePlan(t, "# Plan\n- [ ] Task 1\n- [ ] Task 2")
+		claude := &mocks.ExecutorMock{}
+		claude.RunFunc = func(context.Context, string) executor.Result {
+			if len(claude.RunCalls()) == 2 {
+				require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1\n- [ ] Task 2"), 0o600))
+			}
+			return executor.Result{Output: "working..."}
+		}
+		err := run(t, processor.Config{PlanFile: planFile, MaxIterations: 10, StallLimit: 3}, claude, nil)
+		require.ErrorIs(t, err, processor.ErrStalled)
+		assert.Len(t, claude.RunCalls(), 5, "iteration 2 made progress, 3 more without progress")
+	})
+
+	t.Run("changed files count as progress", func(t *testing.T) {
+		// every iteration edits the same uncommitted file, the changed file list stays the same
+		workFile := filepath.Join(t.TempDir(), "main.go")
+		wt := &mocks.WorkTreeMock{
+			HeadCommitFunc: func() (string, error) { return "abc123", nil },
+			ChangesHashFunc: func() (string, error) {
+				data, err := os.ReadFile(workFile) //nolint:gosec // test file
+				return "main.go:" + string(data), err
+			},
+		}
+		claude := &mocks.ExecutorMock{}
+		claude.RunFunc = func(context.Context, string) executor.Result {
+			content := fmt.Sprintf("package main // edit %d\n", len(claude.RunCalls()))
+			require.NoError(t, os.WriteFile(workFile, []byte(content), 0o600))
+			return executor.Result{Output: "working..."}
+		}
+		err := run(t, processor.Config{PlanFile: writePlan(t, "# Plan\n- [ ] Task 1"), MaxIterations: 5, StallLimit: 2},
+			claude, wt)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, processor.ErrStalled)
+		assert.Contains(t, err.Error(), "max iterations (5) reached")
+		assert.Len(t, claude.RunCalls(), 5)
+	})
+
+	t.Run("new commits count as progress", func(t *testing.T) {
+		commits := 0
+		wt := &mocks.WorkTreeMock{
+			HeadCommitFunc:  func() (string, error) { commits++; return fmt.Sprintf("commit%d", commits), nil },
+			ChangesHashFunc: func() (string, error) { return "", nil },
+		}
+		err := run(t, processor.Config{PlanFile: writePlan(t, "# Plan\n- [ ] Task 1"), MaxIterations: 4, StallLimit: 2},
+			idle(), wt)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, processor.ErrStalled)
+	})
+
+	t.Run("completion wins over stall", func(t *testing.T) {
+		claude := newMockExecutor([]executor.Result{{Output: "working..."}, {Signal: processor.SignalCompleted}})
+		err := run(t, processor.Config{PlanFile: writePlan(t, "# Plan\n- [x] Task 1"), MaxIterations: 5, StallLimit: 2},
+			claude, cleanTree())
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		claude := idle()
+		err := run(t, processor.Config{PlanFile: writePlan(t, "# Plan\n- [ ] Task 1"), MaxIterations: 6}, claude, cleanTree())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, processor.ErrStalled)
+		assert.Len(t, claude.RunCalls(), 6)
+	})
 }
 
 func TestRunner_TaskPhase_ContextCanceled(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
-	require.NoError(t, os.WriteFile(planFile, []byte("# Plan"), 0o600))
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // cancel immediately
@@ -337,6 +1238,11 @@ func TestRunner_TaskPhase_ContextCanceled(t *testing.T) {
 
 	require.Error(t, err)
 	assert.ErrorIs(t, err, context.Canceled)
+
+	// cancellation is recorded as a human action
+	require.Len(t, log.LogHumanActionCalls(), 1)
+	assert.Equal(t, "user", log.LogHumanActionCalls()[0].Actor)
+	assert.Equal(t, "canceled run", log.LogHumanActionCalls()[0].Action)
 }
 
 func TestRunner_ClaudeReview_FailedSignal(t *testing.T) {
@@ -354,6 +1260,71 @@ func TestRunner_ClaudeReview_FailedSignal(t *testing.T) {
 	assert.Contains(t, err.Error(), "FAILED signal")
 }
 
+func TestRunner_ContinueOnReviewFailure(t *testing.T) {
+	t.Run("review failure is fatal by default", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "error", Signal: processor.SignalFailed}, // first review
+		})
+
+		cfg := processor.Config{Mode: processor.ModeReview, MaxIterations: 50, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		err := r.Run(context.Background())
+
+		require.ErrorIs(t, err, processor.ErrReviewFailed)
+		assert.Contains(t, err.Error(), "first review")
+		assert.Len(t, claude.RunCalls(), 1, "no review phase runs after the failure")
+		require.Len(t, log.LogErrorCalls(), 1)
+		assert.False(t, log.LogErrorCalls()[0].Recoverable)
+	})
+
+	t.Run("review failures are skipped", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "error", Signal: processor.SignalFailed},           // first review
+			{Output: "error", Signal: processor.SignalFailed},           // pre-codex review loop
+			{Output: "found issues", Signal: processor.SignalCodexDone}, // codex evaluation
+			{Output: "review done", Signal: processor.SignalReviewDone}, // post-codex review loop
+		})
+		codex := newMockExecutor([]executor.Result{{Output: "src/main.go:10: bug"}})
+
+		cfg := processor.Config{Mode: processor.ModeReview, MaxIterations: 50, CodexEnabled: true,
+			ContinueOnReviewFailure: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, codex)
+		err := r.Run(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, claude.RunCalls(), 4)
+		assert.Len(t, codex.RunCalls(), 1, "codex runs after the failed review phases")
+		require.Len(t, log.LogErrorCalls(), 2)
+		for _, call := range log.LogErrorCalls() {
+			assert.ErrorIs(t, call.Err, processor.ErrReviewFailed)
+			assert.True(t, call.Recoverable)
+		}
+		assert.Contains(t, log.LogErrorCalls()[0].Err.Error(), "first review")
+		assert.Contains(t, log.LogErrorCalls()[1].Err.Error(), "pre-codex review loop")
+	})
+
+	t.Run("task failure stays fatal", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "error", Signal: processor.SignalFailed},
+		})
+
+		cfg := processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 50,
+			ContinueOnReviewFailure: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		err := r.Run(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "task execution failed")
+		assert.Len(t, claude.RunCalls(), 1)
+	})
+}
+
 func TestRunner_CodexPhase_Error(t *testing.T) {
 	log := newMockLogger("progress.txt")
 	claude := newMockExecutor([]executor.Result{
@@ -376,7 +1347,7 @@ func TestRunner_CodexPhase_Error(t *testing.T) {
 func TestRunner_ClaudeExecution_Error(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
-	require.NoError(t, os.WriteFile(planFile, []byte("# Plan"), 0o600))
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
 
 	log := newMockLogger("progress.txt")
 	claude := newMockExecutor([]executor.Result{
@@ -468,6 +1439,21 @@ func TestRunner_HasUncompletedTasks(t *testing.T) {
 			content:  "# Plan\n- [x] Task 1\n  - [ ] Subtask",
 			expected: true,
 		},
+		{
+			name:     "crlf line endings",
+			content:  "# Plan\r\n- [x] Task 1\r\n- [ ] Task 2\r\n",
+			expected: true,
+		},
+		{
+			name:     "byte order mark before first checkbox",
+			content:  "\ufeff- [ ] Task 1\n- [x] Task 2",
+			expected: true,
+		},
+		{
+			name:     "example in fenced code block",
+			content:  "# Plan\n- [x] Task 1\n```\n- [ ] example\n```",
+			expected: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -533,6 +1519,38 @@ func TestRunner_BuildCodexPrompt_CompletedDir(t *testing.T) {
 	assert.NotContains(t, prompt, originalPath)
 }
 
+func TestRunner_BuildCodexPrompt_ReviewPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		paths       []string
+		isFirst     bool
+		wantDiff    string
+		wantScope   string
+		notExpected string
+	}{
+		{name: "no paths reviews all changes", isFirst: true, wantDiff: "Run: git diff main...HEAD\n",
+			wantScope: "code changes between main and HEAD branch.", notExpected: " -- "},
+		{name: "first pass scoped to paths", paths: []string{"pkg/web", "cmd"}, isFirst: true,
+			wantDiff: "Run: git diff main...HEAD -- pkg/web cmd\n", wantScope: "code changes between main and HEAD branch in pkg/web, cmd."},
+		{name: "later pass scoped to paths", paths: []string{"pkg/web"}, isFirst: false,
+			wantDiff: "Run: git diff -- pkg/web\n", wantScope: "(Claude's fixes from previous iteration) in pkg/web."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := processor.Config{DefaultBranch: "main", ReviewPaths: tc.paths}
+			r := processor.NewWithExecutors(cfg, newMockLogger(""), newMockExecutor(nil), newMockExecutor(nil))
+
+			prompt := r.TestBuildCodexPrompt(tc.isFirst, "")
+			assert.Contains(t, prompt, tc.wantDiff)
+			assert.Contains(t, prompt, tc.wantScope)
+			if tc.notExpected != "" {
+				assert.NotContains(t, prompt, tc.notExpected)
+			}
+		})
+	}
+}
+
 func TestRunner_TaskRetryCount_UsedCorrectly(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
@@ -553,17 +1571,167 @@ func TestRunner_TaskRetryCount_UsedCorrectly(t *testing.T) {
 		PlanFile:       planFile,
 		MaxIterations:  10,
 		TaskRetryCount: 2,
-		// use 1ms delay for faster tests
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		AppConfig:      testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	clock := newFakeClock()
+	r.SetClock(clock)
 	err := r.Run(context.Background())
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "FAILED signal")
-	// should have tried 3 times: initial + 2 retries
+	// should have tried 3 times: initial + 2 retries, waiting the default delay before each retry
 	assert.Len(t, claude.RunCalls(), 3)
+	require.Len(t, clock.AfterCalls(), 2)
+	assert.Equal(t, processor.DefaultIterationDelay, clock.AfterCalls()[0].D)
+}
+
+func TestRunner_RetryOnCrash(t *testing.T) {
+	crashLogged := func(log *mocks.LoggerMock) bool {
+		for _, c := range log.PrintCalls() {
+			if strings.HasPrefix(c.Format, "claude crashed:") {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("crash is retried and the task completes", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Error: errors.New("exit status 1")},
+			{Output: "done", Signal: processor.SignalCompleted},
+		})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 10,
+			TaskRetryCount: 1, RetryOnCrash: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+
+		require.NoError(t, r.Run(context.Background()))
+		assert.Len(t, claude.RunCalls(), 2)
+		assert.True(t, crashLogged(log), "crash retry should be logged")
+	})
+
+	t.Run("crash retries share the task retry budget", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Output: "error", Signal: processor.SignalFailed},
+			{Error: errors.New("exit status 1")},
+		})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 10,
+			TaskRetryCount: 1, RetryOnCrash: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+
+		err := r.Run(context.Background())
+		require.ErrorContains(t, err, "claude execution")
+		assert.Len(t, claude.RunCalls(), 2)
+	})
+
+	t.Run("crash is fatal by default", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+
+		log := newMockLogger("progress.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Error: errors.New("exit status 1")},
+			{Output: "done", Signal: processor.SignalCompleted},
+		})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 10,
+			TaskRetryCount: 1, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+
+		err := r.Run(context.Background())
+		require.ErrorContains(t, err, "claude execution")
+		assert.Len(t, claude.RunCalls(), 1)
+		assert.False(t, crashLogged(log))
+	})
+
+	t.Run("error pattern match is not retried", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+		claude := newMockExecutor([]executor.Result{
+			{Error: &executor.PatternMatchError{Pattern: "rate limit", HelpCmd: "claude /usage"}},
+		})
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 10,
+			TaskRetryCount: 1, RetryOnCrash: true, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+
+		var patternErr *executor.PatternMatchError
+		require.ErrorAs(t, r.Run(context.Background()), &patternErr)
+		assert.Len(t, claude.RunCalls(), 1)
+	})
+}
+
+func TestRunner_IterationDelayJitter(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+	t.Run("delays vary within the jitter band", func(t *testing.T) {
+		results := make([]executor.Result, 10)
+		for i := range results {
+			results[i] = executor.Result{Output: "still working"}
+		}
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: len(results),
+			IterationDelayMs: 1000, IterationJitterMs: 200, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), newMockExecutor(results), newMockExecutor(nil))
+		clock := newFakeClock()
+		r.SetClock(clock)
+
+		err := r.Run(context.Background())
+		require.ErrorContains(t, err, "max iterations")
+
+		require.Len(t, clock.AfterCalls(), len(results))
+		distinct := make(map[time.Duration]bool)
+		for _, c := range clock.AfterCalls() {
+			assert.GreaterOrEqual(t, c.D, 800*time.Millisecond)
+			assert.LessOrEqual(t, c.D, 1200*time.Millisecond)
+			distinct[c.D] = true
+		}
+		assert.Greater(t, len(distinct), 1, "jitter should vary the delays, got %v", clock.AfterCalls())
+	})
+
+	t.Run("no jitter keeps fixed delay", func(t *testing.T) {
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 3,
+			IterationDelayMs: 1000, AppConfig: testAppConfig(t)}
+		claude := newMockExecutor([]executor.Result{{Output: "a"}, {Output: "b"}, {Output: "c"}})
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		clock := newFakeClock()
+		r.SetClock(clock)
+
+		require.Error(t, r.Run(context.Background()))
+		require.Len(t, clock.AfterCalls(), 3)
+		for _, c := range clock.AfterCalls() {
+			assert.Equal(t, time.Second, c.D)
+		}
+	})
+
+	t.Run("cancellation interrupts the delay", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		claude := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+			cancel()
+			return executor.Result{Output: "still working"}
+		}}
+		cfg := processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 5,
+			IterationDelayMs: 60000, IterationJitterMs: 1000, AppConfig: testAppConfig(t)}
+		r := processor.NewWithExecutors(cfg, newMockLogger("progress.txt"), claude, newMockExecutor(nil))
+		// the delay never elapses, only cancellation can end the wait
+		clock := newFakeClock()
+		clock.AfterFunc = func(time.Duration) <-chan time.Time { return nil }
+		r.SetClock(clock)
+
+		err := r.Run(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, clock.AfterCalls(), 1)
+	})
 }
 
 // newMockInputCollector creates a mock input collector with predefined answers.
@@ -590,13 +1758,13 @@ func TestRunner_RunPlan_Success(t *testing.T) {
 	inputCollector := newMockInputCollector(nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add health check endpoint",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add health check endpoint",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -620,13 +1788,13 @@ func TestRunner_RunPlan_WithQuestion(t *testing.T) {
 	inputCollector := newMockInputCollector([]string{"Redis"})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add caching layer",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add caching layer",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -635,6 +1803,11 @@ func TestRunner_RunPlan_WithQuestion(t *testing.T) {
 	assert.Len(t, inputCollector.AskQuestionCalls(), 1)
 	assert.Equal(t, "Which cache backend?", inputCollector.AskQuestionCalls()[0].Question)
 	assert.Equal(t, []string{"Redis", "In-memory", "File-based"}, inputCollector.AskQuestionCalls()[0].Options)
+
+	// answer is recorded as a human action
+	require.Len(t, log.LogHumanActionCalls(), 1)
+	assert.Equal(t, "user", log.LogHumanActionCalls()[0].Actor)
+	assert.Equal(t, "answered question: Redis", log.LogHumanActionCalls()[0].Action)
 }
 
 func TestRunner_RunPlan_NoPlanDescription(t *testing.T) {
@@ -675,13 +1848,13 @@ func TestRunner_RunPlan_FailedSignal(t *testing.T) {
 	inputCollector := newMockInputCollector(nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -703,13 +1876,13 @@ func TestRunner_RunPlan_MaxIterations(t *testing.T) {
 
 	// maxPlanIterations = max(5, 10/5) = 5
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    10,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   10,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -727,13 +1900,13 @@ func TestRunner_RunPlan_ContextCanceled(t *testing.T) {
 	inputCollector := newMockInputCollector(nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(ctx)
 
@@ -750,13 +1923,13 @@ func TestRunner_RunPlan_ClaudeError(t *testing.T) {
 	inputCollector := newMockInputCollector(nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -781,13 +1954,13 @@ func TestRunner_RunPlan_InputCollectorError(t *testing.T) {
 	}
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -826,6 +1999,38 @@ func TestRunner_New_CodexNotInstalled_AutoDisables(t *testing.T) {
 	assert.NotNil(t, r, "runner should be created even when codex not found")
 }
 
+func TestRunner_New_ClaudeNotInstalled(t *testing.T) {
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [ ] Task 1"), 0o600))
+
+	appCfg := testAppConfig(t)
+	appCfg.ClaudeCommand = "nonexistent-claude-12345"
+
+	t.Run("reported before any phase", func(t *testing.T) {
+		log := newMockLogger("progress.txt")
+		r := processor.New(processor.Config{Mode: processor.ModeFull, PlanFile: planFile, MaxIterations: 5,
+			AppConfig: appCfg}, log)
+
+		err := r.Run(context.Background())
+		var notFound *executor.CommandNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Contains(t, err.Error(), `claude command "nonexistent-claude-12345" not found in PATH`)
+
+		assert.Empty(t, log.PrintSectionCalls(), "no phase should start")
+		assert.Empty(t, log.PrintCalls())
+		require.Len(t, log.LogErrorCalls(), 1)
+		require.ErrorAs(t, log.LogErrorCalls()[0].Err, &notFound)
+		assert.False(t, log.LogErrorCalls()[0].Recoverable)
+	})
+
+	t.Run("not checked in dry-run", func(t *testing.T) {
+		r := processor.New(processor.Config{Mode: processor.ModeTasksOnly, PlanFile: planFile, MaxIterations: 1,
+			DryRun: true, AppConfig: appCfg}, newMockLogger("progress.txt"))
+		require.NoError(t, r.Run(context.Background()))
+	})
+}
+
 func TestRunner_ErrorPatternMatch_ClaudeInTaskPhase(t *testing.T) {
 	tmpDir := t.TempDir()
 	planFile := filepath.Join(tmpDir, "plan.md")
@@ -919,13 +2124,13 @@ func TestRunner_ErrorPatternMatch_ClaudeInPlanCreation(t *testing.T) {
 	inputCollector := newMockInputCollector(nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -990,13 +2195,13 @@ This is a test plan.
 	})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add health endpoint",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add health endpoint",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1004,6 +2209,10 @@ This is a test plan.
 	assert.Len(t, claude.RunCalls(), 2)
 	assert.Len(t, inputCollector.AskDraftReviewCalls(), 1)
 	assert.Contains(t, inputCollector.AskDraftReviewCalls()[0].PlanContent, "# Test Plan")
+
+	// draft review is recorded as a human action
+	require.Len(t, log.LogHumanActionCalls(), 1)
+	assert.Equal(t, "draft review: accept", log.LogHumanActionCalls()[0].Action)
 }
 
 func TestRunner_RunPlan_PlanDraft_ReviseFlow(t *testing.T) {
@@ -1037,13 +2246,13 @@ func TestRunner_RunPlan_PlanDraft_ReviseFlow(t *testing.T) {
 	})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add health endpoint",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add health endpoint",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1078,13 +2287,13 @@ func TestRunner_RunPlan_PlanDraft_RejectFlow(t *testing.T) {
 	})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add health endpoint",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add health endpoint",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1113,13 +2322,13 @@ func TestRunner_RunPlan_PlanDraft_AskDraftReviewError(t *testing.T) {
 	})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1142,13 +2351,13 @@ This content has no END marker`
 	inputCollector := newMockInputCollectorWithDraftReview(nil, nil)
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "test",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "test",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1164,6 +2373,51 @@ This content has no END marker`
 	assert.True(t, foundWarning, "should log warning about malformed signal")
 }
 
+func TestRunner_RunPlan_MalformedQuestion(t *testing.T) {
+	cfg := processor.Config{Mode: processor.ModePlan, PlanDescription: "test", MaxIterations: 50,
+		AppConfig: testAppConfig(t)}
+
+	t.Run("recoverable payload is asked with a warning", func(t *testing.T) {
+		log := newMockLogger("progress-plan.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "<<<RALPHEX:QUESTION>>>\n{\"question\": \"Which cache?\", \"options\": [\"Redis\", \"Memcached\"]\n<<<RALPHEX:END>>>"},
+			{Output: "plan created", Signal: processor.SignalPlanReady},
+		})
+		inputCollector := newMockInputCollector([]string{"Redis"})
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetInputCollector(inputCollector)
+		require.NoError(t, r.Run(context.Background()))
+
+		require.Len(t, inputCollector.AskQuestionCalls(), 1)
+		assert.Equal(t, []string{"Redis", "Memcached"}, inputCollector.AskQuestionCalls()[0].Options)
+		var warned bool
+		for _, call := range log.PrintCalls() {
+			warned = warned || strings.Contains(call.Format, "recovered question")
+		}
+		assert.True(t, warned, "should warn about the repaired payload")
+		assert.Empty(t, log.LogErrorCalls())
+	})
+
+	t.Run("unrecoverable payload is reported as recoverable error", func(t *testing.T) {
+		log := newMockLogger("progress-plan.txt")
+		claude := newMockExecutor([]executor.Result{
+			{Output: "<<<RALPHEX:QUESTION>>>\n{question: Which cache?, options: Redis}\n<<<RALPHEX:END>>>"},
+			{Output: "plan created", Signal: processor.SignalPlanReady},
+		})
+		inputCollector := newMockInputCollector(nil)
+		r := processor.NewWithExecutors(cfg, log, claude, newMockExecutor(nil))
+		r.SetClock(newFakeClock())
+		r.SetInputCollector(inputCollector)
+		require.NoError(t, r.Run(context.Background()))
+
+		assert.Empty(t, inputCollector.AskQuestionCalls())
+		require.Len(t, log.LogErrorCalls(), 1)
+		assert.Contains(t, log.LogErrorCalls()[0].Err.Error(), "malformed question signal")
+		assert.True(t, log.LogErrorCalls()[0].Recoverable)
+	})
+}
+
 func TestRunner_RunPlan_PlanDraft_WithQuestionThenDraft(t *testing.T) {
 	log := newMockLogger("progress-plan.txt")
 	questionSignal := `<<<RALPHEX:QUESTION>>>
@@ -1191,13 +2445,13 @@ func TestRunner_RunPlan_PlanDraft_WithQuestionThenDraft(t *testing.T) {
 	})
 
 	cfg := processor.Config{
-		Mode:             processor.ModePlan,
-		PlanDescription:  "add API endpoints",
-		MaxIterations:    50,
-		IterationDelayMs: 1,
-		AppConfig:        testAppConfig(t),
+		Mode:            processor.ModePlan,
+		PlanDescription: "add API endpoints",
+		MaxIterations:   50,
+		AppConfig:       testAppConfig(t),
 	}
 	r := processor.NewWithExecutors(cfg, log, claude, codex)
+	r.SetClock(newFakeClock())
 	r.SetInputCollector(inputCollector)
 	err := r.Run(context.Background())
 
@@ -1307,15 +2561,10 @@ func TestRunner_Finalize_FailureDoesNotBlockSuccess(t *testing.T) {
 	// run should succeed despite finalize failure
 	require.NoError(t, err)
 
-	// verify finalize error was logged
-	var foundErrorLog bool
-	for _, call := range log.PrintCalls() {
-		if strings.Contains(call.Format, "finalize step failed") {
-			foundErrorLog = true
-			break
-		}
-	}
-	assert.True(t, foundErrorLog, "should log finalize failure")
+	// verify finalize error was logged as recoverable
+	require.Len(t, log.LogErrorCalls(), 1, "should log finalize failure")
+	assert.Contains(t, log.LogErrorCalls()[0].Err.Error(), "finalize step failed")
+	assert.True(t, log.LogErrorCalls()[0].Recoverable)
 }
 
 func TestRunner_Finalize_FailedSignalDoesNotBlockSuccess(t *testing.T) {
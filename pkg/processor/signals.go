@@ -31,6 +31,8 @@ type QuestionPayload struct {
 	Question string   `json:"question"`
 	Options  []string `json:"options"`
 	Context  string   `json:"context,omitempty"`
+
+	Repaired bool `json:"-"` // payload was malformed JSON recovered by a lenient parse
 }
 
 // IsTerminalSignal returns true if signal indicates execution should stop.
@@ -86,7 +88,11 @@ func ParseQuestionPayload(output string) (*QuestionPayload, error) {
 
 	var payload QuestionPayload
 	if err := json.Unmarshal([]byte(jsonStr), &payload); err != nil {
-		return nil, fmt.Errorf("malformed question signal: invalid JSON: %w", err)
+		// claude sometimes truncates the block or adds comments, try to recover before giving up
+		if lenErr := json.Unmarshal([]byte(repairJSON(jsonStr)), &payload); lenErr != nil {
+			return nil, fmt.Errorf("malformed question signal: invalid JSON: %w", err)
+		}
+		payload.Repaired = true
 	}
 
 	// validate required fields
@@ -100,6 +106,83 @@ func ParseQuestionPayload(output string) (*QuestionPayload, error) {
 	return &payload, nil
 }
 
+// repairJSON makes a best-effort fix of almost-valid JSON: strips // and /* */ comments and trailing commas,
+// and closes an unterminated string and unclosed arrays and objects, in order.
+// the result is not guaranteed to be valid, callers still have to unmarshal it.
+func repairJSON(s string) string {
+	var out strings.Builder
+	var closers []byte // expected closing brackets, innermost last
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			out.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '/' && i+1 < len(s) && s[i+1] == '/':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				i = len(s)
+				continue
+			}
+			i += end + 3
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '{':
+			closers = append(closers, '}')
+			out.WriteByte(c)
+		case c == '[':
+			closers = append(closers, ']')
+			out.WriteByte(c)
+		case c == '}' || c == ']':
+			trimTrailingComma(&out)
+			if len(closers) > 0 {
+				closers = closers[:len(closers)-1]
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	if inString {
+		if escaped { // drop a dangling backslash, it would escape the closing quote
+			str := out.String()
+			out.Reset()
+			out.WriteString(str[:len(str)-1])
+		}
+		out.WriteByte('"')
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		trimTrailingComma(&out)
+		out.WriteByte(closers[i])
+	}
+	return out.String()
+}
+
+// trimTrailingComma removes a trailing comma, and whitespace around it, from the builder's content.
+func trimTrailingComma(b *strings.Builder) {
+	str := strings.TrimRight(b.String(), " \t\r\n")
+	if !strings.HasSuffix(str, ",") {
+		return
+	}
+	b.Reset()
+	b.WriteString(strings.TrimRight(strings.TrimSuffix(str, ","), " \t\r\n"))
+}
+
 // ParsePlanDraftPayload extracts plan content from output containing PLAN_DRAFT signal.
 // returns ErrNoPlanDraftSignal if no plan draft signal is found.
 // returns other error if signal is found but content is malformed.
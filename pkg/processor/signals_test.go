@@ -215,12 +215,19 @@ func TestParseQuestionPayload_MalformedJSON(t *testing.T) {
 			errContains: "missing or empty options field",
 		},
 		{
-			name: "truncated json",
+			name: "truncated inside a key",
 			output: `<<<RALPHEX:QUESTION>>>
-{"question": "test", "options": ["A",
+{"question": "test", "opti
 <<<RALPHEX:END>>>`,
 			errContains: "invalid JSON",
 		},
+		{
+			name: "recovered json without options",
+			output: `<<<RALPHEX:QUESTION>>>
+{"question": "test", // options follow
+<<<RALPHEX:END>>>`,
+			errContains: "missing or empty options field",
+		},
 	}
 
 	for _, tc := range tests {
@@ -233,6 +240,61 @@ func TestParseQuestionPayload_MalformedJSON(t *testing.T) {
 	}
 }
 
+func TestParseQuestionPayload_Recovered(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected *QuestionPayload
+	}{
+		{
+			name:     "truncated options array",
+			payload:  `{"question": "test", "options": ["A",`,
+			expected: &QuestionPayload{Question: "test", Options: []string{"A"}},
+		},
+		{
+			name:     "missing closing brace",
+			payload:  `{"question": "Which cache?", "options": ["Redis", "Memcached"]`,
+			expected: &QuestionPayload{Question: "Which cache?", Options: []string{"Redis", "Memcached"}},
+		},
+		{
+			name:     "truncated inside a string",
+			payload:  `{"question": "Which cache?", "options": ["Redis", "Memc`,
+			expected: &QuestionPayload{Question: "Which cache?", Options: []string{"Redis", "Memc"}},
+		},
+		{
+			name: "trailing comments",
+			payload: `{"question": "Which cache?", "options": ["Redis"]} // pick one
+/* context is optional */`,
+			expected: &QuestionPayload{Question: "Which cache?", Options: []string{"Redis"}},
+		},
+		{
+			name:     "trailing commas",
+			payload:  `{"question": "Which cache?", "options": ["Redis", "Memcached",], "context": "api layer",}`,
+			expected: &QuestionPayload{Question: "Which cache?", Options: []string{"Redis", "Memcached"}, Context: "api layer"},
+		},
+		{
+			name:     "comment markers inside strings are kept",
+			payload:  `{"question": "Use http://cache/* path?", "options": ["Yes", "No"]`,
+			expected: &QuestionPayload{Question: "Use http://cache/* path?", Options: []string{"Yes", "No"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseQuestionPayload(SignalQuestion + "\n" + tc.payload + "\n<<<RALPHEX:END>>>")
+			require.NoError(t, err)
+			tc.expected.Repaired = true
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("valid json is not marked repaired", func(t *testing.T) {
+		result, err := ParseQuestionPayload(SignalQuestion + `{"question": "q", "options": ["A"]}<<<RALPHEX:END>>>`)
+		require.NoError(t, err)
+		assert.False(t, result.Repaired)
+	})
+}
+
 func TestParseQuestionPayload_NoSignal(t *testing.T) {
 	tests := []struct {
 		name   string
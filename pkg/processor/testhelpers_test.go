@@ -38,6 +38,8 @@ func (s *stubLogger) PrintAligned(_ string)            {}
 func (s *stubLogger) LogQuestion(_ string, _ []string) {}
 func (s *stubLogger) LogAnswer(_ string)               {}
 func (s *stubLogger) LogDraftReview(_, _ string)       {}
+func (s *stubLogger) LogHumanAction(_, _ string)       {}
+func (s *stubLogger) LogError(_ error, _ bool)         {}
 func (s *stubLogger) Path() string                     { return s.path }
 func (s *stubLogger) PrintCalls() []printCall          { return s.printCalls }
 
@@ -0,0 +1,20 @@
+package progress
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/umputun/ralphex/pkg/checkbox"
+)
+
+// BOM is the UTF-8 byte order mark some editors and export tools put at the start of text files.
+const BOM = checkbox.BOM
+
+// SkipBOM returns a reader of r's content without a leading UTF-8 byte order mark, if there is one.
+func SkipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(BOM)); err == nil && string(b) == BOM {
+		_, _ = br.Discard(len(BOM))
+	}
+	return br
+}
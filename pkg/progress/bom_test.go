@@ -0,0 +1,30 @@
+package progress
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipBOM(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{name: "with bom", in: BOM + "# Ralphex Progress Log\n", want: "# Ralphex Progress Log\n"},
+		{name: "without bom", in: "# Ralphex Progress Log\n", want: "# Ralphex Progress Log\n"},
+		{name: "bom only", in: BOM, want: ""},
+		{name: "short content", in: "ab", want: "ab"},
+		{name: "empty", in: "", want: ""},
+		{name: "bom inside content is kept", in: "a" + BOM, want: "a" + BOM},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := io.ReadAll(SkipBOM(strings.NewReader(tc.in)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
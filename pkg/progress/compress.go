@@ -0,0 +1,97 @@
+package progress
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CompressedSuffix is appended to the name of a progress file gzipped after its run completed,
+// e.g. progress-plan.txt.gz, see Config.CompressCompleted.
+const CompressedSuffix = ".gz"
+
+// IsCompressed reports whether path names a gzipped progress file.
+func IsCompressed(path string) bool {
+	return strings.HasSuffix(path, CompressedSuffix)
+}
+
+// OpenFile opens a progress file for reading, gzipped files are decompressed transparently.
+// the returned reader of a plain file is the *os.File itself, so it can still seek.
+func OpenFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path) //nolint:gosec // path of a progress file
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	if !IsCompressed(path) {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("open gzip stream: %w", err)
+	}
+	return &gzipFile{Reader: zr, f: f}, nil
+}
+
+// gzipFile reads a gzipped file and closes both the gzip stream and the file.
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+// Close closes the gzip stream and the underlying file.
+func (g *gzipFile) Close() error {
+	return errors.Join(g.Reader.Close(), g.f.Close())
+}
+
+// CompressFile gzips the progress file at path to path+CompressedSuffix and removes the original.
+// the compressed file is written next to the original and renamed into place, keeping its permissions.
+// returns the path of the compressed file.
+func CompressFile(path string) (string, error) {
+	src, err := os.Open(path) //nolint:gosec // path of a progress file
+	if err != nil {
+		return "", fmt.Errorf("open file: %w", err)
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return "", fmt.Errorf("stat file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".compress-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // already renamed on success
+
+	zw := gzip.NewWriter(tmp)
+	zw.Name = filepath.Base(path)
+	zw.ModTime = info.ModTime()
+	if _, err := io.Copy(zw, src); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("compress file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("finish gzip stream: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("chmod temp file: %w", err)
+	}
+
+	dst := path + CompressedSuffix
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", fmt.Errorf("rename compressed file: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return "", fmt.Errorf("remove uncompressed file: %w", err)
+	}
+	return dst, nil
+}
@@ -0,0 +1,105 @@
+package progress
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-plan.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# Ralphex Progress Log\nPlan: plan.md\n"), 0o600))
+
+	dst, err := CompressFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, path+CompressedSuffix, dst)
+	assert.NoFileExists(t, path)
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	f, err := OpenFile(dst)
+	require.NoError(t, err)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "# Ralphex Progress Log\nPlan: plan.md\n", string(content))
+
+	_, err = CompressFile(filepath.Join(dir, "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestOpenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("plain file is seekable", func(t *testing.T) {
+		path := filepath.Join(dir, "plain.txt")
+		require.NoError(t, os.WriteFile(path, []byte("content"), 0o600))
+		f, err := OpenFile(path)
+		require.NoError(t, err)
+		defer f.Close()
+		_, ok := f.(io.Seeker)
+		assert.True(t, ok)
+	})
+
+	t.Run("broken gzip", func(t *testing.T) {
+		path := filepath.Join(dir, "broken.txt.gz")
+		require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0o600))
+		_, err := OpenFile(path)
+		require.ErrorContains(t, err, "open gzip stream")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := OpenFile(filepath.Join(dir, "missing.txt"))
+		require.Error(t, err)
+	})
+}
+
+func TestLogger_CompressCompleted(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cfg := Config{PlanFile: "docs/plans/feature.md", Mode: "full", Branch: "main", NoColor: true, CompressCompleted: true}
+
+	t.Run("completed run is compressed", func(t *testing.T) {
+		l, err := NewLogger(cfg, testColors())
+		require.NoError(t, err)
+		l.Print("task done")
+		require.NoError(t, l.Close())
+
+		assert.NoFileExists(t, "progress-feature.txt")
+		f, err := OpenFile("progress-feature.txt" + CompressedSuffix)
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "task done")
+		assert.Contains(t, string(content), "Completed: ")
+	})
+
+	t.Run("new run replaces compressed file", func(t *testing.T) {
+		require.FileExists(t, "progress-feature.txt"+CompressedSuffix)
+		l, err := NewLogger(cfg, testColors())
+		require.NoError(t, err)
+		assert.NoFileExists(t, "progress-feature.txt"+CompressedSuffix)
+
+		l.SetOutcome(OutcomeFailed)
+		require.NoError(t, l.Close())
+		assert.FileExists(t, "progress-feature.txt", "failed runs are kept uncompressed")
+		assert.NoFileExists(t, "progress-feature.txt"+CompressedSuffix)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		l, err := NewLogger(Config{PlanFile: "other.md", Mode: "full", Branch: "main", NoColor: true}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+		assert.FileExists(t, "progress-other.txt")
+	})
+}
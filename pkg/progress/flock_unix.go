@@ -39,3 +39,14 @@ func TryLockFile(f *os.File) (bool, error) {
 	_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
 	return true, nil
 }
+
+// processAlive reports whether a process with the given pid exists.
+// signal 0 checks for existence without sending anything, EPERM means the process exists but belongs to another user.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
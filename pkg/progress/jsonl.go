@@ -0,0 +1,119 @@
+package progress
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// progress file formats, see Config.Format
+const (
+	FormatText  = "text"  // human-readable timestamped lines (default)
+	FormatJSONL = "jsonl" // one JSON record per line
+)
+
+// record types written in jsonl format.
+const (
+	RecordHeader      = "header"       // first record, carries session metadata
+	RecordOutput      = "output"       // regular output line
+	RecordSection     = "section"      // section header
+	RecordError       = "error"        // error message
+	RecordWarn        = "warn"         // warning message
+	RecordSignal      = "signal"       // line containing a <<<RALPHEX:...>>> signal
+	RecordHumanAction = "human_action" // action performed by a human
+	RecordPlanReady   = "plan_ready"   // plan creation produced a plan file, see Logger.LogPlanReady
+	RecordFooter      = "footer"       // last record, written on Close
+)
+
+// maxRecordSize is the largest jsonl record accepted by ParseProgressJSONL.
+const maxRecordSize = 64 * 1024 * 1024
+
+// Record is a single event of a jsonl progress file.
+// Text holds the same message the text format writes after the timestamp.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Phase     Phase     `json:"phase,omitempty"`
+	Type      string    `json:"type"`
+	Section   string    `json:"section,omitempty"`
+	Signal    string    `json:"signal,omitempty"` // raw signal name, e.g. ALL_TASKS_DONE
+	Text      string    `json:"text,omitempty"`
+	Actor     string    `json:"actor,omitempty"`   // set for human_action records
+	Outcome   string    `json:"outcome,omitempty"` // set for the footer record, see OutcomeCompleted
+	Header    *Header   `json:"header,omitempty"`  // set for the header record
+
+	// PhaseTimings is set for the footer record when phase durations are known, see FormatPhaseTimings
+	PhaseTimings string `json:"phase_timings,omitempty"`
+	// Tokens is set for the footer record when token usage is tracked, see FormatTokenUsage
+	Tokens string `json:"tokens,omitempty"`
+	// Push is set for the footer record when the branch was pushed after the run, see Logger.SetPushResult
+	Push string `json:"push,omitempty"`
+	// PlanFile is set for plan_ready records and the footer record of a plan creation run that produced a plan file
+	PlanFile string `json:"plan_file,omitempty"`
+	// Recoverable is set for error records of errors that didn't stop the run
+	Recoverable bool `json:"recoverable,omitempty"`
+}
+
+// Header holds session metadata written as the first record of a jsonl progress file.
+// the start time is the header record's timestamp.
+type Header struct {
+	Plan     string `json:"plan"`
+	Branch   string `json:"branch"`
+	Worktree string `json:"worktree,omitempty"`
+	Commit   string `json:"commit,omitempty"`
+	Mode     string `json:"mode"`
+	Host     string `json:"host,omitempty"` // hostname of the process holding the file lock
+	PID      int    `json:"pid,omitempty"`  // pid of the process holding the file lock
+
+	Label  string `json:"label,omitempty"`  // user-assigned session label, set by the dashboard
+	Pinned bool   `json:"pinned,omitempty"` // session is exempt from retention pruning, set by the dashboard
+	Part   int    `json:"part,omitempty"`   // part number of a file continued after rotation, see RotatedPath
+}
+
+// ParseProgressJSONL reads records from a jsonl progress file, skipping empty lines.
+// on a malformed line it returns the records parsed so far along with the error.
+func ParseProgressJSONL(r io.Reader) ([]Record, error) {
+	scanner := bufio.NewScanner(SkipBOM(r))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var records []Record
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return records, fmt.Errorf("parse line %d: %w", lineNum, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return records, fmt.Errorf("scan progress: %w", err)
+	}
+	return records, nil
+}
+
+// writeRecord writes a single record as a line of JSON.
+func (l *Logger) writeRecord(rec Record) {
+	if l.file == nil {
+		return
+	}
+	if rec.Timestamp.IsZero() {
+		rec.Timestamp = time.Now()
+	}
+	if rec.Phase == "" && rec.Type != RecordHeader && rec.Type != RecordFooter {
+		rec.Phase = l.phase
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return // record has only scalar and time fields, can't fail
+	}
+	data = append(data, '\n')
+	l.rotateIfFull()
+	n, _ := l.file.Write(data)
+	l.size += int64(n)
+}
@@ -0,0 +1,118 @@
+package progress
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestLogger_JSONLRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	l, err := NewLogger(Config{PlanFile: "docs/plans/feature.md", Mode: "full", Branch: "feature",
+		StartCommit: "abc123", NoColor: true, Format: FormatJSONL}, testColors())
+	require.NoError(t, err)
+	l.stdout = &bytes.Buffer{}
+
+	l.PrintRaw("starting task execution phase\n")
+	l.PrintSection(processor.NewTaskIterationSection(1))
+	l.Print("running task %d", 1)
+	l.PrintAligned("did the thing\n<<<RALPHEX:ALL_TASKS_DONE>>>")
+	l.SetPhase(processor.PhaseReview)
+	l.PrintSection(processor.NewGenericSection("claude review 0: all findings"))
+	l.Warn("slow %s", "response")
+	l.Error("failed: %s", "boom")
+	l.LogHumanAction("web", "paused")
+	require.NoError(t, l.Close())
+
+	f, err := os.Open(l.Path())
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := ParseProgressJSONL(f)
+	require.NoError(t, err)
+	require.Len(t, records, 11)
+
+	hdr := records[0]
+	assert.Equal(t, RecordHeader, hdr.Type)
+	require.NotNil(t, hdr.Header)
+	host, _ := os.Hostname()
+	assert.Equal(t, Header{Plan: "docs/plans/feature.md", Branch: "feature", Commit: "abc123", Mode: "full",
+		Host: host, PID: os.Getpid()}, *hdr.Header)
+	assert.False(t, hdr.Timestamp.IsZero())
+
+	type rec struct {
+		Type, Phase, Section, Signal, Text, Actor string
+	}
+	var got []rec
+	for _, r := range records[1:] {
+		assert.False(t, r.Timestamp.IsZero())
+		got = append(got, rec{Type: r.Type, Phase: string(r.Phase), Section: r.Section, Signal: r.Signal,
+			Text: r.Text, Actor: r.Actor})
+	}
+	assert.Equal(t, []rec{
+		{Type: RecordOutput, Phase: "task", Text: "starting task execution phase"},
+		{Type: RecordSection, Phase: "task", Section: "task iteration 1", Text: "task iteration 1"},
+		{Type: RecordOutput, Phase: "task", Text: "running task 1"},
+		{Type: RecordOutput, Phase: "task", Text: "did the thing"},
+		{Type: RecordSignal, Phase: "task", Signal: "ALL_TASKS_DONE", Text: "<<<RALPHEX:ALL_TASKS_DONE>>>"},
+		{Type: RecordSection, Phase: "review", Section: "claude review 0: all findings", Text: "claude review 0: all findings"},
+		{Type: RecordWarn, Phase: "review", Text: "WARN: slow response"},
+		{Type: RecordError, Phase: "review", Text: "ERROR: failed: boom"},
+		{Type: RecordHumanAction, Phase: "review", Text: "HUMAN ACTION (web): paused", Actor: "web"},
+		{Type: RecordFooter, Text: got[9].Text},
+	}, got)
+	assert.NotEmpty(t, got[9].Text, "footer carries elapsed time")
+
+	// jsonl files carry no text header
+	content, err := os.ReadFile(l.Path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "# Ralphex Progress Log")
+}
+
+func TestNewLogger_UnknownFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	_, err := NewLogger(Config{Mode: "full", Format: "xml"}, testColors())
+	require.EqualError(t, err, `unknown progress format "xml"`)
+	_, statErr := os.Stat("progress.txt")
+	assert.True(t, os.IsNotExist(statErr), "progress file should not be created")
+}
+
+func TestParseProgressJSONL(t *testing.T) {
+	t.Run("skips empty lines", func(t *testing.T) {
+		input := `{"timestamp":"2026-01-22T10:30:00Z","type":"output","phase":"task","text":"a"}` + "\n\n" +
+			`{"timestamp":"2026-01-22T10:30:01Z","type":"signal","phase":"task","signal":"REVIEW_DONE"}` + "\n"
+		records, err := ParseProgressJSONL(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "a", records[0].Text)
+		assert.Equal(t, "REVIEW_DONE", records[1].Signal)
+	})
+
+	t.Run("malformed line returns parsed records", func(t *testing.T) {
+		input := `{"type":"output","text":"a"}` + "\n" + `{"type":"out` + "\n" + `{"type":"output","text":"b"}` + "\n"
+		records, err := ParseProgressJSONL(strings.NewReader(input))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parse line 2")
+		require.Len(t, records, 1)
+		assert.Equal(t, "a", records[0].Text)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		records, err := ParseProgressJSONL(strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
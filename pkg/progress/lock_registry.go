@@ -1,6 +1,7 @@
 package progress
 
 import (
+	"os"
 	"path/filepath"
 	"sync"
 )
@@ -32,6 +33,19 @@ func IsPathLockedByCurrentProcess(path string) bool {
 	return ok
 }
 
+// IsOwnerStale reports whether the lock owner recorded in a progress file header is gone.
+// only owners on this host can be checked, the owner is stale if its process no longer exists.
+// owners on other hosts and headers without owner info are never reported stale.
+func IsOwnerStale(host string, pid int) bool {
+	if host == "" || pid <= 0 {
+		return false
+	}
+	if localHost, err := os.Hostname(); err != nil || localHost != host {
+		return false
+	}
+	return !processAlive(pid)
+}
+
 func canonicalPath(path string) string {
 	if abs, err := filepath.Abs(path); err == nil {
 		path = abs
@@ -49,9 +49,15 @@ type Colors struct {
 }
 
 // NewColors creates Colors from config.ColorConfig.
-// all colors must be provided - use config with embedded defaults fallback.
-// panics if any color value is invalid (configuration error).
+// the theme preset of cfg is applied first, colors set in cfg override it.
+// all colors must be provided by cfg or its theme - use config with embedded defaults fallback.
+// panics if the theme is unknown or any color value is invalid (configuration error).
 func NewColors(cfg config.ColorConfig) *Colors {
+	cfg, err := cfg.ApplyTheme()
+	if err != nil {
+		panic(fmt.Sprintf("invalid color theme: %v", err))
+	}
+
 	c := &Colors{phases: make(map[Phase]*color.Color)}
 	c.task = parseColorOrPanic(cfg.Task, "task")
 	c.review = parseColorOrPanic(cfg.Review, "review")
@@ -132,6 +138,53 @@ type Logger struct {
 	startTime time.Time
 	phase     Phase
 	colors    *Colors
+	format    string
+	tsFormat  string // timestamp format of text output, TimestampLegacy or TimestampISO8601, empty is legacy
+	outcome   string // how the run ended, written to the footer
+	timings   string // per-phase durations written to the footer, see FormatPhaseTimings
+	tokens    string // per-phase token usage written to the footer, see FormatTokenUsage
+	push      string // result of pushing the branch after the run, written to the footer, see SetPushResult
+	planFile  string // plan file produced by plan creation, written to the footer, see LogPlanReady
+
+	header  Header // header written at the start of the file and of each rotated part
+	maxSize int64  // file size in bytes to rotate at, 0 disables rotation
+	size    int64  // bytes written to the current file
+	parts   int    // number of rotated parts, see RotatedPath
+	midLine bool   // last write didn't end with a newline, files are only rotated between lines
+
+	compress bool // gzip the file on Close if the run completed
+
+	// now is the time of printed lines, time.Now unless set with SetClock
+	now func() time.Time
+}
+
+// PushLabel starts the footer line with the result of auto-push, e.g. "Push: pushed feature to origin".
+const PushLabel = "Push"
+
+// PlanFileLabel starts the footer line with the plan file produced by plan creation, e.g. "Plan-File: docs/plans/x.md".
+const PlanFileLabel = "Plan-File"
+
+// PlanReadyPrefix starts the line logged when plan creation produced a plan file, see Logger.LogPlanReady.
+const PlanReadyPrefix = "PLAN READY: "
+
+// run outcomes written to the progress file footer, see Logger.SetOutcome.
+const (
+	OutcomeCompleted = "completed" // run finished normally (default)
+	OutcomeCanceled  = "canceled"  // run was canceled, e.g. by ctrl+c
+	OutcomeFailed    = "failed"    // run stopped with an error
+)
+
+// FooterLabel returns the footer label of a run outcome, e.g. "Canceled" in "Canceled: <time> (<elapsed>)".
+// unknown and empty outcomes are labeled as completed.
+func FooterLabel(outcome string) string {
+	switch outcome {
+	case OutcomeCanceled:
+		return "Canceled"
+	case OutcomeFailed:
+		return "Failed"
+	default:
+		return "Completed"
+	}
 }
 
 // Config holds logger configuration.
@@ -140,7 +193,16 @@ type Config struct {
 	PlanDescription string // plan description for plan mode (used for filename)
 	Mode            string // execution mode: full, review, codex-only, plan
 	Branch          string // current git branch
+	Worktree        string // git worktree path the plan runs in, empty if not using a worktree
+	StartCommit     string // HEAD commit when the session started, used to diff session changes
 	NoColor         bool   // disable color output (sets color.NoColor globally)
+	Format          string // progress file format: FormatText (default) or FormatJSONL
+	NameTemplate    string // progress file name template, see config.ProgressNamePlaceholders, empty uses default names
+	MaxSizeMB       int    // file size in megabytes to rotate at, see RotatedPath, 0 disables rotation
+	TimestampFormat string // timestamp format of text output: TimestampLegacy (default) or TimestampISO8601
+
+	// CompressCompleted gzips the file on Close of a completed run, see CompressFile
+	CompressCompleted bool
 }
 
 // NewLogger creates a logger writing to both a progress file and stdout.
@@ -151,7 +213,21 @@ func NewLogger(cfg Config, colors *Colors) (*Logger, error) {
 		color.NoColor = true
 	}
 
+	format := cfg.Format
+	if format == "" {
+		format = FormatText
+	}
+	if format != FormatText && format != FormatJSONL {
+		return nil, fmt.Errorf("unknown progress format %q", cfg.Format)
+	}
+	if cfg.TimestampFormat != "" && cfg.TimestampFormat != TimestampLegacy && cfg.TimestampFormat != TimestampISO8601 {
+		return nil, fmt.Errorf("unknown timestamp format %q", cfg.TimestampFormat)
+	}
+
 	progressPath := progressFilename(cfg.PlanFile, cfg.PlanDescription, cfg.Mode)
+	if cfg.NameTemplate != "" {
+		progressPath = templateFilename(cfg, time.Now())
+	}
 
 	// ensure progress files are tracked by creating parent dir
 	if dir := filepath.Dir(progressPath); dir != "." {
@@ -164,6 +240,12 @@ func NewLogger(cfg Config, colors *Colors) (*Logger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create progress file: %w", err)
 	}
+	// parts rotated by a previous run of the same plan would be stitched in front of this one,
+	// and its compressed file would show up as a second session of the plan
+	for _, part := range RotatedParts(progressPath) {
+		_ = os.Remove(part)
+	}
+	_ = os.Remove(progressPath + CompressedSuffix)
 
 	// acquire exclusive lock on progress file to signal active session
 	// the lock is held for the duration of execution and released on Close()
@@ -179,21 +261,127 @@ func NewLogger(cfg Config, colors *Colors) (*Logger, error) {
 		startTime: time.Now(),
 		phase:     PhaseTask,
 		colors:    colors,
+		format:    format,
+		tsFormat:  cfg.TimestampFormat,
+		compress:  cfg.CompressCompleted,
 	}
 
-	// write header
+	// write header, including the lock owner so other processes can tell who runs the session
+	host, _ := os.Hostname()
 	planStr := cfg.PlanFile
 	if planStr == "" {
 		planStr = "(no plan - review only)"
 	}
+	l.header = Header{Plan: planStr, Branch: cfg.Branch, Worktree: cfg.Worktree, Commit: cfg.StartCommit, Mode: cfg.Mode,
+		Host: host, PID: os.Getpid()}
+	l.maxSize = int64(cfg.MaxSizeMB) << 20
+	l.writeHeader()
+
+	return l, nil
+}
+
+// writeHeader writes the file header, continuation parts after a rotation also get their part number.
+func (l *Logger) writeHeader() {
+	h := l.header
+	if l.parts > 0 {
+		h.Part = l.parts + 1
+	}
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordHeader, Timestamp: l.startTime, Header: &h})
+		return
+	}
 	l.writeFile("# Ralphex Progress Log\n")
-	l.writeFile("Plan: %s\n", planStr)
-	l.writeFile("Branch: %s\n", cfg.Branch)
-	l.writeFile("Mode: %s\n", cfg.Mode)
-	l.writeFile("Started: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	l.writeFile("Plan: %s\n", h.Plan)
+	l.writeFile("Branch: %s\n", h.Branch)
+	if h.Worktree != "" {
+		l.writeFile("Worktree: %s\n", h.Worktree)
+	}
+	if h.Commit != "" {
+		l.writeFile("Commit: %s\n", h.Commit)
+	}
+	l.writeFile("Mode: %s\n", h.Mode)
+	if h.Host != "" {
+		l.writeFile("Host: %s\n", h.Host)
+	}
+	l.writeFile("PID: %d\n", h.PID)
+	if h.Part > 0 {
+		l.writeFile("%s: %d\n", PartLabel, h.Part)
+	}
+	_, timeLayout := timestampLayouts(l.tsFormat)
+	l.writeFile("Started: %s\n", l.startTime.Format(timeLayout))
 	l.writeFile("%s\n\n", strings.Repeat("-", 60))
+}
 
-	return l, nil
+// PartLabel starts the header line with the part number of a file continued after rotation, e.g. "Part: 2".
+const PartLabel = "Part"
+
+// RotatedPath returns the path of the n-th part rotated out of a progress file, e.g. progress-plan.1.txt
+// for progress-plan.txt. parts are numbered in the order they were written, the file itself holds the latest part.
+func RotatedPath(path string, n int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), n, ext)
+}
+
+// RotatedParts returns the existing parts rotated out of a progress file, oldest first.
+// parts of a compressed file keep their uncompressed names.
+func RotatedParts(path string) []string {
+	path = strings.TrimSuffix(path, CompressedSuffix)
+	var parts []string
+	for n := 1; ; n++ {
+		part := RotatedPath(path, n)
+		if _, err := os.Stat(part); err != nil {
+			return parts
+		}
+		parts = append(parts, part)
+	}
+}
+
+// rotate moves the current progress file to the next rotated part and continues in a fresh locked file
+// at the same path, starting with a continuation header. the lock registry keeps the path registered.
+func (l *Logger) rotate() error {
+	path := l.file.Name()
+	if err := os.Rename(path, RotatedPath(path, l.parts+1)); err != nil {
+		return fmt.Errorf("rename progress file: %w", err)
+	}
+	f, err := os.Create(path) //nolint:gosec // same path as the rotated file
+	if err != nil {
+		return fmt.Errorf("create progress file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("acquire file lock: %w", err)
+	}
+
+	prev := l.file
+	l.file, l.size = f, 0
+	l.parts++
+	l.writeHeader()
+	_ = unlockFile(prev)
+	if err := prev.Close(); err != nil {
+		return fmt.Errorf("close rotated progress file: %w", err)
+	}
+	return nil
+}
+
+// rotateIfFull rotates the progress file once it reached the max size, between lines only.
+// on failure rotation is disabled and the run continues writing to the current file.
+func (l *Logger) rotateIfFull() {
+	if l.maxSize <= 0 || l.size < l.maxSize || l.midLine {
+		return
+	}
+	if err := l.rotate(); err != nil {
+		l.maxSize = 0
+		l.writeStdout("warning: progress file rotation failed, continuing without rotation: %v\n", err)
+	}
+}
+
+// NewConsoleLogger creates a logger writing only to w, without a progress file.
+// it's used to re-render finished sessions, see SetClock for keeping their original timestamps.
+func NewConsoleLogger(w io.Writer, colors *Colors, noColor bool) *Logger {
+	if noColor {
+		color.NoColor = true
+	}
+	return &Logger{stdout: w, startTime: time.Now(), phase: PhaseTask, colors: colors, format: FormatText}
 }
 
 // Path returns the progress file path.
@@ -209,16 +397,62 @@ func (l *Logger) SetPhase(phase Phase) {
 	l.phase = phase
 }
 
-// timestampFormat is the format for timestamps: YY-MM-DD HH:MM:SS
-const timestampFormat = "06-01-02 15:04:05"
+// timestamp formats of text output, see Config.TimestampFormat.
+const (
+	TimestampLegacy  = "legacy"  // [YY-MM-DD HH:MM:SS] lines, YYYY-MM-DD HH:MM:SS start and end times, local time without zone
+	TimestampISO8601 = "iso8601" // RFC 3339 with timezone everywhere, e.g. [2026-01-22T10:30:00+02:00]
+)
+
+// layouts of legacy timestamps: YY-MM-DD HH:MM:SS for lines, YYYY-MM-DD HH:MM:SS for start and end times
+const (
+	timestampFormat     = "06-01-02 15:04:05"
+	fullTimestampFormat = "2006-01-02 15:04:05"
+)
+
+// timestampLayouts returns the time layouts of printed lines and of the start and end times for a timestamp format.
+func timestampLayouts(format string) (line, full string) {
+	if format == TimestampISO8601 {
+		return time.RFC3339, time.RFC3339
+	}
+	return timestampFormat, fullTimestampFormat
+}
+
+// ParseTimestamp parses a timestamp of a text progress file written in any of the timestamp formats.
+// legacy timestamps carry no zone and are returned as UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	for _, layout := range []string{timestampFormat, fullTimestampFormat, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown timestamp format %q", s)
+}
+
+// SetClock sets the time source for timestamps of printed lines.
+func (l *Logger) SetClock(now func() time.Time) {
+	l.now = now
+}
+
+// timestamp returns the formatted time of a printed line.
+func (l *Logger) timestamp() string {
+	layout, _ := timestampLayouts(l.tsFormat)
+	if l.now == nil {
+		return time.Now().Format(layout)
+	}
+	return l.now().Format(layout)
+}
 
 // Print writes a timestamped message to both file and stdout.
 func (l *Logger) Print(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
 	// write to file without color
-	l.writeFile("[%s] %s\n", timestamp, msg)
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordOutput, Text: msg})
+	} else {
+		l.writeFile("[%s] %s\n", timestamp, msg)
+	}
 
 	// write to stdout with color
 	phaseColor := l.colors.ForPhase(l.phase)
@@ -230,7 +464,16 @@ func (l *Logger) Print(format string, args ...any) {
 // PrintRaw writes without timestamp (for streaming output).
 func (l *Logger) PrintRaw(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
-	l.writeFile("%s", msg)
+	if l.format == FormatJSONL {
+		// one record per non-empty line, matching how the text format is read back
+		for line := range strings.SplitSeq(msg, "\n") {
+			if line != "" {
+				l.writeRecord(Record{Type: RecordOutput, Text: line})
+			}
+		}
+	} else {
+		l.writeFile("%s", msg)
+	}
 	l.writeStdout("%s", msg)
 }
 
@@ -238,7 +481,11 @@ func (l *Logger) PrintRaw(format string, args ...any) {
 // format: "\n--- {label} ---\n"
 func (l *Logger) PrintSection(section processor.Section) {
 	header := fmt.Sprintf("\n--- %s ---\n", section.Label)
-	l.writeFile("%s", header)
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordSection, Section: section.Label, Text: section.Label})
+	} else {
+		l.writeFile("%s", header)
+	}
 	l.writeStdout("%s", l.colors.Warn().Sprint(header))
 }
 
@@ -344,15 +591,23 @@ func (l *Logger) PrintAligned(text string) {
 		displayLine := formatListItem(line)
 
 		// timestamp each line
-		timestamp := time.Now().Format(timestampFormat)
+		timestamp := l.timestamp()
 		tsPrefix := l.colors.Timestamp().Sprintf("[%s]", timestamp)
-		l.writeFile("[%s] %s\n", timestamp, displayLine)
+		sig := extractSignal(line)
+		switch {
+		case l.format != FormatJSONL:
+			l.writeFile("[%s] %s\n", timestamp, displayLine)
+		case sig != "":
+			l.writeRecord(Record{Type: RecordSignal, Signal: sig, Text: displayLine})
+		default:
+			l.writeRecord(Record{Type: RecordOutput, Text: displayLine})
+		}
 
 		// use red for signal lines
 		lineColor := phaseColor
 
 		// format signal lines nicely
-		if sig := extractSignal(line); sig != "" {
+		if sig != "" {
 			displayLine = sig
 			lineColor = l.colors.Signal()
 		}
@@ -414,21 +669,46 @@ func isListItem(line string) bool {
 // Error writes an error message in red.
 func (l *Logger) Error(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
-	l.writeFile("[%s] ERROR: %s\n", timestamp, msg)
+	l.writeLine(RecordError, timestamp, "ERROR: "+msg)
 
 	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
 	errStr := l.colors.Error().Sprintf("ERROR: %s", msg)
 	l.writeStdout("%s %s\n", tsStr, errStr)
 }
 
+// RecoverableSuffix marks error lines of text progress files for errors that didn't stop the run.
+const RecoverableSuffix = " (recoverable)"
+
+// LogError records an error encountered by the runner, in red like Error.
+// recoverable errors are marked with RecoverableSuffix in text files and the recoverable field in jsonl files.
+func (l *Logger) LogError(err error, recoverable bool) {
+	timestamp := l.timestamp()
+	text := "ERROR: " + err.Error()
+
+	switch {
+	case l.format == FormatJSONL:
+		l.writeRecord(Record{Type: RecordError, Text: text, Recoverable: recoverable})
+	case recoverable:
+		l.writeFile("[%s] %s%s\n", timestamp, text, RecoverableSuffix)
+	default:
+		l.writeFile("[%s] %s\n", timestamp, text)
+	}
+
+	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
+	if recoverable {
+		text += RecoverableSuffix
+	}
+	l.writeStdout("%s %s\n", tsStr, l.colors.Error().Sprint(text))
+}
+
 // Warn writes a warning message in yellow.
 func (l *Logger) Warn(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
-	l.writeFile("[%s] WARN: %s\n", timestamp, msg)
+	l.writeLine(RecordWarn, timestamp, "WARN: "+msg)
 
 	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
 	warnStr := l.colors.Warn().Sprintf("WARN: %s", msg)
@@ -438,10 +718,10 @@ func (l *Logger) Warn(format string, args ...any) {
 // LogQuestion logs a question and its options for plan creation mode.
 // format: QUESTION: <question>\n OPTIONS: <opt1>, <opt2>, ...
 func (l *Logger) LogQuestion(question string, options []string) {
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
-	l.writeFile("[%s] QUESTION: %s\n", timestamp, question)
-	l.writeFile("[%s] OPTIONS: %s\n", timestamp, strings.Join(options, ", "))
+	l.writeLine(RecordOutput, timestamp, "QUESTION: "+question)
+	l.writeLine(RecordOutput, timestamp, "OPTIONS: "+strings.Join(options, ", "))
 
 	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
 	questionStr := l.colors.Info().Sprintf("QUESTION: %s", question)
@@ -453,9 +733,9 @@ func (l *Logger) LogQuestion(question string, options []string) {
 // LogAnswer logs the user's answer for plan creation mode.
 // format: ANSWER: <answer>
 func (l *Logger) LogAnswer(answer string) {
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
-	l.writeFile("[%s] ANSWER: %s\n", timestamp, answer)
+	l.writeLine(RecordOutput, timestamp, "ANSWER: "+answer)
 
 	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
 	answerStr := l.colors.Info().Sprintf("ANSWER: %s", answer)
@@ -466,34 +746,116 @@ func (l *Logger) LogAnswer(answer string) {
 // format: DRAFT REVIEW: <action>
 // if feedback is non-empty: FEEDBACK: <feedback>
 func (l *Logger) LogDraftReview(action, feedback string) {
-	timestamp := time.Now().Format(timestampFormat)
+	timestamp := l.timestamp()
 
-	l.writeFile("[%s] DRAFT REVIEW: %s\n", timestamp, action)
+	l.writeLine(RecordOutput, timestamp, "DRAFT REVIEW: "+action)
 
 	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
 	actionStr := l.colors.Info().Sprintf("DRAFT REVIEW: %s", action)
 	l.writeStdout("%s %s\n", tsStr, actionStr)
 
 	if feedback != "" {
-		l.writeFile("[%s] FEEDBACK: %s\n", timestamp, feedback)
+		l.writeLine(RecordOutput, timestamp, "FEEDBACK: "+feedback)
 		feedbackStr := l.colors.Info().Sprintf("FEEDBACK: %s", feedback)
 		l.writeStdout("%s %s\n", tsStr, feedbackStr)
 	}
 }
 
+// LogHumanAction records an action performed by a human during the run (answer, review, cancel, etc.).
+// format: HUMAN ACTION (<actor>): <action>
+func (l *Logger) LogHumanAction(actor, action string) {
+	timestamp := l.timestamp()
+
+	text := fmt.Sprintf("HUMAN ACTION (%s): %s", actor, action)
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordHumanAction, Actor: actor, Text: text})
+	} else {
+		l.writeFile("[%s] %s\n", timestamp, text)
+	}
+
+	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
+	actionStr := l.colors.Info().Sprintf("HUMAN ACTION (%s): %s", actor, action)
+	l.writeStdout("%s %s\n", tsStr, actionStr)
+}
+
+// LogPlanReady records the plan file produced by plan creation, the path is also written to the footer on Close.
+// format: PLAN READY: <plan file>
+func (l *Logger) LogPlanReady(planFile string) {
+	timestamp := l.timestamp()
+	l.planFile = planFile
+
+	text := PlanReadyPrefix + planFile
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordPlanReady, Text: text, PlanFile: planFile})
+	} else {
+		l.writeFile("[%s] %s\n", timestamp, text)
+	}
+
+	tsStr := l.colors.Timestamp().Sprintf("[%s]", timestamp)
+	l.writeStdout("%s %s\n", tsStr, l.colors.Info().Sprint(text))
+}
+
 // Elapsed returns formatted elapsed time since start.
 func (l *Logger) Elapsed() string {
 	return humanize.RelTime(l.startTime, time.Now(), "", "")
 }
 
+// SetOutcome records how the run ended, one of the Outcome* constants.
+// it's written to the footer on Close, runs without an outcome are recorded as completed.
+func (l *Logger) SetOutcome(outcome string) {
+	l.outcome = outcome
+}
+
+// SetPhaseTimings records how long each phase ran, written to the footer on Close.
+func (l *Logger) SetPhaseTimings(timings map[Phase]time.Duration) {
+	l.timings = FormatPhaseTimings(timings)
+}
+
+// SetTokenUsage records provider-reported tokens per phase, written to the footer on Close.
+// an empty usage is written as unavailable.
+func (l *Logger) SetTokenUsage(usage map[Phase]int64) {
+	l.tokens = FormatTokenUsage(usage)
+}
+
+// SetPushResult records the result of pushing the branch after the run, written to the footer on Close.
+// multi-line results, e.g. git errors, are joined into a single line.
+func (l *Logger) SetPushResult(result string) {
+	l.push = strings.Join(strings.Fields(result), " ")
+}
+
 // Close writes footer, releases the file lock, and closes the progress file.
+// with Config.CompressCompleted set, the file of a completed run is then replaced by its gzipped copy.
 func (l *Logger) Close() error {
 	if l.file == nil {
 		return nil
 	}
+	l.maxSize = 0 // footer stays in one piece
 
-	l.writeFile("\n%s\n", strings.Repeat("-", 60))
-	l.writeFile("Completed: %s (%s)\n", time.Now().Format("2006-01-02 15:04:05"), l.Elapsed())
+	outcome := l.outcome
+	if outcome == "" {
+		outcome = OutcomeCompleted
+	}
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: RecordFooter, Text: l.Elapsed(), Outcome: outcome,
+			PhaseTimings: l.timings, Tokens: l.tokens, Push: l.push, PlanFile: l.planFile})
+	} else {
+		l.writeFile("\n%s\n", strings.Repeat("-", 60))
+		// timings, tokens, push and plan file go before the outcome line, readers expect the outcome on the last line
+		if l.timings != "" {
+			l.writeFile("%s: %s\n", PhaseTimingsLabel, l.timings)
+		}
+		if l.tokens != "" {
+			l.writeFile("%s: %s\n", TokensLabel, l.tokens)
+		}
+		if l.push != "" {
+			l.writeFile("%s: %s\n", PushLabel, l.push)
+		}
+		if l.planFile != "" {
+			l.writeFile("%s: %s\n", PlanFileLabel, l.planFile)
+		}
+		_, timeLayout := timestampLayouts(l.tsFormat)
+		l.writeFile("%s: %s (%s)\n", FooterLabel(outcome), time.Now().Format(timeLayout), l.Elapsed())
+	}
 
 	// release file lock before closing
 	_ = unlockFile(l.file)
@@ -502,13 +864,34 @@ func (l *Logger) Close() error {
 	if err := l.file.Close(); err != nil {
 		return fmt.Errorf("close progress file: %w", err)
 	}
+	if l.compress && outcome == OutcomeCompleted {
+		if _, err := CompressFile(l.file.Name()); err != nil {
+			return fmt.Errorf("compress progress file: %w", err)
+		}
+	}
 	return nil
 }
 
 func (l *Logger) writeFile(format string, args ...any) {
-	if l.file != nil {
-		fmt.Fprintf(l.file, format, args...)
+	if l.file == nil {
+		return
+	}
+	l.rotateIfFull()
+	text := fmt.Sprintf(format, args...)
+	n, _ := io.WriteString(l.file, text)
+	l.size += int64(n)
+	if text != "" {
+		l.midLine = !strings.HasSuffix(text, "\n")
+	}
+}
+
+// writeLine writes a timestamped line in text format or a record of the given type in jsonl format.
+func (l *Logger) writeLine(recType, timestamp, text string) {
+	if l.format == FormatJSONL {
+		l.writeRecord(Record{Type: recType, Text: text})
+		return
 	}
+	l.writeFile("[%s] %s\n", timestamp, text)
 }
 
 func (l *Logger) writeStdout(format string, args ...any) {
@@ -547,6 +930,39 @@ func progressFilename(planFile, planDescription, mode string) string {
 	}
 }
 
+// templateFilename renders the progress file name from cfg.NameTemplate.
+// the rendered name is wrapped in the "progress-" prefix and ".txt" suffix the dashboard discovers files by.
+// falls back to the default name if the template renders to nothing, e.g. only an empty {branch}.
+func templateFilename(cfg Config, now time.Time) string {
+	slug := ""
+	switch {
+	case cfg.Mode == "plan" && cfg.PlanDescription != "":
+		slug = sanitizePlanName(cfg.PlanDescription)
+	case cfg.PlanFile != "":
+		slug = sanitizePlanName(strings.TrimSuffix(filepath.Base(cfg.PlanFile), ".md"))
+	}
+	branch := ""
+	if cfg.Branch != "" {
+		branch = sanitizePlanName(strings.ReplaceAll(cfg.Branch, "/", "-"))
+	}
+
+	name := strings.NewReplacer(
+		"{date}", now.Format("2006-01-02"),
+		"{branch}", branch,
+		"{mode}", cfg.Mode,
+		"{slug}", slug,
+		"/", "-", "\\", "-",
+	).Replace(cfg.NameTemplate)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.Trim(name, "-_. ")
+	if name == "" {
+		return progressFilename(cfg.PlanFile, cfg.PlanDescription, cfg.Mode)
+	}
+	return "progress-" + name + ".txt"
+}
+
 // sanitizePlanName converts plan description to a safe filename component.
 // replaces spaces with dashes, removes special characters, and limits length.
 func sanitizePlanName(desc string) string {
@@ -2,10 +2,15 @@ package progress
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
@@ -65,10 +70,121 @@ func TestNewLogger(t *testing.T) {
 			require.NoError(t, err)
 			assert.Contains(t, string(content), "# Ralphex Progress Log")
 			assert.Contains(t, string(content), "Mode: "+tc.cfg.Mode)
+			assert.Contains(t, string(content), "PID: "+strconv.Itoa(os.Getpid())+"\n")
 		})
 	}
 }
 
+func TestNewLogger_OptionalHeaderFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	t.Run("written when set", func(t *testing.T) {
+		l, err := NewLogger(Config{PlanFile: "a.md", Mode: "full", Branch: "a", Worktree: "/src/proj-worktrees/a",
+			StartCommit: "abc123", NoColor: true}, testColors())
+		require.NoError(t, err)
+		defer l.Close()
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Branch: a\nWorktree: /src/proj-worktrees/a\nCommit: abc123\nMode: full\n")
+	})
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		l, err := NewLogger(Config{PlanFile: "b.md", Mode: "full", Branch: "b", NoColor: true}, testColors())
+		require.NoError(t, err)
+		defer l.Close()
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "Worktree:")
+		assert.NotContains(t, string(content), "Commit:")
+	})
+}
+
+func TestLogger_TimestampFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	ts := time.Date(2026, 1, 22, 10, 30, 5, 0, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		format, wantLine, wantStarted, wantFooter string
+	}{
+		{format: "", wantLine: "[26-01-22 10:30:05] hello", wantStarted: `Started: \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\n`,
+			wantFooter: `Completed: \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} \(`},
+		{format: TimestampLegacy, wantLine: "[26-01-22 10:30:05] hello", wantStarted: `Started: \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\n`,
+			wantFooter: `Completed: \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} \(`},
+		{format: TimestampISO8601, wantLine: "[2026-01-22T10:30:05+02:00] hello",
+			wantStarted: `Started: \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})\n`,
+			wantFooter:  `Completed: \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2}) \(`},
+	}
+	for _, tc := range tests {
+		t.Run("format "+tc.format, func(t *testing.T) {
+			l, err := NewLogger(Config{PlanFile: "ts-" + tc.format + ".md", Mode: "full", Branch: "main", NoColor: true,
+				TimestampFormat: tc.format}, testColors())
+			require.NoError(t, err)
+			l.stdout = io.Discard
+			l.SetClock(func() time.Time { return ts })
+			l.Print("hello")
+			require.NoError(t, l.Close())
+
+			content, err := os.ReadFile(l.Path())
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tc.wantLine)
+			assert.Regexp(t, tc.wantStarted, string(content))
+			assert.Regexp(t, tc.wantFooter, string(content))
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewLogger(Config{PlanFile: "ts-bad.md", Mode: "full", TimestampFormat: "rfc822"}, testColors())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown timestamp format "rfc822"`)
+	})
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "26-01-22 10:30:05", want: time.Date(2026, 1, 22, 10, 30, 5, 0, time.UTC)},
+		{in: "2026-01-22 10:30:05", want: time.Date(2026, 1, 22, 10, 30, 5, 0, time.UTC)},
+		{in: "2026-01-22T10:30:05Z", want: time.Date(2026, 1, 22, 10, 30, 5, 0, time.UTC)},
+		{in: "2026-01-22T10:30:05+02:00", want: time.Date(2026, 1, 22, 8, 30, 5, 0, time.UTC)},
+		{in: "2026-01-22T10:30:05.123-05:00", want: time.Date(2026, 1, 22, 15, 30, 5, 123000000, time.UTC)},
+		{in: "22/01/2026 10:30", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseTimestamp(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tc.want.Equal(got), "got %v, want %v", got, tc.want)
+		})
+	}
+
+	// timestamps written in both formats parse back to the same instant, up to the legacy format's missing zone
+	ts := time.Date(2026, 1, 22, 10, 30, 5, 0, time.UTC)
+	for _, format := range []string{TimestampLegacy, TimestampISO8601} {
+		line, full := timestampLayouts(format)
+		for _, layout := range []string{line, full} {
+			got, err := ParseTimestamp(ts.Format(layout))
+			require.NoError(t, err)
+			assert.True(t, ts.Equal(got), "%s round trip of %s: got %v", format, layout, got)
+		}
+	}
+}
+
 func TestLogger_Print(t *testing.T) {
 	tmpDir := t.TempDir()
 	origDir, _ := os.Getwd()
@@ -94,6 +210,26 @@ func TestLogger_Print(t *testing.T) {
 	assert.Contains(t, buf.String(), "test message 42")
 }
 
+func TestNewConsoleLogger(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	var buf bytes.Buffer
+	l := NewConsoleLogger(&buf, testColors(), true)
+	l.SetClock(func() time.Time { return time.Date(2026, 1, 22, 10, 0, 1, 0, time.UTC) })
+	l.Print("replayed %s", "line")
+	l.Warn("careful")
+	require.NoError(t, l.Close())
+
+	assert.Equal(t, "[26-01-22 10:00:01] replayed line\n[26-01-22 10:00:01] WARN: careful\n", buf.String())
+	assert.Empty(t, l.Path())
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no progress file created")
+}
+
 func TestLogger_PrintRaw(t *testing.T) {
 	tmpDir := t.TempDir()
 	origDir, _ := os.Getwd()
@@ -206,6 +342,57 @@ func TestLogger_Error(t *testing.T) {
 	assert.Contains(t, buf.String(), "ERROR: something failed: reason")
 }
 
+func TestLogger_LogError(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	t.Run("text", func(t *testing.T) {
+		l, err := NewLogger(Config{Mode: "full", Branch: "test", NoColor: true}, testColors())
+		require.NoError(t, err)
+		defer func() { _ = l.Close() }()
+		var buf bytes.Buffer
+		l.stdout = &buf
+
+		l.LogError(errors.New("codex loop: codex exited"), false)
+		l.LogError(errors.New("finalize step failed: boom"), true)
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.Regexp(t, `\] ERROR: codex loop: codex exited\n`, string(content))
+		assert.Regexp(t, `\] ERROR: finalize step failed: boom \(recoverable\)\n`, string(content))
+		assert.Contains(t, buf.String(), "ERROR: codex loop: codex exited\n")
+		assert.Contains(t, buf.String(), "ERROR: finalize step failed: boom (recoverable)\n")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		l, err := NewLogger(Config{Mode: "full", Branch: "jsonl", NoColor: true, Format: FormatJSONL}, testColors())
+		require.NoError(t, err)
+		l.stdout = io.Discard
+		l.LogError(errors.New("fatal"), false)
+		l.LogError(errors.New("finalize step failed: boom"), true)
+		require.NoError(t, l.Close())
+
+		f, err := os.Open(l.Path())
+		require.NoError(t, err)
+		defer f.Close()
+		records, err := ParseProgressJSONL(f)
+		require.NoError(t, err)
+		var errs []Record
+		for _, rec := range records {
+			if rec.Type == RecordError {
+				errs = append(errs, rec)
+			}
+		}
+		require.Len(t, errs, 2)
+		assert.Equal(t, "ERROR: fatal", errs[0].Text)
+		assert.False(t, errs[0].Recoverable)
+		assert.Equal(t, "ERROR: finalize step failed: boom", errs[1].Text)
+		assert.True(t, errs[1].Recoverable)
+	})
+}
+
 func TestLogger_Warn(t *testing.T) {
 	tmpDir := t.TempDir()
 	origDir, _ := os.Getwd()
@@ -322,6 +509,428 @@ func TestLogger_Close(t *testing.T) {
 	assert.Contains(t, string(content), strings.Repeat("-", 60))
 }
 
+func TestLogger_Close_Outcome(t *testing.T) {
+	tests := []struct {
+		name, outcome, format, want string
+	}{
+		{name: "canceled text", outcome: OutcomeCanceled, want: "Canceled: "},
+		{name: "failed text", outcome: OutcomeFailed, want: "Failed: "},
+		{name: "unknown outcome text", outcome: "weird", want: "Completed: "},
+		{name: "canceled jsonl", outcome: OutcomeCanceled, format: FormatJSONL, want: `"outcome":"canceled"`},
+		{name: "failed jsonl", outcome: OutcomeFailed, format: FormatJSONL, want: `"outcome":"failed"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			origDir, _ := os.Getwd()
+			require.NoError(t, os.Chdir(tmpDir))
+			defer func() { _ = os.Chdir(origDir) }()
+
+			l, err := NewLogger(Config{Mode: "full", Branch: "test", Format: tc.format}, testColors())
+			require.NoError(t, err)
+			l.SetOutcome(tc.outcome)
+			require.NoError(t, l.Close())
+
+			content, err := os.ReadFile(l.Path())
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+			assert.Contains(t, lines[len(lines)-1], tc.want)
+		})
+	}
+}
+
+func TestLogger_Close_PhaseTimings(t *testing.T) {
+	timings := map[Phase]time.Duration{processor.PhaseTask: 12*time.Minute + 3*time.Second, processor.PhaseReview: 4 * time.Minute}
+
+	t.Run("text", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		l.SetPhaseTimings(timings)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		require.GreaterOrEqual(t, len(lines), 2)
+		assert.Equal(t, "Phase-Timings: task=12m3s review=4m0s", lines[len(lines)-2])
+		assert.True(t, strings.HasPrefix(lines[len(lines)-1], "Completed: "), "outcome stays on the last line")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test", Format: FormatJSONL}, testColors())
+		require.NoError(t, err)
+		l.SetPhaseTimings(timings)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		assert.Contains(t, lines[len(lines)-1], `"phase_timings":"task=12m3s review=4m0s"`)
+	})
+
+	t.Run("no timings", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "Phase-Timings")
+	})
+}
+
+func TestLogger_Close_PushResult(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		l.SetTokenUsage(map[Phase]int64{processor.PhaseTask: 1200})
+		l.SetPushResult("failed: git push: exit status 1:\nfatal: 'origin' does not appear to be a git repository")
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		require.GreaterOrEqual(t, len(lines), 3)
+		assert.Equal(t, "Tokens: task=1200", lines[len(lines)-3])
+		assert.Equal(t, "Push: failed: git push: exit status 1: fatal: 'origin' does not appear to be a git repository",
+			lines[len(lines)-2])
+		assert.True(t, strings.HasPrefix(lines[len(lines)-1], "Completed: "), "outcome stays on the last line")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test", Format: FormatJSONL}, testColors())
+		require.NoError(t, err)
+		l.SetPushResult("pushed test to origin")
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		assert.Contains(t, lines[len(lines)-1], `"push":"pushed test to origin"`)
+	})
+
+	t.Run("not pushed", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "Push:")
+	})
+}
+
+func TestLogger_LogPlanReady(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{PlanDescription: "add cache", Mode: "plan", Branch: "main"}, testColors())
+		require.NoError(t, err)
+		l.SetPushResult("pushed main to origin")
+		l.LogPlanReady("docs/plans/add-cache.md")
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "] PLAN READY: docs/plans/add-cache.md\n")
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		require.GreaterOrEqual(t, len(lines), 3)
+		assert.Equal(t, "Push: pushed main to origin", lines[len(lines)-3])
+		assert.Equal(t, "Plan-File: docs/plans/add-cache.md", lines[len(lines)-2])
+		assert.True(t, strings.HasPrefix(lines[len(lines)-1], "Completed: "), "outcome stays on the last line")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{PlanDescription: "add cache", Mode: "plan", Branch: "main", Format: FormatJSONL},
+			testColors())
+		require.NoError(t, err)
+		l.LogPlanReady("docs/plans/add-cache.md")
+		require.NoError(t, l.Close())
+
+		f, err := os.Open(l.Path())
+		require.NoError(t, err)
+		defer f.Close()
+		records, err := ParseProgressJSONL(f)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(records), 3)
+		ready := records[len(records)-2]
+		assert.Equal(t, RecordPlanReady, ready.Type)
+		assert.Equal(t, "docs/plans/add-cache.md", ready.PlanFile)
+		assert.Equal(t, "PLAN READY: docs/plans/add-cache.md", ready.Text)
+		footer := records[len(records)-1]
+		assert.Equal(t, RecordFooter, footer.Type)
+		assert.Equal(t, "docs/plans/add-cache.md", footer.PlanFile)
+	})
+
+	t.Run("no plan file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{PlanDescription: "add cache", Mode: "plan", Branch: "main"}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "Plan-File:")
+	})
+}
+
+func TestLogger_Rotation(t *testing.T) {
+	// write prints numbered lines past a small rotation threshold, with a streamed line split across writes
+	write := func(t *testing.T, cfg Config) *Logger {
+		t.Helper()
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+		cfg.MaxSizeMB = 1
+		l, err := NewLogger(cfg, testColors())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1<<20), l.maxSize)
+		l.maxSize = 2048 // rotate every few lines instead of every megabyte
+		for i := range 100 {
+			l.Print("line %03d %s", i, strings.Repeat("x", 50))
+			if i == 50 {
+				l.PrintRaw("streamed ")
+				l.PrintRaw("line\n")
+			}
+		}
+		require.NoError(t, l.Close())
+		return l
+	}
+
+	t.Run("text", func(t *testing.T) {
+		l := write(t, Config{PlanFile: "docs/plans/feature.md", Mode: "full", Branch: "test"})
+		parts := RotatedParts(l.Path())
+		require.Len(t, parts, l.parts)
+		require.GreaterOrEqual(t, len(parts), 2)
+		assert.Equal(t, "progress-feature.1.txt", parts[0])
+
+		var lines []string
+		for i, path := range append(parts, l.Path()) {
+			content, err := os.ReadFile(path) //nolint:gosec // test file
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(content), "# Ralphex Progress Log\nPlan: docs/plans/feature.md\n"), path)
+			if i == 0 {
+				assert.NotContains(t, string(content), "Part:")
+			} else {
+				assert.Contains(t, string(content), fmt.Sprintf("\nPart: %d\n", i+1), path)
+			}
+			if path != l.Path() {
+				assert.LessOrEqual(t, len(content), 2048+100, "parts rotate after the first line past the threshold")
+				assert.NotContains(t, string(content), "Completed:", "footer only in the last part")
+			}
+			for line := range strings.SplitSeq(string(content), "\n") {
+				if _, text, ok := strings.Cut(line, "] "); ok {
+					lines = append(lines, strings.Fields(text)[1])
+				} else if strings.HasPrefix(line, "streamed") {
+					lines = append(lines, line)
+				}
+			}
+		}
+		require.Len(t, lines, 101)
+		for i := range 100 {
+			want := fmt.Sprintf("%03d", i)
+			if i > 50 {
+				assert.Equal(t, want, lines[i+1])
+				continue
+			}
+			assert.Equal(t, want, lines[i])
+		}
+		assert.Equal(t, "streamed line", lines[51], "split line is kept in one part")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		l := write(t, Config{Mode: "review", Branch: "test", Format: FormatJSONL})
+		parts := RotatedParts(l.Path())
+		require.GreaterOrEqual(t, len(parts), 2)
+
+		var texts []string
+		for i, path := range append(parts, l.Path()) {
+			f, err := os.Open(path) //nolint:gosec // test file
+			require.NoError(t, err)
+			records, err := ParseProgressJSONL(f)
+			require.NoError(t, f.Close())
+			require.NoError(t, err)
+			require.Equal(t, RecordHeader, records[0].Type)
+			if i == 0 {
+				assert.Zero(t, records[0].Header.Part)
+			} else {
+				assert.Equal(t, i+1, records[0].Header.Part)
+			}
+			for _, rec := range records[1:] {
+				if rec.Type == RecordOutput && strings.HasPrefix(rec.Text, "line ") {
+					texts = append(texts, rec.Text[5:8])
+				}
+			}
+		}
+		require.Len(t, texts, 100)
+		for i, text := range texts {
+			assert.Equal(t, fmt.Sprintf("%03d", i), text)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		// stale part left by an earlier run of the same plan
+		require.NoError(t, os.WriteFile("progress-review.1.txt", []byte("old run\n"), 0o600))
+		l, err := NewLogger(Config{Mode: "review", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		for i := range 100 {
+			l.Print("line %03d %s", i, strings.Repeat("x", 50))
+		}
+		require.NoError(t, l.Close())
+		assert.Empty(t, RotatedParts(l.Path()))
+	})
+}
+
+func TestRotatedPath(t *testing.T) {
+	assert.Equal(t, "progress-plan.1.txt", RotatedPath("progress-plan.txt", 1))
+	assert.Equal(t, "dir/progress-v1.2.12.txt", RotatedPath("dir/progress-v1.2.txt", 12))
+}
+
+func TestLogger_Close_TokenUsage(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		l.SetPhaseTimings(map[Phase]time.Duration{processor.PhaseTask: time.Minute})
+		l.SetTokenUsage(map[Phase]int64{processor.PhaseTask: 1200, processor.PhaseReview: 300})
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		require.GreaterOrEqual(t, len(lines), 3)
+		assert.Equal(t, "Phase-Timings: task=1m0s", lines[len(lines)-3])
+		assert.Equal(t, "Tokens: task=1200 review=300", lines[len(lines)-2])
+		assert.True(t, strings.HasPrefix(lines[len(lines)-1], "Completed: "), "outcome stays on the last line")
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		l.SetTokenUsage(map[Phase]int64{})
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Tokens: unavailable\n")
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test", Format: FormatJSONL}, testColors())
+		require.NoError(t, err)
+		l.SetTokenUsage(map[Phase]int64{processor.PhaseCodex: 42})
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		assert.Contains(t, lines[len(lines)-1], `"tokens":"codex=42"`)
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(origDir) }()
+
+		l, err := NewLogger(Config{Mode: "full", Branch: "test"}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+
+		content, err := os.ReadFile(l.Path())
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "Tokens:")
+	})
+}
+
+func TestIsOwnerStale(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		host string
+		pid  int
+		want bool
+	}{
+		{name: "current process", host: host, pid: os.Getpid(), want: false},
+		{name: "dead process on this host", host: host, pid: 1 << 30, want: true},
+		{name: "other host", host: "other-box", pid: 1 << 30, want: false},
+		{name: "no host", pid: 1 << 30, want: false},
+		{name: "no pid", host: host, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsOwnerStale(tc.host, tc.pid))
+		})
+	}
+}
+
 func TestGetProgressFilename(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -351,6 +960,33 @@ func TestGetProgressFilename(t *testing.T) {
 	}
 }
 
+func TestTemplateFilename(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"date and plan file", Config{NameTemplate: "{date}-{slug}", PlanFile: "docs/plans/Add Cache.md", Mode: "full"},
+			"progress-2026-03-14-add-cache.txt"},
+		{"all placeholders", Config{NameTemplate: "{date}_{branch}_{mode}_{slug}", PlanFile: "feature.md", Mode: "review",
+			Branch: "feat/login"}, "progress-2026-03-14_feat-login_review_feature.txt"},
+		{"plan description", Config{NameTemplate: "{mode}-{slug}", PlanDescription: "implement caching", Mode: "plan"},
+			"progress-plan-implement-caching.txt"},
+		{"literal project name", Config{NameTemplate: "billing-{date}", Mode: "full"}, "progress-billing-2026-03-14.txt"},
+		{"empty values collapse", Config{NameTemplate: "{date}-{branch}-{slug}", Mode: "codex-only"},
+			"progress-2026-03-14.txt"},
+		{"renders to nothing", Config{NameTemplate: "{branch}", PlanFile: "feature.md", Mode: "review"},
+			"progress-feature-review.txt"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, templateFilename(tc.cfg, now))
+		})
+	}
+}
+
 func TestSanitizePlanName(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -553,6 +1189,17 @@ func TestNewColors(t *testing.T) {
 		assert.NotNil(t, colors.ForPhase(PhaseClaudeEval))
 	})
 
+	t.Run("applies theme preset with overrides", func(t *testing.T) {
+		colors := NewColors(config.ColorConfig{Theme: "solarized", Task: "1,2,3"})
+		assert.True(t, colors.ForPhase(PhaseTask).Equals(color.RGB(1, 2, 3)), "explicit color overrides the preset")
+		assert.True(t, colors.ForPhase(PhaseReview).Equals(color.RGB(42, 161, 152)), "solarized cyan from the preset")
+		assert.True(t, colors.Error().Equals(color.RGB(220, 50, 47)), "solarized red from the preset")
+	})
+
+	t.Run("panics on unknown theme", func(t *testing.T) {
+		assert.Panics(t, func() { NewColors(config.ColorConfig{Theme: "neon"}) })
+	})
+
 	t.Run("panics on invalid task color", func(t *testing.T) {
 		cfg := config.ColorConfig{
 			Task:       "invalid",
@@ -819,3 +1466,27 @@ func TestLogger_PlanModeFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_LogHumanAction(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	l, err := NewLogger(Config{Mode: "full", PlanFile: "docs/plans/feature.md", Branch: "main", NoColor: true}, testColors())
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	var buf bytes.Buffer
+	l.stdout = &buf
+
+	l.LogHumanAction("user", "canceled run")
+
+	// check file output, timestamp prefix included
+	content, err := os.ReadFile(l.Path())
+	require.NoError(t, err)
+	assert.Regexp(t, `\[\d{2}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\] HUMAN ACTION \(user\): canceled run\n`, string(content))
+
+	// check stdout output
+	assert.Contains(t, buf.String(), "HUMAN ACTION (user): canceled run")
+}
@@ -0,0 +1,45 @@
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// PhaseTimingsLabel starts the footer line with per-phase durations, e.g. "Phase-Timings: task=12m3s review=4m1s".
+const PhaseTimingsLabel = "Phase-Timings"
+
+// phaseOrder is the order phases are listed in the timing summary.
+var phaseOrder = []Phase{processor.PhaseTask, processor.PhaseReview, processor.PhaseCodex, processor.PhaseClaudeEval,
+	processor.PhasePlan, processor.PhaseFinalize}
+
+// FormatPhaseTimings formats per-phase durations as space separated phase=duration pairs,
+// rounded to seconds. phases that were never entered are omitted.
+func FormatPhaseTimings(timings map[Phase]time.Duration) string {
+	parts := make([]string, 0, len(timings))
+	for _, p := range phaseOrder {
+		if d, ok := timings[p]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", p, d.Round(time.Second)))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParsePhaseTimings parses a timing summary written by FormatPhaseTimings.
+func ParsePhaseTimings(s string) (map[Phase]time.Duration, error) {
+	timings := make(map[Phase]time.Duration)
+	for field := range strings.FieldsSeq(s) {
+		name, val, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid phase timing %q", field)
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration of phase %s: %w", name, err)
+		}
+		timings[Phase(name)] = d
+	}
+	return timings, nil
+}
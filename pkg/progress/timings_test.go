@@ -0,0 +1,74 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestFormatPhaseTimings(t *testing.T) {
+	tests := []struct {
+		name    string
+		timings map[Phase]time.Duration
+		want    string
+	}{
+		{name: "empty", timings: nil, want: ""},
+		{name: "phase order", timings: map[Phase]time.Duration{
+			processor.PhaseFinalize: time.Minute, processor.PhaseTask: 2 * time.Minute, processor.PhaseCodex: 3 * time.Second,
+		}, want: "task=2m0s codex=3s finalize=1m0s"},
+		{name: "rounded to seconds", timings: map[Phase]time.Duration{processor.PhaseReview: 1500 * time.Millisecond},
+			want: "review=2s"},
+		{name: "zero duration kept", timings: map[Phase]time.Duration{processor.PhaseTask: 0}, want: "task=0s"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FormatPhaseTimings(tc.timings))
+		})
+	}
+}
+
+func TestParsePhaseTimings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[Phase]time.Duration
+		wantErr string
+	}{
+		{name: "empty", input: "", want: map[Phase]time.Duration{}},
+		{name: "valid", input: "task=12m3s review=4m1s", want: map[Phase]time.Duration{
+			processor.PhaseTask: 12*time.Minute + 3*time.Second, processor.PhaseReview: 4*time.Minute + time.Second,
+		}},
+		{name: "extra spaces", input: "  codex=5s  ", want: map[Phase]time.Duration{processor.PhaseCodex: 5 * time.Second}},
+		{name: "missing separator", input: "task", wantErr: `invalid phase timing "task"`},
+		{name: "missing name", input: "=5s", wantErr: `invalid phase timing "=5s"`},
+		{name: "bad duration", input: "task=forever", wantErr: "invalid duration of phase task"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePhaseTimings(tc.input)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPhaseTimings_RoundTrip(t *testing.T) {
+	timings := map[Phase]time.Duration{
+		processor.PhaseTask: 12*time.Minute + 3*time.Second, processor.PhaseReview: 4 * time.Minute,
+		processor.PhaseClaudeEval: 42 * time.Second,
+	}
+	got, err := ParsePhaseTimings(FormatPhaseTimings(timings))
+	require.NoError(t, err)
+	assert.Equal(t, timings, got)
+}
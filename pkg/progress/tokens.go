@@ -0,0 +1,49 @@
+package progress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TokensLabel starts the footer line with per-phase token usage, e.g. "Tokens: task=120500 review=30211".
+const TokensLabel = "Tokens"
+
+// TokensUnavailable is written instead of counts when token usage was tracked but never reported.
+const TokensUnavailable = "unavailable"
+
+// FormatTokenUsage formats per-phase token counts as space separated phase=count pairs.
+// an empty usage is formatted as TokensUnavailable.
+func FormatTokenUsage(usage map[Phase]int64) string {
+	parts := make([]string, 0, len(usage))
+	for _, p := range phaseOrder {
+		if n, ok := usage[p]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%d", p, n))
+		}
+	}
+	if len(parts) == 0 {
+		return TokensUnavailable
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseTokenUsage parses token usage written by FormatTokenUsage.
+// TokensUnavailable is parsed as an empty, non-nil map.
+func ParseTokenUsage(s string) (map[Phase]int64, error) {
+	usage := make(map[Phase]int64)
+	if strings.TrimSpace(s) == TokensUnavailable {
+		return usage, nil
+	}
+	for field := range strings.FieldsSeq(s) {
+		name, val, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid token usage %q", field)
+		}
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token count of phase %s: %w", name, err)
+		}
+		usage[Phase(name)] = n
+	}
+	return usage, nil
+}
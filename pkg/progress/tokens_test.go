@@ -0,0 +1,67 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestFormatTokenUsage(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage map[Phase]int64
+		want  string
+	}{
+		{name: "nil", usage: nil, want: TokensUnavailable},
+		{name: "empty", usage: map[Phase]int64{}, want: TokensUnavailable},
+		{name: "phase order", usage: map[Phase]int64{
+			processor.PhaseFinalize: 10, processor.PhaseTask: 120500, processor.PhaseCodex: 3000,
+		}, want: "task=120500 codex=3000 finalize=10"},
+		{name: "zero count kept", usage: map[Phase]int64{processor.PhaseReview: 0}, want: "review=0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FormatTokenUsage(tc.usage))
+		})
+	}
+}
+
+func TestParseTokenUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[Phase]int64
+		wantErr string
+	}{
+		{name: "unavailable", input: "unavailable", want: map[Phase]int64{}},
+		{name: "valid", input: "task=120500 review=30211", want: map[Phase]int64{
+			processor.PhaseTask: 120500, processor.PhaseReview: 30211,
+		}},
+		{name: "missing separator", input: "task", wantErr: `invalid token usage "task"`},
+		{name: "bad count", input: "task=many", wantErr: "invalid token count of phase task"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTokenUsage(tc.input)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTokenUsage_RoundTrip(t *testing.T) {
+	usage := map[Phase]int64{processor.PhaseTask: 1, processor.PhaseCodex: 2, processor.PhaseReview: 3}
+	got, err := ParseTokenUsage(FormatTokenUsage(usage))
+	require.NoError(t, err)
+	assert.Equal(t, usage, got)
+}
@@ -0,0 +1,244 @@
+package web
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TextSpan is a run of output text sharing one ANSI style, rendered by the dashboard as a span with Class.
+type TextSpan struct {
+	Text  string `json:"text"`
+	Class string `json:"class,omitempty"` // space-separated css classes, e.g. "ansi-bold ansi-fg-red"
+}
+
+// ansiColorNames are the class suffixes of the 8 basic ANSI colors, bright variants get a "bright-" prefix.
+var ansiColorNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// ansiStyle is the SGR state applied to a run of text. colors are 0-15 palette indexes, -1 is the default.
+type ansiStyle struct {
+	bold, dim, italic, underline bool
+	fg, bg                       int
+}
+
+var defaultANSIStyle = ansiStyle{fg: -1, bg: -1}
+
+// class returns the css classes of the style, empty for the default style.
+func (s ansiStyle) class() string {
+	var classes []string
+	if s.bold {
+		classes = append(classes, "ansi-bold")
+	}
+	if s.dim {
+		classes = append(classes, "ansi-dim")
+	}
+	if s.italic {
+		classes = append(classes, "ansi-italic")
+	}
+	if s.underline {
+		classes = append(classes, "ansi-underline")
+	}
+	if s.fg >= 0 {
+		classes = append(classes, "ansi-fg-"+ansiColorName(s.fg))
+	}
+	if s.bg >= 0 {
+		classes = append(classes, "ansi-bg-"+ansiColorName(s.bg))
+	}
+	return strings.Join(classes, " ")
+}
+
+// ansiColorName returns the class suffix of a 0-15 palette color.
+func ansiColorName(c int) string {
+	if c >= 8 {
+		return "bright-" + ansiColorNames[c-8]
+	}
+	return ansiColorNames[c]
+}
+
+// apply updates the style with the parameters of an SGR sequence ("ESC [ params m").
+// unsupported attributes are ignored, extended colors outside the 16 color palette reset to the default color.
+func (s *ansiStyle) apply(params string) {
+	if params == "" {
+		*s = defaultANSIStyle
+		return
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue // e.g. colon sub-parameters, not supported
+		}
+		switch {
+		case code == 0:
+			*s = defaultANSIStyle
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.dim = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold, s.dim = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.fg = code - 30
+		case code == 39:
+			s.fg = -1
+		case code >= 40 && code <= 47:
+			s.bg = code - 40
+		case code == 49:
+			s.bg = -1
+		case code >= 90 && code <= 97:
+			s.fg = code - 90 + 8
+		case code >= 100 && code <= 107:
+			s.bg = code - 100 + 8
+		case code == 38 || code == 48:
+			color, n := extendedANSIColor(codes[i+1:])
+			i += n
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// extendedANSIColor parses the parameters following 38 or 48: "5;n" for 256 colors or "2;r;g;b" for true color.
+// returns the palette index (-1 if not in the 16 color palette) and the number of parameters consumed.
+func extendedANSIColor(params []string) (color, consumed int) {
+	if len(params) == 0 {
+		return -1, 0
+	}
+	switch params[0] {
+	case "5":
+		if len(params) < 2 {
+			return -1, len(params)
+		}
+		if n, err := strconv.Atoi(params[1]); err == nil && n >= 0 && n < 16 {
+			return n, 2
+		}
+		return -1, 2
+	case "2":
+		return -1, min(len(params), 4)
+	default:
+		return -1, 1
+	}
+}
+
+// parseANSI splits text containing ANSI escape sequences into plain text and styled spans.
+// SGR sequences (bold, dim, italic, underline, 16 color foreground and background, resets) become span classes,
+// all other escape sequences (cursor movement, OSC titles and links, malformed sequences) and control
+// characters other than tab are dropped. spans is nil if the text has no styling.
+func parseANSI(text string) (plain string, spans []TextSpan) {
+	if !hasControlChars(text) {
+		return text, nil
+	}
+
+	var out, run strings.Builder
+	style := defaultANSIStyle
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		spans = append(spans, TextSpan{Text: run.String(), Class: style.class()})
+		run.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		if c != 0x1b {
+			if c == '\t' || (c >= 0x20 && c != 0x7f) {
+				out.WriteByte(c)
+				run.WriteByte(c)
+			}
+			i++
+			continue
+		}
+
+		// escape sequence
+		if i+1 >= len(text) {
+			break // lone ESC at the end
+		}
+		switch text[i+1] {
+		case '[': // CSI: parameter bytes 0x30-0x3f, intermediate bytes 0x20-0x2f, final byte 0x40-0x7e
+			j := i + 2
+			for j < len(text) && text[j] >= 0x20 && text[j] <= 0x3f {
+				j++
+			}
+			if j >= len(text) || text[j] < 0x40 || text[j] > 0x7e {
+				i = j // malformed, drop the sequence up to the offending byte
+				continue
+			}
+			if text[j] == 'm' && isSGRParams(text[i+2:j]) {
+				flush()
+				style.apply(text[i+2 : j])
+			}
+			i = j + 1
+		case ']': // OSC, terminated by BEL or ESC \
+			j := i + 2
+			for j < len(text) && text[j] != 0x07 && text[j] != 0x1b {
+				j++
+			}
+			switch {
+			case j < len(text) && text[j] == 0x07:
+				j++
+			case j+1 < len(text) && text[j+1] == '\\':
+				j += 2
+			}
+			i = j
+		default: // other escapes: optional intermediate bytes 0x20-0x2f and a final byte, e.g. "ESC ( B"
+			j := i + 1
+			for j < len(text) && text[j] >= 0x20 && text[j] <= 0x2f {
+				j++
+			}
+			i = j + 1
+		}
+	}
+	flush()
+
+	for _, span := range spans {
+		if span.Class != "" {
+			return out.String(), spans
+		}
+	}
+	return out.String(), nil
+}
+
+// hasControlChars reports whether text contains an escape or other control character except tab.
+func hasControlChars(text string) bool {
+	for i := range len(text) {
+		if c := text[i]; (c < 0x20 && c != '\t') || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// isSGRParams reports whether CSI parameters are plain SGR ones (digits and separators, no private markers).
+func isSGRParams(params string) bool {
+	for i := range len(params) {
+		if c := params[i]; (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// renderANSI strips ANSI escape sequences from the event text, keeping the styling of output events as spans.
+// events without control characters, including already rendered ones, are returned as is.
+func renderANSI(e Event) Event {
+	if !hasControlChars(e.Text) {
+		return e
+	}
+	plain, spans := parseANSI(e.Text)
+	e.Text = plain
+	if e.Type == EventTypeOutput {
+		e.Spans = spans
+	}
+	return e
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestParseANSI(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantPlain string
+		wantSpans []TextSpan
+	}{
+		{name: "plain text", input: "no escapes here", wantPlain: "no escapes here"},
+		{name: "tab kept", input: "a\tb", wantPlain: "a\tb"},
+		{name: "foreground color and reset", input: "\x1b[31mred\x1b[0m plain", wantPlain: "red plain",
+			wantSpans: []TextSpan{{Text: "red", Class: "ansi-fg-red"}, {Text: " plain"}}},
+		{name: "empty reset", input: "\x1b[32mgreen\x1b[m done", wantPlain: "green done",
+			wantSpans: []TextSpan{{Text: "green", Class: "ansi-fg-green"}, {Text: " done"}}},
+		{name: "combined attributes", input: "\x1b[1;4;93;41mwarn\x1b[22mx", wantPlain: "warnx",
+			wantSpans: []TextSpan{{Text: "warn", Class: "ansi-bold ansi-underline ansi-fg-bright-yellow ansi-bg-red"},
+				{Text: "x", Class: "ansi-underline ansi-fg-bright-yellow ansi-bg-red"}}},
+		{name: "default colors", input: "\x1b[36;44mc\x1b[39mb\x1b[49mn", wantPlain: "cbn",
+			wantSpans: []TextSpan{{Text: "c", Class: "ansi-fg-cyan ansi-bg-blue"}, {Text: "b", Class: "ansi-bg-blue"}, {Text: "n"}}},
+		{name: "bright background, dim and italic", input: "\x1b[2;3;104mx\x1b[23my", wantPlain: "xy",
+			wantSpans: []TextSpan{{Text: "x", Class: "ansi-dim ansi-italic ansi-bg-bright-blue"}, {Text: "y", Class: "ansi-dim ansi-bg-bright-blue"}}},
+		{name: "256 color in palette", input: "\x1b[38;5;9mx", wantPlain: "x",
+			wantSpans: []TextSpan{{Text: "x", Class: "ansi-fg-bright-red"}}},
+		{name: "256 color outside palette keeps other attributes", input: "\x1b[1;38;5;200mx", wantPlain: "x",
+			wantSpans: []TextSpan{{Text: "x", Class: "ansi-bold"}}},
+		{name: "true color is skipped with its parameters", input: "\x1b[48;2;10;20;30;32mx", wantPlain: "x",
+			wantSpans: []TextSpan{{Text: "x", Class: "ansi-fg-green"}}},
+		{name: "style without text", input: "\x1b[31m\x1b[0mplain", wantPlain: "plain"},
+		{name: "cursor and erase sequences dropped", input: "\x1b[2K\x1b[1Gprogress 50%\x1b[?25l", wantPlain: "progress 50%"},
+		{name: "osc title and hyperlink dropped", input: "\x1b]0;title\x07see \x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\",
+			wantPlain: "see link"},
+		{name: "charset designation dropped", input: "\x1b(Bok", wantPlain: "ok"},
+		{name: "control characters dropped", input: "a\rb\x08c\x07d\x7f", wantPlain: "abcd"},
+		{name: "lone escape at end", input: "text\x1b", wantPlain: "text"},
+		{name: "unterminated csi", input: "text\x1b[31", wantPlain: "text"},
+		{name: "malformed csi keeps following text", input: "\x1b[31\x01red", wantPlain: "red"},
+		{name: "unterminated osc", input: "a\x1b]0;title", wantPlain: "a"},
+		{name: "html is left for the client to escape", input: "\x1b[31m<b>x</b>", wantPlain: "<b>x</b>",
+			wantSpans: []TextSpan{{Text: "<b>x</b>", Class: "ansi-fg-red"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plain, spans := parseANSI(tc.input)
+			assert.Equal(t, tc.wantPlain, plain)
+			assert.Equal(t, tc.wantSpans, spans)
+		})
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	t.Run("output keeps spans", func(t *testing.T) {
+		e := renderANSI(NewOutputEvent(processor.PhaseTask, "\x1b[32m+ added\x1b[0m"))
+		assert.Equal(t, "+ added", e.Text)
+		assert.Equal(t, []TextSpan{{Text: "+ added", Class: "ansi-fg-green"}}, e.Spans)
+		assert.Equal(t, e, renderANSI(e), "rendering is idempotent")
+	})
+
+	t.Run("other events are stripped only", func(t *testing.T) {
+		e := renderANSI(NewErrorEvent(processor.PhaseTask, "\x1b[31mERROR: boom\x1b[0m", false))
+		assert.Equal(t, "ERROR: boom", e.Text)
+		assert.Nil(t, e.Spans)
+	})
+}
+
+func TestReadProgressEvents_ANSI(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress-ansi.txt")
+	content := "# Ralphex Progress Log\nPlan: plan.md\n" + "------------------------------------------------------------\n\n" +
+		"--- task iteration 1 ---\n[26-01-22 10:00:01] \x1b[1mbold\x1b[0m text\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	events, err := ReadProgressEvents(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, "bold text", last.Text)
+	assert.Equal(t, []TextSpan{{Text: "bold", Class: "ansi-bold"}, {Text: " text"}}, last.Spans)
+}
@@ -1,10 +1,14 @@
 package web
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/tmaxmax/go-sse"
+
 	"github.com/umputun/ralphex/pkg/processor"
 )
 
@@ -20,6 +24,7 @@ type BroadcastLogger struct {
 	session     *Session
 	phase       processor.Phase
 	currentTask int // tracks current task number for boundary events
+	iteration   int // number of the current section, stamped on all events
 }
 
 // NewBroadcastLogger creates a logger that wraps inner and broadcasts to the session's SSE server.
@@ -46,7 +51,7 @@ func (b *BroadcastLogger) SetPhase(phase processor.Phase) {
 // Print writes a timestamped message and broadcasts it.
 func (b *BroadcastLogger) Print(format string, args ...any) {
 	b.inner.Print(format, args...)
-	b.broadcast(NewOutputEvent(b.phase, formatText(format, args...)))
+	b.broadcast(b.outputEvent(formatText(format, args...)))
 }
 
 // PrintRaw writes without timestamp and broadcasts it.
@@ -57,8 +62,10 @@ func (b *BroadcastLogger) PrintRaw(format string, args ...any) {
 
 // PrintSection writes a section header and broadcasts it.
 // emits task/iteration boundary events based on section type.
+// events broadcast from here on carry the section's iteration number.
 func (b *BroadcastLogger) PrintSection(section processor.Section) {
 	b.inner.PrintSection(section)
+	b.iteration = section.Iteration
 
 	// emit boundary events based on section type
 	switch section.Type {
@@ -90,7 +97,7 @@ func (b *BroadcastLogger) PrintSection(section processor.Section) {
 // PrintAligned writes text with timestamp on each line and broadcasts it.
 func (b *BroadcastLogger) PrintAligned(text string) {
 	b.inner.PrintAligned(text)
-	b.broadcast(NewOutputEvent(b.phase, text))
+	b.broadcast(b.outputEvent(text))
 
 	if signal := extractTerminalSignal(text); signal != "" {
 		b.broadcast(NewSignalEvent(b.phase, signal))
@@ -119,15 +126,64 @@ func (b *BroadcastLogger) LogDraftReview(action, feedback string) {
 	}
 }
 
+// LogHumanAction logs an action performed by a human and broadcasts it as a human action event.
+func (b *BroadcastLogger) LogHumanAction(actor, action string) {
+	b.inner.LogHumanAction(actor, action)
+	b.broadcast(NewHumanActionEvent(b.phase, actor, fmt.Sprintf("HUMAN ACTION (%s): %s", actor, action)))
+}
+
+// LogError logs an error encountered by the runner and broadcasts it as an error event.
+func (b *BroadcastLogger) LogError(err error, recoverable bool) {
+	b.inner.LogError(err, recoverable)
+	b.broadcast(NewErrorEvent(b.phase, "ERROR: "+err.Error(), recoverable))
+}
+
+// IsPaused reports whether the session was paused from the dashboard.
+func (b *BroadcastLogger) IsPaused() bool {
+	return b.session.IsPaused()
+}
+
+// WaitResume blocks until the session is resumed from the dashboard or ctx is canceled.
+func (b *BroadcastLogger) WaitResume(ctx context.Context) error {
+	return b.session.WaitResume(ctx)
+}
+
+// PauseActions returns the pause and resume requests made from the dashboard since the previous call.
+func (b *BroadcastLogger) PauseActions() []processor.PauseAction {
+	return b.session.PauseActions()
+}
+
+// RecordPrompt keeps the prompt sent to an executor in the session, so the dashboard can show it.
+func (b *BroadcastLogger) RecordPrompt(phase processor.Phase, prompt string) {
+	b.session.setPrompt(phase, prompt)
+}
+
 // Path returns the progress file path.
 func (b *BroadcastLogger) Path() string {
 	return b.inner.Path()
 }
 
+// outputEvent creates an output event for the text, or a debug event for debug lines.
+func (b *BroadcastLogger) outputEvent(text string) Event {
+	if strings.HasPrefix(text, debugPrefix) {
+		return NewDebugEvent(b.phase, text)
+	}
+	return NewOutputEvent(b.phase, text)
+}
+
 // broadcast sends an event to the session's SSE server for live streaming and replay.
-// errors are logged but not propagated since logging is the primary operation.
+// errors are logged but not propagated since logging is the primary operation,
+// events published after the session was closed on shutdown are dropped silently.
+// events below the session's log level are dropped, they are still written by the inner logger.
+// events without an iteration of their own get the current section's one.
 func (b *BroadcastLogger) broadcast(e Event) {
-	if err := b.session.Publish(e); err != nil {
+	if !b.session.logs(e) {
+		return
+	}
+	if e.Iteration == 0 {
+		e.Iteration = b.iteration
+	}
+	if err := b.session.Publish(e); err != nil && !errors.Is(err, sse.ErrProviderClosed) {
 		log.Printf("[WARN] failed to broadcast event: %v", err)
 	}
 }
@@ -1,13 +1,24 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
 
+	"github.com/umputun/ralphex/pkg/config"
+	"github.com/umputun/ralphex/pkg/executor"
 	"github.com/umputun/ralphex/pkg/processor"
 	"github.com/umputun/ralphex/pkg/processor/mocks"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 func TestNewBroadcastLogger(t *testing.T) {
@@ -222,6 +233,79 @@ func TestBroadcastLogger_PrintSection_IterationEvents(t *testing.T) {
 	require.Len(t, mockLogger.PrintSectionCalls(), 2)
 }
 
+func TestBroadcastLogger_Iteration(t *testing.T) {
+	for _, format := range []string{progress.FormatText, progress.FormatJSONL} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			oldWd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(dir))
+			t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+			logger, err := progress.NewLogger(progress.Config{PlanFile: "plan.md", Mode: "full", Branch: "main",
+				NoColor: true, Format: format}, testColors())
+			require.NoError(t, err)
+
+			session := NewSession("live", "/tmp/live.txt")
+			defer session.Close()
+			bl := NewBroadcastLogger(logger, session)
+			bl.Print("before any section")
+			bl.PrintSection(processor.NewTaskIterationSection(1))
+			bl.Print("working on task 1")
+			bl.PrintSection(processor.NewTaskIterationSection(2))
+			bl.Print("working on task 2")
+			bl.SetPhase(processor.PhaseReview)
+			bl.PrintSection(processor.NewClaudeReviewSection(3, ": critical/major"))
+			bl.Print("reviewing")
+			bl.PrintSection(processor.NewGenericSection("finalize"))
+			bl.Print("finalizing")
+			require.NoError(t, logger.Close())
+
+			// live events as streamed to clients, compared with the events reloaded from the progress file
+			writer := &mockMessageWriter{}
+			replayer := session.SSE.Provider.(*sse.Joe).Replayer
+			require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+			var live []Event
+			for _, msg := range writer.messages {
+				_, data, ok := strings.Cut(msg, "data: ")
+				require.True(t, ok, msg)
+				var e Event
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(data)), &e))
+				live = append(live, e)
+			}
+
+			iterations := func(events []Event) map[string]int {
+				res := map[string]int{}
+				for _, e := range events {
+					res[string(e.Type)+":"+e.Text] = e.Iteration
+				}
+				return res
+			}
+			liveIter := iterations(live)
+			assert.Equal(t, 0, liveIter["output:before any section"])
+			assert.Equal(t, 1, liveIter["output:working on task 1"])
+			assert.Equal(t, 1, liveIter["task_end:task 1 completed"])
+			assert.Equal(t, 2, liveIter["task_start:task iteration 2"])
+			assert.Equal(t, 2, liveIter["section:task iteration 2"])
+			assert.Equal(t, 2, liveIter["output:working on task 2"])
+			assert.Equal(t, 3, liveIter["iteration_start:claude review 3: critical/major"])
+			assert.Equal(t, 3, liveIter["output:reviewing"])
+			assert.Equal(t, 0, liveIter["section:finalize"])
+			assert.Equal(t, 0, liveIter["output:finalizing"])
+
+			reloaded, err := ReadProgressEvents(filepath.Join(dir, logger.Path()))
+			require.NoError(t, err)
+			reloadIter := iterations(reloaded)
+			for _, key := range []string{"output:before any section", "output:working on task 1", "task_start:task iteration 2",
+				"section:task iteration 2", "output:working on task 2", "section:claude review 3: critical/major",
+				"output:reviewing", "section:finalize", "output:finalizing"} {
+				require.Contains(t, reloadIter, key)
+				assert.Equal(t, liveIter[key], reloadIter[key], "iteration of %q survives a reload", key)
+			}
+		})
+	}
+}
+
 func TestBroadcastLogger_LogQuestion(t *testing.T) {
 	mockLogger := &mocks.LoggerMock{
 		LogQuestionFunc: func(string, []string) {},
@@ -281,6 +365,69 @@ func TestBroadcastLogger_LogDraftReview_ReviseWithFeedback(t *testing.T) {
 	assert.Equal(t, "Please add more details to Task 3", mockLogger.LogDraftReviewCalls()[0].Feedback)
 }
 
+func TestBroadcastLogger_LogHumanAction(t *testing.T) {
+	mockLogger := &mocks.LoggerMock{
+		LogHumanActionFunc: func(string, string) {},
+	}
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	bl := NewBroadcastLogger(mockLogger, session)
+
+	bl.LogHumanAction("user", "canceled run")
+
+	require.Len(t, mockLogger.LogHumanActionCalls(), 1)
+	assert.Equal(t, "user", mockLogger.LogHumanActionCalls()[0].Actor)
+	assert.Equal(t, "canceled run", mockLogger.LogHumanActionCalls()[0].Action)
+}
+
+func TestBroadcastLogger_LogError(t *testing.T) {
+	mockLogger := &mocks.LoggerMock{
+		LogErrorFunc: func(error, bool) {},
+	}
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	bl := NewBroadcastLogger(mockLogger, session)
+	bl.phase = processor.PhaseFinalize
+
+	assert.Nil(t, session.LastError())
+	bl.LogError(errors.New("finalize step failed: boom"), true)
+
+	require.Len(t, mockLogger.LogErrorCalls(), 1)
+	assert.True(t, mockLogger.LogErrorCalls()[0].Recoverable)
+	last := session.LastError()
+	require.NotNil(t, last)
+	assert.Equal(t, EventTypeError, last.Type)
+	assert.Equal(t, processor.PhaseFinalize, last.Phase)
+	assert.Equal(t, "ERROR: finalize step failed: boom", last.Text)
+	assert.True(t, last.Recoverable)
+}
+
+func TestBroadcastLogger_RunnerCodexError(t *testing.T) {
+	appCfg, err := config.Load(t.TempDir())
+	require.NoError(t, err)
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	bl := NewBroadcastLogger(progress.NewConsoleLogger(io.Discard, testColors(), true), session)
+
+	codex := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+		return executor.Result{Error: errors.New("codex exited with status 2")}
+	}}
+	claude := &mocks.ExecutorMock{RunFunc: func(context.Context, string) executor.Result {
+		return executor.Result{}
+	}}
+	r := processor.NewWithExecutors(processor.Config{Mode: processor.ModeCodexOnly, MaxIterations: 1,
+		CodexEnabled: true, AppConfig: appCfg}, bl, claude, codex)
+	require.Error(t, r.Run(context.Background()))
+
+	last := session.LastError()
+	require.NotNil(t, last, "error event should be broadcast")
+	assert.Equal(t, EventTypeError, last.Type)
+	assert.Equal(t, processor.PhaseCodex, last.Phase)
+	assert.Contains(t, last.Text, "codex execution: codex exited with status 2")
+	assert.False(t, last.Recoverable)
+	assert.Equal(t, last, newSessionInfo(session).LastError, "last error is exposed by the sessions API")
+}
+
 func TestExtractTerminalSignal(t *testing.T) {
 	cases := []struct {
 		name   string
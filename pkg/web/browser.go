@@ -0,0 +1,75 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// errHeadless is returned when there is no display to open a browser on.
+var errHeadless = errors.New("no display available")
+
+// browserOpener opens urls in the default browser with the platform opener command.
+type browserOpener struct {
+	goos   string                                  // target platform, runtime.GOOS by default
+	getenv func(key string) string                 // environment lookup, os.Getenv by default
+	run    func(name string, args ...string) error // starts the opener command without waiting for it
+}
+
+// newBrowserOpener makes a browserOpener for the current platform.
+func newBrowserOpener() *browserOpener {
+	return &browserOpener{goos: runtime.GOOS, getenv: os.Getenv, run: startCommand}
+}
+
+// Open opens url in the default browser.
+// returns errHeadless on unix-like systems without X11 or Wayland display, where opening can't work.
+func (o *browserOpener) Open(url string) error {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("can't open %s in a browser", url)
+	}
+	if o.headless() {
+		return errHeadless
+	}
+	name, args := browserCommand(o.goos, url)
+	if err := o.run(name, args...); err != nil {
+		return fmt.Errorf("run %s: %w", name, err)
+	}
+	return nil
+}
+
+// headless reports whether there is no display to open a browser on.
+// macOS and windows always have one, other systems need DISPLAY or WAYLAND_DISPLAY.
+func (o *browserOpener) headless() bool {
+	switch o.goos {
+	case "darwin", "windows":
+		return false
+	default:
+		return o.getenv("DISPLAY") == "" && o.getenv("WAYLAND_DISPLAY") == ""
+	}
+}
+
+// browserCommand returns the command opening url in the default browser on goos.
+func browserCommand(goos, url string) (name string, args []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		// the empty argument is the window title, start treats the first quoted argument as one
+		return "cmd", []string{"/c", "start", "", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// startCommand starts a command and reaps it in the background, the opener may outlive the call.
+func startCommand(name string, args ...string) error {
+	cmd := exec.Command(name, args...) //nolint:gosec,noctx // opener commands are fixed, the browser should outlive ralphex
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{goos: "darwin", wantName: "open", wantArgs: []string{"http://localhost:8080"}},
+		{goos: "windows", wantName: "cmd", wantArgs: []string{"/c", "start", "", "http://localhost:8080"}},
+		{goos: "linux", wantName: "xdg-open", wantArgs: []string{"http://localhost:8080"}},
+		{goos: "freebsd", wantName: "xdg-open", wantArgs: []string{"http://localhost:8080"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.goos, func(t *testing.T) {
+			name, args := browserCommand(tc.goos, "http://localhost:8080")
+			assert.Equal(t, tc.wantName, name)
+			assert.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
+
+func TestBrowserOpener_Open(t *testing.T) {
+	// newOpener returns an opener for goos with the given environment, recording the commands it runs
+	newOpener := func(goos string, env map[string]string, runErr error) (*browserOpener, *[][]string) {
+		var calls [][]string
+		return &browserOpener{
+			goos:   goos,
+			getenv: func(key string) string { return env[key] },
+			run: func(name string, args ...string) error {
+				calls = append(calls, append([]string{name}, args...))
+				return runErr
+			},
+		}, &calls
+	}
+
+	t.Run("opens url with platform command", func(t *testing.T) {
+		for _, goos := range []string{"darwin", "windows", "linux"} {
+			o, calls := newOpener(goos, map[string]string{"DISPLAY": ":0"}, nil)
+			require.NoError(t, o.Open("http://localhost:8080"))
+			name, args := browserCommand(goos, "http://localhost:8080")
+			assert.Equal(t, [][]string{append([]string{name}, args...)}, *calls, goos)
+		}
+	})
+
+	t.Run("wayland display is enough", func(t *testing.T) {
+		o, calls := newOpener("linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, nil)
+		require.NoError(t, o.Open("http://localhost:8080"))
+		assert.Len(t, *calls, 1)
+	})
+
+	t.Run("headless is skipped", func(t *testing.T) {
+		o, calls := newOpener("linux", nil, nil)
+		err := o.Open("http://localhost:8080")
+		require.ErrorIs(t, err, errHeadless)
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("socket url is rejected", func(t *testing.T) {
+		o, calls := newOpener("darwin", nil, nil)
+		err := o.Open("unix:/tmp/ralphex.sock")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unix:/tmp/ralphex.sock")
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("run error is returned", func(t *testing.T) {
+		o, _ := newOpener("darwin", nil, errors.New("not found"))
+		err := o.Open("http://localhost:8080")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "run open: not found")
+	})
+}
+
+func TestDashboard_OpenBrowser(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  DashboardConfig
+		want []string // opened urls
+	}{
+		{name: "default host", cfg: DashboardConfig{Port: 8080}, want: []string{"http://localhost:8080"}},
+		{name: "ipv6 host", cfg: DashboardConfig{Port: 9090, Host: "::1"}, want: []string{"http://[::1]:9090"}},
+		{name: "lan host", cfg: DashboardConfig{Port: 8080, Host: "192.168.1.10"}, want: []string{"http://192.168.1.10:8080"}},
+		{name: "socket", cfg: DashboardConfig{Port: 8080, Socket: "/tmp/ralphex.sock"}, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Colors = testColors()
+			tc.cfg.OpenBrowser = true
+			d := NewDashboard(tc.cfg)
+			require.NotNil(t, d.browser)
+
+			var opened []string
+			d.browser.goos = "darwin"
+			d.browser.run = func(_ string, args ...string) error {
+				opened = append(opened, args[len(args)-1])
+				return nil
+			}
+			d.openBrowser()
+			assert.Equal(t, tc.want, opened)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		d := NewDashboard(DashboardConfig{Port: 8080, Colors: testColors()})
+		assert.Nil(t, d.browser)
+		d.openBrowser() // no-op, must not panic
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/umputun/ralphex/pkg/processor"
@@ -17,37 +20,99 @@ const serverStartupTimeout = 100 * time.Millisecond
 
 // DashboardConfig holds configuration for dashboard initialization.
 type DashboardConfig struct {
-	BaseLog         processor.Logger // base progress logger
-	Port            int              // web server port
-	PlanFile        string           // path to plan file (empty for watch-only mode)
-	Branch          string           // current git branch
-	WatchDirs       []string         // CLI watch directories
-	ConfigWatchDirs []string         // config file watch directories
-	Colors          *progress.Colors // colors for output
+	BaseLog           processor.Logger // base progress logger
+	Port              int              // web server port
+	Host              string           // interface address to bind, empty uses DefaultHost
+	PlanFile          string           // path to plan file (empty for watch-only mode)
+	Branch            string           // current git branch
+	WatchDirs         []string         // CLI watch directories
+	ConfigWatchDirs   []string         // config file watch directories
+	Colors            *progress.Colors // colors for output
+	SessionRetention  time.Duration    // prune completed sessions older than this, 0 disables pruning
+	AuthToken         string           // token required to access the dashboard, empty disables auth
+	Socket            string           // unix socket path to listen on instead of Port
+	TailPollInterval  time.Duration    // how often watched progress files are polled, 0 uses the tailer default
+	ShutdownTimeout   time.Duration    // grace period for draining connections on shutdown, 0 uses the server default
+	KeepAliveInterval time.Duration    // idle time before SSE streams get a keep-alive comment, 0 uses the server default
+	WatchRecursive    bool             // scan subdirectories of watch dirs for progress files
+	FollowCompleted   bool             // keep tailing progress files of sessions that finished while watched
+	LazyLoadCompleted bool             // load content of sessions discovered finished on first access
+	ReadOnly          bool             // reject requests changing session state
+	CodexDisabled     bool             // codex review is off, its phase isn't offered as a filter
+	RateLimitPerMin   int              // max requests per minute changing session state, 0 disables the limit
+	MaxEventTextBytes int              // truncate event text longer than this before streaming, 0 disables
+	ProgressLog       io.Writer        // mirror events of watched sessions here, e.g. stdout, nil disables
+	ProgressLogLevel  string           // lowest level mirrored to ProgressLog, one of ProgressLogLevels
+	OpenBrowser       bool             // open the dashboard in the default browser once it's up
+	BuildInfo         BuildInfo        // version of the running binary, reported by /api/version
+	ConfigSources     []string         // config files loaded at startup, reported by /api/version
 }
 
 // Dashboard manages web server and file watching for progress monitoring.
 type Dashboard struct {
 	port            int
+	host            string
 	planFile        string
 	branch          string
 	baseLog         processor.Logger
 	watchDirs       []string
 	configWatchDirs []string
 	colors          *progress.Colors
+	retention       time.Duration
+	authToken       string
+	socket          string
+	pollInterval    time.Duration
+	shutdownTimeout time.Duration
+	keepAlive       time.Duration
+	watchRecursive  bool
+	followCompleted bool
+	lazyLoad        bool
+	readOnly        bool
+	codexDisabled   bool
+	rateLimitPerMin int
+	maxTextBytes    int
+	browser         *browserOpener // opens the dashboard once started, nil disables
+	buildInfo       BuildInfo
+	configSources   []string
+
+	progressLog      io.Writer
+	progressLogLevel string
 }
 
 // NewDashboard creates a new dashboard with the given configuration.
 func NewDashboard(cfg DashboardConfig) *Dashboard {
-	return &Dashboard{
+	d := &Dashboard{
 		port:            cfg.Port,
+		host:            cfg.Host,
 		planFile:        cfg.PlanFile,
 		branch:          cfg.Branch,
 		baseLog:         cfg.BaseLog,
 		watchDirs:       cfg.WatchDirs,
 		configWatchDirs: cfg.ConfigWatchDirs,
 		colors:          cfg.Colors,
+		retention:       cfg.SessionRetention,
+		authToken:       cfg.AuthToken,
+		socket:          cfg.Socket,
+		pollInterval:    cfg.TailPollInterval,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		keepAlive:       cfg.KeepAliveInterval,
+		watchRecursive:  cfg.WatchRecursive,
+		followCompleted: cfg.FollowCompleted,
+		lazyLoad:        cfg.LazyLoadCompleted,
+		readOnly:        cfg.ReadOnly,
+		codexDisabled:   cfg.CodexDisabled,
+		rateLimitPerMin: cfg.RateLimitPerMin,
+		maxTextBytes:    cfg.MaxEventTextBytes,
+		buildInfo:       cfg.BuildInfo,
+		configSources:   cfg.ConfigSources,
+
+		progressLog:      cfg.ProgressLog,
+		progressLogLevel: cfg.ProgressLogLevel,
 	}
+	if cfg.OpenBrowser {
+		d.browser = newBrowserOpener()
+	}
+	return d
 }
 
 // Start creates the web server and broadcast logger, starting the server in background.
@@ -56,7 +121,7 @@ func NewDashboard(cfg DashboardConfig) *Dashboard {
 func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 	// create session for SSE streaming (handles both live streaming and history replay)
 	session := NewSession("main", d.baseLog.Path())
-	broadcastLog := NewBroadcastLogger(d.baseLog, session)
+	session.maxTextBytes = d.maxTextBytes
 
 	// extract plan name for display
 	planName := "(no plan)"
@@ -65,10 +130,20 @@ func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 	}
 
 	cfg := ServerConfig{
-		Port:     d.port,
-		PlanName: planName,
-		Branch:   d.branch,
-		PlanFile: d.planFile,
+		Port:            d.port,
+		Host:            d.host,
+		PlanName:        planName,
+		Branch:          d.branch,
+		PlanFile:        d.planFile,
+		AuthToken:       d.authToken,
+		Socket:          d.socket,
+		ShutdownTimeout: d.shutdownTimeout,
+		KeepAlive:       d.keepAlive,
+		ReadOnly:        d.readOnly,
+		CodexDisabled:   d.codexDisabled,
+		RateLimitPerMin: d.rateLimitPerMin,
+		BuildInfo:       d.buildInfo,
+		ConfigSources:   d.configSources,
 	}
 
 	// determine if we should use multi-session mode
@@ -81,10 +156,17 @@ func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 	if useMultiSession {
 		// multi-session mode: use SessionManager and Watcher
 		sm := NewSessionManager()
+		sm.SetPollInterval(d.pollInterval)
+		sm.SetFollowCompleted(d.followCompleted)
+		sm.SetLazyLoadCompleted(d.lazyLoad)
+		sm.SetMaxEventTextBytes(d.maxTextBytes)
+		if err := d.setProgressLog(sm); err != nil {
+			return nil, err
+		}
 
 		// register the live execution session so dashboard uses it instead of creating a duplicate
 		// this ensures live events from BroadcastLogger go to the same session the dashboard displays
-		sm.Register(session)
+		session = sm.Register(session)
 
 		// resolve watch directories (CLI > config > cwd)
 		dirs := ResolveWatchDirs(d.watchDirs, d.configWatchDirs)
@@ -94,6 +176,10 @@ func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 		if err != nil {
 			return nil, fmt.Errorf("create watcher: %w", err)
 		}
+		watcher.retention = d.retention
+		if !d.watchRecursive {
+			watcher.depth = 0
+		}
 
 		srv, err = NewServerWithSessions(cfg, sm)
 		if err != nil {
@@ -108,8 +194,10 @@ func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 		}
 	}
 
+	broadcastLog := NewBroadcastLogger(d.baseLog, session)
+
 	// start server with startup check
-	srvErrCh, err := startServerAsync(ctx, srv, d.port)
+	srvErrCh, err := startServerAsync(ctx, srv)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +220,8 @@ func (d *Dashboard) Start(ctx context.Context) (processor.Logger, error) {
 		}
 	}()
 
-	d.colors.Info().Printf("web dashboard: http://localhost:%d\n", d.port)
+	d.colors.Info().Printf("web dashboard: %s\n", d.url())
+	d.openBrowser()
 	return broadcastLog, nil
 }
 
@@ -145,32 +234,84 @@ func (d *Dashboard) RunWatchOnly(ctx context.Context, dirs []string) error {
 	}
 
 	// setup server and watcher
-	srvErrCh, watchErrCh, err := setupWatchMode(ctx, d.port, dirs)
+	srvErrCh, watchErrCh, err := d.setupWatchMode(ctx, dirs)
 	if err != nil {
 		return err
 	}
 
 	// print startup info
-	printWatchInfo(dirs, d.port, d.colors)
+	printWatchInfo(dirs, d.url(), d.colors)
+	d.openBrowser()
 
 	// monitor for errors until shutdown
-	return monitorErrors(ctx, srvErrCh, watchErrCh, d.colors)
+	err = monitorErrors(ctx, srvErrCh, watchErrCh, d.colors)
+
+	// wait for the server to drain its clients, bounded by the shutdown timeout
+	<-srvErrCh
+	return err
+}
+
+// openBrowser opens the dashboard in the default browser, if enabled.
+// failures, e.g. in headless environments, are logged and don't stop the dashboard.
+func (d *Dashboard) openBrowser() {
+	if d.browser == nil {
+		return
+	}
+	if err := d.browser.Open(d.url()); err != nil {
+		d.colors.Info().Printf("can't open browser: %v, open %s manually\n", err, d.url())
+	}
+}
+
+// setProgressLog enables mirroring of watched sessions to the progress log, if configured.
+func (d *Dashboard) setProgressLog(sm *SessionManager) error {
+	if d.progressLog == nil {
+		return nil
+	}
+	level := d.progressLogLevel
+	if level == "" {
+		level = ProgressLogLevels[0]
+	}
+	if err := sm.SetProgressLog(d.progressLog, level); err != nil {
+		return fmt.Errorf("progress log: %w", err)
+	}
+	return nil
 }
 
 // setupWatchMode creates and starts the web server and file watcher for watch-only mode.
 // returns error channels for monitoring both components.
-func setupWatchMode(ctx context.Context, port int, dirs []string) (chan error, chan error, error) {
+func (d *Dashboard) setupWatchMode(ctx context.Context, dirs []string) (chan error, chan error, error) {
 	sm := NewSessionManager()
+	sm.SetPollInterval(d.pollInterval)
+	sm.SetFollowCompleted(d.followCompleted)
+	sm.SetLazyLoadCompleted(d.lazyLoad)
+	sm.SetMaxEventTextBytes(d.maxTextBytes)
+	if err := d.setProgressLog(sm); err != nil {
+		return nil, nil, err
+	}
 	watcher, err := NewWatcher(dirs, sm)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create watcher: %w", err)
 	}
+	watcher.retention = d.retention
+	if !d.watchRecursive {
+		watcher.depth = 0
+	}
 
 	serverCfg := ServerConfig{
-		Port:     port,
-		PlanName: "(watch mode)",
-		Branch:   "",
-		PlanFile: "",
+		Port:            d.port,
+		Host:            d.host,
+		PlanName:        "(watch mode)",
+		Branch:          "",
+		PlanFile:        "",
+		AuthToken:       d.authToken,
+		Socket:          d.socket,
+		ShutdownTimeout: d.shutdownTimeout,
+		KeepAlive:       d.keepAlive,
+		ReadOnly:        d.readOnly,
+		CodexDisabled:   d.codexDisabled,
+		RateLimitPerMin: d.rateLimitPerMin,
+		BuildInfo:       d.buildInfo,
+		ConfigSources:   d.configSources,
 	}
 
 	srv, err := NewServerWithSessions(serverCfg, sm)
@@ -179,7 +320,7 @@ func setupWatchMode(ctx context.Context, port int, dirs []string) (chan error, c
 	}
 
 	// start server with startup check
-	srvErrCh, err := startServerAsync(ctx, srv, port)
+	srvErrCh, err := startServerAsync(ctx, srv)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -198,7 +339,7 @@ func setupWatchMode(ctx context.Context, port int, dirs []string) (chan error, c
 
 // startServerAsync starts a web server in the background and waits briefly for startup errors.
 // returns the error channel for monitoring late errors, or an error if startup fails.
-func startServerAsync(ctx context.Context, srv *Server, port int) (chan error, error) {
+func startServerAsync(ctx context.Context, srv *Server) (chan error, error) {
 	errCh := make(chan error, 1)
 	go func() {
 		if err := srv.Start(ctx); err != nil {
@@ -211,7 +352,7 @@ func startServerAsync(ctx context.Context, srv *Server, port int) (chan error, e
 	select {
 	case err := <-errCh:
 		if err != nil {
-			return nil, fmt.Errorf("web server failed to start on port %d: %w", port, err)
+			return nil, fmt.Errorf("web server failed to start on %s: %w", srv.ListenAddr(), err)
 		}
 	case <-time.After(serverStartupTimeout):
 		// server started successfully
@@ -252,11 +393,28 @@ func monitorErrors(ctx context.Context, srvErrCh, watchErrCh chan error, colors
 }
 
 // printWatchInfo prints startup information for watch-only mode.
-func printWatchInfo(dirs []string, port int, colors *progress.Colors) {
+func printWatchInfo(dirs []string, url string, colors *progress.Colors) {
 	colors.Info().Printf("watch-only mode: monitoring %d directories\n", len(dirs))
 	for _, dir := range dirs {
 		colors.Info().Printf("  %s\n", dir)
 	}
-	colors.Info().Printf("web dashboard: http://localhost:%d\n", port)
+	colors.Info().Printf("web dashboard: %s\n", url)
 	colors.Info().Printf("press Ctrl+C to exit\n")
 }
+
+// url returns the dashboard address for startup messages.
+// hosts listening on all interfaces are shown as localhost, as that's where the dashboard is reachable for sure.
+func (d *Dashboard) url() string {
+	if d.socket != "" {
+		return "unix:" + d.socket
+	}
+	return DashboardURL(d.host, d.port)
+}
+
+// DashboardURL returns the http address of a dashboard bound to host and port, empty host is DefaultHost.
+func DashboardURL(host string, port int) string {
+	if ip := net.ParseIP(host); host == "" || host == DefaultHost || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -147,7 +147,8 @@ func TestSetupWatchMode(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	srvErrCh, watchErrCh, err := setupWatchMode(ctx, 0, []string{tmpDir})
+	d := NewDashboard(DashboardConfig{Port: 0, Colors: testColors()})
+	srvErrCh, watchErrCh, err := d.setupWatchMode(ctx, []string{tmpDir})
 	require.NoError(t, err)
 	assert.NotNil(t, srvErrCh)
 	assert.NotNil(t, watchErrCh)
@@ -167,7 +168,7 @@ func TestStartServerAsync_Success(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	errCh, err := startServerAsync(ctx, srv, 0)
+	errCh, err := startServerAsync(ctx, srv)
 	require.NoError(t, err)
 	assert.NotNil(t, errCh)
 
@@ -211,12 +212,12 @@ func TestStartServerAsync_PortInUse(t *testing.T) {
 	defer cancel()
 
 	// first server should start
-	errCh, err := startServerAsync(ctx, srv, 8999)
+	errCh, err := startServerAsync(ctx, srv)
 	require.NoError(t, err)
 	defer func() { <-errCh }()
 
 	// second server should fail
-	_, err = startServerAsync(ctx, srv2, 8999)
+	_, err = startServerAsync(ctx, srv2)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to start")
 }
@@ -273,5 +274,28 @@ func TestPrintWatchInfo(t *testing.T) {
 	colors := testColors()
 
 	// just verify it doesn't panic
-	printWatchInfo([]string{"/tmp", "/var"}, 8080, colors)
+	printWatchInfo([]string{"/tmp", "/var"}, "http://localhost:8080", colors)
+}
+
+func TestDashboardURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "", want: "http://localhost:8080"},
+		{host: "127.0.0.1", want: "http://localhost:8080"},
+		{host: "0.0.0.0", want: "http://localhost:8080"},
+		{host: "::", want: "http://localhost:8080"},
+		{host: "::1", want: "http://[::1]:8080"},
+		{host: "192.168.1.10", want: "http://192.168.1.10:8080"},
+		{host: "localhost", want: "http://localhost:8080"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.want, DashboardURL(tc.host, 8080))
+		})
+	}
+
+	d := NewDashboard(DashboardConfig{Port: 9090, Host: "::1"})
+	assert.Equal(t, "http://[::1]:9090", d.url())
 }
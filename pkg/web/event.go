@@ -4,7 +4,9 @@ package web
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/tmaxmax/go-sse"
 	"github.com/umputun/ralphex/pkg/processor"
@@ -16,6 +18,7 @@ type EventType string
 // event type constants for SSE streaming.
 const (
 	EventTypeOutput         EventType = "output"          // regular output line
+	EventTypeDebug          EventType = "debug"           // debug output line, written by runs with --debug
 	EventTypeSection        EventType = "section"         // section header
 	EventTypeError          EventType = "error"           // error message
 	EventTypeWarn           EventType = "warn"            // warning message
@@ -23,6 +26,9 @@ const (
 	EventTypeTaskStart      EventType = "task_start"      // task execution started
 	EventTypeTaskEnd        EventType = "task_end"        // task execution ended
 	EventTypeIterationStart EventType = "iteration_start" // review/codex iteration started
+	EventTypeHumanAction    EventType = "human_action"    // human intervention (answer, review, pause, cancel)
+	EventTypeStatus         EventType = "status"          // session status change (paused, resumed)
+	EventTypePlanReady      EventType = "plan_ready"      // plan creation produced a plan file
 )
 
 // Event represents a single event to be streamed to web clients.
@@ -35,6 +41,14 @@ type Event struct {
 	Signal       string          `json:"signal,omitempty"`
 	TaskNum      int             `json:"task_num,omitempty"`      // 1-based task index from plan (matches plan.tasks[].number)
 	IterationNum int             `json:"iteration_num,omitempty"` // 1-based iteration index for review/codex phases
+	Actor        string          `json:"actor,omitempty"`         // who performed a human action (e.g. "user", "web")
+	Recoverable  bool            `json:"recoverable,omitempty"`   // error events only, the error didn't stop the run
+	PlanFile     string          `json:"plan_file,omitempty"`     // plan_ready events only, the produced plan file
+	// Iteration is the number of the section that produced the event ("task iteration 3", "codex iteration 2"),
+	// 0 for events outside numbered sections
+	Iteration int `json:"iteration,omitempty"`
+	// Spans is the styled text of output lines with ANSI colors, Text then holds the same text without styling
+	Spans []TextSpan `json:"spans,omitempty"`
 }
 
 // NewOutputEvent creates an output event with current timestamp.
@@ -58,10 +72,21 @@ func NewSectionEvent(phase processor.Phase, name string) Event {
 	}
 }
 
-// NewErrorEvent creates an error event.
-func NewErrorEvent(phase processor.Phase, text string) Event {
+// NewErrorEvent creates an error event, recoverable errors didn't stop the run.
+func NewErrorEvent(phase processor.Phase, text string, recoverable bool) Event {
 	return Event{
-		Type:      EventTypeError,
+		Type:        EventTypeError,
+		Phase:       phase,
+		Text:        text,
+		Recoverable: recoverable,
+		Timestamp:   time.Now(),
+	}
+}
+
+// NewDebugEvent creates a debug output event.
+func NewDebugEvent(phase processor.Phase, text string) Event {
+	return Event{
+		Type:      EventTypeDebug,
 		Phase:     phase,
 		Text:      text,
 		Timestamp: time.Now(),
@@ -96,6 +121,7 @@ func NewTaskStartEvent(phase processor.Phase, taskNum int, text string) Event {
 		Phase:     phase,
 		Text:      text,
 		TaskNum:   taskNum,
+		Iteration: taskNum,
 		Timestamp: time.Now(),
 	}
 }
@@ -107,6 +133,7 @@ func NewTaskEndEvent(phase processor.Phase, taskNum int, text string) Event {
 		Phase:     phase,
 		Text:      text,
 		TaskNum:   taskNum,
+		Iteration: taskNum,
 		Timestamp: time.Now(),
 	}
 }
@@ -118,10 +145,31 @@ func NewIterationStartEvent(phase processor.Phase, iterationNum int, text string
 		Phase:        phase,
 		Text:         text,
 		IterationNum: iterationNum,
+		Iteration:    iterationNum,
 		Timestamp:    time.Now(),
 	}
 }
 
+// NewHumanActionEvent creates a human action event attributed to the given actor.
+func NewHumanActionEvent(phase processor.Phase, actor, text string) Event {
+	return Event{
+		Type:      EventTypeHumanAction,
+		Phase:     phase,
+		Text:      text,
+		Actor:     actor,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewStatusEvent creates a session status event, e.g. "paused" or "resumed".
+func NewStatusEvent(status string) Event {
+	return Event{
+		Type:      EventTypeStatus,
+		Text:      status,
+		Timestamp: time.Now(),
+	}
+}
+
 // MarshalJSON implements json.Marshaler for SSE streaming.
 // this allows Event to be used directly with json.Marshal.
 func (e Event) MarshalJSON() ([]byte, error) {
@@ -156,3 +204,38 @@ func (e Event) ToSSEMessage() *sse.Message {
 	msg.AppendData(string(jsonData))
 	return msg
 }
+
+// truncateEventText cuts event text longer than limit bytes, appending "… truncated N bytes" with the number
+// of bytes dropped. the cut never splits a UTF-8 character or leaves an unfinished ANSI escape sequence behind.
+// limit <= 0 disables truncation.
+func truncateEventText(e Event, limit int) Event {
+	if limit <= 0 || len(e.Text) <= limit {
+		return e
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(e.Text[cut]) {
+		cut--
+	}
+	if esc := strings.LastIndexByte(e.Text[:cut], '\x1b'); esc >= 0 && !escapeComplete(e.Text[esc:cut]) {
+		cut = esc
+	}
+	e.Text = fmt.Sprintf("%s… truncated %d bytes", e.Text[:cut], len(e.Text)-cut)
+	return e
+}
+
+// escapeComplete reports whether seq, starting with ESC, holds a whole escape sequence.
+// CSI sequences ("ESC [ params final") end with a byte in the 0x40-0x7e range.
+func escapeComplete(seq string) bool {
+	if len(seq) < 2 {
+		return false
+	}
+	if seq[1] != '[' {
+		return true
+	}
+	for i := 2; i < len(seq); i++ {
+		if seq[i] >= 0x40 && seq[i] <= 0x7e {
+			return true
+		}
+	}
+	return false
+}
@@ -35,11 +35,18 @@ func TestNewSectionEvent(t *testing.T) {
 }
 
 func TestNewErrorEvent(t *testing.T) {
-	e := NewErrorEvent(processor.PhaseCodex, "something failed")
+	e := NewErrorEvent(processor.PhaseCodex, "something failed", false)
 
 	assert.Equal(t, EventTypeError, e.Type)
 	assert.Equal(t, processor.PhaseCodex, e.Phase)
 	assert.Equal(t, "something failed", e.Text)
+	assert.False(t, e.Recoverable)
+
+	e = NewErrorEvent(processor.PhaseFinalize, "finalize step failed", true)
+	assert.True(t, e.Recoverable)
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"recoverable":true`)
 }
 
 func TestNewWarnEvent(t *testing.T) {
@@ -164,6 +171,16 @@ func TestNewIterationStartEvent(t *testing.T) {
 	assert.Zero(t, e.TaskNum)
 }
 
+func TestNewHumanActionEvent(t *testing.T) {
+	e := NewHumanActionEvent(processor.PhasePlan, "user", "HUMAN ACTION (user): draft review: accept")
+
+	assert.Equal(t, EventTypeHumanAction, e.Type)
+	assert.Equal(t, processor.PhasePlan, e.Phase)
+	assert.Equal(t, "user", e.Actor)
+	assert.Equal(t, "HUMAN ACTION (user): draft review: accept", e.Text)
+	assert.False(t, e.Timestamp.IsZero())
+}
+
 func TestEvent_JSON_TaskAndIterationFields(t *testing.T) {
 	t.Run("task event includes task_num", func(t *testing.T) {
 		e := NewTaskStartEvent(processor.PhaseTask, 7, "task iteration 7")
@@ -255,3 +272,25 @@ func TestEvent_ToSSEMessage(t *testing.T) {
 		assert.Contains(t, string(data), "task_start")
 	})
 }
+
+func TestTruncateEventText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{name: "disabled", text: "0123456789", limit: 0, want: "0123456789"},
+		{name: "within limit", text: "0123456789", limit: 10, want: "0123456789"},
+		{name: "over limit", text: "0123456789", limit: 4, want: "0123… truncated 6 bytes"},
+		{name: "keeps utf-8 characters whole", text: "ab✓cd", limit: 3, want: "ab… truncated 5 bytes"},
+		{name: "drops unfinished escape sequence", text: "ok \x1b[31mred\x1b[0m", limit: 6, want: "ok … truncated 12 bytes"},
+		{name: "keeps finished escape sequence", text: "ok \x1b[31mred\x1b[0m", limit: 10, want: "ok \x1b[31mre… truncated 5 bytes"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := truncateEventText(NewOutputEvent(processor.PhaseTask, tc.text), tc.limit)
+			assert.Equal(t, tc.want, e.Text)
+		})
+	}
+}
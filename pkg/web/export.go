@@ -0,0 +1,114 @@
+package web
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+// exportManifestName is the name of the archive entry describing the exported sessions
+const exportManifestName = "manifest.json"
+
+// exportEntry describes an exported session in the archive manifest.
+type exportEntry struct {
+	SessionInfo
+	Files []string `json:"files"` // archive entries of the session's progress file and its rotated parts, oldest first
+}
+
+// handleExport streams a zip archive with the progress files of all sessions, including their rotated parts,
+// each under a directory named by the session ID, followed by manifest.json with the session metadata.
+// ?state=<state> limits the archive to sessions in that state, e.g. completed.
+// the archive is written while files are read, so it is never held in memory; files that can't be read
+// are left out of the archive and the manifest.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	state := SessionState(r.URL.Query().Get("state"))
+	switch state {
+	case "", SessionStateActive, SessionStateCompleted, SessionStateCanceled, SessionStateFailed:
+	default:
+		http.Error(w, fmt.Sprintf("invalid state %q", state), http.StatusBadRequest)
+		return
+	}
+
+	var sessions []*Session
+	switch {
+	case s.sm != nil:
+		sessions = s.sm.All()
+	case s.session != nil:
+		sessions = []*Session{s.session}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].GetLastModified().After(sessions[j].GetLastModified())
+	})
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", "ralphex-sessions-"+time.Now().Format("2006-01-02")+".zip"))
+
+	zw := zip.NewWriter(w)
+	manifest := make([]exportEntry, 0, len(sessions))
+	for _, session := range sessions {
+		if state != "" && session.GetState() != state {
+			continue
+		}
+		entry := exportEntry{SessionInfo: newSessionInfo(session), Files: []string{}}
+		progressPath := session.GetPath()
+		for _, file := range append(progress.RotatedParts(progressPath), progressPath) {
+			name := path.Join(session.ID, filepath.Base(file))
+			if err := addExportFile(zw, name, file); err != nil {
+				// the response is already streaming, a broken archive is all the client can get
+				log.Printf("[WARN] failed to export %s: %v", file, err)
+				continue
+			}
+			entry.Files = append(entry.Files, name)
+		}
+		manifest = append(manifest, entry)
+	}
+
+	mw, err := zw.Create(exportManifestName)
+	if err != nil {
+		log.Printf("[WARN] failed to export manifest: %v", err)
+		return
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		log.Printf("[WARN] failed to export manifest: %v", err)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("[WARN] failed to finish export archive: %v", err)
+	}
+}
+
+// addExportFile copies a file into the archive under name, keeping its modification time.
+// a file that can't be opened is skipped without writing an entry.
+func addExportFile(zw *zip.Writer, name, file string) error {
+	f, err := os.Open(file) //nolint:gosec // path of a discovered session
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat file: %w", err)
+	}
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: info.ModTime()})
+	if err != nil {
+		return fmt.Errorf("create entry: %w", err)
+	}
+	if _, err := io.Copy(fw, f); err != nil {
+		return fmt.Errorf("copy file: %w", err)
+	}
+	return nil
+}
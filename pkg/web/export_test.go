@@ -0,0 +1,112 @@
+package web
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+func TestServer_HandleExport(t *testing.T) {
+	dir := t.TempDir()
+	progressFile := func(name, plan, footer string) string {
+		path := filepath.Join(dir, name)
+		content := "# Ralphex Progress Log\nPlan: " + plan + "\nBranch: main\nMode: full\nStarted: 2026-01-22 10:00:00\n" +
+			strings.Repeat("-", 60) + "\n\n[26-01-22 10:00:01] working on " + plan + "\n\n" + strings.Repeat("-", 60) + "\n" + footer + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	donePath := progressFile("progress-done.txt", "docs/plans/done.md", "Completed: 2026-01-22 10:05:00 (5m0s)")
+	require.NoError(t, os.WriteFile(progress.RotatedPath(donePath, 1), []byte("earlier part\n"), 0o600))
+	failedPath := progressFile("progress-broken.txt", "docs/plans/broken.md", "Failed: 2026-01-22 10:06:00 (6m0s)")
+
+	sm := NewSessionManager()
+	defer sm.Close()
+	_, err := sm.Discover(dir)
+	require.NoError(t, err)
+	srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+	require.NoError(t, err)
+	doneID, failedID := sessionIDFromPath(donePath), sessionIDFromPath(failedPath)
+
+	// export requests the archive and returns its entries by name along with the decoded manifest
+	export := func(t *testing.T, query string) (map[string]string, []exportEntry) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/export"+query, http.NoBody)
+		w := httptest.NewRecorder()
+		srv.handleExport(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="ralphex-sessions-`)
+
+		zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		entries := make(map[string]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, rc.Close())
+			require.NoError(t, err)
+			entries[f.Name] = string(data)
+		}
+		require.Contains(t, entries, exportManifestName)
+		var manifest []exportEntry
+		require.NoError(t, json.Unmarshal([]byte(entries[exportManifestName]), &manifest))
+		return entries, manifest
+	}
+
+	t.Run("all sessions", func(t *testing.T) {
+		entries, manifest := export(t, "")
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, []string{doneID + "/progress-done.1.txt", doneID + "/progress-done.txt",
+			failedID + "/progress-broken.txt", exportManifestName}, names)
+		doneContent, err := os.ReadFile(donePath) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Equal(t, string(doneContent), entries[doneID+"/progress-done.txt"])
+		assert.Equal(t, "earlier part\n", entries[doneID+"/progress-done.1.txt"])
+
+		require.Len(t, manifest, 2)
+		byID := map[string]exportEntry{manifest[0].ID: manifest[0], manifest[1].ID: manifest[1]}
+		assert.Equal(t, SessionStateCompleted, byID[doneID].State)
+		assert.Equal(t, "docs/plans/done.md", byID[doneID].PlanPath)
+		assert.Equal(t, []string{doneID + "/progress-done.1.txt", doneID + "/progress-done.txt"}, byID[doneID].Files)
+		assert.Equal(t, SessionStateFailed, byID[failedID].State)
+		assert.Equal(t, []string{failedID + "/progress-broken.txt"}, byID[failedID].Files)
+	})
+
+	t.Run("completed only", func(t *testing.T) {
+		entries, manifest := export(t, "?state=completed")
+		assert.Len(t, entries, 3)
+		assert.NotContains(t, entries, failedID+"/progress-broken.txt")
+		require.Len(t, manifest, 1)
+		assert.Equal(t, doneID, manifest[0].ID)
+	})
+
+	t.Run("no matching sessions", func(t *testing.T) {
+		entries, manifest := export(t, "?state=active")
+		assert.Len(t, entries, 1)
+		assert.Empty(t, manifest)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/export?state=done", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.handleExport(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `invalid state "done"`)
+	})
+}
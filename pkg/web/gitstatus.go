@@ -0,0 +1,68 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/umputun/ralphex/pkg/git"
+)
+
+// fileStatus is a file with uncommitted changes in the session status response.
+type fileStatus struct {
+	Path   string `json:"path"`   // path relative to the repository root
+	Status string `json:"status"` // two-letter status as in git status --porcelain, e.g. " M", "A ", "??"
+}
+
+// handleSessionStatus serves the files changed but not yet committed in the session's working tree,
+// like git status --porcelain, so reviewers can follow what a running session touches.
+// worktree sessions report their worktree, other sessions the repository holding the progress file.
+// returns 404 if the session is unknown, its directory was removed or is not a git repository.
+func (s *Server) handleSessionStatus(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	dir := sessionWorkDir(session)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "session directory no longer exists", http.StatusNotFound)
+		return
+	}
+	gitSvc, err := git.NewService(dir, gitLogger{})
+	if err != nil {
+		log.Printf("[WARN] failed to open repository for session %s: %v", sessionID, err)
+		http.Error(w, "session directory is not a git repository", http.StatusNotFound)
+		return
+	}
+	status, err := gitSvc.Status()
+	if err != nil {
+		log.Printf("[WARN] failed to get git status of session %s: %v", sessionID, err)
+		http.Error(w, "unable to get git status", http.StatusInternalServerError)
+		return
+	}
+
+	files := make([]fileStatus, 0, len(status))
+	for _, f := range status {
+		files = append(files, fileStatus{Path: f.Path, Status: f.Status})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(files)
+}
+
+// sessionWorkDir returns the directory the session works in: its worktree if the progress header records one,
+// otherwise the directory of the progress file.
+func sessionWorkDir(session *Session) string {
+	// header is parsed on demand since single-session mode doesn't populate metadata
+	path := session.GetPath()
+	if meta, err := ParseProgressHeader(path); err == nil && meta.Worktree != "" {
+		return meta.Worktree
+	}
+	return filepath.Dir(path)
+}
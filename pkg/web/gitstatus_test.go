@@ -0,0 +1,115 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HandleSessionStatus(t *testing.T) {
+	// initRepo creates a repository with committed README.md and .gitignore ignoring progress files
+	initRepo := func(t *testing.T) (*gogit.Repository, string) {
+		t.Helper()
+		dir := t.TempDir()
+		repo, err := gogit.PlainInit(dir, false)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Test\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("progress*.txt\n"), 0o600))
+		wt, err := repo.Worktree()
+		require.NoError(t, err)
+		require.NoError(t, wt.AddGlob("."))
+		_, err = wt.Commit("initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return repo, dir
+	}
+
+	// serve writes a progress file with the given extra header lines and returns a single-session server
+	serve := func(t *testing.T, dir, headerExtra string) *Server {
+		t.Helper()
+		progressPath := filepath.Join(dir, "progress-plan.txt")
+		require.NoError(t, os.WriteFile(progressPath, []byte("# Ralphex Progress Log\nPlan: plan.md\nBranch: main\n"+
+			headerExtra+"Mode: full\nStarted: 2026-01-22 10:30:00\n"+strings.Repeat("-", 60)+"\n"), 0o600))
+		session := NewSession("main", progressPath)
+		t.Cleanup(session.Close)
+		srv, err := NewServer(ServerConfig{Port: 8080}, session)
+		require.NoError(t, err)
+		return srv
+	}
+
+	request := func(srv *Server, sessionID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/status", http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionStatus(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) []fileStatus {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var files []fileStatus
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &files))
+		return files
+	}
+
+	t.Run("lists changed files", func(t *testing.T) {
+		repo, dir := initRepo(t)
+		srv := serve(t, dir, "")
+		assert.Equal(t, "[]\n", request(srv, "main").Body.String(), "clean tree")
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "pkg"), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pkg", "new.go"), []byte("package pkg\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "staged.go"), []byte("package main\n"), 0o600))
+		wt, err := repo.Worktree()
+		require.NoError(t, err)
+		_, err = wt.Add("staged.go")
+		require.NoError(t, err)
+
+		files := decode(t, request(srv, "main"))
+		assert.Equal(t, []fileStatus{{Path: "README.md", Status: " M"}, {Path: "pkg/new.go", Status: "??"},
+			{Path: "staged.go", Status: "A "}}, files)
+	})
+
+	t.Run("worktree session", func(t *testing.T) {
+		_, wtDir := initRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(wtDir, "worktree.go"), []byte("package main\n"), 0o600))
+
+		// progress file outside the worktree, in a directory that is not a repository
+		srv := serve(t, t.TempDir(), "Worktree: "+wtDir+"\n")
+		files := decode(t, request(srv, "main"))
+		assert.Equal(t, []fileStatus{{Path: "worktree.go", Status: "??"}}, files)
+	})
+
+	t.Run("not a git repository", func(t *testing.T) {
+		srv := serve(t, t.TempDir(), "")
+		w := request(srv, "main")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "session directory is not a git repository")
+	})
+
+	t.Run("deleted worktree", func(t *testing.T) {
+		srv := serve(t, t.TempDir(), "Worktree: "+filepath.Join(t.TempDir(), "removed")+"\n")
+		w := request(srv, "main")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "session directory no longer exists")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		srv := serve(t, t.TempDir(), "")
+		assert.Equal(t, http.StatusNotFound, request(srv, "other").Code)
+	})
+}
@@ -0,0 +1,144 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+)
+
+// hubSubscriberBuffer is the capacity of each hub subscriber's event channel.
+// events are dropped for subscribers that fall this far behind.
+const hubSubscriberBuffer = 64
+
+// LifecycleEventType represents the type of session lifecycle event streamed by the Hub.
+type LifecycleEventType string
+
+// lifecycle event type constants for the management stream.
+const (
+	LifecycleSessionAdded        LifecycleEventType = "session_added"         // session discovered or registered
+	LifecycleSessionUpdated      LifecycleEventType = "session_updated"       // progress file or metadata changed
+	LifecycleSessionRemoved      LifecycleEventType = "session_removed"       // session removed, evicted or pruned
+	LifecycleSessionStateChanged LifecycleEventType = "session_state_changed" // session became active or completed
+)
+
+// LifecycleEvent is a session lifecycle notification sent to management stream clients.
+type LifecycleEvent struct {
+	Type      LifecycleEventType `json:"type"`
+	SessionID string             `json:"sessionId"`
+	Session   *SessionInfo       `json:"session,omitempty"`   // current session info, not set for removals
+	PrevState SessionState       `json:"prevState,omitempty"` // previous state for state changes
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// Hub fans out session lifecycle events to management stream subscribers.
+// unlike per-session streams it keeps no history, clients fetch the current list from /api/sessions.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[chan LifecycleEvent]struct{}
+	closed bool
+}
+
+// NewHub creates a management hub with no subscribers.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[chan LifecycleEvent]struct{})}
+}
+
+// Subscribe registers a subscriber and returns its event channel with a function to unsubscribe.
+// the channel is closed on unsubscribe or when the hub is closed.
+func (h *Hub) Subscribe() (events <-chan LifecycleEvent, unsubscribe func()) {
+	ch := make(chan LifecycleEvent, hubSubscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subs[ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish sends a lifecycle event to all subscribers without blocking.
+// events are dropped for subscribers with a full buffer and after Close.
+func (h *Hub) Publish(e LifecycleEvent) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("[WARN] management stream subscriber is too slow, dropped %s event for %s", e.Type, e.SessionID)
+		}
+	}
+}
+
+// ServeHTTP streams lifecycle events to the client as SSE until the request is done or the hub is closed.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sess, err := sse.Upgrade(w, r)
+	if err != nil {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := h.Subscribe()
+	defer unsubscribe()
+
+	// send headers right away so the client sees the stream open
+	if err := sess.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Printf("[WARN] failed to encode lifecycle event: %v", err)
+				continue
+			}
+			msg := &sse.Message{}
+			msg.AppendData(string(data))
+			if err := sess.Send(msg); err != nil {
+				return
+			}
+			if err := sess.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close closes all subscriber channels, ending their streams. later subscribers get a closed channel.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for ch := range h.subs {
+		close(ch)
+	}
+	h.subs = nil
+}
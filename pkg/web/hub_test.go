@@ -0,0 +1,123 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextLifecycleEvent waits for the next event on ch, failing the test on timeout or closed channel.
+func nextLifecycleEvent(t *testing.T, ch <-chan LifecycleEvent) LifecycleEvent {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for lifecycle event")
+		return LifecycleEvent{}
+	}
+}
+
+func TestHub_PublishSubscribe(t *testing.T) {
+	hub := NewHub()
+	defer hub.Close()
+
+	first, unsubFirst := hub.Subscribe()
+	second, unsubSecond := hub.Subscribe()
+	defer unsubSecond()
+
+	hub.Publish(LifecycleEvent{Type: LifecycleSessionAdded, SessionID: "a"})
+	for _, ch := range []<-chan LifecycleEvent{first, second} {
+		e := nextLifecycleEvent(t, ch)
+		assert.Equal(t, LifecycleSessionAdded, e.Type)
+		assert.Equal(t, "a", e.SessionID)
+		assert.False(t, e.Timestamp.IsZero(), "timestamp set on publish")
+	}
+
+	unsubFirst()
+	_, ok := <-first
+	assert.False(t, ok, "channel closed on unsubscribe")
+	unsubFirst() // second call is a no-op
+
+	hub.Publish(LifecycleEvent{Type: LifecycleSessionRemoved, SessionID: "a"})
+	assert.Equal(t, LifecycleSessionRemoved, nextLifecycleEvent(t, second).Type)
+}
+
+func TestHub_SlowSubscriberDropsEvents(t *testing.T) {
+	hub := NewHub()
+	defer hub.Close()
+	ch, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	for range hubSubscriberBuffer + 1 {
+		hub.Publish(LifecycleEvent{Type: LifecycleSessionUpdated, SessionID: "a"}) // must not block
+	}
+	assert.Len(t, ch, hubSubscriberBuffer)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe()
+	hub.Close()
+
+	_, ok := <-ch
+	assert.False(t, ok, "subscriber channel closed")
+	unsubscribe() // safe after close
+	hub.Close()   // idempotent
+	hub.Publish(LifecycleEvent{Type: LifecycleSessionAdded, SessionID: "a"})
+
+	late, _ := hub.Subscribe()
+	_, ok = <-late
+	assert.False(t, ok, "subscribing to a closed hub returns a closed channel")
+}
+
+func TestHub_ServeHTTP(t *testing.T) {
+	hub := NewHub()
+	ts := httptest.NewServer(hub)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, http.NoBody)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// headers are flushed after subscribing, so the event can't be missed
+	hub.Publish(LifecycleEvent{Type: LifecycleSessionStateChanged, SessionID: "s1", PrevState: SessionStateActive,
+		Session: &SessionInfo{ID: "s1", State: SessionStateCompleted}})
+
+	reader := bufio.NewReader(resp.Body)
+	var data string
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		data, _ = strings.CutPrefix(strings.TrimSpace(line), "data: ")
+	}
+
+	var e LifecycleEvent
+	require.NoError(t, json.Unmarshal([]byte(data), &e))
+	assert.Equal(t, LifecycleSessionStateChanged, e.Type)
+	assert.Equal(t, "s1", e.SessionID)
+	assert.Equal(t, SessionStateActive, e.PrevState)
+	require.NotNil(t, e.Session)
+	assert.Equal(t, SessionStateCompleted, e.Session.State)
+
+	// closing the hub ends the stream
+	hub.Close()
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "\n", string(rest), "only the message terminator left")
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+// iterationResult is the response of the session iteration endpoint.
+type iterationResult struct {
+	Phase     processor.Phase `json:"phase"`
+	Iteration int             `json:"iteration"`
+	Section   string          `json:"section"` // section header of the iteration, e.g. "task iteration 3"
+	Events    []Event         `json:"events"`
+}
+
+// iterationCollector picks the events of one iteration from progress events, fed in file order.
+// the iteration starts after the first section of the phase stamped with its number
+// and ends at the next section or task/iteration boundary.
+type iterationCollector struct {
+	phase     processor.Phase
+	iteration int
+
+	section    string // header of the found iteration, empty until found
+	collecting bool
+	events     []Event
+}
+
+// add processes a single event.
+func (c *iterationCollector) add(e Event) error {
+	switch e.Type {
+	case EventTypeSection:
+		if c.section == "" && e.Phase == c.phase && e.Iteration == c.iteration {
+			c.section, c.collecting = e.Section, true
+			return nil
+		}
+		c.collecting = false
+	case EventTypeTaskStart, EventTypeIterationStart:
+		c.collecting = false
+	}
+	if c.collecting {
+		c.events = append(c.events, e)
+	}
+	return nil
+}
+
+// handleSessionIteration returns the events of a single iteration of a session, e.g. "task iteration 3",
+// read from its progress file. the phase query parameter selects the phase, task by default.
+// returns 404 if the session has no such iteration.
+func (s *Server) handleSessionIteration(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || n < 1 {
+		http.Error(w, "invalid iteration number", http.StatusBadRequest)
+		return
+	}
+	phase := processor.Phase(r.URL.Query().Get("phase"))
+	switch phase {
+	case "":
+		phase = processor.PhaseTask
+	case processor.PhaseTask, processor.PhaseReview, processor.PhaseCodex, processor.PhasePlan:
+	default:
+		http.Error(w, "invalid phase: "+string(phase), http.StatusBadRequest)
+		return
+	}
+
+	c := iterationCollector{phase: phase, iteration: n}
+	path := session.GetPath()
+	for _, part := range append(progress.RotatedParts(path), path) {
+		if _, err := readProgressEvents(part, 0, 0, c.add); err != nil {
+			log.Printf("[WARN] failed to read iteration of session %s: %v", sessionID, err)
+			http.Error(w, "unable to read progress file", http.StatusInternalServerError)
+			return
+		}
+	}
+	if c.section == "" {
+		http.Error(w, "iteration not found: "+strconv.Itoa(n), http.StatusNotFound)
+		return
+	}
+	if c.events == nil {
+		c.events = []Event{}
+	}
+
+	data, err := json.Marshal(iterationResult{Phase: phase, Iteration: n, Section: c.section, Events: c.events})
+	if err != nil {
+		log.Printf("[WARN] failed to encode iteration: %v", err)
+		http.Error(w, "unable to encode iteration", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
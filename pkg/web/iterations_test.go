@@ -0,0 +1,121 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestServer_HandleSessionIteration(t *testing.T) {
+	content := "# Ralphex Progress Log\nPlan: docs/plans/feature.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n" +
+		"--- task iteration 1 ---\n" +
+		"[26-01-22 10:00:01] first task started\n" +
+		"[26-01-22 10:00:02] first task done\n" +
+		"--- task iteration 2 ---\n" +
+		"[26-01-22 10:01:01] second task started\n" +
+		"[26-01-22 10:01:02] ERROR: tests failed\n" +
+		"[26-01-22 10:01:03] second task fixed\n" +
+		"--- task iteration 3 ---\n" +
+		"[26-01-22 10:02:01] third task\n" +
+		"--- claude review 1: all findings ---\n" +
+		"[26-01-22 10:03:01] review found an issue\n" +
+		"--- codex iteration 1 ---\n" +
+		"[26-01-22 10:04:01] codex output\n"
+	progressPath := filepath.Join(t.TempDir(), "progress-feature.txt")
+	require.NoError(t, os.WriteFile(progressPath, []byte(content), 0o600))
+
+	session := NewSession("main", progressPath)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(t *testing.T, sessionID, n, query string) (int, iterationResult) {
+		t.Helper()
+		target := "/api/sessions/" + sessionID + "/iterations/" + n
+		if query != "" {
+			target += "?" + query
+		}
+		req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		req.SetPathValue("id", sessionID)
+		req.SetPathValue("n", n)
+		w := httptest.NewRecorder()
+		srv.handleSessionIteration(w, req)
+		var res iterationResult
+		if w.Code == http.StatusOK {
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		}
+		return w.Code, res
+	}
+	texts := func(events []Event) []string {
+		res := make([]string, 0, len(events))
+		for _, e := range events {
+			res = append(res, e.Text)
+		}
+		return res
+	}
+
+	t.Run("middle task iteration", func(t *testing.T) {
+		code, res := request(t, "main", "2", "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, processor.PhaseTask, res.Phase)
+		assert.Equal(t, 2, res.Iteration)
+		assert.Equal(t, "task iteration 2", res.Section)
+		assert.Equal(t, []string{"second task started", "ERROR: tests failed", "second task fixed"}, texts(res.Events))
+		assert.Equal(t, EventTypeError, res.Events[1].Type)
+		for _, e := range res.Events {
+			assert.Equal(t, 2, e.Iteration)
+		}
+	})
+
+	t.Run("first and last task iterations", func(t *testing.T) {
+		code, res := request(t, "main", "1", "phase=task")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []string{"first task started", "first task done"}, texts(res.Events))
+
+		code, res = request(t, "main", "3", "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []string{"third task"}, texts(res.Events), "review section ends the last task iteration")
+	})
+
+	t.Run("other phases", func(t *testing.T) {
+		code, res := request(t, "main", "1", "phase=review")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "claude review 1: all findings", res.Section)
+		assert.Equal(t, []string{"review found an issue"}, texts(res.Events))
+
+		code, res = request(t, "main", "1", "phase=codex")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []string{"codex output"}, texts(res.Events))
+	})
+
+	t.Run("out of range iteration", func(t *testing.T) {
+		code, _ := request(t, "main", "4", "")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _ = request(t, "main", "2", "phase=codex")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("invalid iteration or phase", func(t *testing.T) {
+		for _, n := range []string{"0", "-1", "abc"} {
+			code, _ := request(t, "main", n, "")
+			assert.Equal(t, http.StatusBadRequest, code, n)
+		}
+		code, _ := request(t, "main", "1", "phase=finalize")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		code, _ := request(t, "missing", "1", "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
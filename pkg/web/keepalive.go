@@ -0,0 +1,77 @@
+package web
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+)
+
+// defaultKeepAliveInterval is the idle time after which SSE streams get a keep-alive comment when no
+// interval is configured, below the 30-60s idle timeouts common for reverse proxies.
+const defaultKeepAliveInterval = 20 * time.Second
+
+// keepAliveComment is the SSE comment written to idle streams, EventSource clients ignore comments.
+const keepAliveComment = "keep-alive"
+
+// keepAliveWriter wraps the client of an SSE stream and writes a keep-alive comment whenever nothing was
+// flushed to it for the interval, so proxies don't close quiet connections. comments go to this client only,
+// they are never published to the session, so they don't take replay buffer space or count as dropped events.
+// the provider and the keep-alive loop write from different goroutines, mu serializes them.
+type keepAliveWriter struct {
+	mu        sync.Mutex
+	client    sse.MessageWriter
+	lastFlush time.Time
+}
+
+// newKeepAliveWriter creates a keep-alive writer for the client, counting idle time from now.
+func newKeepAliveWriter(client sse.MessageWriter) *keepAliveWriter {
+	return &keepAliveWriter{client: client, lastFlush: time.Now()}
+}
+
+// Send sends the message to the client.
+func (k *keepAliveWriter) Send(m *sse.Message) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.client.Send(m) //nolint:wrapcheck // pass through client errors as-is
+}
+
+// Flush flushes sent messages to the client and resets the idle time.
+func (k *keepAliveWriter) Flush() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.lastFlush = time.Now()
+	return k.client.Flush() //nolint:wrapcheck // pass through client errors as-is
+}
+
+// run writes a keep-alive comment each time the stream was idle for interval,
+// until ctx is canceled or writing to the client fails.
+func (k *keepAliveWriter) run(ctx context.Context, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		k.mu.Lock()
+		idle := time.Since(k.lastFlush)
+		var err error
+		if idle >= interval {
+			msg := &sse.Message{}
+			msg.AppendComment(keepAliveComment)
+			if err = k.client.Send(msg); err == nil {
+				err = k.client.Flush()
+			}
+			k.lastFlush, idle = time.Now(), 0
+		}
+		k.mu.Unlock()
+		if err != nil {
+			return
+		}
+		timer.Reset(interval - idle)
+	}
+}
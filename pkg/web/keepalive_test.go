@@ -0,0 +1,104 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// streamRecorder is a response writer safe to read while a handler streams to it.
+type streamRecorder struct {
+	mu     sync.Mutex
+	header http.Header
+	body   strings.Builder
+}
+
+func (r *streamRecorder) Header() http.Header { return r.header }
+
+func (r *streamRecorder) WriteHeader(int) {}
+
+func (r *streamRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body.Write(p)
+}
+
+func (r *streamRecorder) Flush() {}
+
+func (r *streamRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body.String()
+}
+
+func TestServer_KeepAlive(t *testing.T) {
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, "some output")))
+	srv, err := NewServer(ServerConfig{Port: 8080, KeepAlive: 20 * time.Millisecond}, session)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &streamRecorder{header: http.Header{}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.handleEvents(w, httptest.NewRequest(http.MethodGet, "/events", http.NoBody).WithContext(ctx))
+	}()
+
+	assert.Eventually(t, func() bool { return strings.Count(w.String(), ": keep-alive\n\n") >= 2 },
+		time.Second, 5*time.Millisecond, "idle stream gets keep-alive comments")
+	cancel()
+	<-done
+
+	body := w.String()
+	assert.Less(t, strings.Index(body, "some output"), strings.Index(body, ": keep-alive"), "comments follow the replay")
+	assert.NotContains(t, body, "event: heartbeat", "keep-alive is independent of heartbeats")
+	replayer, ok := session.SSE.Provider.(*sse.Joe).Replayer.(*allEventsReplayer)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), replayer.puts.Load(), "comments are not stored for replay")
+	assert.Zero(t, replayer.Dropped())
+
+	// a new client replays only the published event, without the comments sent to the first one
+	replayCtx, replayCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer replayCancel()
+	rec := httptest.NewRecorder()
+	replaySrv, err := NewServer(ServerConfig{Port: 8080, KeepAlive: time.Hour}, session)
+	require.NoError(t, err)
+	replaySrv.handleEvents(rec, httptest.NewRequest(http.MethodGet, "/events", http.NoBody).WithContext(replayCtx))
+	assert.Contains(t, rec.Body.String(), "some output")
+	assert.NotContains(t, rec.Body.String(), "keep-alive")
+}
+
+func TestKeepAliveWriter_ActiveStream(t *testing.T) {
+	writer := &mockMessageWriter{}
+	client := newKeepAliveWriter(writer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.run(ctx, 50*time.Millisecond)
+	}()
+
+	// messages flushed more often than the interval keep the stream busy, no comments needed
+	for range 10 {
+		msg := NewOutputEvent(processor.PhaseTask, "line").ToSSEMessage()
+		require.NoError(t, client.Send(msg))
+		require.NoError(t, client.Flush())
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	assert.Equal(t, 10, writer.messageCount, "only the sent messages reach the client")
+}
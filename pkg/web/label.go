@@ -0,0 +1,216 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+const (
+	labelPrefix     = "Label: " // header line holding the session label in text progress files
+	maxLabelLength  = 100       // max label length in characters
+	maxLabelBodyLen = 4096      // max size of the label request body
+)
+
+// errSessionRunning is returned when the progress file of a running session would have to be rewritten
+var errSessionRunning = errors.New("session is running")
+
+// handleSessionLabel sets the label of a session, an empty label removes it.
+// the label is written to the progress file header, so it survives dashboard restarts.
+// running sessions can't be labeled, their progress file is still being written, and get 409.
+// responds with the updated session info as JSON.
+func (s *Server) handleSessionLabel(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	var req struct {
+		Label string `json:"label"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLabelBodyLen)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	label := strings.TrimSpace(req.Label)
+	if strings.ContainsAny(label, "\r\n") || utf8.RuneCountInString(label) > maxLabelLength {
+		http.Error(w, fmt.Sprintf("label must be a single line of at most %d characters", maxLabelLength), http.StatusBadRequest)
+		return
+	}
+
+	if err := session.SetLabel(label); err != nil {
+		if errors.Is(err, errSessionRunning) {
+			http.Error(w, "session is running, it can be labeled once finished", http.StatusConflict)
+			return
+		}
+		log.Printf("[WARN] failed to set label of session %s: %v", sessionID, err)
+		http.Error(w, "unable to update progress file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newSessionInfo(session))
+}
+
+// SetLabel writes the label to the progress file header and updates the session metadata.
+// returns errSessionRunning if the progress file is locked by a running session.
+func (s *Session) SetLabel(label string) error {
+	if err := s.rewriteHeader(func() error { return writeProgressLabel(s.GetPath(), label) }); err != nil {
+		return err
+	}
+	meta := s.GetMetadata()
+	meta.Label = label
+	s.SetMetadata(meta)
+	return nil
+}
+
+// rewriteHeader runs write, which rewrites the header of the progress file of a finished session.
+// returns errSessionRunning if the progress file is locked by a running session.
+// a tailer following the file is restarted past the rewritten content, so no events are replayed.
+func (s *Session) rewriteHeader(write func() error) error {
+	active, err := IsActive(s.GetPath())
+	if err != nil {
+		return fmt.Errorf("check active state: %w", err)
+	}
+	if active {
+		return errSessionRunning
+	}
+
+	tailing := s.IsTailing()
+	if tailing {
+		s.StopTailing()
+	}
+	err = write()
+	if tailing {
+		if tailErr := s.StartTailing(false); tailErr != nil {
+			log.Printf("[WARN] failed to restart tailing for session %s: %v", s.ID, tailErr)
+		} else {
+			s.FollowFinished()
+		}
+	}
+	return err
+}
+
+// writeProgressLabel sets the label in the header of the progress file, keeping the rest of the content intact.
+// an empty label removes it.
+func writeProgressLabel(path, label string) error {
+	return writeProgressHeader(path, labelPrefix, label, func(h *progress.Header) { h.Label = label })
+}
+
+// writeProgressHeader sets a header field of the progress file, keeping the rest of the content intact.
+// text files get a "<prefix><value>" header line, removed if value is empty, jsonl files get setJSONL
+// applied to their header record. the file is replaced atomically, compressed files stay compressed.
+func writeProgressHeader(path, prefix, value string, setJSONL func(h *progress.Header)) error {
+	f, err := progress.OpenFile(path)
+	if err != nil {
+		return fmt.Errorf("read progress file: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("read progress file: %w", err)
+	}
+
+	var updated []byte
+	if isJSONLProgress(path) {
+		updated, err = setJSONLHeader(data, setJSONL)
+	} else {
+		updated, err = setTextHeaderLine(data, prefix, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat progress file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".header-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // already renamed on success
+	if progress.IsCompressed(path) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(updated) // writes to a buffer can't fail
+		_ = zw.Close()
+		updated = buf.Bytes()
+	}
+	if _, err := tmp.Write(updated); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace progress file: %w", err)
+	}
+	return nil
+}
+
+// setTextHeaderLine replaces or removes the header line starting with prefix in a text progress file,
+// a new line is added at the end of the header, before the separator.
+func setTextHeaderLine(data []byte, prefix, value string) ([]byte, error) {
+	var header []string
+	rest := data
+	for len(rest) > 0 {
+		line, tail, _ := bytes.Cut(rest, []byte("\n"))
+		if bytes.HasPrefix(line, []byte("---")) {
+			break
+		}
+		if !bytes.HasPrefix(line, []byte(prefix)) {
+			header = append(header, string(line))
+		}
+		rest = tail
+	}
+	if len(rest) == 0 {
+		return nil, errors.New("progress file header not found")
+	}
+	if value != "" {
+		header = append(header, prefix+value)
+	}
+
+	var buf bytes.Buffer
+	for _, line := range header {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	buf.Write(rest)
+	return buf.Bytes(), nil
+}
+
+// setJSONLHeader applies set to the header record of a jsonl progress file.
+func setJSONLHeader(data []byte, set func(h *progress.Header)) ([]byte, error) {
+	first, rest, _ := bytes.Cut(data, []byte("\n"))
+	var rec progress.Record
+	if err := json.Unmarshal(first, &rec); err != nil {
+		return nil, fmt.Errorf("parse header record: %w", err)
+	}
+	if rec.Type != progress.RecordHeader || rec.Header == nil {
+		return nil, errors.New("progress file header not found")
+	}
+	set(rec.Header)
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("encode header record: %w", err)
+	}
+	return append(append(line, '\n'), rest...), nil
+}
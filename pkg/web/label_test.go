@@ -0,0 +1,181 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+const labelTestProgress = "# Ralphex Progress Log\nPlan: docs/plans/cache.md\nBranch: main\nMode: full\n" +
+	"Started: 2026-01-22 10:00:00\n------------------------------------------------------------\n\n" +
+	"[26-01-22 10:00:01] Plan: not a header line\n[26-01-22 10:00:02] Label: not a header line either\n"
+
+func TestWriteProgressLabel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress-cache.txt")
+	require.NoError(t, os.WriteFile(path, []byte(labelTestProgress), 0o600))
+	header, body, _ := strings.Cut(labelTestProgress, "-----")
+	body = "-----" + body
+
+	t.Run("adds label", func(t *testing.T) {
+		require.NoError(t, writeProgressLabel(path, "cache rollout"))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, header+"Label: cache rollout\n"+body, string(data))
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Equal(t, "cache rollout", meta.Label)
+		assert.Equal(t, "main", meta.Branch)
+		assert.Equal(t, "docs/plans/cache.md", meta.PlanPath)
+	})
+
+	t.Run("replaces label", func(t *testing.T) {
+		require.NoError(t, writeProgressLabel(path, "retry"))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, header+"Label: retry\n"+body, string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "permissions are kept")
+	})
+
+	t.Run("removes label", func(t *testing.T) {
+		require.NoError(t, writeProgressLabel(path, ""))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, labelTestProgress, string(data))
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Empty(t, meta.Label)
+	})
+
+	t.Run("no temp files left", func(t *testing.T) {
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "progress-cache.txt", entries[0].Name())
+	})
+
+	t.Run("file without header", func(t *testing.T) {
+		noHeader := filepath.Join(t.TempDir(), "progress-broken.txt")
+		require.NoError(t, os.WriteFile(noHeader, []byte("just text\n"), 0o600))
+		require.ErrorContains(t, writeProgressLabel(noHeader, "x"), "header not found")
+		data, err := os.ReadFile(noHeader)
+		require.NoError(t, err)
+		assert.Equal(t, "just text\n", string(data))
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		dir := t.TempDir()
+		oldWd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+		logger, err := progress.NewLogger(progress.Config{PlanFile: "cache.md", Mode: "full", Branch: "main", NoColor: true,
+			Format: progress.FormatJSONL}, testColors())
+		require.NoError(t, err)
+		logger.Print("working")
+		require.NoError(t, logger.Close())
+		jsonlPath := filepath.Join(dir, logger.Path())
+		before, err := os.ReadFile(jsonlPath)
+		require.NoError(t, err)
+
+		require.NoError(t, writeProgressLabel(jsonlPath, "cache rollout"))
+		meta, err := ParseProgressHeader(jsonlPath)
+		require.NoError(t, err)
+		assert.Equal(t, "cache rollout", meta.Label)
+		assert.Equal(t, "main", meta.Branch)
+
+		after, err := os.ReadFile(jsonlPath)
+		require.NoError(t, err)
+		_, beforeRest, _ := strings.Cut(string(before), "\n")
+		_, afterRest, _ := strings.Cut(string(after), "\n")
+		assert.Equal(t, beforeRest, afterRest, "records after the header are kept")
+		assert.Contains(t, string(after), `"label":"cache rollout"`)
+	})
+}
+
+func TestServer_HandleSessionLabel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-cache.txt")
+	require.NoError(t, os.WriteFile(path, []byte(labelTestProgress), 0o600))
+
+	session := NewSession("cache", path)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(t *testing.T, srv *Server, sessionID, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/label", strings.NewReader(body))
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionLabel(w, req)
+		return w
+	}
+
+	t.Run("sets label", func(t *testing.T) {
+		w := request(t, srv, "cache", `{"label": "  cache rollout "}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var info SessionInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		assert.Equal(t, "cache rollout", info.Label)
+		assert.Equal(t, "cache rollout", session.GetMetadata().Label)
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Equal(t, "cache rollout", meta.Label)
+	})
+
+	t.Run("invalid labels", func(t *testing.T) {
+		for _, body := range []string{`not json`, `{"label": "two\nlines"}`, `{"label": "` + strings.Repeat("x", 101) + `"}`} {
+			w := request(t, srv, "cache", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+		assert.Equal(t, "cache rollout", session.GetMetadata().Label, "label unchanged")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		w := request(t, srv, "other", `{"label": "x"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("running session", func(t *testing.T) {
+		runDir := t.TempDir()
+		oldWd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(runDir))
+		t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+		logger, err := progress.NewLogger(progress.Config{PlanFile: "cache.md", Mode: "full", Branch: "main", NoColor: true},
+			testColors())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = logger.Close() })
+		runPath := filepath.Join(runDir, logger.Path())
+		before, err := os.ReadFile(runPath)
+		require.NoError(t, err)
+
+		running := NewSession("running", runPath)
+		t.Cleanup(running.Close)
+		runSrv, err := NewServer(ServerConfig{Port: 8080}, running)
+		require.NoError(t, err)
+		w := request(t, runSrv, "running", `{"label": "x"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		after, err := os.ReadFile(runPath)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after), "progress file of a running session is not touched")
+	})
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// LogLevels are the levels of session events, from the most to the least verbose.
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// DefaultLogLevel is the log level of new sessions, debug events are not shown until it is lowered.
+const DefaultLogLevel = "info"
+
+// debugPrefix marks debug output lines, e.g. "[debug] non-JSON line: ..." written by executors with --debug
+const debugPrefix = "[debug]"
+
+// maxLogLevelBodyLen is the max size of the log level request body
+const maxLogLevelBodyLen = 1024
+
+// handleSessionLogLevel sets the log level of a session, text events below it are no longer published to SSE clients.
+// takes effect for the following events, already published ones are kept.
+// responds with the resulting level as JSON.
+func (s *Server) handleSessionLogLevel(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	var req struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLogLevelBodyLen)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := session.SetLogLevel(strings.ToLower(strings.TrimSpace(req.Level))); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Level string `json:"level"`
+	}{Level: session.LogLevel()})
+}
+
+// SetLogLevel sets the lowest level of events published by the session, one of LogLevels.
+func (s *Session) SetLogLevel(level string) error {
+	if !slices.Contains(LogLevels, level) {
+		return fmt.Errorf("invalid log level %q, want one of %s", level, strings.Join(LogLevels, ", "))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logLevel = level
+	return nil
+}
+
+// LogLevel returns the lowest level of events published by the session.
+func (s *Session) LogLevel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.logLevel == "" {
+		return DefaultLogLevel
+	}
+	return s.logLevel
+}
+
+// logs reports whether the event is at or above the session's log level.
+// the level only gates text lines, structural events (sections, task and iteration boundaries,
+// signals, status) always pass since phase tabs and completion detection depend on them.
+func (s *Session) logs(e Event) bool {
+	switch e.Type {
+	case EventTypeOutput, EventTypeDebug, EventTypeWarn, EventTypeError:
+		return levelIndex(eventLevel(e)) >= levelIndex(s.LogLevel())
+	default:
+		return true
+	}
+}
+
+// eventLevel returns the level of an event, one of LogLevels.
+func eventLevel(e Event) string {
+	switch e.Type {
+	case EventTypeDebug:
+		return "debug"
+	case EventTypeError:
+		return "error"
+	case EventTypeWarn:
+		return "warn"
+	default:
+		return "info"
+	}
+}
+
+// levelIndex returns the position of the level in LogLevels, higher is less verbose.
+func levelIndex(level string) int {
+	return slices.Index(LogLevels, level)
+}
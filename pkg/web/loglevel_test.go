@@ -0,0 +1,150 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/processor/mocks"
+)
+
+func TestBroadcastLogger_LogLevel(t *testing.T) {
+	var written []string
+	inner := &mocks.LoggerMock{
+		PrintFunc:        func(format string, _ ...any) { written = append(written, format) },
+		PrintAlignedFunc: func(text string) { written = append(written, text) },
+		LogErrorFunc:     func(err error, _ bool) { written = append(written, err.Error()) },
+	}
+	session := NewSession("live", "/tmp/live.txt")
+	defer session.Close()
+	bl := NewBroadcastLogger(inner, session)
+	assert.Equal(t, DefaultLogLevel, session.LogLevel())
+
+	bl.Print("run started")
+	bl.PrintAligned("[debug] non-JSON line: hidden\n")
+	bl.Print("info 1")
+	require.NoError(t, session.SetLogLevel("debug"))
+	bl.PrintAligned("[debug] non-JSON line: shown\n")
+	require.NoError(t, session.SetLogLevel("error"))
+	bl.Print("info 2")
+	bl.LogError(assert.AnError, true)
+	require.NoError(t, session.SetLogLevel("info"))
+	bl.PrintAligned("[debug] non-JSON line: hidden again\n")
+	bl.Print("info 3")
+
+	writer := &mockMessageWriter{}
+	replayer := session.SSE.Provider.(*sse.Joe).Replayer
+	require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	var published []string
+	for _, msg := range writer.messages {
+		_, data, ok := strings.Cut(msg, "data: ")
+		require.True(t, ok, msg)
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(data)), &e))
+		published = append(published, string(e.Type)+":"+strings.TrimSpace(e.Text))
+	}
+	assert.Equal(t, []string{"output:info 1", "debug:[debug] non-JSON line: shown", "error:ERROR: " + assert.AnError.Error(),
+		"output:info 3"}, published[len(published)-4:])
+	assert.NotContains(t, published, "output:info 2")
+	for _, p := range published {
+		assert.NotContains(t, p, "hidden", "debug events below the level are not published")
+	}
+
+	// the inner logger gets everything, the level only applies to the dashboard
+	assert.Len(t, written, 8)
+}
+
+func TestBroadcastLogger_LogLevelKeepsStructuralEvents(t *testing.T) {
+	inner := &mocks.LoggerMock{
+		PrintFunc:        func(string, ...any) {},
+		PrintAlignedFunc: func(string) {},
+		PrintSectionFunc: func(processor.Section) {},
+	}
+	session := NewSession("live", "/tmp/live.txt")
+	defer session.Close()
+	bl := NewBroadcastLogger(inner, session)
+	bl.Print("run started")
+	require.NoError(t, session.SetLogLevel("error"))
+
+	bl.PrintSection(processor.NewTaskIterationSection(1))
+	bl.Print("hidden output")
+	bl.PrintAligned("done " + processor.SignalCompleted + "\n")
+
+	writer := &mockMessageWriter{}
+	replayer := session.SSE.Provider.(*sse.Joe).Replayer
+	require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	var types []EventType
+	for _, msg := range writer.messages {
+		_, data, ok := strings.Cut(msg, "data: ")
+		require.True(t, ok, msg)
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(data)), &e))
+		types = append(types, e.Type)
+	}
+	require.GreaterOrEqual(t, len(types), 3)
+	assert.Equal(t, []EventType{EventTypeTaskStart, EventTypeSection, EventTypeSignal}, types[len(types)-3:])
+}
+
+func TestSession_SetLogLevel(t *testing.T) {
+	session := NewSession("s", "/tmp/s.txt")
+	defer session.Close()
+
+	for _, level := range LogLevels {
+		require.NoError(t, session.SetLogLevel(level))
+		assert.Equal(t, level, session.LogLevel())
+	}
+	require.EqualError(t, session.SetLogLevel("verbose"), `invalid log level "verbose", want one of debug, info, warn, error`)
+	assert.Equal(t, "error", session.LogLevel(), "level unchanged")
+}
+
+func TestServer_HandleSessionLogLevel(t *testing.T) {
+	session := NewSession("main", "/tmp/main.txt")
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(sessionID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/loglevel", strings.NewReader(body))
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionLogLevel(w, req)
+		return w
+	}
+
+	t.Run("sets level", func(t *testing.T) {
+		w := request("main", `{"level": " Debug "}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"level": "debug"}`, w.Body.String())
+		assert.Equal(t, "debug", session.LogLevel())
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		for _, body := range []string{`not json`, `{"level": "verbose"}`, `{}`} {
+			w := request("main", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+		assert.Equal(t, "debug", session.LogLevel(), "level unchanged")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		w := request("other", `{"level": "info"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestProgressMirror_SkipsDebug(t *testing.T) {
+	var buf strings.Builder
+	mirror, err := newProgressMirror(&buf, "info")
+	require.NoError(t, err)
+	mirror.write("s1", NewDebugEvent(processor.PhaseTask, "[debug] noisy"))
+	mirror.write("s1", NewOutputEvent(processor.PhaseTask, "kept"))
+	assert.NotContains(t, buf.String(), "noisy")
+	assert.Contains(t, buf.String(), "kept")
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ProgressLogLevels are the levels accepted by progress mirroring, from the most to the least verbose.
+// "info" mirrors all events but debug ones, "warn" warnings and errors, "error" only errors.
+var ProgressLogLevels = []string{"info", "warn", "error"}
+
+// progressMirror writes events of tailed sessions to a writer, e.g. the stdout of a dashboard in a container,
+// so runs can be followed in its logs. lines are prefixed with the session ID, sessions can be interleaved.
+type progressMirror struct {
+	mu       sync.Mutex
+	w        io.Writer
+	minLevel int // index in LogLevels
+}
+
+// newProgressMirror creates a mirror writing events at the given level or above to w.
+func newProgressMirror(w io.Writer, level string) (*progressMirror, error) {
+	if !slices.Contains(ProgressLogLevels, level) {
+		return nil, fmt.Errorf("invalid progress log level %q, want one of %s", level, strings.Join(ProgressLogLevels, ", "))
+	}
+	return &progressMirror{w: w, minLevel: levelIndex(level)}, nil
+}
+
+// write writes the event of the session if its level is high enough.
+func (p *progressMirror) write(sessionID string, e Event) {
+	if e.Type == EventTypeStatus || levelIndex(eventLevel(e)) < p.minLevel {
+		return
+	}
+
+	text := e.Text
+	if e.Type == EventTypeSection {
+		text = "--- " + e.Section + " ---"
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for line := range strings.SplitSeq(strings.TrimRight(text, "\n"), "\n") {
+		_, _ = fmt.Fprintf(p.w, "[%s] [%s] %s\n", sessionID, e.Timestamp.Format("15:04:05"), line)
+	}
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes and reads
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgressMirror_Write(t *testing.T) {
+	ts := time.Date(2026, 1, 22, 10, 30, 15, 0, time.UTC)
+	events := []Event{
+		{Type: EventTypeSection, Section: "task iteration 1", Timestamp: ts},
+		{Type: EventTypeOutput, Text: "line one\nline two\n", Timestamp: ts},
+		{Type: EventTypeWarn, Text: "warning: slow", Timestamp: ts},
+		{Type: EventTypeError, Text: "ERROR: boom", Timestamp: ts},
+		{Type: EventTypeStatus, Text: "paused", Timestamp: ts},
+	}
+
+	tests := []struct {
+		level string
+		want  string
+	}{
+		{level: "info", want: "[s1] [10:30:15] --- task iteration 1 ---\n[s1] [10:30:15] line one\n[s1] [10:30:15] line two\n" +
+			"[s1] [10:30:15] warning: slow\n[s1] [10:30:15] ERROR: boom\n"},
+		{level: "warn", want: "[s1] [10:30:15] warning: slow\n[s1] [10:30:15] ERROR: boom\n"},
+		{level: "error", want: "[s1] [10:30:15] ERROR: boom\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.level, func(t *testing.T) {
+			var buf bytes.Buffer
+			mirror, err := newProgressMirror(&buf, tc.level)
+			require.NoError(t, err)
+			for _, e := range events {
+				mirror.write("s1", e)
+			}
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+
+	t.Run("invalid level", func(t *testing.T) {
+		_, err := newProgressMirror(&bytes.Buffer{}, "debug")
+		require.EqualError(t, err, `invalid progress log level "debug", want one of info, warn, error`)
+	})
+}
+
+func TestSessionManager_ProgressLog(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	// a running plan, its progress file is locked until the logger is closed
+	logger, err := progress.NewLogger(progress.Config{PlanFile: "plan.md", Mode: "full", Branch: "main", NoColor: true},
+		testColors())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+
+	var stdout syncBuffer
+	m := NewSessionManager()
+	t.Cleanup(m.Close)
+	m.SetPollInterval(10 * time.Millisecond)
+	require.NoError(t, m.SetProgressLog(&stdout, "info"))
+	ids, err := m.Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	require.Equal(t, SessionStateActive, m.Get(ids[0]).GetState())
+
+	logger.PrintSection(processor.NewTaskIterationSection(1))
+	logger.Print("runner output line")
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(stdout.String(), "runner output line")
+	}, 2*time.Second, 10*time.Millisecond)
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout.String()), "\n") {
+		assert.True(t, strings.HasPrefix(line, "["+ids[0]+"] "), "line %q is prefixed with session id", line)
+	}
+	assert.Contains(t, stdout.String(), "--- task iteration 1 ---")
+}
@@ -0,0 +1,33 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// PhaseInfo describes a phase the dashboard can filter output by.
+type PhaseInfo struct {
+	ID   processor.Phase `json:"id"`
+	Name string          `json:"name"` // display name of the phase tab
+}
+
+// availablePhases returns the filterable phases in display order.
+// the codex phase is omitted when codex review is disabled.
+func availablePhases(codexDisabled bool) []PhaseInfo {
+	phases := []PhaseInfo{
+		{ID: processor.PhaseTask, Name: "Implementation"},
+		{ID: processor.PhaseReview, Name: "Claude Review"},
+	}
+	if !codexDisabled {
+		phases = append(phases, PhaseInfo{ID: processor.PhaseCodex, Name: "Codex Review"})
+	}
+	return phases
+}
+
+// handlePhases returns the phases the dashboard offers as output filters.
+func (s *Server) handlePhases(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(availablePhases(s.cfg.CodexDisabled))
+}
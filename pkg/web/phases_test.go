@@ -0,0 +1,47 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+func TestServer_HandlePhases(t *testing.T) {
+	tests := []struct {
+		name          string
+		codexDisabled bool
+		want          []PhaseInfo
+	}{
+		{name: "codex enabled", want: []PhaseInfo{
+			{ID: processor.PhaseTask, Name: "Implementation"},
+			{ID: processor.PhaseReview, Name: "Claude Review"},
+			{ID: processor.PhaseCodex, Name: "Codex Review"},
+		}},
+		{name: "codex disabled", codexDisabled: true, want: []PhaseInfo{
+			{ID: processor.PhaseTask, Name: "Implementation"},
+			{ID: processor.PhaseReview, Name: "Claude Review"},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := NewServer(ServerConfig{Port: 8080, CodexDisabled: tc.codexDisabled}, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			srv.handlePhases(w, httptest.NewRequest(http.MethodGet, "/api/phases", http.NoBody))
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var got []PhaseInfo
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
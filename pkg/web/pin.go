@@ -0,0 +1,62 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+const (
+	pinnedPrefix = "Pinned: " // header line marking a pinned session in text progress files
+	pinnedValue  = "true"     // value of the pinned header line
+)
+
+// handleSessionPin pins or unpins a session, depending on the route.
+// pinned sessions are skipped by retention pruning, the flag is written to the progress file header.
+// running sessions can't be pinned, their progress file is still being written, and get 409.
+// responds with the updated session info as JSON.
+func (s *Server) handleSessionPin(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	pinned := !strings.HasSuffix(r.URL.Path, "/unpin")
+	if err := session.SetPinned(pinned); err != nil {
+		if errors.Is(err, errSessionRunning) {
+			http.Error(w, "session is running, it can be pinned once finished", http.StatusConflict)
+			return
+		}
+		log.Printf("[WARN] failed to set pinned state of session %s: %v", sessionID, err)
+		http.Error(w, "unable to update progress file", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newSessionInfo(session))
+}
+
+// SetPinned writes the pinned flag to the progress file header and updates the session metadata.
+// returns errSessionRunning if the progress file is locked by a running session.
+func (s *Session) SetPinned(pinned bool) error {
+	value := ""
+	if pinned {
+		value = pinnedValue
+	}
+	write := func() error {
+		return writeProgressHeader(s.GetPath(), pinnedPrefix, value, func(h *progress.Header) { h.Pinned = pinned })
+	}
+	if err := s.rewriteHeader(write); err != nil {
+		return err
+	}
+	meta := s.GetMetadata()
+	meta.Pinned = pinned
+	s.SetMetadata(meta)
+	return nil
+}
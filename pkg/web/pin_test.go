@@ -0,0 +1,139 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+func TestServer_HandleSessionPin(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-cache.txt")
+	require.NoError(t, os.WriteFile(path, []byte(labelTestProgress), 0o600))
+
+	session := NewSession("cache", path)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(t *testing.T, srv *Server, sessionID, action string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/"+action, http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionPin(w, req)
+		return w
+	}
+
+	t.Run("pins session", func(t *testing.T) {
+		w := request(t, srv, "cache", "pin")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var info SessionInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		assert.True(t, info.Pinned)
+		assert.True(t, session.GetMetadata().Pinned)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		header, _, _ := strings.Cut(string(data), "-----")
+		assert.Contains(t, header, "Pinned: true\n")
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.True(t, meta.Pinned)
+		assert.Equal(t, "main", meta.Branch)
+	})
+
+	t.Run("pin is idempotent", func(t *testing.T) {
+		w := request(t, srv, "cache", "pin")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(data), "Pinned: "))
+	})
+
+	t.Run("unpins session", func(t *testing.T) {
+		w := request(t, srv, "cache", "unpin")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var info SessionInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		assert.False(t, info.Pinned)
+		assert.False(t, session.GetMetadata().Pinned)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, labelTestProgress, string(data), "header line removed")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		w := request(t, srv, "other", "pin")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("running session", func(t *testing.T) {
+		runDir := t.TempDir()
+		oldWd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(runDir))
+		t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+		logger, err := progress.NewLogger(progress.Config{PlanFile: "cache.md", Mode: "full", Branch: "main", NoColor: true},
+			testColors())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = logger.Close() })
+		runPath := filepath.Join(runDir, logger.Path())
+		before, err := os.ReadFile(runPath)
+		require.NoError(t, err)
+
+		running := NewSession("running", runPath)
+		t.Cleanup(running.Close)
+		runSrv, err := NewServer(ServerConfig{Port: 8080}, running)
+		require.NoError(t, err)
+		w := request(t, runSrv, "running", "pin")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		after, err := os.ReadFile(runPath)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after), "progress file of a running session is not touched")
+	})
+}
+
+func TestSession_SetPinnedJSONL(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	logger, err := progress.NewLogger(progress.Config{PlanFile: "cache.md", Mode: "full", Branch: "main", NoColor: true,
+		Format: progress.FormatJSONL}, testColors())
+	require.NoError(t, err)
+	logger.Print("working")
+	require.NoError(t, logger.Close())
+	path := filepath.Join(dir, logger.Path())
+
+	session := NewSession("cache", path)
+	t.Cleanup(session.Close)
+	require.NoError(t, session.SetPinned(true))
+	meta, err := ParseProgressHeader(path)
+	require.NoError(t, err)
+	assert.True(t, meta.Pinned)
+	assert.Equal(t, "main", meta.Branch)
+
+	require.NoError(t, session.SetPinned(false))
+	meta, err = ParseProgressHeader(path)
+	require.NoError(t, err)
+	assert.False(t, meta.Pinned)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"pinned"`)
+}
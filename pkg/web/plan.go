@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 // TaskStatus represents the execution status of a task.
@@ -54,7 +56,8 @@ func ParsePlan(content string) (*Plan, error) {
 		Tasks: make([]Task, 0),
 	}
 
-	scanner := bufio.NewScanner(strings.NewReader(content))
+	// bufio.ScanLines drops the \r of CRLF line endings, so only a leading byte order mark needs trimming
+	scanner := bufio.NewScanner(strings.NewReader(strings.TrimPrefix(content, progress.BOM)))
 	var currentTask *Task
 
 	for scanner.Scan() {
@@ -121,6 +124,87 @@ func ParsePlanFile(path string) (*Plan, error) {
 	return ParsePlan(string(content))
 }
 
+// PlanTask is a single checkbox entry of a plan file, with the checkboxes nested below it.
+type PlanTask struct {
+	Title    string     `json:"title"`
+	Status   TaskStatus `json:"status"`             // pending or done, active for a parent with some of its subtree done
+	Line     int        `json:"line"`               // 1-based line number in the plan file
+	Children []PlanTask `json:"children,omitempty"` // checkboxes indented below this one
+}
+
+// ParsePlanTasks reads a plan file and returns all "- [ ]" / "- [x]" entries in file order, as a tree.
+// a checkbox indented deeper than the one before it is nested below it, see nestPlanTasks.
+// unlike ParsePlan it doesn't require task headers.
+func ParsePlanTasks(path string) ([]PlanTask, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from session metadata
+	if err != nil {
+		return nil, fmt.Errorf("read plan file: %w", err)
+	}
+
+	var items []indentedTask
+	for i, line := range strings.Split(strings.TrimPrefix(string(content), progress.BOM), "\n") {
+		trimmed := strings.TrimSpace(line)
+		matches := checkboxPattern.FindStringSubmatch(trimmed)
+		if matches == nil {
+			continue
+		}
+		status := TaskStatusPending
+		if matches[1] == "x" || matches[1] == "X" {
+			status = TaskStatusDone
+		}
+		indent := strings.Index(strings.ReplaceAll(line, "\t", "    "), trimmed) // a tab indents like 4 spaces
+		items = append(items, indentedTask{indent: indent,
+			task: PlanTask{Title: strings.TrimSpace(matches[2]), Status: status, Line: i + 1}})
+	}
+
+	pos := 0
+	tasks := nestPlanTasks(items, &pos, -1)
+	if tasks == nil {
+		tasks = make([]PlanTask, 0)
+	}
+	return tasks, nil
+}
+
+// indentedTask is a plan checkbox with the indentation of its line, in columns.
+type indentedTask struct {
+	indent int
+	task   PlanTask
+}
+
+// nestPlanTasks builds the tasks of items[*pos:] indented deeper than parentIndent, advancing *pos past them.
+// each task gets the following deeper indented items as children, and a status aggregated over its subtree.
+func nestPlanTasks(items []indentedTask, pos *int, parentIndent int) []PlanTask {
+	var tasks []PlanTask
+	for *pos < len(items) && items[*pos].indent > parentIndent {
+		item := items[*pos]
+		*pos++
+		item.task.Children = nestPlanTasks(items, pos, item.indent)
+		if len(item.task.Children) > 0 {
+			item.task.Status = subtreeStatus(item.task)
+		}
+		tasks = append(tasks, item.task)
+	}
+	return tasks
+}
+
+// subtreeStatus returns done if a task and all its children are done, pending if none of them is done
+// or in progress, and active otherwise. children statuses are already aggregated over their own subtrees.
+func subtreeStatus(task PlanTask) TaskStatus {
+	done, pending := task.Status == TaskStatusDone, task.Status == TaskStatusPending
+	for _, child := range task.Children {
+		done = done && child.Status == TaskStatusDone
+		pending = pending && child.Status == TaskStatusPending
+	}
+	switch {
+	case done:
+		return TaskStatusDone
+	case pending:
+		return TaskStatusPending
+	default:
+		return TaskStatusActive
+	}
+}
+
 // JSON returns the plan as JSON bytes.
 func (p *Plan) JSON() ([]byte, error) {
 	data, err := json.Marshal(p)
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 func TestParsePlan(t *testing.T) {
@@ -48,6 +51,19 @@ Some description here.
 		assert.Equal(t, TaskStatusPending, plan.Tasks[1].Status) // all unchecked
 	})
 
+	t.Run("crlf and byte order mark parse like lf", func(t *testing.T) {
+		content := "# My Test Plan\n\n### Task 1: First Task\n\n- [ ] Do something\n- [x] Already done\n"
+		want, err := ParsePlan(content)
+		require.NoError(t, err)
+		require.Equal(t, "My Test Plan", want.Title)
+
+		for _, variant := range []string{strings.ReplaceAll(content, "\n", "\r\n"), progress.BOM + content} {
+			plan, err := ParsePlan(variant)
+			require.NoError(t, err)
+			assert.Equal(t, want, plan)
+		}
+	})
+
 	t.Run("parses iteration headers as tasks", func(t *testing.T) {
 		content := `# Plan
 
@@ -185,6 +201,111 @@ func TestParsePlanFile(t *testing.T) {
 	})
 }
 
+func TestParsePlanTasks(t *testing.T) {
+	t.Run("collects checkboxes with status and line", func(t *testing.T) {
+		content := `# Plan
+
+### Task 1: First
+- [x] done item
+  - [ ] nested item
+
+## Success criteria
+- [X] upper case check
+not a checkbox - [ ] inline
+`
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		tasks, err := ParsePlanTasks(path)
+		require.NoError(t, err)
+		assert.Equal(t, []PlanTask{
+			{Title: "done item", Status: TaskStatusActive, Line: 4, Children: []PlanTask{
+				{Title: "nested item", Status: TaskStatusPending, Line: 5},
+			}},
+			{Title: "upper case check", Status: TaskStatusDone, Line: 8},
+		}, tasks)
+	})
+
+	t.Run("nests checkboxes by indentation", func(t *testing.T) {
+		content := "### Task 1: First\n" +
+			"- [ ] top\n" +
+			"  - [x] level 1 done\n" +
+			"    - [x] level 2 done\n" +
+			"  - [ ] level 1 pending\n" +
+			"    - [ ] level 2 pending\n" +
+			"\t\t- [x] tab indented\n" +
+			"- [ ] all pending\n" +
+			"  - [ ] child pending\n" +
+			"- [x] all done\n" +
+			"  - [x] child done\n" +
+			"    - [x] grandchild done\n" +
+			"- [ ] unchecked parent\n" +
+			"  - [x] child done\n" +
+			"   - [ ] odd indent\n"
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		tasks, err := ParsePlanTasks(path)
+		require.NoError(t, err)
+		assert.Equal(t, []PlanTask{
+			{Title: "top", Status: TaskStatusActive, Line: 2, Children: []PlanTask{
+				{Title: "level 1 done", Status: TaskStatusDone, Line: 3, Children: []PlanTask{
+					{Title: "level 2 done", Status: TaskStatusDone, Line: 4},
+				}},
+				{Title: "level 1 pending", Status: TaskStatusActive, Line: 5, Children: []PlanTask{
+					{Title: "level 2 pending", Status: TaskStatusActive, Line: 6, Children: []PlanTask{
+						{Title: "tab indented", Status: TaskStatusDone, Line: 7},
+					}},
+				}},
+			}},
+			{Title: "all pending", Status: TaskStatusPending, Line: 8, Children: []PlanTask{
+				{Title: "child pending", Status: TaskStatusPending, Line: 9},
+			}},
+			{Title: "all done", Status: TaskStatusDone, Line: 10, Children: []PlanTask{
+				{Title: "child done", Status: TaskStatusDone, Line: 11, Children: []PlanTask{
+					{Title: "grandchild done", Status: TaskStatusDone, Line: 12},
+				}},
+			}},
+			{Title: "unchecked parent", Status: TaskStatusActive, Line: 13, Children: []PlanTask{
+				{Title: "child done", Status: TaskStatusActive, Line: 14, Children: []PlanTask{
+					{Title: "odd indent", Status: TaskStatusPending, Line: 15},
+				}},
+			}},
+		}, tasks)
+	})
+
+	t.Run("crlf and byte order mark parse like lf", func(t *testing.T) {
+		content := "- [x] done item\n### Task 1: First\n- [ ] pending item\n"
+		lfPath := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(lfPath, []byte(content), 0o600))
+		want, err := ParsePlanTasks(lfPath)
+		require.NoError(t, err)
+		require.Len(t, want, 2)
+
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte(progress.BOM+strings.ReplaceAll(content, "\n", "\r\n")), 0o600))
+		tasks, err := ParsePlanTasks(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, tasks)
+	})
+
+	t.Run("empty plan returns empty slice", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(path, []byte("# Plan\n"), 0o600))
+
+		tasks, err := ParsePlanTasks(path)
+		require.NoError(t, err)
+		assert.NotNil(t, tasks)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("returns error for missing file", func(t *testing.T) {
+		_, err := ParsePlanTasks("/nonexistent/file.md")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
 func TestPlan_JSON(t *testing.T) {
 	plan := &Plan{
 		Title: "Test Plan",
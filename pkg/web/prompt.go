@@ -0,0 +1,48 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// handleSessionPrompt serves the last prompt the session's runner sent to an executor, with the phase it was sent in,
+// so prompt issues can be debugged with the literal text claude or codex received.
+// prompts are only known for sessions run by this process, they are kept after the session finished.
+// returns 404 if the session is unknown or no prompt was recorded for it.
+func (s *Server) handleSessionPrompt(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	phase, prompt := session.Prompt()
+	if prompt == "" {
+		http.Error(w, "no prompt recorded for session: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Phase  processor.Phase `json:"phase"`
+		Prompt string          `json:"prompt"`
+	}{Phase: phase, Prompt: prompt})
+}
+
+// Prompt returns the last prompt sent to an executor by the session's runner and the phase it was sent in.
+// the prompt is empty if the session isn't run by this process or no executor was called yet.
+func (s *Session) Prompt() (processor.Phase, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.promptPhase, s.prompt
+}
+
+// setPrompt records the prompt sent to an executor, replacing the previous one.
+func (s *Session) setPrompt(phase processor.Phase, prompt string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.promptPhase, s.prompt = phase, prompt
+}
@@ -0,0 +1,80 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/config"
+	"github.com/umputun/ralphex/pkg/executor"
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/processor/mocks"
+)
+
+func TestServer_HandleSessionPrompt(t *testing.T) {
+	session := NewSession("main", "/tmp/main.txt")
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(sessionID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/prompt", http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionPrompt(w, req)
+		return w
+	}
+
+	t.Run("no prompt recorded", func(t *testing.T) {
+		w := request("main")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "no prompt recorded for session: main")
+	})
+
+	t.Run("last prompt of a mock-driven run", func(t *testing.T) {
+		planFile := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planFile, []byte("# Plan\n- [x] Task 1"), 0o600))
+		appCfg, err := config.Load(t.TempDir())
+		require.NoError(t, err)
+
+		var received []string
+		claude := &mocks.ExecutorMock{RunFunc: func(_ context.Context, prompt string) executor.Result {
+			received = append(received, prompt)
+			return executor.Result{Output: "review done", Signal: processor.SignalReviewDone}
+		}}
+		inner := &mocks.LoggerMock{
+			SetPhaseFunc:     func(processor.Phase) {},
+			PrintFunc:        func(string, ...any) {},
+			PrintSectionFunc: func(processor.Section) {},
+			PrintAlignedFunc: func(string) {},
+		}
+		bl := NewBroadcastLogger(inner, session)
+		r := processor.NewWithExecutors(processor.Config{Mode: processor.ModeReview, PlanFile: planFile, MaxIterations: 5,
+			IterationDelayMs: 1, AppConfig: appCfg}, bl, claude, &mocks.ExecutorMock{})
+		r.SetPromptRecorder(bl)
+		require.NoError(t, r.Run(context.Background()))
+		require.NotEmpty(t, received)
+
+		w := request("main")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var resp struct {
+			Phase  string `json:"phase"`
+			Prompt string `json:"prompt"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "review", resp.Phase)
+		assert.Equal(t, received[len(received)-1], resp.Prompt, "prompt is the literal one the executor received")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request("other").Code)
+	})
+}
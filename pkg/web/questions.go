@@ -0,0 +1,104 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// prefixes of the plan creation Q&A lines written to progress files by LogQuestion and LogAnswer
+const (
+	questionPrefix = "QUESTION: "
+	optionsPrefix  = "OPTIONS: "
+	answerPrefix   = "ANSWER: "
+)
+
+// questionEntry is a question asked during plan creation with its options and answer.
+// Answer is empty for a question that was never answered.
+type questionEntry struct {
+	Question  string    `json:"question"`
+	Options   []string  `json:"options"`
+	Answer    string    `json:"answer,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// questionsResult is the response of the session questions endpoint.
+// Pending is the last question of an active session while it waits for an answer.
+type questionsResult struct {
+	Questions []questionEntry `json:"questions"`
+	Pending   *questionEntry  `json:"pending,omitempty"`
+}
+
+// questionCollector rebuilds the Q&A history from progress events, fed in file order.
+type questionCollector struct {
+	questions []questionEntry
+	current   *questionEntry // asked and not answered yet
+}
+
+// add processes a single event, events other than Q&A lines are ignored.
+func (c *questionCollector) add(e Event) error {
+	switch {
+	case strings.HasPrefix(e.Text, questionPrefix):
+		c.flush()
+		c.current = &questionEntry{Question: strings.TrimPrefix(e.Text, questionPrefix), Options: []string{}, Timestamp: e.Timestamp}
+	case strings.HasPrefix(e.Text, optionsPrefix) && c.current != nil:
+		// options are logged joined with ", ", so an option containing ", " can't be told apart from two options
+		if opts := strings.TrimPrefix(e.Text, optionsPrefix); opts != "" {
+			c.current.Options = strings.Split(opts, ", ")
+		}
+	case strings.HasPrefix(e.Text, answerPrefix) && c.current != nil:
+		c.current.Answer = strings.TrimPrefix(e.Text, answerPrefix)
+		c.flush()
+	}
+	return nil
+}
+
+// flush moves the current question to the history.
+func (c *questionCollector) flush() {
+	if c.current != nil {
+		c.questions = append(c.questions, *c.current)
+		c.current = nil
+	}
+}
+
+// result returns the history, an unanswered last question is pending if the session is still running.
+func (c *questionCollector) result(active bool) questionsResult {
+	var pending *questionEntry
+	if active {
+		pending, c.current = c.current, nil
+	}
+	c.flush()
+	if c.questions == nil {
+		c.questions = []questionEntry{}
+	}
+	return questionsResult{Questions: c.questions, Pending: pending}
+}
+
+// handleSessionQuestions returns the questions asked during plan creation and their answers,
+// rebuilt from the session's progress file, so prior decisions are available after a resume.
+func (s *Server) handleSessionQuestions(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	var qc questionCollector
+	if _, err := readProgressEvents(session.GetPath(), 0, 0, qc.add); err != nil {
+		log.Printf("[WARN] failed to read questions of session %s: %v", sessionID, err)
+		http.Error(w, "unable to read progress file", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(qc.result(session.GetState() == SessionStateActive))
+	if err != nil {
+		log.Printf("[WARN] failed to encode questions: %v", err)
+		http.Error(w, "unable to encode questions", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,135 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
+)
+
+func TestServer_HandleSessionQuestions(t *testing.T) {
+	// plan session with two answered questions and a third one still waiting, as left by an interrupted run
+	content := "# Ralphex Progress Log\nPlan: add caching\nMode: plan\n" + strings.Repeat("-", 60) + "\n\n" +
+		"--- plan iteration 1 ---\n" +
+		"[26-01-22 10:00:01] exploring codebase\n" +
+		"[26-01-22 10:00:02] QUESTION: Which cache backend?\n" +
+		"[26-01-22 10:00:02] OPTIONS: Redis, In-memory, File-based\n" +
+		"[26-01-22 10:00:05] ANSWER: Redis\n" +
+		"--- plan iteration 2 ---\n" +
+		"[26-01-22 10:01:00] QUESTION: Cache TTL?\n" +
+		"[26-01-22 10:01:00] OPTIONS: 1 minute, 1 hour\n" +
+		"[26-01-22 10:01:07] ANSWER: 1 hour\n" +
+		"--- plan iteration 3 ---\n" +
+		"[26-01-22 10:02:00] QUESTION: Invalidate on write?\n" +
+		"[26-01-22 10:02:00] OPTIONS: Yes, No\n"
+	progressPath := filepath.Join(t.TempDir(), "progress-plan-add-caching.txt")
+	require.NoError(t, os.WriteFile(progressPath, []byte(content), 0o600))
+
+	session := NewSession("main", progressPath)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(t *testing.T, sessionID string) (int, questionsResult) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/questions", http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionQuestions(w, req)
+		var res questionsResult
+		if w.Code == http.StatusOK {
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		}
+		return w.Code, res
+	}
+	ts := func(s string) time.Time {
+		v, err := time.Parse("06-01-02 15:04:05", s)
+		require.NoError(t, err)
+		return v
+	}
+	answered := []questionEntry{
+		{Question: "Which cache backend?", Options: []string{"Redis", "In-memory", "File-based"}, Answer: "Redis",
+			Timestamp: ts("26-01-22 10:00:02")},
+		{Question: "Cache TTL?", Options: []string{"1 minute", "1 hour"}, Answer: "1 hour", Timestamp: ts("26-01-22 10:01:00")},
+	}
+	unanswered := questionEntry{Question: "Invalidate on write?", Options: []string{"Yes", "No"},
+		Timestamp: ts("26-01-22 10:02:00")}
+
+	t.Run("active session has a pending question", func(t *testing.T) {
+		session.SetState(SessionStateActive)
+		code, res := request(t, "main")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, answered, res.Questions)
+		require.NotNil(t, res.Pending)
+		assert.Equal(t, unanswered, *res.Pending)
+	})
+
+	t.Run("finished session keeps the unanswered question in history", func(t *testing.T) {
+		session.SetState(SessionStateCanceled)
+		code, res := request(t, "main")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []questionEntry{answered[0], answered[1], unanswered}, res.Questions)
+		assert.Nil(t, res.Pending)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		code, _ := request(t, "other")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("session without questions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "progress-plan.txt")
+		require.NoError(t, os.WriteFile(path, []byte("[26-01-22 10:00:01] no questions here\n"), 0o600))
+		noQuestions := NewSession("none", path)
+		t.Cleanup(noQuestions.Close)
+		srv, err := NewServer(ServerConfig{Port: 8080}, noQuestions)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/none/questions", http.NoBody)
+		req.SetPathValue("id", "none")
+		w := httptest.NewRecorder()
+		srv.handleSessionQuestions(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"questions":[]}`, w.Body.String())
+	})
+}
+
+func TestQuestionCollector_JSONL(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	logger, err := progress.NewLogger(progress.Config{PlanDescription: "add caching", Mode: "plan", Branch: "main",
+		NoColor: true, Format: progress.FormatJSONL}, testColors())
+	require.NoError(t, err)
+	logger.LogQuestion("Which cache backend?", []string{"Redis", "In-memory"})
+	logger.LogAnswer("In-memory")
+	logger.LogQuestion("Cache TTL?", []string{"1 minute", "1 hour"})
+	require.NoError(t, logger.Close())
+
+	var qc questionCollector
+	_, err = readProgressEvents(filepath.Join(dir, logger.Path()), 0, 0, qc.add)
+	require.NoError(t, err)
+	res := qc.result(true)
+
+	require.Len(t, res.Questions, 1)
+	assert.Equal(t, "Which cache backend?", res.Questions[0].Question)
+	assert.Equal(t, []string{"Redis", "In-memory"}, res.Questions[0].Options)
+	assert.Equal(t, "In-memory", res.Questions[0].Answer)
+	assert.False(t, res.Questions[0].Timestamp.IsZero())
+	require.NotNil(t, res.Pending)
+	assert.Equal(t, "Cache TTL?", res.Pending.Question)
+	assert.Empty(t, res.Pending.Answer)
+}
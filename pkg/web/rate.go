@@ -0,0 +1,60 @@
+package web
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateTimeConstant is the time constant of the event rate moving average,
+// a burst of events fades to about a third of its rate after this long.
+const rateTimeConstant = 30 * time.Second
+
+// rateMeter tracks the event rate of a session as an exponential moving average of events per second.
+// events are counted with mark, the average is updated on each tick from the events counted since the previous one.
+// the first tick only starts the measurement, so history loaded before it doesn't count as throughput.
+type rateMeter struct {
+	mu    sync.Mutex
+	count int64     // events since the last tick
+	rate  float64   // moving average, events per second
+	last  time.Time // time of the last tick, zero before the first one
+}
+
+// mark counts one event.
+func (m *rateMeter) mark() {
+	m.mu.Lock()
+	m.count++
+	m.mu.Unlock()
+}
+
+// tick folds the events counted since the previous tick into the average and returns it.
+// the weight of the new sample grows with the time since the previous tick, so irregular ticks are handled.
+func (m *rateMeter) tick(now time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := m.count
+	m.count = 0
+	if m.last.IsZero() {
+		m.last = now
+		return m.rate
+	}
+	dt := now.Sub(m.last)
+	if dt <= 0 {
+		m.count = count // keep the events for the next tick
+		return m.rate
+	}
+	m.last = now
+
+	sample := float64(count) / dt.Seconds()
+	alpha := 1 - math.Exp(-dt.Seconds()/rateTimeConstant.Seconds())
+	m.rate += alpha * (sample - m.rate)
+	return m.rate
+}
+
+// value returns the current average without updating it.
+func (m *rateMeter) value() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rate
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateMeter(t *testing.T) {
+	start := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+
+	t.Run("first tick starts the window", func(t *testing.T) {
+		var m rateMeter
+		for range 100 {
+			m.mark()
+		}
+		assert.InDelta(t, 0, m.tick(start), 1e-9, "events before the first tick are not counted")
+		assert.InDelta(t, 0, m.tick(start.Add(15*time.Second)), 1e-9)
+	})
+
+	t.Run("moving average", func(t *testing.T) {
+		var m rateMeter
+		m.tick(start)
+		for range 30 {
+			m.mark()
+		}
+		alpha := 1 - math.Exp(-0.5) // 15s tick with 30s time constant
+		rate := m.tick(start.Add(15 * time.Second))
+		assert.InDelta(t, alpha*2, rate, 1e-9, "30 events in 15s is a sample of 2/s")
+		assert.InDelta(t, rate, m.value(), 1e-9)
+
+		for range 30 {
+			m.mark()
+		}
+		assert.InDelta(t, alpha*2+alpha*(2-alpha*2), m.tick(start.Add(30*time.Second)), 1e-9)
+	})
+
+	t.Run("decays to zero when idle", func(t *testing.T) {
+		var m rateMeter
+		m.tick(start)
+		for range 60 {
+			m.mark()
+		}
+		peak := m.tick(start.Add(15 * time.Second))
+		assert.Greater(t, peak, 0.0)
+		assert.InDelta(t, peak*math.Exp(-1), m.tick(start.Add(45*time.Second)), 1e-9)
+		assert.InDelta(t, 0, m.tick(start.Add(time.Hour)), 1e-6)
+	})
+
+	t.Run("tick without elapsed time keeps the events", func(t *testing.T) {
+		var m rateMeter
+		m.tick(start)
+		for range 30 {
+			m.mark()
+		}
+		assert.InDelta(t, 0, m.tick(start), 1e-9)
+		assert.InDelta(t, (1-math.Exp(-0.5))*2, m.tick(start.Add(15*time.Second)), 1e-9)
+	})
+}
@@ -0,0 +1,57 @@
+package web
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all clients of the mutating endpoints.
+// it holds up to perMin tokens, refilled continuously at perMin tokens per minute.
+// a nil rateLimiter allows everything.
+type rateLimiter struct {
+	mu     sync.Mutex
+	perMin int
+	tokens float64
+	last   time.Time
+	now    func() time.Time // clock, replaced in tests
+}
+
+// newRateLimiter creates a full bucket allowing perMin requests per minute, returns nil if perMin is not positive.
+func newRateLimiter(perMin int) *rateLimiter {
+	if perMin <= 0 {
+		return nil
+	}
+	return &rateLimiter{perMin: perMin, tokens: float64(perMin), now: time.Now}
+}
+
+// allow takes a token from the bucket. if the bucket is empty it returns false
+// with the time until the next token is available.
+func (l *rateLimiter) allow() (ok bool, retryAfter time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		refill := now.Sub(l.last).Minutes() * float64(l.perMin)
+		l.tokens = math.Min(float64(l.perMin), l.tokens+refill)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	missing := (1 - l.tokens) / float64(l.perMin) // fraction of a minute until a full token
+	return false, time.Duration(missing * float64(time.Minute))
+}
+
+// retryAfterSeconds formats a wait duration as the Retry-After header value, rounded up to whole seconds.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
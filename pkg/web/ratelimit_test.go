@@ -0,0 +1,102 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newRateLimiter(0))
+		assert.Nil(t, newRateLimiter(-5))
+		var l *rateLimiter
+		for range 100 {
+			ok, _ := l.allow()
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("exhausts and refills", func(t *testing.T) {
+		now := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+		l := newRateLimiter(6) // one token every 10s
+		l.now = func() time.Time { return now }
+
+		for i := range 6 {
+			ok, _ := l.allow()
+			require.True(t, ok, "request %d", i)
+		}
+		ok, retryAfter := l.allow()
+		assert.False(t, ok)
+		assert.Equal(t, 10*time.Second, retryAfter)
+
+		now = now.Add(4 * time.Second)
+		ok, retryAfter = l.allow()
+		assert.False(t, ok)
+		assert.InDelta(t, 6*time.Second, retryAfter, float64(time.Millisecond))
+
+		now = now.Add(6 * time.Second)
+		ok, _ = l.allow()
+		assert.True(t, ok, "a token is back after 10s")
+		ok, _ = l.allow()
+		assert.False(t, ok)
+
+		now = now.Add(time.Hour)
+		for i := range 6 {
+			ok, _ = l.allow()
+			require.True(t, ok, "bucket is full again, request %d", i)
+		}
+		ok, _ = l.allow()
+		assert.False(t, ok, "refill is capped at the bucket size")
+	})
+}
+
+func TestServer_RateLimited(t *testing.T) {
+	now := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+	srv, err := NewServer(ServerConfig{Port: 8080, RateLimitPerMin: 2}, nil)
+	require.NoError(t, err)
+	srv.limiter.now = func() time.Time { return now }
+
+	calls := 0
+	handler := srv.rateLimited(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	})
+	request := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPost, "/api/sessions/x/pause", http.NoBody))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, request().Code)
+	assert.Equal(t, http.StatusOK, request().Code)
+
+	w := request()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, 2, calls, "limited request doesn't reach the handler")
+
+	now = now.Add(29500 * time.Millisecond)
+	w = request()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"), "rounded up to whole seconds")
+
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, request().Code, "recovers after the window")
+	assert.Equal(t, 3, calls)
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080}, nil)
+		require.NoError(t, err)
+		for range 100 {
+			w := httptest.NewRecorder()
+			srv.rateLimited(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })(w,
+				httptest.NewRequest(http.MethodPost, "/api/sessions/x/pause", http.NoBody))
+			require.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+}
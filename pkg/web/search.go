@@ -0,0 +1,125 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// maxSearchMatches limits the number of matches returned by the session search endpoint.
+const maxSearchMatches = 500
+
+// searchSnippetRadius is the number of bytes of context kept on each side of a match in a snippet.
+const searchSnippetRadius = 60
+
+// searchMatch is a single event matching a search query.
+// Seq is the position of the event in the progress file, starting at 0.
+type searchMatch struct {
+	Seq     int    `json:"seq"`
+	Event   Event  `json:"event"`
+	Snippet string `json:"snippet"`
+}
+
+// searchResult is the response of the session search endpoint.
+// Total counts all matching events, Matches holds at most maxSearchMatches of them.
+type searchResult struct {
+	Query     string        `json:"query"`
+	Total     int           `json:"total"`
+	Truncated bool          `json:"truncated,omitempty"`
+	Matches   []searchMatch `json:"matches"`
+}
+
+// handleSessionSearch searches the events of a session's progress file, including history that
+// is no longer in the replay buffer. accepts ?q=<query>, ?regex=true to treat the query as a regular
+// expression and ?ignoreCase=true for case-insensitive matching. an invalid pattern is rejected with 400.
+func (s *Server) handleSessionSearch(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	q := query.Get("q")
+	if q == "" {
+		http.Error(w, "missing q parameter", http.StatusBadRequest)
+		return
+	}
+	isRegex, _ := strconv.ParseBool(query.Get("regex"))
+	ignoreCase, _ := strconv.ParseBool(query.Get("ignoreCase"))
+	re, err := compileSearchPattern(q, isRegex, ignoreCase)
+	if err != nil {
+		http.Error(w, "invalid regex: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := searchResult{Query: q, Matches: []searchMatch{}}
+	seq := 0
+	collect := func(e Event) error {
+		defer func() { seq++ }()
+		loc := re.FindStringIndex(e.Text)
+		if loc == nil {
+			return nil
+		}
+		result.Total++
+		if len(result.Matches) >= maxSearchMatches {
+			result.Truncated = true
+			return nil
+		}
+		result.Matches = append(result.Matches, searchMatch{Seq: seq, Event: e, Snippet: searchSnippet(e.Text, loc[0], loc[1])})
+		return nil
+	}
+	if _, err := readProgressEvents(session.GetPath(), 0, 0, collect); err != nil {
+		log.Printf("[WARN] failed to search session %s: %v", sessionID, err)
+		http.Error(w, "unable to read progress file", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("[WARN] failed to encode search result: %v", err)
+		http.Error(w, "unable to encode search result", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// compileSearchPattern builds the matcher for a search query. literal queries are quoted,
+// so both modes share the same matching code.
+func compileSearchPattern(q string, isRegex, ignoreCase bool) (*regexp.Regexp, error) {
+	pattern := q
+	if !isRegex {
+		pattern = regexp.QuoteMeta(q)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern) //nolint:wrapcheck // the caller reports the compile error as is
+}
+
+// searchSnippet returns the text around the match at [start, end), cut to searchSnippetRadius bytes
+// on each side and marked with "..." where text was cut. cuts never split a multibyte character.
+func searchSnippet(text string, start, end int) string {
+	from := max(start-searchSnippetRadius, 0)
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	to := min(end+searchSnippetRadius, len(text))
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	snippet := text[from:to]
+	if from > 0 {
+		snippet = "..." + snippet
+	}
+	if to < len(text) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HandleSessionSearch(t *testing.T) {
+	content := "# Ralphex Progress Log\nPlan: plan.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n" +
+		"--- task iteration 1 ---\n" +
+		"[26-01-22 10:00:01] running Tests for pkg/web\n" +
+		"[26-01-22 10:00:02] all tests passed\n" +
+		"[26-01-22 10:00:03] ERROR: build failed on line 42\n"
+	progressPath := filepath.Join(t.TempDir(), "progress-plan.txt")
+	require.NoError(t, os.WriteFile(progressPath, []byte(content), 0o600))
+
+	session := NewSession("main", progressPath)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(sessionID string, params url.Values) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/search?"+params.Encode(), http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionSearch(w, req)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	search := func(t *testing.T, params url.Values) searchResult {
+		t.Helper()
+		code, body := request("main", params)
+		require.Equal(t, http.StatusOK, code, body)
+		var res searchResult
+		require.NoError(t, json.Unmarshal([]byte(body), &res))
+		return res
+	}
+	texts := func(res searchResult) []string {
+		var out []string
+		for _, m := range res.Matches {
+			out = append(out, m.Event.Text)
+		}
+		return out
+	}
+
+	t.Run("literal is case sensitive", func(t *testing.T) {
+		res := search(t, url.Values{"q": {"tests"}})
+		assert.Equal(t, "tests", res.Query)
+		assert.Equal(t, 1, res.Total)
+		assert.Equal(t, []string{"all tests passed"}, texts(res))
+		assert.Equal(t, 3, res.Matches[0].Seq, "task start and section events come first")
+	})
+
+	t.Run("literal treats regex characters as text", func(t *testing.T) {
+		res := search(t, url.Values{"q": {"pkg/web"}})
+		assert.Equal(t, []string{"running Tests for pkg/web"}, texts(res))
+		res = search(t, url.Values{"q": {"line .2"}})
+		assert.Zero(t, res.Total)
+		assert.NotNil(t, res.Matches, "no matches is an empty list")
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		res := search(t, url.Values{"q": {"TESTS"}, "ignoreCase": {"true"}})
+		assert.Equal(t, 2, res.Total)
+		assert.Equal(t, []string{"running Tests for pkg/web", "all tests passed"}, texts(res))
+	})
+
+	t.Run("regex", func(t *testing.T) {
+		res := search(t, url.Values{"q": {`line \d+$`}, "regex": {"true"}})
+		assert.Equal(t, []string{"ERROR: build failed on line 42"}, texts(res))
+		assert.Equal(t, EventTypeError, res.Matches[0].Event.Type)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		code, body := request("main", url.Values{"q": {"(unclosed"}, "regex": {"true"}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Contains(t, body, "invalid regex")
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		code, _ := request("main", url.Values{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		code, body := request("nonexistent", url.Values{"q": {"x"}})
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "session not found")
+	})
+}
+
+func TestServer_HandleSessionSearch_Limit(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("# Ralphex Progress Log\nPlan: plan.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n")
+	for range maxSearchMatches + 5 {
+		sb.WriteString("[26-01-22 10:00:01] match me\n")
+	}
+	progressPath := filepath.Join(t.TempDir(), "progress-plan.txt")
+	require.NoError(t, os.WriteFile(progressPath, []byte(sb.String()), 0o600))
+
+	session := NewSession("main", progressPath)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/main/search?q=match", http.NoBody)
+	req.SetPathValue("id", "main")
+	w := httptest.NewRecorder()
+	srv.handleSessionSearch(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res searchResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, maxSearchMatches+5, res.Total)
+	assert.Len(t, res.Matches, maxSearchMatches)
+	assert.True(t, res.Truncated)
+}
+
+func TestSearchSnippet(t *testing.T) {
+	long := strings.Repeat("a", 100) + "needle" + strings.Repeat("b", 100)
+	tests := []struct {
+		name       string
+		text       string
+		start, end int
+		want       string
+	}{
+		{name: "short text kept whole", text: "find the needle here", start: 9, end: 15, want: "find the needle here"},
+		{name: "cut on both sides", text: long, start: 100, end: 106,
+			want: "..." + strings.Repeat("a", 60) + "needle" + strings.Repeat("b", 60) + "..."},
+		{name: "match at start", text: "needle" + strings.Repeat("b", 100), start: 0, end: 6,
+			want: "needle" + strings.Repeat("b", 60) + "..."},
+		{name: "multibyte boundary", text: strings.Repeat("é", 40) + "needle", start: 80, end: 86,
+			want: "..." + strings.Repeat("é", 30) + "needle"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, searchSnippet(tc.text, tc.start, tc.end))
+		})
+	}
+}
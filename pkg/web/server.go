@@ -2,6 +2,7 @@ package web
 
 import (
 	"context"
+	"crypto/subtle"
 	"embed"
 	"encoding/json"
 	"errors"
@@ -9,11 +10,21 @@ import (
 	"html/template"
 	"io/fs"
 	"log"
+	"net"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/git"
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 //go:embed templates static
@@ -21,12 +32,39 @@ var embeddedFS embed.FS
 
 // ServerConfig holds configuration for the web server.
 type ServerConfig struct {
-	Port     int    // port to listen on
-	PlanName string // plan name to display in dashboard
-	Branch   string // git branch name
-	PlanFile string // path to plan file for /api/plan endpoint
+	Port      int    // port to listen on
+	Host      string // interface address to bind Port on, empty uses DefaultHost
+	PlanName  string // plan name to display in dashboard
+	Branch    string // git branch name
+	PlanFile  string // path to plan file for /api/plan endpoint
+	AuthToken string // if set, all routes require this token (bearer or basic auth password)
+	Socket    string // if set, listen on this unix socket instead of Port
+	ReadOnly  bool   // if set, endpoints changing session state respond with 403
+
+	CodexDisabled bool // omit the codex phase from /api/phases
+
+	RateLimitPerMin int // max requests per minute to endpoints changing session state, 0 disables the limit
+
+	ShutdownTimeout   time.Duration // grace period for draining connections on shutdown, 0 uses defaultShutdownTimeout
+	HeartbeatInterval time.Duration // how often sessions send heartbeat events to SSE clients, 0 uses defaultHeartbeatInterval
+	KeepAlive         time.Duration // idle time before SSE streams get a keep-alive comment, 0 uses defaultKeepAliveInterval
+
+	BuildInfo     BuildInfo // version of the running binary, reported by /api/version
+	ConfigSources []string  // config files loaded at startup, reported by /api/version
 }
 
+// DefaultHost is the address the server binds to when no host is configured, keeping the dashboard local.
+const DefaultHost = "127.0.0.1"
+
+// defaultShutdownTimeout is how long shutdown waits for open requests to finish.
+const defaultShutdownTimeout = 5 * time.Second
+
+// defaultHeartbeatInterval is how often sessions send heartbeat events when no interval is configured.
+const defaultHeartbeatInterval = 15 * time.Second
+
+// webActor is the actor recorded for actions requested from the dashboard.
+const webActor = "web"
+
 // Server provides HTTP server for the real-time dashboard.
 type Server struct {
 	cfg     ServerConfig
@@ -34,6 +72,8 @@ type Server struct {
 	sm      *SessionManager // used for multi-session mode (dashboard)
 	srv     *http.Server
 	tmpl    *template.Template
+	started time.Time    // server creation time, reported as uptime by health endpoints
+	limiter *rateLimiter // limits endpoints changing session state, nil if unlimited
 
 	// plan caching - set after first successful load (single-session mode)
 	planMu    sync.Mutex
@@ -52,6 +92,8 @@ func NewServer(cfg ServerConfig, session *Session) (*Server, error) {
 		cfg:     cfg,
 		session: session,
 		tmpl:    tmpl,
+		started: time.Now(),
+		limiter: newRateLimiter(cfg.RateLimitPerMin),
 	}, nil
 }
 
@@ -64,9 +106,11 @@ func NewServerWithSessions(cfg ServerConfig, sm *SessionManager) (*Server, error
 	}
 
 	return &Server{
-		cfg:  cfg,
-		sm:   sm,
-		tmpl: tmpl,
+		cfg:     cfg,
+		sm:      sm,
+		tmpl:    tmpl,
+		started: time.Now(),
+		limiter: newRateLimiter(cfg.RateLimitPerMin),
 	}, nil
 }
 
@@ -80,6 +124,28 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/events", s.handleEvents)
 	mux.HandleFunc("/api/plan", s.handlePlan)
 	mux.HandleFunc("/api/sessions", s.handleSessions)
+	mux.HandleFunc("GET /api/events", s.handleManagementEvents)
+	mux.HandleFunc("GET /api/export", s.handleExport)
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
+	mux.HandleFunc("GET /api/version", s.handleVersion)
+	mux.HandleFunc("GET /api/phases", s.handlePhases)
+	mux.HandleFunc("GET /api/warnings", s.handleWarnings)
+	mux.HandleFunc("GET /api/sessions/{id}", s.handleSession)
+	mux.HandleFunc("GET /api/sessions/{id}/plan", s.handleSessionPlanTasks)
+	mux.HandleFunc("GET /api/sessions/{id}/diff", s.handleSessionDiff)
+	mux.HandleFunc("GET /api/sessions/{id}/status", s.handleSessionStatus)
+	mux.HandleFunc("GET /api/sessions/{id}/history", s.handleSessionHistory)
+	mux.HandleFunc("GET /api/sessions/{id}/search", s.handleSessionSearch)
+	mux.HandleFunc("GET /api/sessions/{id}/questions", s.handleSessionQuestions)
+	mux.HandleFunc("GET /api/sessions/{id}/iterations/{n}", s.handleSessionIteration)
+	mux.HandleFunc("GET /api/sessions/{id}/prompt", s.handleSessionPrompt)
+	mux.HandleFunc("POST /api/sessions/{id}/pause", s.writable(s.rateLimited(s.handleSessionPause)))
+	mux.HandleFunc("POST /api/sessions/{id}/resume", s.writable(s.rateLimited(s.handleSessionPause)))
+	mux.HandleFunc("POST /api/sessions/{id}/label", s.writable(s.rateLimited(s.handleSessionLabel)))
+	mux.HandleFunc("POST /api/sessions/{id}/pin", s.writable(s.rateLimited(s.handleSessionPin)))
+	mux.HandleFunc("POST /api/sessions/{id}/unpin", s.writable(s.rateLimited(s.handleSessionPin)))
+	mux.HandleFunc("POST /api/sessions/{id}/loglevel", s.writable(s.rateLimited(s.handleSessionLogLevel)))
 
 	// static files
 	staticFS, err := fs.Sub(embeddedFS, "static")
@@ -88,33 +154,177 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.FS(staticFS))))
 
+	ln, err := s.listen()
+	if err != nil {
+		return err
+	}
+	if s.cfg.Socket != "" {
+		defer func() {
+			if rmErr := os.Remove(s.cfg.Socket); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+				log.Printf("[WARN] failed to remove socket %s: %v", s.cfg.Socket, rmErr)
+			}
+		}()
+	}
+
 	s.srv = &http.Server{
-		Addr:              fmt.Sprintf("127.0.0.1:%d", s.cfg.Port),
-		Handler:           mux,
+		Addr:              ln.Addr().String(),
+		Handler:           s.requireAuth(mux),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
+	// heartbeats stop with the server, whether it was shut down or failed to serve
+	hbCtx, hbCancel := context.WithCancel(ctx)
+	defer hbCancel()
+	go s.heartbeatLoop(hbCtx)
+
 	// start shutdown listener
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		_ = s.srv.Shutdown(shutdownCtx)
+		if err := s.shutdown(); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
 	}()
 
-	err = s.srv.ListenAndServe()
+	err = s.srv.Serve(ln)
 	if errors.Is(err, http.ErrServerClosed) {
+		if ctx.Err() != nil {
+			<-shutdownDone // serve returns as soon as shutdown starts, wait for clients to drain
+		}
 		return nil
 	}
 	return fmt.Errorf("http server: %w", err)
 }
 
+// heartbeatLoop sends a heartbeat on every session served until ctx is canceled.
+func (s *Server) heartbeatLoop(ctx context.Context) {
+	interval := s.cfg.HeartbeatInterval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.heartbeat(now)
+		}
+	}
+}
+
+// heartbeat sends a heartbeat on the single session or on every session of the manager.
+func (s *Server) heartbeat(now time.Time) {
+	sessions := []*Session{s.session}
+	if s.sm != nil {
+		sessions = s.sm.All()
+	}
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		if _, err := session.Heartbeat(now); err != nil && !errors.Is(err, sse.ErrProviderClosed) {
+			log.Printf("[WARN] heartbeat for session %s: %v", session.ID, err)
+		}
+	}
+}
+
+// ListenAddr describes where the server listens, e.g. "127.0.0.1:8080", "[::1]:8080" or "socket /run/ralphex.sock".
+func (s *Server) ListenAddr() string {
+	if s.cfg.Socket != "" {
+		return "socket " + s.cfg.Socket
+	}
+	return s.tcpAddr()
+}
+
+// tcpAddr returns the host:port address to listen on, bracketing IPv6 hosts.
+func (s *Server) tcpAddr() string {
+	host := s.cfg.Host
+	if host == "" {
+		host = DefaultHost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(s.cfg.Port))
+}
+
+// listen creates the server listener, a unix socket if configured or TCP on the configured host otherwise.
+func (s *Server) listen() (net.Listener, error) {
+	if s.cfg.Socket == "" {
+		ln, err := net.Listen("tcp", s.tcpAddr())
+		if err != nil {
+			return nil, fmt.Errorf("listen tcp: %w", err)
+		}
+		return ln, nil
+	}
+
+	// refuse to take over a live socket, remove a stale one left by a crashed process.
+	// anything other than a socket at that path is left alone, it's likely a mistyped flag
+	if fi, err := os.Lstat(s.cfg.Socket); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("socket path %s exists and is not a socket", s.cfg.Socket)
+		}
+		if conn, dialErr := net.DialTimeout("unix", s.cfg.Socket, time.Second); dialErr == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("socket %s is already in use", s.cfg.Socket)
+		}
+		if rmErr := os.Remove(s.cfg.Socket); rmErr != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", rmErr)
+		}
+	}
+
+	ln, err := net.Listen("unix", s.cfg.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("listen unix: %w", err)
+	}
+	return ln, nil
+}
+
+// requireAuth wraps the handler with token authentication if AuthToken is configured.
+// accepts "Authorization: Bearer <token>" or basic auth with the token as password (any username),
+// so browsers can log in via the native prompt. the SSE endpoints also accept the token
+// as ?token= query parameter because EventSource can't set headers.
+func (s *Server) requireAuth(next http.Handler) http.Handler {
+	if s.cfg.AuthToken == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// health probes carry no session data and orchestrators can't always send credentials
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="ralphex"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorized checks request credentials against the configured token.
+func (s *Server) authorized(r *http.Request) bool {
+	var provided string
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		provided = strings.TrimSpace(bearer)
+	} else if _, password, ok := r.BasicAuth(); ok {
+		provided = password
+	} else if r.URL.Path == "/events" || r.URL.Path == "/api/events" {
+		provided = r.URL.Query().Get("token")
+	}
+	if provided == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(s.cfg.AuthToken)) == 1
+}
+
 // Stop gracefully shuts down the server.
 func (s *Server) Stop() error {
 	if s.srv == nil {
 		return nil
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
 	defer cancel()
 	if err := s.srv.Shutdown(ctx); err != nil {
 		return fmt.Errorf("shutdown server: %w", err)
@@ -122,6 +332,41 @@ func (s *Server) Stop() error {
 	return nil
 }
 
+// shutdown stops accepting connections, ends all SSE streams and stops session tailers,
+// then waits up to the shutdown timeout for open requests. remaining connections are closed forcibly.
+func (s *Server) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout())
+	defer cancel()
+
+	// http shutdown closes the listeners right away and then waits for active requests,
+	// SSE streams never finish on their own, so they are ended while it waits
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.srv.Shutdown(ctx)
+	}()
+
+	if s.sm != nil {
+		s.sm.Close() // closes the management hub and all sessions
+	}
+	if s.session != nil {
+		s.session.Close()
+	}
+
+	if err := <-errCh; err != nil {
+		_ = s.srv.Close()
+		return fmt.Errorf("shutdown server: %w", err)
+	}
+	return nil
+}
+
+// shutdownTimeout returns the configured shutdown grace period or the default.
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.cfg.ShutdownTimeout > 0 {
+		return s.cfg.ShutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
 // Session returns the server's session (for single-session mode).
 func (s *Server) Session() *Session {
 	return s.session
@@ -205,20 +450,12 @@ func (s *Server) handleSessionPlan(w http.ResponseWriter, sessionID string) {
 	}
 
 	meta := session.GetMetadata()
-	if meta.PlanPath == "" {
+	planPath := sessionPlanPath(session)
+	if planPath == "" {
 		http.Error(w, "no plan file for session", http.StatusNotFound)
 		return
 	}
 
-	// resolve plan path: absolute paths used as-is, relative paths resolved from session directory
-	var planPath string
-	if filepath.IsAbs(meta.PlanPath) {
-		planPath = meta.PlanPath
-	} else {
-		sessionDir := filepath.Dir(session.Path)
-		planPath = filepath.Join(sessionDir, meta.PlanPath)
-	}
-
 	plan, err := loadPlanWithFallback(planPath)
 	if err != nil {
 		log.Printf("[WARN] failed to load plan file %s: %v", meta.PlanPath, err)
@@ -237,6 +474,221 @@ func (s *Server) handleSessionPlan(w http.ResponseWriter, sessionID string) {
 	_, _ = w.Write(data)
 }
 
+// handleSessionPlanTasks serves the plan checkboxes for a session as a JSON tree, see ParsePlanTasks.
+// responds with 404 "Plan not available" if the session has no plan or the file is missing,
+// and with 422 "No tasks in plan" if the plan exists but has no checkboxes.
+func (s *Server) handleSessionPlanTasks(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	planPath := sessionPlanPath(session)
+	if planPath == "" {
+		http.Error(w, "Plan not available", http.StatusNotFound)
+		return
+	}
+
+	tasks, err := ParsePlanTasks(planPath)
+	if err != nil && errors.Is(err, fs.ErrNotExist) {
+		tasks, err = ParsePlanTasks(filepath.Join(filepath.Dir(planPath), "completed", filepath.Base(planPath)))
+	}
+	if err != nil {
+		log.Printf("[WARN] failed to load plan file %s: %v", planPath, err)
+		http.Error(w, "Plan not available", http.StatusNotFound)
+		return
+	}
+	if len(tasks) == 0 {
+		http.Error(w, "No tasks in plan", http.StatusUnprocessableEntity)
+		return
+	}
+
+	data, err := json.Marshal(tasks)
+	if err != nil {
+		log.Printf("[WARN] failed to encode plan tasks: %v", err)
+		http.Error(w, "unable to encode plan tasks", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// handleSessionDiff serves the unified diff between the session's starting commit and the current HEAD
+// of the repository holding the session's progress file.
+// returns 404 if the session is unknown or its progress header has no starting commit.
+func (s *Server) handleSessionDiff(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	// header is parsed on demand since single-session mode doesn't populate metadata
+	path := session.GetPath()
+	meta, err := ParseProgressHeader(path)
+	if err != nil || meta.StartCommit == "" {
+		http.Error(w, "Start commit not recorded for session", http.StatusNotFound)
+		return
+	}
+
+	gitSvc, err := git.NewService(filepath.Dir(path), gitLogger{})
+	if err != nil {
+		log.Printf("[WARN] failed to open repository for session %s: %v", sessionID, err)
+		http.Error(w, "Repository not available", http.StatusNotFound)
+		return
+	}
+	diff, err := gitSvc.DiffSince(meta.StartCommit)
+	if err != nil {
+		log.Printf("[WARN] failed to diff session %s: %v", sessionID, err)
+		http.Error(w, "unable to build diff", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(diff))
+}
+
+// historyChunk is the response of the session history endpoint.
+// Start is the file offset of the first returned event, the next chunk is requested with before=Start.
+type historyChunk struct {
+	Start  int64   `json:"start"`
+	Events []Event `json:"events"`
+}
+
+// handleSessionHistory returns events from the part of a progress file that was skipped when loading
+// a large completed session. accepts ?before=<offset> and returns up to progressTailSize bytes of
+// content ending at that offset.
+func (s *Server) handleSessionHistory(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	path := session.GetPath()
+	info, err := os.Stat(path)
+	if err != nil {
+		http.Error(w, "progress file not available", http.StatusNotFound)
+		return
+	}
+	before, err := strconv.ParseInt(r.URL.Query().Get("before"), 10, 64)
+	if err != nil || before <= 0 || before > info.Size() {
+		http.Error(w, "invalid before offset", http.StatusBadRequest)
+		return
+	}
+
+	chunk := historyChunk{Events: []Event{}}
+	collect := func(e Event) error {
+		chunk.Events = append(chunk.Events, e)
+		return nil
+	}
+	chunk.Start, err = readProgressEvents(path, max(before-progressTailSize, 0), before, collect)
+	if err != nil {
+		log.Printf("[WARN] failed to read history of session %s: %v", sessionID, err)
+		http.Error(w, "unable to read history", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(chunk)
+	if err != nil {
+		log.Printf("[WARN] failed to encode history: %v", err)
+		http.Error(w, "unable to encode history", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// gitLogger adapts the standard logger to git.Logger for git operations done by the server.
+type gitLogger struct{}
+
+func (gitLogger) Printf(format string, args ...any) (int, error) {
+	log.Printf("[DEBUG] "+format, args...)
+	return 0, nil
+}
+
+// writable wraps a handler changing session state, in read-only mode it responds with 403 instead.
+func (s *Server) writable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.ReadOnly {
+			http.Error(w, "dashboard is read-only", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// rateLimited wraps a handler changing session state, responding with 429 and Retry-After
+// once the configured number of requests per minute is used up.
+func (s *Server) rateLimited(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ok, retryAfter := s.limiter.allow(); !ok {
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleSessionPause pauses or resumes the runner of a session, depending on the route.
+// only sessions executed by this process can be controlled, others get 409.
+// responds with the resulting pause state as JSON.
+func (s *Server) handleSessionPause(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+	if !progress.IsPathLockedByCurrentProcess(session.GetPath()) {
+		http.Error(w, "session is not running in this process", http.StatusConflict)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/resume") {
+		session.Resume(webActor)
+	} else {
+		session.Pause(webActor)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Paused bool `json:"paused"`
+	}{Paused: session.IsPaused()})
+}
+
+// lookupSession returns the session with the given ID from the session manager,
+// or the direct session in single-session mode. returns nil if not found.
+func (s *Server) lookupSession(id string) *Session {
+	if s.sm != nil {
+		return s.sm.Get(id)
+	}
+	if s.session != nil && s.session.ID == id {
+		return s.session
+	}
+	return nil
+}
+
+// sessionPlanPath resolves the plan file path from session metadata.
+// absolute paths are used as-is, relative paths are resolved from the session directory.
+// returns empty string if the session has no plan.
+func sessionPlanPath(session *Session) string {
+	meta := session.GetMetadata()
+	if meta.PlanPath == "" {
+		return ""
+	}
+	if filepath.IsAbs(meta.PlanPath) {
+		return meta.PlanPath
+	}
+	return filepath.Join(filepath.Dir(session.GetPath()), meta.PlanPath)
+}
+
 // loadPlan returns a cached plan or loads it from disk (with completed/ fallback).
 func (s *Server) loadPlan() (*Plan, error) {
 	s.planMu.Lock()
@@ -268,6 +720,8 @@ func loadPlanWithFallback(path string) (*Plan, error) {
 
 // handleEvents serves the SSE stream.
 // in multi-session mode, accepts ?session=<id> query parameter.
+// idle streams get keep-alive comments, see keepAliveWriter, and live events are queued
+// per client, so a slow client only coalesces its own output, see subscriberQueue.
 func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 	sessionID := r.URL.Query().Get("session")
 	log.Printf("[SSE] connection request: session=%s", sessionID)
@@ -280,15 +734,80 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// delegate to go-sse Server which handles:
-	// - SSE protocol (headers, event formatting)
-	// - Connection management
+	sess, err := sse.Upgrade(w, r)
+	if err != nil {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// the config event goes to this client only, ahead of the replayed history
+	if err := sess.Send(s.configMessage()); err != nil {
+		log.Printf("[SSE] failed to send config: %v", err)
+		return
+	}
+	if err := sess.Flush(); err != nil {
+		return
+	}
+	session.EnsureLoaded()
+
+	// keep-alive comments and queued events stop before the handler returns, the response can't be written after that.
+	// a failed write ends the stream
+	client := newKeepAliveWriter(sess)
+	queue := newSubscriberQueue(client)
+	streamCtx, stopStream := context.WithCancel(r.Context())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		client.run(streamCtx, s.keepAliveInterval())
+	}()
+	go func() {
+		defer wg.Done()
+		if err := queue.run(streamCtx); err != nil {
+			stopStream()
+		}
+	}()
+	defer func() {
+		stopStream()
+		wg.Wait()
+	}()
+
+	// subscribe to the session's go-sse provider which handles:
 	// - History replay via FiniteReplayer
+	// - Live events
 	// - Graceful disconnection
-	session.SSE.ServeHTTP(w, r)
+	sub := sse.Subscription{Client: queue, LastEventID: sess.LastEventID, Topics: []string{defaultTopic}}
+	if err := session.SSE.Provider.Subscribe(streamCtx, sub); err != nil {
+		log.Printf("[SSE] subscribe error: session=%s - %v", sessionID, err)
+	}
+	if skipped := queue.Skipped(); skipped > 0 {
+		log.Printf("[SSE] slow client skipped %d output lines: session=%s", skipped, sessionID)
+	}
 	log.Printf("[SSE] connection closed: session=%s", sessionID)
 }
 
+// keepAliveInterval returns the idle time after which SSE streams get a keep-alive comment.
+func (s *Server) keepAliveInterval() time.Duration {
+	if s.cfg.KeepAlive > 0 {
+		return s.cfg.KeepAlive
+	}
+	return defaultKeepAliveInterval
+}
+
+// configEventType is the SSE event name of the server config message sent when a client connects.
+const configEventType = "config"
+
+// configMessage returns the SSE message describing server settings the dashboard adapts to.
+// it has an event name and no ID, so clients don't mix it with output events and replay is unaffected.
+func (s *Server) configMessage() *sse.Message {
+	data, _ := json.Marshal(struct {
+		ReadOnly bool `json:"readOnly"`
+	}{ReadOnly: s.cfg.ReadOnly})
+	msg := &sse.Message{Type: sse.Type(configEventType)}
+	msg.AppendData(string(data))
+	return msg
+}
+
 // getSession returns the session for the request.
 // in single-session mode, returns the server's session.
 // in multi-session mode, looks up the session by ID from query parameter.
@@ -322,10 +841,33 @@ type SessionInfo struct {
 	// DirPath is the full filesystem path to the session directory (used for grouping and copy-to-clipboard).
 	DirPath      string    `json:"dirPath,omitempty"`
 	PlanPath     string    `json:"planPath,omitempty"`
+	Label        string    `json:"label,omitempty"`  // user-assigned name, shown instead of the plan name
+	Pinned       bool      `json:"pinned,omitempty"` // session is exempt from retention pruning
 	Branch       string    `json:"branch,omitempty"`
 	Mode         string    `json:"mode,omitempty"`
 	StartTime    time.Time `json:"startTime"`
 	LastModified time.Time `json:"lastModified"`
+	// HistoryOffset is the progress file offset where loaded output starts, >0 means earlier output
+	// can be fetched from /api/sessions/{id}/history.
+	HistoryOffset int64 `json:"historyOffset,omitempty"`
+	// OwnerHost and OwnerPID identify the process running an active session.
+	// not set for finished sessions or when the recorded owner process is gone.
+	OwnerHost string `json:"ownerHost,omitempty"`
+	OwnerPID  int    `json:"ownerPid,omitempty"`
+	// PhaseTimingsMs is the time spent in each phase in milliseconds, set for finished sessions
+	// whose progress file recorded phase timings.
+	PhaseTimingsMs map[processor.Phase]int64 `json:"phaseTimingsMs,omitempty"`
+	// Tokens is the provider-reported token usage per phase, set for finished sessions that tracked it.
+	// TokensUnavailable is set instead when usage was tracked but the provider never reported it.
+	Tokens            map[processor.Phase]int64 `json:"tokens,omitempty"`
+	TokensUnavailable bool                      `json:"tokensUnavailable,omitempty"`
+	// LastError is the most recent error event of the session, e.g. the error that stopped a failed run.
+	LastError *Event `json:"lastError,omitempty"`
+	// PlanFile is the plan file produced by a finished plan creation session.
+	PlanFile string `json:"planFile,omitempty"`
+
+	// EventRate is the moving average of events per second, as of the last heartbeat.
+	EventRate float64 `json:"eventRate"`
 }
 
 // handleSessions returns a list of all discovered sessions.
@@ -353,27 +895,7 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 	// convert to API response format
 	infos := make([]SessionInfo, 0, len(sessions))
 	for _, session := range sessions {
-		meta := session.GetMetadata()
-		var dirPath string
-		if absPath, err := filepath.Abs(session.Path); err == nil {
-			dirPath = filepath.Dir(absPath)
-		} else {
-			dirPath = filepath.Dir(session.Path)
-			if dirPath == "." || dirPath == ".." {
-				dirPath = ""
-			}
-		}
-		infos = append(infos, SessionInfo{
-			ID:           session.ID,
-			State:        session.GetState(),
-			Dir:          extractProjectDir(session.Path),
-			DirPath:      dirPath,
-			PlanPath:     meta.PlanPath,
-			Branch:       meta.Branch,
-			Mode:         meta.Mode,
-			StartTime:    meta.StartTime,
-			LastModified: session.GetLastModified(),
-		})
+		infos = append(infos, newSessionInfo(session))
 	}
 
 	data, err := json.Marshal(infos)
@@ -387,6 +909,136 @@ func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(data)
 }
 
+// handleWarnings returns the watched directories that couldn't be scanned for sessions, see SessionManager.Warnings.
+// single-session mode doesn't scan directories and always returns an empty list.
+func (s *Server) handleWarnings(w http.ResponseWriter, _ *http.Request) {
+	warnings := []DirWarning{}
+	if s.sm != nil {
+		warnings = s.sm.Warnings()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(warnings)
+}
+
+// handleSession returns a single session, including the phase timings, token usage and produced plan file
+// of finished sessions.
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	session := s.lookupSession(sessionID)
+	if session == nil {
+		http.Error(w, "session not found: "+sessionID, http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(newSessionInfo(session))
+	if err != nil {
+		log.Printf("[WARN] failed to encode session %s: %v", sessionID, err)
+		http.Error(w, "unable to encode session", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// newSessionInfo builds the API representation of a session.
+func newSessionInfo(session *Session) SessionInfo {
+	meta, path := session.GetMetadata(), session.GetPath()
+	var dirPath string
+	if absPath, err := filepath.Abs(path); err == nil {
+		dirPath = filepath.Dir(absPath)
+	} else {
+		dirPath = filepath.Dir(path)
+		if dirPath == "." || dirPath == ".." {
+			dirPath = ""
+		}
+	}
+	state := session.GetState()
+	info := SessionInfo{
+		ID:            session.ID,
+		State:         state,
+		Dir:           extractProjectDir(path),
+		DirPath:       dirPath,
+		PlanPath:      meta.PlanPath,
+		Label:         meta.Label,
+		Pinned:        meta.Pinned,
+		Branch:        meta.Branch,
+		Mode:          meta.Mode,
+		StartTime:     meta.StartTime,
+		LastModified:  session.GetLastModified(),
+		HistoryOffset: session.GetHistoryOffset(),
+		LastError:     session.LastError(),
+		EventRate:     session.EventRate(),
+		PlanFile:      meta.PlanFile,
+	}
+	if len(meta.PhaseTimings) > 0 {
+		info.PhaseTimingsMs = make(map[processor.Phase]int64, len(meta.PhaseTimings))
+		for phase, d := range meta.PhaseTimings {
+			info.PhaseTimingsMs[phase] = d.Milliseconds()
+		}
+	}
+	if meta.Tokens != nil {
+		info.Tokens = meta.Tokens
+		info.TokensUnavailable = len(meta.Tokens) == 0
+	}
+	if state == SessionStateActive && meta.PID > 0 && !progress.IsOwnerStale(meta.Host, meta.PID) {
+		info.OwnerHost, info.OwnerPID = meta.Host, meta.PID
+	}
+	return info
+}
+
+// handleManagementEvents serves the session lifecycle SSE stream of the session manager.
+// only available in multi-session mode.
+func (s *Server) handleManagementEvents(w http.ResponseWriter, r *http.Request) {
+	if s.sm == nil {
+		http.Error(w, "session events are only available in dashboard mode", http.StatusNotFound)
+		return
+	}
+	s.sm.Hub().ServeHTTP(w, r)
+}
+
+// healthStatus is the response body of the health and readiness endpoints.
+type healthStatus struct {
+	Status        string `json:"status"`
+	UptimeSeconds int64  `json:"uptimeSeconds"`
+	Sessions      int    `json:"sessions"`
+}
+
+// handleHealthz reports liveness, it always succeeds once the server is serving requests.
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	s.writeHealth(w, http.StatusOK, "ok")
+}
+
+// handleReadyz reports readiness. in dashboard mode the server is ready only after the initial
+// discovery of all watched directories has completed, single-session mode is always ready.
+func (s *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if s.sm != nil && !s.sm.Discovered() {
+		s.writeHealth(w, http.StatusServiceUnavailable, "discovering")
+		return
+	}
+	s.writeHealth(w, http.StatusOK, "ready")
+}
+
+// writeHealth writes a health status response with the given code.
+func (s *Server) writeHealth(w http.ResponseWriter, code int, status string) {
+	sessions := 0
+	switch {
+	case s.sm != nil:
+		sessions = s.sm.Count()
+	case s.session != nil:
+		sessions = 1
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(healthStatus{
+		Status:        status,
+		UptimeSeconds: int64(time.Since(s.started).Seconds()),
+		Sessions:      sessions,
+	})
+}
+
 // extractProjectDir extracts project directory name from session path.
 // handles edge cases where path has no meaningful parent directory.
 func extractProjectDir(path string) string {
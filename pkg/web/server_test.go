@@ -1,20 +1,30 @@
 package web
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 func TestNewServer(t *testing.T) {
@@ -143,6 +153,131 @@ func TestServer_StartStop(t *testing.T) {
 	}
 }
 
+func TestServer_UnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "ralphex.sock")
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	srv, err := NewServer(ServerConfig{PlanName: "test", Socket: socketPath}, session)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(socketPath)
+		return statErr == nil
+	}, time.Second, 10*time.Millisecond)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	resp, err := client.Get("http://ralphex/api/sessions")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "[]", string(body))
+
+	// a second server must refuse the live socket
+	srv2, err := NewServer(ServerConfig{Socket: socketPath}, session)
+	require.NoError(t, err)
+	err = srv2.Start(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already in use")
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop in time")
+	}
+	assert.NoFileExists(t, socketPath, "socket should be removed on shutdown")
+}
+
+func TestServer_UnixSocketReplacesStale(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "stale.sock")
+	// leave a socket file behind with nothing listening, as a crashed process would
+	stale, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	ul, ok := stale.(*net.UnixListener)
+	require.True(t, ok)
+	ul.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	require.FileExists(t, socketPath)
+
+	srv, err := NewServer(ServerConfig{Socket: socketPath}, nil)
+	require.NoError(t, err)
+	ln, err := srv.listen()
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.Equal(t, "unix", ln.Addr().Network())
+	assert.Equal(t, "socket "+socketPath, srv.ListenAddr())
+}
+
+func TestServer_UnixSocketKeepsRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(path, []byte("important"), 0o600))
+
+	srv, err := NewServer(ServerConfig{Socket: path}, nil)
+	require.NoError(t, err)
+	_, err = srv.listen()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exists and is not a socket")
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, "important", string(data))
+}
+
+func TestServer_BindHost(t *testing.T) {
+	tests := []struct {
+		name, host, wantIP string
+	}{
+		{name: "default is ipv4 loopback", host: "", wantIP: "127.0.0.1"},
+		{name: "explicit ipv4 loopback", host: "127.0.0.1", wantIP: "127.0.0.1"},
+		{name: "ipv6 loopback", host: "::1", wantIP: "::1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := NewServer(ServerConfig{Host: tc.host}, nil)
+			require.NoError(t, err)
+			ln, err := srv.listen()
+			if err != nil && tc.wantIP == "::1" {
+				t.Skipf("ipv6 loopback is not available: %v", err)
+			}
+			require.NoError(t, err)
+			defer ln.Close()
+
+			addr, ok := ln.Addr().(*net.TCPAddr)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantIP, addr.IP.String())
+
+			go func() { _ = http.Serve(ln, http.HandlerFunc(srv.handleHealthz)) }() //nolint:gosec // test server
+			resp, err := http.Get("http://" + ln.Addr().String() + "/healthz")
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+
+	t.Run("listen address", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:8080", srv.ListenAddr())
+
+		srv, err = NewServer(ServerConfig{Port: 8080, Host: "::"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "[::]:8080", srv.ListenAddr())
+	})
+}
+
 func TestServer_Stop(t *testing.T) {
 	t.Run("stop without start is safe", func(t *testing.T) {
 		session := NewSession("test", "/tmp/test.txt")
@@ -347,6 +482,235 @@ func TestServer_HandlePlan(t *testing.T) {
 	})
 }
 
+func TestServer_HandleSessionPause(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	// live session, progress file locked by this process
+	logger, err := progress.NewLogger(progress.Config{PlanFile: filepath.Join(dir, "plan.md"), Mode: "full",
+		Branch: "main"}, testColors())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+	live := NewSession("main", logger.Path())
+
+	// session from another process, not locked by us
+	otherPath := filepath.Join(dir, "progress-other.txt")
+	createProgressFile(t, otherPath, "other.md", "main", "full")
+
+	sm := NewSessionManager()
+	defer sm.Close()
+	sm.Register(live)
+	_, err = sm.Discover(dir)
+	require.NoError(t, err)
+
+	srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+	require.NoError(t, err)
+
+	request := func(id, action string) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+id+"/"+action, http.NoBody)
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+		srv.handleSessionPause(w, req)
+		return w.Code, w.Body.String()
+	}
+
+	code, body := request(live.ID, "pause")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"paused":true}`, body)
+	assert.True(t, live.IsPaused())
+
+	code, body = request(live.ID, "pause") // idempotent
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"paused":true}`, body)
+
+	code, body = request(live.ID, "resume")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"paused":false}`, body)
+	assert.False(t, live.IsPaused())
+
+	// requests are attributed to the dashboard, the repeated pause is not recorded
+	var actions []string
+	for _, a := range live.PauseActions() {
+		actions = append(actions, a.Actor+": "+a.Action)
+	}
+	assert.Equal(t, []string{"web: paused run", "web: resumed run"}, actions)
+
+	code, body = request(sessionIDFromPath(otherPath), "pause")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, body, "not running in this process")
+
+	code, _ = request("nonexistent", "pause")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer_ReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	progressFile := filepath.Join(dir, "progress-test.txt")
+	createProgressFile(t, progressFile, "test.md", "main", "full")
+	session := NewSession(sessionIDFromPath(progressFile), progressFile)
+	defer session.Close()
+
+	t.Run("mutating endpoints are forbidden", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080, ReadOnly: true}, session)
+		require.NoError(t, err)
+
+		for _, action := range []string{"pause", "resume"} {
+			req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+session.ID+"/"+action, http.NoBody)
+			req.SetPathValue("id", session.ID)
+			w := httptest.NewRecorder()
+			srv.writable(srv.handleSessionPause)(w, req)
+			assert.Equal(t, http.StatusForbidden, w.Code, action)
+			assert.Contains(t, w.Body.String(), "read-only")
+		}
+		assert.False(t, session.IsPaused())
+	})
+
+	t.Run("read endpoints keep working", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080, ReadOnly: true}, session)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		srv.handleSessions(w, httptest.NewRequest(http.MethodGet, "/api/sessions", http.NoBody))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+session.ID, http.NoBody)
+		req.SetPathValue("id", session.ID)
+		w = httptest.NewRecorder()
+		srv.handleSession(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("writable passes through when not read-only", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080}, session)
+		require.NoError(t, err)
+		called := false
+		w := httptest.NewRecorder()
+		srv.writable(func(http.ResponseWriter, *http.Request) { called = true })(w,
+			httptest.NewRequest(http.MethodPost, "/api/sessions/x/pause", http.NoBody))
+		assert.True(t, called)
+	})
+
+	t.Run("config event is sent first", func(t *testing.T) {
+		for _, readOnly := range []bool{true, false} {
+			srv, err := NewServer(ServerConfig{Port: 8080, ReadOnly: readOnly}, session)
+			require.NoError(t, err)
+			require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, "some output")))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			req := httptest.NewRequest(http.MethodGet, "/events", http.NoBody).WithContext(ctx)
+			w := httptest.NewRecorder()
+			srv.handleEvents(w, req)
+			cancel()
+
+			body := w.Body.String()
+			want := fmt.Sprintf("event: config\ndata: {\"readOnly\":%t}\n\n", readOnly)
+			assert.True(t, strings.HasPrefix(body, want), "body: %q", body)
+			assert.Contains(t, body, "some output", "history is replayed after the config event")
+			assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		}
+	})
+}
+
+func TestServer_Heartbeat(t *testing.T) {
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	srv, err := NewServer(ServerConfig{Port: 8080, HeartbeatInterval: 10 * time.Millisecond}, session)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	go srv.heartbeatLoop(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", http.NoBody).WithContext(ctx)
+	w := httptest.NewRecorder()
+	srv.handleEvents(w, req)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "event: heartbeat\ndata: {\"rate\":0,\"timestamp\":", "idle session gets heartbeats")
+
+	t.Run("event rate in session info", func(t *testing.T) {
+		start := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+		s := NewSession("rate", "/tmp/rate.txt")
+		defer s.Close()
+		_, err := s.Heartbeat(start)
+		require.NoError(t, err)
+		for range 10 {
+			require.NoError(t, s.Publish(NewOutputEvent(processor.PhaseTask, "line")))
+		}
+		_, err = s.Heartbeat(start.Add(10 * time.Second))
+		require.NoError(t, err)
+
+		info := newSessionInfo(s)
+		assert.Greater(t, info.EventRate, 0.0)
+		assert.InDelta(t, s.EventRate(), info.EventRate, 1e-9)
+	})
+}
+
+func TestServer_RequireAuth(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("no token configured allows all requests", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080}, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		srv.requireAuth(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		setup    func(r *http.Request)
+		wantCode int
+	}{
+		{name: "missing credentials", path: "/", wantCode: http.StatusUnauthorized},
+		{name: "valid bearer token", path: "/api/sessions", wantCode: http.StatusOK,
+			setup: func(r *http.Request) { r.Header.Set("Authorization", "Bearer secret") }},
+		{name: "invalid bearer token", path: "/api/sessions", wantCode: http.StatusUnauthorized,
+			setup: func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") }},
+		{name: "valid basic auth", path: "/", wantCode: http.StatusOK,
+			setup: func(r *http.Request) { r.SetBasicAuth("anyone", "secret") }},
+		{name: "invalid basic auth", path: "/", wantCode: http.StatusUnauthorized,
+			setup: func(r *http.Request) { r.SetBasicAuth("anyone", "wrong") }},
+		{name: "sse without token", path: "/events", wantCode: http.StatusUnauthorized},
+		{name: "sse with query token", path: "/events?token=secret", wantCode: http.StatusOK},
+		{name: "sse with wrong query token", path: "/events?token=wrong", wantCode: http.StatusUnauthorized},
+		{name: "lifecycle sse without token", path: "/api/events", wantCode: http.StatusUnauthorized},
+		{name: "lifecycle sse with query token", path: "/api/events?token=secret", wantCode: http.StatusOK},
+		{name: "lifecycle sse with wrong query token", path: "/api/events?token=wrong", wantCode: http.StatusUnauthorized},
+		{name: "query token rejected outside sse", path: "/api/sessions?token=secret", wantCode: http.StatusUnauthorized},
+		{name: "static files protected", path: "/static/app.js", wantCode: http.StatusUnauthorized},
+		{name: "liveness probe open", path: "/healthz", wantCode: http.StatusOK},
+		{name: "readiness probe open", path: "/readyz", wantCode: http.StatusOK},
+	}
+
+	srv, err := NewServer(ServerConfig{Port: 8080, AuthToken: "secret"}, nil)
+	require.NoError(t, err)
+	handler := srv.requireAuth(next)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+			if tc.setup != nil {
+				tc.setup(req)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantCode, w.Code)
+			if tc.wantCode == http.StatusUnauthorized {
+				assert.Equal(t, `Basic realm="ralphex"`, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
 func TestNewServerWithSessions(t *testing.T) {
 	sm := NewSessionManager()
 	defer sm.Close()
@@ -432,6 +796,8 @@ Started: 2026-01-22 10:30:00
 		assert.Equal(t, "docs/plans/test-plan.md", sessions[0].PlanPath)
 		assert.Equal(t, "feature-branch", sessions[0].Branch)
 		assert.Equal(t, "full", sessions[0].Mode)
+		assert.Empty(t, sessions[0].OwnerHost, "finished sessions have no owner")
+		assert.Zero(t, sessions[0].OwnerPID)
 	})
 
 	t.Run("rejects non-GET methods", func(t *testing.T) {
@@ -455,6 +821,166 @@ Started: 2026-01-22 10:30:00
 	})
 }
 
+func TestNewSessionInfo_Owner(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		state    SessionState
+		host     string
+		pid      int
+		wantHost string
+		wantPID  int
+	}{
+		{name: "active with live owner", state: SessionStateActive, host: host, pid: os.Getpid(),
+			wantHost: host, wantPID: os.Getpid()},
+		{name: "active on another host", state: SessionStateActive, host: "other-box", pid: 4242,
+			wantHost: "other-box", wantPID: 4242},
+		{name: "active with stale owner", state: SessionStateActive, host: host, pid: 1 << 30},
+		{name: "active without owner", state: SessionStateActive},
+		{name: "completed", state: SessionStateCompleted, host: host, pid: os.Getpid()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			session := NewSession("test", "/tmp/progress-test.txt")
+			defer session.Close()
+			session.SetState(tc.state)
+			session.SetMetadata(SessionMetadata{Host: tc.host, PID: tc.pid})
+
+			info := newSessionInfo(session)
+			assert.Equal(t, tc.wantHost, info.OwnerHost)
+			assert.Equal(t, tc.wantPID, info.OwnerPID)
+		})
+	}
+}
+
+func TestNewSessionInfo_PhaseTimings(t *testing.T) {
+	session := NewSession("test", "/tmp/progress-test.txt")
+	defer session.Close()
+	session.SetState(SessionStateCompleted)
+	session.SetMetadata(SessionMetadata{PhaseTimings: map[processor.Phase]time.Duration{
+		processor.PhaseTask: 90 * time.Second, processor.PhaseReview: 1500 * time.Millisecond}})
+
+	data, err := json.Marshal(newSessionInfo(session))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"phaseTimingsMs":{"review":1500,"task":90000}`)
+
+	session.SetMetadata(SessionMetadata{})
+	data, err = json.Marshal(newSessionInfo(session))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "phaseTimingsMs")
+}
+
+func TestNewSessionInfo_Tokens(t *testing.T) {
+	session := NewSession("test", "/tmp/progress-test.txt")
+	defer session.Close()
+	session.SetState(SessionStateCompleted)
+
+	session.SetMetadata(SessionMetadata{Tokens: map[processor.Phase]int64{processor.PhaseTask: 1200}})
+	info := newSessionInfo(session)
+	assert.Equal(t, map[processor.Phase]int64{processor.PhaseTask: 1200}, info.Tokens)
+	assert.False(t, info.TokensUnavailable)
+
+	session.SetMetadata(SessionMetadata{Tokens: map[processor.Phase]int64{}})
+	data, err := json.Marshal(newSessionInfo(session))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tokensUnavailable":true`)
+	assert.NotContains(t, string(data), `"tokens":`)
+
+	session.SetMetadata(SessionMetadata{})
+	data, err = json.Marshal(newSessionInfo(session))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "tokens")
+}
+
+func TestServer_HandleSession(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-done.txt")
+	content := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\nStarted: 2026-01-22 10:00:00\n" +
+		strings.Repeat("-", 60) + "\n\n[26-01-22 10:00:01] working\n\n" + strings.Repeat("-", 60) +
+		"\nPhase-Timings: task=4m0s\nTokens: task=1500 review=20\nCompleted: 2026-01-22 10:05:00 (5m0s)\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	sm := NewSessionManager()
+	defer sm.Close()
+	_, err := sm.Discover(dir)
+	require.NoError(t, err)
+	srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+	require.NoError(t, err)
+
+	request := func(sessionID string) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID, http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSession(w, req)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("returns session with tokens", func(t *testing.T) {
+		code, body := request(sessionIDFromPath(path))
+		require.Equal(t, http.StatusOK, code)
+
+		var info SessionInfo
+		require.NoError(t, json.Unmarshal([]byte(body), &info))
+		assert.Equal(t, sessionIDFromPath(path), info.ID)
+		assert.Equal(t, SessionStateCompleted, info.State)
+		assert.Equal(t, map[processor.Phase]int64{processor.PhaseTask: 1500, processor.PhaseReview: 20}, info.Tokens)
+		assert.Equal(t, map[processor.Phase]int64{processor.PhaseTask: 240000}, info.PhaseTimingsMs)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		code, body := request("missing")
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "session not found: missing")
+	})
+}
+
+func TestServer_HandleSession_PlanFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-plan-add-cache.txt")
+	content := "# Ralphex Progress Log\nPlan: add cache\nBranch: main\nMode: plan\nStarted: 2026-01-22 10:00:00\n" +
+		strings.Repeat("-", 60) + "\n\n[26-01-22 10:00:01] plan creation completed\n" +
+		"[26-01-22 10:00:02] PLAN READY: docs/plans/add-cache.md\n\n" + strings.Repeat("-", 60) +
+		"\nPlan-File: docs/plans/add-cache.md\nCompleted: 2026-01-22 10:05:00 (5m0s)\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	sm := NewSessionManager()
+	defer sm.Close()
+	_, err := sm.Discover(dir)
+	require.NoError(t, err)
+	srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+	require.NoError(t, err)
+
+	sessionID := sessionIDFromPath(path)
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID, http.NoBody)
+	req.SetPathValue("id", sessionID)
+	w := httptest.NewRecorder()
+	srv.handleSession(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info SessionInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, SessionStateCompleted, info.State)
+	assert.Equal(t, "docs/plans/add-cache.md", info.PlanFile)
+
+	// the plan_ready event carries the path as well
+	var ready []Event
+	events, err := ReadProgressEvents(path)
+	require.NoError(t, err)
+	for _, e := range events {
+		if e.Type == EventTypePlanReady {
+			ready = append(ready, e)
+		}
+	}
+	require.Len(t, ready, 1)
+	assert.Equal(t, "docs/plans/add-cache.md", ready[0].PlanFile)
+}
+
 func TestServer_HandleEvents_WithSession(t *testing.T) {
 	t.Run("returns 404 for unknown session", func(t *testing.T) {
 		sm := NewSessionManager()
@@ -682,6 +1208,233 @@ Started: 2026-01-22 10:30:00
 	})
 }
 
+func TestServer_HandleSessionPlanTasks(t *testing.T) {
+	// setup creates a session whose progress file points at planPath and returns server with session id
+	setup := func(t *testing.T, planPath string) (*Server, string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		progressPath := filepath.Join(tmpDir, "progress-tasks.txt")
+		header := "# Ralphex Progress Log\n"
+		if planPath != "" {
+			header += "Plan: " + planPath + "\n"
+		}
+		header += "Branch: main\nMode: full\nStarted: 2026-01-22 10:30:00\n" +
+			"------------------------------------------------------------\n"
+		require.NoError(t, os.WriteFile(progressPath, []byte(header), 0o600))
+
+		sm := NewSessionManager()
+		t.Cleanup(sm.Close)
+		_, err := sm.Discover(tmpDir)
+		require.NoError(t, err)
+
+		srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+		require.NoError(t, err)
+		return srv, sessionIDFromPath(progressPath)
+	}
+
+	request := func(srv *Server, sessionID string) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/plan", http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionPlanTasks(w, req)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("returns tasks for session plan", func(t *testing.T) {
+		planPath := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planPath, []byte("# Plan\n\n### Task 1: a\n- [x] first\n- [ ] second\n"), 0o600))
+		srv, id := setup(t, planPath)
+
+		code, body := request(srv, id)
+		assert.Equal(t, http.StatusOK, code)
+
+		var tasks []PlanTask
+		require.NoError(t, json.Unmarshal([]byte(body), &tasks))
+		assert.Equal(t, []PlanTask{
+			{Title: "first", Status: TaskStatusDone, Line: 4},
+			{Title: "second", Status: TaskStatusPending, Line: 5},
+		}, tasks)
+	})
+
+	t.Run("falls back to completed directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "completed"), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "completed", "plan.md"), []byte("- [x] done\n"), 0o600))
+		srv, id := setup(t, filepath.Join(dir, "plan.md"))
+
+		code, body := request(srv, id)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `"title":"done"`)
+	})
+
+	t.Run("plan not available", func(t *testing.T) {
+		srv, id := setup(t, filepath.Join(t.TempDir(), "missing.md"))
+		code, body := request(srv, id)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "Plan not available")
+		assert.NotContains(t, body, "missing.md")
+
+		srv, id = setup(t, "")
+		code, body = request(srv, id)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "Plan not available")
+	})
+
+	t.Run("no tasks in plan", func(t *testing.T) {
+		planPath := filepath.Join(t.TempDir(), "plan.md")
+		require.NoError(t, os.WriteFile(planPath, []byte("# Plan\n\nnothing to do yet\n"), 0o600))
+		srv, id := setup(t, planPath)
+
+		code, body := request(srv, id)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "No tasks in plan")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		srv, _ := setup(t, "")
+		code, body := request(srv, "nonexistent")
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "session not found")
+	})
+}
+
+func TestServer_HandleSessionDiff(t *testing.T) {
+	// commitFile writes and commits a file in the repo, returning the commit hash
+	commitFile := func(t *testing.T, repo *gogit.Repository, dir, name, content string) string {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		wt, err := repo.Worktree()
+		require.NoError(t, err)
+		_, err = wt.Add(name)
+		require.NoError(t, err)
+		hash, err := wt.Commit("add "+name, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash.String()
+	}
+
+	// setup creates a repo with a progress file recording startCommit and returns single-session server
+	setup := func(t *testing.T, withCommit bool) (srv *Server, repo *gogit.Repository, dir string) {
+		t.Helper()
+		dir = t.TempDir()
+		repo, err := gogit.PlainInit(dir, false)
+		require.NoError(t, err)
+		start := commitFile(t, repo, dir, "README.md", "# Test\n")
+
+		header := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\n"
+		if withCommit {
+			header += "Commit: " + start + "\n"
+		}
+		header += "Mode: full\nStarted: 2026-01-22 10:30:00\n" +
+			"------------------------------------------------------------\n"
+		progressPath := filepath.Join(dir, "progress-plan.txt")
+		require.NoError(t, os.WriteFile(progressPath, []byte(header), 0o600))
+
+		session := NewSession("main", progressPath)
+		t.Cleanup(session.Close)
+		srv, err = NewServer(ServerConfig{Port: 8080}, session)
+		require.NoError(t, err)
+		return srv, repo, dir
+	}
+
+	request := func(srv *Server, sessionID string) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/diff", http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionDiff(w, req)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("returns changes since session start", func(t *testing.T) {
+		srv, repo, dir := setup(t, true)
+		commitFile(t, repo, dir, "main.go", "package main\n\nfunc main() {}\n")
+
+		code, body := request(srv, "main")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "diff --git a/main.go b/main.go")
+		assert.Contains(t, body, "+func main() {}")
+		assert.NotContains(t, body, "README.md")
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		srv, _, _ := setup(t, true)
+		code, body := request(srv, "main")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, body)
+	})
+
+	t.Run("start commit not recorded", func(t *testing.T) {
+		srv, _, _ := setup(t, false)
+		code, body := request(srv, "main")
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "Start commit not recorded")
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		srv, _, _ := setup(t, true)
+		code, body := request(srv, "nonexistent")
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "session not found")
+	})
+}
+
+func TestServer_HandleSessionHistory(t *testing.T) {
+	content := "# Ralphex Progress Log\nPlan: plan.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n" +
+		"--- task iteration 1 ---\n[26-01-22 10:00:01] first\n[26-01-22 10:00:02] second\n"
+	progressPath := filepath.Join(t.TempDir(), "progress-plan.txt")
+	require.NoError(t, os.WriteFile(progressPath, []byte(content), 0o600))
+
+	session := NewSession("main", progressPath)
+	t.Cleanup(session.Close)
+	srv, err := NewServer(ServerConfig{Port: 8080}, session)
+	require.NoError(t, err)
+
+	request := func(sessionID, before string) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/history?before="+before, http.NoBody)
+		req.SetPathValue("id", sessionID)
+		w := httptest.NewRecorder()
+		srv.handleSessionHistory(w, req)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("returns events before offset", func(t *testing.T) {
+		before := strings.Index(content, "[26-01-22 10:00:02]")
+		code, body := request("main", strconv.Itoa(before))
+		require.Equal(t, http.StatusOK, code)
+
+		var chunk historyChunk
+		require.NoError(t, json.Unmarshal([]byte(body), &chunk))
+		assert.Zero(t, chunk.Start, "small file is returned from the beginning")
+		require.Len(t, chunk.Events, 3)
+		assert.Equal(t, EventTypeTaskStart, chunk.Events[0].Type)
+		assert.Equal(t, EventTypeSection, chunk.Events[1].Type)
+		assert.Equal(t, "first", chunk.Events[2].Text)
+	})
+
+	t.Run("invalid offsets", func(t *testing.T) {
+		for _, before := range []string{"", "abc", "0", "-5", strconv.Itoa(len(content) + 1)} {
+			code, _ := request("main", before)
+			assert.Equal(t, http.StatusBadRequest, code, "before=%q", before)
+		}
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		code, body := request("nonexistent", "10")
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Contains(t, body, "session not found")
+	})
+}
+
 func TestLoadPlanWithFallback(t *testing.T) {
 	t.Run("loads plan from primary path", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -777,3 +1530,206 @@ func TestExtractProjectDir(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_HandleManagementEvents(t *testing.T) {
+	t.Run("not available in single-session mode", func(t *testing.T) {
+		session := NewSession("main", "/tmp/progress.txt")
+		t.Cleanup(session.Close)
+		srv, err := NewServer(ServerConfig{Port: 8080}, session)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/events", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.handleManagementEvents(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("streams lifecycle events in dashboard mode", func(t *testing.T) {
+		sm := NewSessionManager()
+		t.Cleanup(sm.Close)
+		srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+		require.NoError(t, err)
+
+		ts := httptest.NewServer(http.HandlerFunc(srv.handleManagementEvents))
+		defer ts.Close()
+		resp, err := http.Get(ts.URL) //nolint:noctx // test request
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		progressPath := filepath.Join(t.TempDir(), "progress-plan.txt")
+		require.NoError(t, os.WriteFile(progressPath, []byte("# Ralphex Progress Log\nPlan: plan.md\n"), 0o600))
+		sm.Register(NewSession("ignored", progressPath))
+
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, line, `"type":"session_added"`)
+	})
+}
+
+func TestServer_HealthEndpoints(t *testing.T) {
+	request := func(handler http.HandlerFunc) (int, healthStatus) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var status healthStatus
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.GreaterOrEqual(t, status.UptimeSeconds, int64(0))
+		status.UptimeSeconds = 0 // depends on test timing
+		return w.Code, status
+	}
+
+	t.Run("single-session mode is always ready", func(t *testing.T) {
+		session := NewSession("main", "/tmp/progress.txt")
+		t.Cleanup(session.Close)
+		srv, err := NewServer(ServerConfig{Port: 8080}, session)
+		require.NoError(t, err)
+
+		code, status := request(srv.handleHealthz)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthStatus{Status: "ok", Sessions: 1}, status)
+
+		code, status = request(srv.handleReadyz)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthStatus{Status: "ready", Sessions: 1}, status)
+	})
+
+	t.Run("dashboard mode is ready after discovery", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		createProgressFile(t, filepath.Join(tmpDir, "progress-one.txt"), "one.md", "main", "full")
+		createProgressFile(t, filepath.Join(tmpDir, "progress-two.txt"), "two.md", "main", "full")
+
+		sm := NewSessionManager()
+		t.Cleanup(sm.Close)
+		srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, sm)
+		require.NoError(t, err)
+
+		code, status := request(srv.handleHealthz)
+		assert.Equal(t, http.StatusOK, code, "alive before discovery")
+		assert.Equal(t, "ok", status.Status)
+
+		code, status = request(srv.handleReadyz)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, healthStatus{Status: "discovering"}, status)
+
+		w, err := NewWatcher([]string{tmpDir}, sm)
+		require.NoError(t, err)
+		go func() {
+			_ = w.Start(t.Context())
+		}()
+
+		require.Eventually(t, func() bool {
+			code, _ := request(srv.handleReadyz)
+			return code == http.StatusOK
+		}, time.Second, 10*time.Millisecond, "readyz should flip to 200 after discovery")
+		_, status = request(srv.handleReadyz)
+		assert.Equal(t, healthStatus{Status: "ready", Sessions: 2}, status)
+	})
+}
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	goroutinesBefore := runtime.NumGoroutine()
+
+	tmpDir := t.TempDir()
+	progressPath := filepath.Join(tmpDir, "progress-shutdown.txt")
+	createProgressFile(t, progressPath, "plan.md", "main", "full")
+
+	sm := NewSessionManager()
+	session := NewSession("ignored", progressPath)
+	sm.Register(session)
+	require.NoError(t, session.StartTailing(true))
+	require.True(t, session.IsTailing())
+	require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, "first")))
+	require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, "second")))
+	lifecycle, _ := sm.Hub().Subscribe()
+
+	socketPath := filepath.Join(tmpDir, "ralphex.sock")
+	srv, err := NewServerWithSessions(ServerConfig{Socket: socketPath, ShutdownTimeout: 2 * time.Second}, sm)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(socketPath)
+		return statErr == nil
+	}, time.Second, 10*time.Millisecond)
+
+	transport := &http.Transport{DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+	}}
+	client := &http.Client{Transport: transport}
+	defer transport.CloseIdleConnections()
+
+	// open a session stream and the management stream, both stay open until shutdown
+	streams := make([]*http.Response, 0, 2)
+	for _, path := range []string{"/events?session=" + session.ID, "/api/events"} {
+		resp, getErr := client.Get("http://ralphex" + path)
+		require.NoError(t, getErr)
+		require.Equal(t, http.StatusOK, resp.StatusCode, path)
+		streams = append(streams, resp)
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("server did not stop in time")
+	}
+
+	// streams are ended by the server, not by the grace period running out
+	for _, resp := range streams {
+		_, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	_, ok := <-lifecycle
+	assert.False(t, ok, "management hub should be closed")
+	assert.False(t, session.IsTailing(), "tailer should be stopped")
+	assert.Empty(t, sm.All())
+
+	// polled in the test goroutine, assert.Eventually runs its condition in an extra goroutine
+	transport.CloseIdleConnections()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > goroutinesBefore && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutinesBefore, "server goroutines should exit")
+}
+
+func TestServer_HandleWarnings(t *testing.T) {
+	m := NewSessionManager()
+	t.Cleanup(m.Close)
+	m.setDirError("/watch/b", errors.New("open /watch/b: permission denied"))
+	m.setDirError("/watch/a", errors.New("open /watch/a: permission denied"))
+	m.setDirError("/watch/gone", os.ErrNotExist)
+	srv, err := NewServerWithSessions(ServerConfig{Port: 8080}, m)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	srv.handleWarnings(w, httptest.NewRequest(http.MethodGet, "/api/warnings", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"dir":"/watch/a","error":"open /watch/a: permission denied"},`+
+		`{"dir":"/watch/b","error":"open /watch/b: permission denied"}]`, w.Body.String())
+
+	t.Run("cleared after successful discovery", func(t *testing.T) {
+		dir := t.TempDir()
+		m.setDirError(dir, errors.New("open: permission denied"))
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+		for _, warning := range m.Warnings() {
+			assert.NotEqual(t, dir, warning.Dir)
+		}
+	})
+
+	t.Run("single-session mode", func(t *testing.T) {
+		single, err := NewServer(ServerConfig{Port: 8080}, NewSession("test", "/tmp/test.txt"))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		single.handleWarnings(w, httptest.NewRequest(http.MethodGet, "/api/warnings", http.NoBody))
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+}
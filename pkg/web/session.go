@@ -2,13 +2,20 @@ package web
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 // DefaultReplayerSize is the maximum number of events to keep for replay to late-joining clients.
@@ -22,25 +29,59 @@ const DefaultReplayerSize = 10000
 // as strings starting at "1". by setting LastEventID to "0" when empty, we effectively
 // request replay of all stored events. this depends on FiniteReplayer's internal
 // ID generation scheme - if the library changes this behavior, replay may break.
+//
+// the inner replayer keeps only the last size events. once older events are evicted, a full replay
+// starts with a warning event telling the client how many earlier events were dropped.
 type allEventsReplayer struct {
 	inner *sse.FiniteReplayer
+	size  int          // capacity of the inner replayer
+	puts  atomic.Int64 // number of events stored so far, including evicted ones
 }
 
-// Put delegates to the inner replayer.
+// Put delegates to the inner replayer. heartbeats are passed through without being stored,
+// they only matter to clients connected at the time.
 func (r *allEventsReplayer) Put(message *sse.Message, topics []string) (*sse.Message, error) {
-	return r.inner.Put(message, topics) //nolint:wrapcheck // pass through replayer errors as-is
+	if message.Type == sse.Type(heartbeatEventType) {
+		return message, nil
+	}
+	msg, err := r.inner.Put(message, topics)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // pass through replayer errors as-is
+	}
+	r.puts.Add(1)
+	return msg, nil
+}
+
+// Dropped returns the number of events evicted from the replay buffer.
+func (r *allEventsReplayer) Dropped() int64 {
+	return max(r.puts.Load()-int64(r.size), 0)
 }
 
-// Replay replays events. If LastEventID is empty, replays from ID "0" (all events).
+// Replay replays events. If LastEventID is empty, replays from ID "0" (all events),
+// preceded by a truncation marker when earlier events were evicted.
+// history is written straight to the client of a subscriberQueue, it's never coalesced.
 func (r *allEventsReplayer) Replay(subscription sse.Subscription) error {
+	if q, ok := subscription.Client.(*subscriberQueue); ok {
+		subscription.Client = q.client
+	}
 	// if no LastEventID, replay from the beginning by using ID "0"
 	// (our auto-generated IDs start at 1, so "0" means "replay everything")
 	if subscription.LastEventID.String() == "" {
 		subscription.LastEventID = sse.ID("0")
+		if dropped := r.Dropped(); dropped > 0 {
+			if err := subscription.Client.Send(newHistoryTruncatedEvent(dropped).ToSSEMessage()); err != nil {
+				return fmt.Errorf("send truncation marker: %w", err)
+			}
+		}
 	}
 	return r.inner.Replay(subscription) //nolint:wrapcheck // pass through replayer errors as-is
 }
 
+// newHistoryTruncatedEvent creates the warning event sent ahead of a replay that lost its earliest events.
+func newHistoryTruncatedEvent(dropped int64) Event {
+	return NewWarnEvent(processor.PhaseTask, fmt.Sprintf("history truncated: %d earlier events dropped", dropped))
+}
+
 // SessionState represents the current state of a session.
 type SessionState string
 
@@ -48,14 +89,35 @@ type SessionState string
 const (
 	SessionStateActive    SessionState = "active"    // session is running (progress file locked)
 	SessionStateCompleted SessionState = "completed" // session finished (no lock held)
+	SessionStateCanceled  SessionState = "canceled"  // session was canceled, per the progress file footer
+	SessionStateFailed    SessionState = "failed"    // session stopped with an error, per the progress file footer
 )
 
+// Finished reports whether the state is one of the terminal states (completed, canceled or failed).
+func (s SessionState) Finished() bool {
+	return s == SessionStateCompleted || s == SessionStateCanceled || s == SessionStateFailed
+}
+
 // SessionMetadata holds parsed information from progress file header.
 type SessionMetadata struct {
-	PlanPath  string    // path to plan file (from "Plan:" header line)
-	Branch    string    // git branch (from "Branch:" header line)
-	Mode      string    // execution mode: full, review, codex-only (from "Mode:" header line)
-	StartTime time.Time // start time (from "Started:" header line)
+	PlanPath    string    // path to plan file (from "Plan:" header line)
+	Branch      string    // git branch (from "Branch:" header line)
+	Mode        string    // execution mode: full, review, codex-only (from "Mode:" header line)
+	StartTime   time.Time // start time (from "Started:" header line)
+	StartCommit string    // HEAD commit when the session started (from "Commit:" header line)
+	Host        string    // hostname of the process holding the lock (from "Host:" header line)
+	PID         int       // pid of the process holding the lock (from "PID:" header line)
+	Label       string    // user-assigned session label (from "Label:" header line)
+	Pinned      bool      // session is never pruned by retention (from "Pinned:" header line)
+	Worktree    string    // git worktree the plan runs in, empty if not using one (from "Worktree:" header line)
+
+	// PhaseTimings is the time spent in each phase, from the footer of a finished session
+	PhaseTimings map[processor.Phase]time.Duration
+	// Tokens is the provider-reported token usage per phase, from the footer of a finished session.
+	// nil when usage was not tracked, empty when it was tracked but never reported.
+	Tokens map[processor.Phase]int64
+	// PlanFile is the plan file produced by a plan creation session, from the footer of a finished session
+	PlanFile string
 }
 
 // defaultTopic is the SSE topic used for all events within a session.
@@ -67,7 +129,7 @@ type Session struct {
 	mu sync.RWMutex
 
 	ID       string          // unique identifier (derived from progress filename)
-	Path     string          // full path to progress file
+	Path     string          // full path to progress file, switches to the .gz variant once compressed, see GetPath
 	Metadata SessionMetadata // parsed header information
 	State    SessionState    // current state (active/completed)
 	SSE      *sse.Server     // SSE server for this session (handles subscriptions and replay)
@@ -81,6 +143,56 @@ type Session struct {
 
 	// loaded tracks whether historical data has been loaded into the SSE server
 	loaded bool
+
+	// lazyLoad defers loading a finished session's content until a client subscribes, see EnsureLoaded.
+	// set before the session is published, not changed afterwards.
+	lazyLoad bool
+
+	// tailerConfig is used for tailers created by StartTailing
+	tailerConfig TailerConfig
+
+	// historyOffset is the byte offset where loaded history starts, >0 when earlier content was skipped
+	historyOffset int64
+
+	// followCompleted keeps the tailer running after the session finishes, see FollowFinished.
+	// finishedOffset and finishedResets mark the tailer position at that point, content read past it
+	// reactivates the session and onReactivate is called with the finished state it left.
+	followCompleted bool
+	finishedOffset  int64
+	finishedResets  int64
+	onReactivate    func(prevState SessionState)
+
+	// mirror receives tailed events in addition to SSE clients, nil disables mirroring
+	mirror *progressMirror
+
+	// maxTextBytes is the event text size Publish truncates at, 0 disables truncation.
+	// set before the session is published, not changed afterwards.
+	maxTextBytes int
+
+	// logLevel is the lowest level of published events, see SetLogLevel. empty means DefaultLogLevel
+	logLevel string
+
+	// prompt is the last prompt sent to an executor by the runner of this process, see RecordPrompt
+	prompt      string
+	promptPhase processor.Phase
+
+	// lastError is the most recent error event published to the session, nil if none
+	lastError *Event
+
+	// phase and iteration of the latest published events, reported by heartbeats.
+	// iteration is the task number in the task phase and the review/codex iteration otherwise.
+	phase     processor.Phase
+	iteration int
+
+	// rate measures published events per second, updated by heartbeats
+	rate rateMeter
+
+	// pause state for the runner attached to this session, resumeCh is closed on resume.
+	// pauseActions keeps the pause and resume requests until the runner collects them
+	pauseMu      sync.Mutex
+	paused       bool
+	resumeCh     chan struct{}
+	pauseActions []processor.PauseAction
 }
 
 // NewSession creates a new session for the given progress file path.
@@ -97,7 +209,7 @@ func NewSession(id, path string) *Session {
 	// wrap in allEventsReplayer to replay all events on first connection
 	var replayer sse.Replayer
 	if finiteReplayer != nil {
-		replayer = &allEventsReplayer{inner: finiteReplayer}
+		replayer = &allEventsReplayer{inner: finiteReplayer, size: DefaultReplayerSize}
 	}
 
 	sseServer := &sse.Server{
@@ -110,10 +222,11 @@ func NewSession(id, path string) *Session {
 	}
 
 	return &Session{
-		ID:    id,
-		Path:  path,
-		State: SessionStateCompleted, // default to completed until proven active
-		SSE:   sseServer,
+		ID:           id,
+		Path:         path,
+		State:        SessionStateCompleted, // default to completed until proven active
+		SSE:          sseServer,
+		tailerConfig: DefaultTailerConfig(),
 	}
 }
 
@@ -138,6 +251,20 @@ func (s *Session) SetState(state SessionState) {
 	s.State = state
 }
 
+// SetPath updates the progress file path thread-safely, e.g. after the file was compressed.
+func (s *Session) SetPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Path = path
+}
+
+// GetPath returns the progress file path thread-safely.
+func (s *Session) GetPath() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Path
+}
+
 // GetState returns the session's state thread-safely.
 func (s *Session) GetState() SessionState {
 	s.mu.RLock()
@@ -179,10 +306,42 @@ func (s *Session) MarkLoadedIfNot() bool {
 	return true
 }
 
+// EnsureLoaded loads the content of a lazily loaded finished session on first access.
+// sessions loaded already, active ones and ones that were tailed get their events otherwise and are left as is.
+func (s *Session) EnsureLoaded() {
+	s.mu.RLock()
+	skip := !s.lazyLoad || s.Tailer != nil || !s.State.Finished()
+	s.mu.RUnlock()
+	if skip || !s.MarkLoadedIfNot() {
+		return
+	}
+	loadProgressFileIntoSession(s.GetPath(), s)
+}
+
+// SetHistoryOffset records the progress file offset where the loaded history starts.
+func (s *Session) SetHistoryOffset(offset int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyOffset = offset
+}
+
+// GetHistoryOffset returns the progress file offset where the loaded history starts.
+// zero means the whole file was loaded.
+func (s *Session) GetHistoryOffset() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.historyOffset
+}
+
 // StartTailing begins tailing the progress file and feeding events to SSE clients.
-// if fromStart is true, reads from the beginning of the file; otherwise from the end.
+// if fromStart is true, reads from the beginning of the file after publishing the parts rotated out of it; otherwise from the end.
 // does nothing if already tailing.
 func (s *Session) StartTailing(fromStart bool) error {
+	if fromStart && !s.IsTailing() {
+		// parts rotated out of the file go first, the tailer reads the file itself from its start
+		loadRotatedParts(s.GetPath(), s.Publish)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -190,7 +349,7 @@ func (s *Session) StartTailing(fromStart bool) error {
 		return nil // already tailing
 	}
 
-	s.Tailer = NewTailer(s.Path, DefaultTailerConfig())
+	s.Tailer = NewTailer(s.Path, s.tailerConfig)
 	if err := s.Tailer.Start(fromStart); err != nil {
 		s.Tailer = nil
 		return err
@@ -217,6 +376,49 @@ func (s *Session) StopTailing() {
 	}
 }
 
+// FollowsCompleted returns whether the session keeps tailing its file after it finished.
+func (s *Session) FollowsCompleted() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.followCompleted
+}
+
+// FollowFinished keeps tailing a session that has just finished instead of stopping, if the session
+// follows completed files. records the current end of the file, new content appended past it
+// (e.g. by a resumed run) or a rotated file switches the session back to active.
+// returns false if the session doesn't follow completed files and tailing should be stopped.
+func (s *Session) FollowFinished() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.followCompleted || s.Tailer == nil || progress.IsCompressed(s.Path) {
+		return false // nothing is appended to a compressed file
+	}
+	s.finishedOffset, s.finishedResets = s.Tailer.Offset(), s.Tailer.Resets()
+	if info, err := os.Stat(s.Path); err == nil {
+		s.finishedOffset = max(s.finishedOffset, info.Size()) // footer may not be tailed yet
+	}
+	return true
+}
+
+// reactivateIfResumed switches a followed finished session back to active once the tailer has read
+// content written after it finished.
+func (s *Session) reactivateIfResumed(tailer *Tailer) {
+	s.mu.Lock()
+	if !s.followCompleted || !s.State.Finished() ||
+		(tailer.Offset() <= s.finishedOffset && tailer.Resets() == s.finishedResets) {
+		s.mu.Unlock()
+		return
+	}
+	prevState := s.State
+	s.State = SessionStateActive
+	onReactivate := s.onReactivate
+	s.mu.Unlock()
+
+	if onReactivate != nil {
+		onReactivate(prevState)
+	}
+}
+
 // IsTailing returns whether the session is currently tailing its progress file.
 func (s *Session) IsTailing() bool {
 	s.mu.RLock()
@@ -224,9 +426,13 @@ func (s *Session) IsTailing() bool {
 	return s.Tailer != nil && s.Tailer.IsRunning()
 }
 
-// Publish sends an event to all connected SSE clients and stores it for replay.
+// Publish sends an event to all connected SSE clients and stores it for replay, error events are also kept as LastError.
+// event text over maxTextBytes is truncated first, the progress file keeps the full text.
 // returns an error if publishing fails.
 func (s *Session) Publish(event Event) error {
+	event = truncateEventText(event, s.maxTextBytes)
+	event = renderANSI(event)
+	s.track(event)
 	msg := event.ToSSEMessage()
 	if err := s.SSE.Publish(msg, defaultTopic); err != nil {
 		return fmt.Errorf("publish event: %w", err)
@@ -234,11 +440,157 @@ func (s *Session) Publish(event Event) error {
 	return nil
 }
 
+// track records the progress state carried by a published event: last error, phase, iteration and event rate.
+// status events are about the session rather than the run, they don't count as throughput.
+func (s *Session) track(event Event) {
+	if event.Type == EventTypeStatus {
+		return
+	}
+	s.rate.mark()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if event.Phase != "" {
+		s.phase = event.Phase
+	}
+	switch event.Type {
+	case EventTypeError:
+		s.lastError = &event
+	case EventTypeTaskStart:
+		s.iteration = event.TaskNum
+	case EventTypeIterationStart:
+		s.iteration = event.IterationNum
+	}
+}
+
+// EventRate returns the moving average of events published per second, as of the last heartbeat.
+func (s *Session) EventRate() float64 {
+	return s.rate.value()
+}
+
+// heartbeatEventType is the SSE event name of heartbeat messages, see Session.Heartbeat.
+const heartbeatEventType = "heartbeat"
+
+// Heartbeat is the periodic progress summary streamed to SSE clients of a session, sent even when no
+// output is flowing so clients can tell the run is alive and idle connections are kept open.
+type Heartbeat struct {
+	Phase     processor.Phase `json:"phase,omitempty"`
+	Iteration int             `json:"iteration,omitempty"` // task number or review/codex iteration
+	Rate      float64         `json:"rate"`                // events per second, moving average
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Heartbeat updates the event rate with the events published since the previous heartbeat
+// and sends the resulting summary to connected clients. heartbeats are not stored for replay.
+func (s *Session) Heartbeat(now time.Time) (Heartbeat, error) {
+	hb := Heartbeat{Rate: s.rate.tick(now), Timestamp: now}
+	s.mu.RLock()
+	hb.Phase, hb.Iteration = s.phase, s.iteration
+	s.mu.RUnlock()
+
+	data, err := json.Marshal(hb)
+	if err != nil {
+		return hb, fmt.Errorf("marshal heartbeat: %w", err)
+	}
+	msg := &sse.Message{Type: sse.Type(heartbeatEventType)}
+	msg.AppendData(string(data))
+	if err := s.SSE.Publish(msg, defaultTopic); err != nil {
+		return hb, fmt.Errorf("publish heartbeat: %w", err)
+	}
+	return hb, nil
+}
+
+// LastError returns a copy of the most recent error event published to the session, nil if there was none.
+func (s *Session) LastError() *Event {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.lastError == nil {
+		return nil
+	}
+	e := *s.lastError
+	return &e
+}
+
+// Pause marks the session as paused on behalf of actor and notifies SSE clients.
+// the runner blocks before its next executor call until Resume is called.
+// returns false if the session was already paused.
+func (s *Session) Pause(actor string) bool {
+	s.pauseMu.Lock()
+	if s.paused {
+		s.pauseMu.Unlock()
+		return false
+	}
+	s.paused = true
+	s.resumeCh = make(chan struct{})
+	s.pauseActions = append(s.pauseActions, processor.PauseAction{Actor: actor, Action: "paused run", Time: time.Now()})
+	s.pauseMu.Unlock()
+
+	if err := s.Publish(NewStatusEvent("paused")); err != nil {
+		log.Printf("[WARN] failed to publish pause event: %v", err)
+	}
+	return true
+}
+
+// Resume releases a paused session on behalf of actor and notifies SSE clients.
+// returns false if the session was not paused.
+func (s *Session) Resume(actor string) bool {
+	s.pauseMu.Lock()
+	if !s.paused {
+		s.pauseMu.Unlock()
+		return false
+	}
+	s.paused = false
+	close(s.resumeCh)
+	s.pauseActions = append(s.pauseActions, processor.PauseAction{Actor: actor, Action: "resumed run", Time: time.Now()})
+	s.pauseMu.Unlock()
+
+	if err := s.Publish(NewStatusEvent("resumed")); err != nil {
+		log.Printf("[WARN] failed to publish resume event: %v", err)
+	}
+	return true
+}
+
+// IsPaused returns true if the session is paused.
+func (s *Session) IsPaused() bool {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	return s.paused
+}
+
+// PauseActions returns the pause and resume requests made since the previous call, oldest first.
+func (s *Session) PauseActions() []processor.PauseAction {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	actions := s.pauseActions
+	s.pauseActions = nil
+	return actions
+}
+
+// WaitResume blocks until the session is resumed or ctx is canceled.
+// returns immediately if the session is not paused.
+func (s *Session) WaitResume(ctx context.Context) error {
+	s.pauseMu.Lock()
+	if !s.paused {
+		s.pauseMu.Unlock()
+		return nil
+	}
+	ch := s.resumeCh
+	s.pauseMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck // caller wraps
+	case <-ch:
+		return nil
+	}
+}
+
 // feedEvents reads events from the tailer and publishes them to SSE clients.
 func (s *Session) feedEvents() {
 	s.mu.RLock()
 	tailer := s.Tailer
 	stopCh := s.stopTailCh
+	mirror := s.mirror
 	s.mu.RUnlock()
 
 	if tailer == nil {
@@ -254,9 +606,15 @@ func (s *Session) feedEvents() {
 			if !ok {
 				return
 			}
-			if err := s.Publish(event); err != nil {
-				log.Printf("[WARN] failed to publish tailed event: %v", err)
+			if s.logs(event) {
+				if err := s.Publish(event); err != nil {
+					log.Printf("[WARN] failed to publish tailed event: %v", err)
+				}
+			}
+			if mirror != nil {
+				mirror.write(s.ID, event)
 			}
+			s.reactivateIfResumed(tailer)
 		}
 	}
 }
@@ -266,7 +624,7 @@ func (s *Session) Close() {
 	s.StopTailing()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	if err := s.SSE.Shutdown(ctx); err != nil {
+	if err := s.SSE.Shutdown(ctx); err != nil && !errors.Is(err, sse.ErrProviderClosed) {
 		log.Printf("[WARN] failed to shutdown SSE server: %v", err)
 	}
 }
@@ -2,11 +2,16 @@ package web
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"io"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,34 +31,188 @@ const MaxCompletedSessions = 100
 // set to 64MB to handle large outputs (e.g., diffs of large JSON files).
 const maxScannerBuffer = 64 * 1024 * 1024
 
+// completed progress files larger than largeProgressFileSize are loaded partially,
+// only the last progressTailSize bytes are published and earlier content is served in
+// chunks of the same size by the session history endpoint.
+const (
+	largeProgressFileSize = 8 * 1024 * 1024
+	progressTailSize      = 1024 * 1024
+)
+
 // SessionManager maintains a registry of all discovered sessions.
 // it handles discovery of progress files, state detection via flock,
 // and provides access to sessions by ID.
 // completed sessions are automatically evicted when MaxCompletedSessions is exceeded.
 type SessionManager struct {
-	mu       sync.RWMutex
-	sessions map[string]*Session // keyed by session ID
+	mu           sync.RWMutex
+	sessions     map[string]*Session // keyed by session ID
+	pollInterval time.Duration       // tailer poll interval for discovered sessions, 0 uses the tailer default
+	follow       bool                // keep tailing discovered sessions after they finish
+	lazyLoad     bool                // load discovered finished sessions on first access, see Session.EnsureLoaded
+	maxTextBytes int                 // event text size of discovered sessions to truncate at, 0 disables
+	hub          *Hub                // streams session lifecycle events
+	discovered   bool                // initial discovery of all watched directories completed
+	mirror       *progressMirror     // mirrors tailed events of discovered sessions, nil disables
+	dirErrors    map[string]error    // directories discovery failed to read, keyed by path, see Warnings
+}
+
+// DirWarning describes a watched directory that couldn't be scanned for progress files.
+type DirWarning struct {
+	Dir   string `json:"dir"`
+	Error string `json:"error"`
 }
 
 // NewSessionManager creates a new session manager with an empty registry.
 func NewSessionManager() *SessionManager {
 	return &SessionManager{
-		sessions: make(map[string]*Session),
+		sessions:  make(map[string]*Session),
+		hub:       NewHub(),
+		dirErrors: make(map[string]error),
+	}
+}
+
+// Warnings returns the directories the latest discovery couldn't read, sorted by path.
+// sessions in these directories are missing from the registry until they become readable.
+func (m *SessionManager) Warnings() []DirWarning {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	warnings := make([]DirWarning, 0, len(m.dirErrors))
+	for dir, err := range m.dirErrors {
+		warnings = append(warnings, DirWarning{Dir: dir, Error: err.Error()})
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Dir < warnings[j].Dir })
+	return warnings
+}
+
+// setDirError records the error of reading dir during discovery, a nil error clears it.
+// missing directories are not reported, they are expected when files or directories are removed.
+func (m *SessionManager) setDirError(dir string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil || errors.Is(err, fs.ErrNotExist) {
+		delete(m.dirErrors, dir)
+		return
+	}
+	m.dirErrors[dir] = err
+}
+
+// clearDirErrors drops recorded errors of root and the directories below it.
+func (m *SessionManager) clearDirErrors(root string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prefix := strings.TrimSuffix(root, string(filepath.Separator)) + string(filepath.Separator)
+	for dir := range m.dirErrors {
+		if dir == root || strings.HasPrefix(dir, prefix) {
+			delete(m.dirErrors, dir)
+		}
+	}
+}
+
+// Hub returns the management hub streaming lifecycle events of the managed sessions.
+func (m *SessionManager) Hub() *Hub {
+	return m.hub
+}
+
+// MarkDiscovered records that the initial discovery of all watched directories has completed.
+func (m *SessionManager) MarkDiscovered() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.discovered = true
+}
+
+// Discovered reports whether the initial discovery of all watched directories has completed.
+func (m *SessionManager) Discovered() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.discovered
+}
+
+// Count returns the number of sessions in the registry.
+func (m *SessionManager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.sessions)
+}
+
+// SetPollInterval sets how often tailers of sessions discovered after this call check for new content.
+func (m *SessionManager) SetPollInterval(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pollInterval = d
+}
+
+// SetProgressLog mirrors events of sessions discovered after this call to w, e.g. stdout for container logs.
+// only events at level (one of ProgressLogLevels) or above are written.
+func (m *SessionManager) SetProgressLog(w io.Writer, level string) error {
+	mirror, err := newProgressMirror(w, level)
+	if err != nil {
+		return err
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mirror = mirror
+	return nil
+}
+
+// SetFollowCompleted sets whether sessions discovered after this call keep tailing their progress file
+// once finished, so content appended later is streamed and the session becomes active again.
+func (m *SessionManager) SetFollowCompleted(follow bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.follow = follow
+}
+
+// SetLazyLoadCompleted sets whether sessions discovered after this call, if finished, are registered with
+// metadata only, their content is loaded when a client first subscribes to them, see Session.EnsureLoaded.
+func (m *SessionManager) SetLazyLoadCompleted(lazy bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lazyLoad = lazy
+}
+
+// SetMaxEventTextBytes sets the event text size sessions discovered after this call truncate their events at,
+// see Session.Publish. 0 disables truncation.
+func (m *SessionManager) SetMaxEventTextBytes(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxTextBytes = n
 }
 
-// Discover scans a directory for progress files matching progress-*.txt pattern.
+// Discover scans a directory for progress files matching progress-*.txt pattern, or progress-*.txt.gz once compressed.
 // for each file found, it creates or updates a session in the registry.
-// returns the list of discovered session IDs.
+// returns the list of discovered session IDs. a directory that can't be read is
+// reported by Warnings until a later discovery succeeds, a missing one has no sessions.
 func (m *SessionManager) Discover(dir string) ([]string, error) {
-	pattern := filepath.Join(dir, "progress-*.txt")
-	matches, err := filepath.Glob(pattern)
+	entries, err := os.ReadDir(dir)
+	m.setDirError(dir, err)
 	if err != nil {
-		return nil, fmt.Errorf("glob progress files: %w", err)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	var matches []string
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), progress.CompressedSuffix)
+		if matched, _ := filepath.Match("progress-*.txt", name); !matched || entry.IsDir() {
+			continue
+		}
+		// while a file is being compressed both variants exist, the plain one is kept until it's removed
+		if name != entry.Name() && names[name] {
+			continue
+		}
+		matches = append(matches, filepath.Join(dir, entry.Name()))
 	}
 
 	ids := make([]string, 0, len(matches))
 	for _, path := range matches {
+		if isRotatedPart(path) {
+			continue // loaded along with the file it was rotated from
+		}
 		id := sessionIDFromPath(path)
 		ids = append(ids, id)
 
@@ -63,19 +222,51 @@ func (m *SessionManager) Discover(dir string) ([]string, error) {
 		m.mu.RUnlock()
 
 		if existing != nil {
+			// the file was compressed, the session follows it under the same ID
+			if existing.GetPath() != path {
+				existing.StopTailing() // nothing is appended to a compressed file
+				existing.SetPath(path)
+			}
 			// update existing session state
+			prevState, prevModified := existing.GetState(), existing.GetLastModified()
 			if err := m.updateSession(existing); err != nil {
 				// log error but continue with other sessions
 				continue
 			}
+			if state := existing.GetState(); state != prevState {
+				m.notify(LifecycleSessionStateChanged, existing, prevState)
+			} else if !existing.GetLastModified().Equal(prevModified) {
+				m.notify(LifecycleSessionUpdated, existing, "")
+			}
 		} else {
-			// create new session
+			// create new session, its unexported fields are set before it is published to other goroutines
 			session := NewSession(id, path)
+			m.mu.RLock()
+			if m.pollInterval > 0 {
+				session.tailerConfig.PollInterval = m.pollInterval
+			}
+			session.mirror = m.mirror
+			session.lazyLoad = m.lazyLoad
+			session.maxTextBytes = m.maxTextBytes
+			if m.follow {
+				session.followCompleted = true
+				session.onReactivate = func(prevState SessionState) {
+					m.notify(LifecycleSessionStateChanged, session, prevState)
+				}
+			}
+			m.mu.RUnlock()
 			if err := m.updateSession(session); err != nil {
 				continue
 			}
 			m.mu.Lock()
+			if m.sessions[id] != nil {
+				// added by a concurrent discovery meanwhile, keep the published session
+				m.mu.Unlock()
+				session.Close()
+				continue
+			}
 			m.sessions[id] = session
+			m.notify(LifecycleSessionAdded, session, "")
 			m.evictOldCompleted()
 			m.mu.Unlock()
 		}
@@ -85,25 +276,39 @@ func (m *SessionManager) Discover(dir string) ([]string, error) {
 }
 
 // DiscoverRecursive walks a directory tree and discovers all progress files.
-// unlike Discover, this searches subdirectories recursively.
-// returns the list of all discovered session IDs (deduplicated).
-func (m *SessionManager) DiscoverRecursive(root string) ([]string, error) {
+// unlike Discover, this searches subdirectories recursively, up to maxDepth levels below root.
+// returns the list of all discovered session IDs (deduplicated). directories that can't be read
+// are skipped and reported by Warnings, the walk goes on and their errors are joined in the returned error.
+func (m *SessionManager) DiscoverRecursive(root string, maxDepth int) ([]string, error) {
 	seenDirs := make(map[string]bool)
 	seenIDs := make(map[string]bool)
 	var allIDs []string
+	var dirErrs []error
+	m.clearDirErrors(root)
 
 	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
-			// skip directories that can't be accessed
+			// record directories that can't be accessed and skip them
+			if d == nil || d.IsDir() {
+				m.setDirError(path, err)
+				if !errors.Is(err, fs.ErrNotExist) {
+					dirErrs = append(dirErrs, err)
+				}
+			}
 			if d != nil && d.IsDir() {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
-		// skip hidden directories
-		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != root {
-			return filepath.SkipDir
+		// skip hidden directories and directories below the depth limit
+		if d.IsDir() && path != root {
+			if strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if rel, relErr := filepath.Rel(root, path); relErr == nil && strings.Count(rel, string(filepath.Separator)) >= maxDepth {
+				return filepath.SkipDir
+			}
 		}
 
 		// skip non-progress files
@@ -120,7 +325,8 @@ func (m *SessionManager) DiscoverRecursive(root string) ([]string, error) {
 
 		ids, discoverErr := m.Discover(dir)
 		if discoverErr != nil {
-			return nil //nolint:nilerr // best-effort discovery, errors for individual directories are ignored
+			dirErrs = append(dirErrs, fmt.Errorf("discover %s: %w", dir, discoverErr))
+			return nil
 		}
 
 		for _, id := range ids {
@@ -137,23 +343,23 @@ func (m *SessionManager) DiscoverRecursive(root string) ([]string, error) {
 		return allIDs, fmt.Errorf("walk directory %s: %w", root, err)
 	}
 
-	return allIDs, nil
+	return allIDs, errors.Join(dirErrs...)
 }
 
 // updateSession refreshes a session's state and metadata from its progress file.
 // handles starting/stopping tailing based on state transitions.
 func (m *SessionManager) updateSession(session *Session) error {
-	prevState := session.GetState()
+	prevState, path := session.GetState(), session.GetPath()
 
 	// check if file is locked (active session)
-	active, err := IsActive(session.Path)
+	active, err := IsActive(path)
 	if err != nil {
 		return fmt.Errorf("check active state: %w", err)
 	}
 
-	newState := SessionStateCompleted
-	if active {
-		newState = SessionStateActive
+	newState := SessionStateActive
+	if !active {
+		newState = finishedState(path)
 	}
 	session.SetState(newState)
 
@@ -164,28 +370,32 @@ func (m *SessionManager) updateSession(session *Session) error {
 			if tailErr := session.StartTailing(true); tailErr != nil {
 				log.Printf("[WARN] failed to start tailing for session %s: %v", session.ID, tailErr)
 			}
-		} else if newState == SessionStateCompleted && session.IsTailing() {
-			// session completed, stop tailing
+		} else if newState.Finished() && session.IsTailing() && !session.FollowFinished() {
+			// session completed, stop tailing unless it follows completed files
 			session.StopTailing()
 		}
 	}
 
 	// for completed sessions that haven't been loaded yet, load the file content once
-	// this handles sessions discovered after they finished.
+	// this handles sessions discovered after they finished, lazily loaded ones wait for their first client.
 	// MarkLoadedIfNot is atomic to prevent double-loading from concurrent goroutines.
-	if newState == SessionStateCompleted && session.MarkLoadedIfNot() {
-		loadProgressFileIntoSession(session.Path, session)
+	if newState.Finished() && !session.lazyLoad && session.MarkLoadedIfNot() {
+		loadProgressFileIntoSession(path, session)
 	}
 
-	// parse metadata from file header
-	meta, err := ParseProgressHeader(session.Path)
+	// parse metadata from file header, and footer once finished
+	meta, err := ParseProgressHeader(path)
 	if err != nil {
 		return fmt.Errorf("parse header: %w", err)
 	}
+	if newState.Finished() {
+		footer, _ := ParseProgressFooter(path) // timings and tokens are optional, missing or broken ones are left empty
+		meta.PhaseTimings, meta.Tokens, meta.PlanFile = footer.PhaseTimings, footer.Tokens, footer.PlanFile
+	}
 	session.SetMetadata(meta)
 
 	// update last modified time
-	info, err := os.Stat(session.Path)
+	info, err := os.Stat(path)
 	if err != nil {
 		return fmt.Errorf("stat file: %w", err)
 	}
@@ -221,26 +431,99 @@ func (m *SessionManager) Remove(id string) {
 	if session, ok := m.sessions[id]; ok {
 		session.Close()
 		delete(m.sessions, id)
+		m.hub.Publish(LifecycleEvent{Type: LifecycleSessionRemoved, SessionID: id})
 	}
 }
 
-// Register adds an externally-created session to the manager.
-// This is used when a session is created for live execution (BroadcastLogger)
+// Register adds an externally-created session to the manager, or updates the session already registered
+// under the same ID. This is used when a session is created for live execution (BroadcastLogger)
 // and needs to be visible in the multi-session dashboard.
 // The session's ID is derived from its path using sessionIDFromPath.
-func (m *SessionManager) Register(session *Session) {
-	id := sessionIDFromPath(session.Path)
+// An existing session keeps its SSE server and tailer, so subscribed clients and replay history are not lost,
+// and takes the state of the passed session and each of its non-empty metadata fields, see mergeMetadata.
+// Returns the registered session, which is the existing one on update; callers publishing events should use it
+// instead of the passed one.
+func (m *SessionManager) Register(session *Session) *Session {
+	id := sessionIDFromPath(session.GetPath())
 	session.ID = id // ensure ID matches what SessionManager expects
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// don't overwrite existing session
-	if _, exists := m.sessions[id]; exists {
-		return
+	existing, exists := m.sessions[id]
+	if !exists {
+		m.sessions[id] = session
+		m.notify(LifecycleSessionAdded, session, "")
+		return session
 	}
+	if existing == session {
+		return existing
+	}
+
+	prevState, prevMeta := existing.GetState(), existing.GetMetadata()
+	existing.SetMetadata(mergeMetadata(prevMeta, session.GetMetadata()))
+	existing.SetState(session.GetState())
+	if state := existing.GetState(); state != prevState {
+		m.notify(LifecycleSessionStateChanged, existing, prevState)
+	} else if !reflect.DeepEqual(existing.GetMetadata(), prevMeta) {
+		m.notify(LifecycleSessionUpdated, existing, "")
+	}
+	return existing
+}
+
+// removeProgressFiles removes the files in order, stopping at the first failure.
+func removeProgressFiles(paths []string) error {
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
 
-	m.sessions[id] = session
+// mergeMetadata returns dst with every non-empty field of src, so a registering session
+// doesn't drop what the existing one already knows, e.g. phase timings parsed from the footer.
+func mergeMetadata(dst, src SessionMetadata) SessionMetadata {
+	if src.PlanPath != "" {
+		dst.PlanPath = src.PlanPath
+	}
+	if src.Branch != "" {
+		dst.Branch = src.Branch
+	}
+	if src.Mode != "" {
+		dst.Mode = src.Mode
+	}
+	if !src.StartTime.IsZero() {
+		dst.StartTime = src.StartTime
+	}
+	if src.StartCommit != "" {
+		dst.StartCommit = src.StartCommit
+	}
+	if src.Host != "" {
+		dst.Host = src.Host
+	}
+	if src.PID != 0 {
+		dst.PID = src.PID
+	}
+	if src.Label != "" {
+		dst.Label = src.Label
+	}
+	if src.Pinned {
+		dst.Pinned = true
+	}
+	if src.Worktree != "" {
+		dst.Worktree = src.Worktree
+	}
+	if src.PhaseTimings != nil {
+		dst.PhaseTimings = src.PhaseTimings
+	}
+	if src.Tokens != nil {
+		dst.Tokens = src.Tokens
+	}
+	if src.PlanFile != "" {
+		dst.PlanFile = src.PlanFile
+	}
+	return dst
 }
 
 // Close closes all sessions and clears the registry.
@@ -252,6 +535,14 @@ func (m *SessionManager) Close() {
 		session.Close()
 	}
 	m.sessions = make(map[string]*Session)
+	m.hub.Close()
+}
+
+// notify publishes a lifecycle event carrying the session's current info to the management hub.
+// prevState is only used for state change events.
+func (m *SessionManager) notify(typ LifecycleEventType, session *Session, prevState SessionState) {
+	info := newSessionInfo(session)
+	m.hub.Publish(LifecycleEvent{Type: typ, SessionID: session.ID, Session: &info, PrevState: prevState})
 }
 
 // evictOldCompleted removes oldest completed sessions when count exceeds MaxCompletedSessions.
@@ -260,7 +551,7 @@ func (m *SessionManager) evictOldCompleted() {
 	// count completed sessions
 	var completed []*Session
 	for _, s := range m.sessions {
-		if s.GetState() == SessionStateCompleted {
+		if s.GetState().Finished() {
 			completed = append(completed, s)
 		}
 	}
@@ -282,9 +573,62 @@ func (m *SessionManager) evictOldCompleted() {
 		session := completed[i]
 		session.Close()
 		delete(m.sessions, session.ID)
+		m.hub.Publish(LifecycleEvent{Type: LifecycleSessionRemoved, SessionID: session.ID})
 	}
 }
 
+// PruneOlderThan deletes progress files of completed sessions with no activity within d
+// and removes them from the registry. last activity is the later of the session start time
+// and the file modification time. active (locked) and pinned sessions are never pruned.
+// returns the sorted IDs of pruned sessions.
+func (m *SessionManager) PruneOlderThan(d time.Duration) []string {
+	cutoff := time.Now().Add(-d)
+
+	m.mu.RLock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		sessions = append(sessions, s)
+	}
+	m.mu.RUnlock()
+
+	var pruned []string
+	for _, session := range sessions {
+		if session.GetState() == SessionStateActive || session.IsTailing() || session.GetMetadata().Pinned {
+			continue
+		}
+
+		path := session.GetPath()
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		lastActivity := info.ModTime()
+		if start := session.GetMetadata().StartTime; start.After(lastActivity) {
+			lastActivity = start
+		}
+		if lastActivity.After(cutoff) {
+			continue
+		}
+
+		// re-check the lock, the session may have been resumed since the last refresh
+		active, err := IsActive(path)
+		if err != nil || active {
+			continue
+		}
+
+		// rotated parts go first, left without the main file they would be discovered as sessions of their own
+		if err := removeProgressFiles(append(progress.RotatedParts(path), path)); err != nil {
+			log.Printf("[WARN] failed to remove stale progress file %s: %v", path, err)
+			continue
+		}
+		m.Remove(session.ID)
+		pruned = append(pruned, session.ID)
+	}
+
+	sort.Strings(pruned)
+	return pruned
+}
+
 // StartTailingActive starts tailing for all active sessions.
 // for each active session not already tailing, starts tailing from the beginning
 // to populate the buffer with existing content.
@@ -306,7 +650,7 @@ func (m *SessionManager) StartTailingActive() {
 }
 
 // RefreshStates checks all sessions for state changes (active->completed).
-// stops tailing for sessions that have completed.
+// stops tailing for sessions that have completed, unless they follow completed files.
 func (m *SessionManager) RefreshStates() {
 	m.mu.RLock()
 	sessions := make([]*Session, 0, len(m.sessions))
@@ -316,35 +660,197 @@ func (m *SessionManager) RefreshStates() {
 	m.mu.RUnlock()
 
 	for _, session := range sessions {
-		// only check sessions that are currently tailing
-		if !session.IsTailing() {
+		// only check sessions that are currently tailing, followed ones are already known to be finished
+		if !session.IsTailing() || (session.FollowsCompleted() && session.GetState().Finished()) {
 			continue
 		}
 
 		// check if session is still active
-		active, err := IsActive(session.Path)
+		path := session.GetPath()
+		active, err := IsActive(path)
 		if err != nil {
 			continue
 		}
 
 		if !active {
-			// session completed, update state and stop tailing
-			session.SetState(SessionStateCompleted)
-			session.StopTailing()
+			// session finished, update state and stop tailing
+			prevState := session.GetState()
+			newState := finishedState(path)
+			footer, _ := ParseProgressFooter(path) // timings are optional, see updateSession
+			meta := session.GetMetadata()
+			meta.PhaseTimings, meta.Tokens, meta.PlanFile = footer.PhaseTimings, footer.Tokens, footer.PlanFile
+			session.SetMetadata(meta)
+			session.SetState(newState)
+			if !session.FollowFinished() {
+				session.StopTailing()
+			}
+			if prevState != newState {
+				m.notify(LifecycleSessionStateChanged, session, prevState)
+			}
+		}
+	}
+}
+
+// footerTailSize is how much of the progress file end is read to find the footer.
+const footerTailSize = 4096
+
+// finishedState returns the terminal state of a finished session from its progress file footer.
+// files without a recognized footer, e.g. from a killed process, are treated as completed.
+func finishedState(path string) SessionState {
+	footer, _ := ParseProgressFooter(path) // the outcome is kept even if the timings can't be parsed
+	switch footer.Outcome {
+	case progress.OutcomeCanceled:
+		return SessionStateCanceled
+	case progress.OutcomeFailed:
+		return SessionStateFailed
+	default:
+		return SessionStateCompleted
+	}
+}
+
+// ProgressFooter holds the information written at the end of a finished progress file.
+type ProgressFooter struct {
+	Outcome      string                            // how the run ended, see progress.OutcomeCompleted, empty without footer
+	PhaseTimings map[processor.Phase]time.Duration // time spent in each phase, nil if not recorded
+	Tokens       map[processor.Phase]int64         // reported tokens per phase, nil if not tracked, empty if unavailable
+	Push         string                            // result of auto-push, e.g. "pushed feature to origin", empty if not pushed
+	PlanFile     string                            // plan file produced by plan creation, empty for other runs
+}
+
+// ParseProgressFooter reads the footer at the end of a progress file.
+// the text footer ends with the outcome line, optionally preceded by the phase timings, token usage, push
+// and plan file lines:
+//
+//	------------------------------------------------------------
+//	Phase-Timings: task=12m3s review=4m1s
+//	Tokens: task=120500 review=30211
+//	Push: pushed feature to origin
+//	Plan-File: docs/plans/feature.md
+//	Completed: 2026-01-22 10:30:00 (16 minutes)
+//
+// the jsonl footer is the last record. a file without footer, e.g. from a killed process,
+// returns an empty ProgressFooter.
+func ParseProgressFooter(path string) (ProgressFooter, error) {
+	buf, err := readFileTail(path, footerTailSize)
+	if err != nil {
+		return ProgressFooter{}, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(buf), "\n"), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+
+	var footer ProgressFooter
+	var timings, tokens string
+	if strings.HasPrefix(last, "{") {
+		var rec progress.Record
+		if json.Unmarshal([]byte(last), &rec) != nil || rec.Type != progress.RecordFooter {
+			return ProgressFooter{}, nil
+		}
+		footer.Outcome = rec.Outcome
+		if footer.Outcome == "" {
+			footer.Outcome = progress.OutcomeCompleted
+		}
+		timings, tokens, footer.Push, footer.PlanFile = rec.PhaseTimings, rec.Tokens, rec.Push, rec.PlanFile
+	} else {
+		for _, o := range []string{progress.OutcomeCompleted, progress.OutcomeCanceled, progress.OutcomeFailed} {
+			if strings.HasPrefix(last, progress.FooterLabel(o)+": ") {
+				footer.Outcome = o
+			}
+		}
+		if footer.Outcome == "" {
+			return ProgressFooter{}, nil
+		}
+		for i := len(lines) - 2; i >= 0; i-- {
+			line := strings.TrimSpace(lines[i])
+			if val, ok := strings.CutPrefix(line, progress.PhaseTimingsLabel+": "); ok {
+				timings = val
+				continue
+			}
+			if val, ok := strings.CutPrefix(line, progress.TokensLabel+": "); ok {
+				tokens = val
+				continue
+			}
+			if val, ok := strings.CutPrefix(line, progress.PushLabel+": "); ok {
+				footer.Push = val
+				continue
+			}
+			if val, ok := strings.CutPrefix(line, progress.PlanFileLabel+": "); ok {
+				footer.PlanFile = val
+				continue
+			}
+			break // footer labels end at the separator line
+		}
+	}
+
+	if timings != "" {
+		if footer.PhaseTimings, err = progress.ParsePhaseTimings(timings); err != nil {
+			return footer, fmt.Errorf("parse phase timings: %w", err) // outcome stays valid
+		}
+	}
+	if tokens != "" {
+		if footer.Tokens, err = progress.ParseTokenUsage(tokens); err != nil {
+			return footer, fmt.Errorf("parse token usage: %w", err)
+		}
+	}
+	return footer, nil
+}
+
+// readFileTail returns the last n bytes of a progress file.
+// compressed files can't be read from an offset, their content is decompressed up to the end.
+func readFileTail(path string, n int64) ([]byte, error) {
+	if progress.IsCompressed(path) {
+		f, err := progress.OpenFile(path)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // already wrapped by OpenFile
+		}
+		defer f.Close()
+		var tail []byte
+		chunk := make([]byte, 32*1024)
+		for {
+			k, err := f.Read(chunk)
+			tail = append(tail, chunk[:k]...)
+			if excess := int64(len(tail)) - n; excess > 0 {
+				tail = append(tail[:0], tail[excess:]...)
+			}
+			if errors.Is(err, io.EOF) {
+				return tail, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read footer: %w", err)
+			}
 		}
 	}
+
+	f, err := os.Open(path) //nolint:gosec // path comes from discovered progress files
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat file: %w", err)
+	}
+	start := max(info.Size()-n, 0)
+	buf := make([]byte, info.Size()-start)
+	if _, err := f.ReadAt(buf, start); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read footer: %w", err)
+	}
+	return buf, nil
 }
 
 // sessionIDFromPath derives a session ID from the progress file path.
-// the ID includes the filename (without the "progress-" prefix and ".txt" suffix)
+// the ID includes the filename (without the "progress-" prefix and ".txt" or ".txt.gz" suffix)
 // plus an FNV-64a hash of the canonical absolute path to avoid collisions across directories.
 //
 // format: <plan-name>-<16-char-hex-hash>
 // example: "/tmp/progress-my-plan.txt" -> "my-plan-a1b2c3d4e5f67890"
 //
 // the hash ensures uniqueness when the same plan name exists in different directories.
-// the path is canonicalized (absolute + cleaned) before hashing for stability.
+// the path is canonicalized (absolute + cleaned, without the ".gz" suffix) before hashing for stability,
+// so a progress file keeps its session ID once compressed.
 func sessionIDFromPath(path string) string {
+	path = strings.TrimSuffix(path, progress.CompressedSuffix)
 	base := filepath.Base(path)
 	id := strings.TrimPrefix(base, "progress-")
 	id = strings.TrimSuffix(id, ".txt")
@@ -362,11 +868,19 @@ func sessionIDFromPath(path string) string {
 
 // IsActive checks if a progress file is locked by another process or the current one.
 // returns true if the file is locked (session is running), false otherwise.
-// uses flock with LOCK_EX|LOCK_NB to test without blocking.
+// uses flock with LOCK_EX|LOCK_NB to test without blocking. a lock is treated as stale, and the
+// session as not active, when the owner recorded in the header ran on this host and has exited.
+// compressed files are never active, they are only written once the run completed.
 func IsActive(path string) (bool, error) {
 	if progress.IsPathLockedByCurrentProcess(path) {
 		return true, nil
 	}
+	if progress.IsCompressed(path) {
+		if _, err := os.Stat(path); err != nil {
+			return false, fmt.Errorf("stat file: %w", err)
+		}
+		return false, nil
+	}
 
 	f, err := os.Open(path) //nolint:gosec // path from user-controlled glob pattern, acceptable for session discovery
 	if err != nil {
@@ -381,8 +895,14 @@ func IsActive(path string) (bool, error) {
 	}
 
 	// if we got the lock, file is not active
-	// if we didn't get the lock, file is locked by another process (active)
-	return !gotLock, nil
+	// if we didn't get the lock, file is locked by another process (active), unless its recorded owner is gone
+	if gotLock {
+		return false, nil
+	}
+	if meta, err := ParseProgressHeader(path); err == nil && progress.IsOwnerStale(meta.Host, meta.PID) {
+		return false, nil
+	}
+	return true, nil
 }
 
 // ParseProgressHeader reads the header section of a progress file and extracts metadata.
@@ -391,22 +911,47 @@ func IsActive(path string) (bool, error) {
 //	# Ralphex Progress Log
 //	Plan: path/to/plan.md
 //	Branch: feature-branch
+//	Worktree: /path/to/repo-worktrees/feature-branch
 //	Mode: full
+//	Host: build-box
+//	PID: 4242
 //	Started: 2026-01-22 10:30:00
 //	------------------------------------------------------------
+//
+// for jsonl progress files the metadata is taken from the leading header record.
 func ParseProgressHeader(path string) (SessionMetadata, error) {
-	f, err := os.Open(path) //nolint:gosec // path from user-controlled glob pattern, acceptable for session discovery
+	f, err := progress.OpenFile(path)
 	if err != nil {
-		return SessionMetadata{}, fmt.Errorf("open file: %w", err)
+		return SessionMetadata{}, err //nolint:wrapcheck // already wrapped by OpenFile
 	}
 	defer f.Close()
 
 	var meta SessionMetadata
-	scanner := bufio.NewScanner(f)
+	// bufio.ScanLines drops the \r of CRLF line endings, so only a leading byte order mark needs skipping
+	scanner := bufio.NewScanner(progress.SkipBOM(f))
 	// increase buffer size for large lines (matching executor)
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxScannerBuffer)
 
+	if isJSONLProgress(path) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return SessionMetadata{}, fmt.Errorf("scan file: %w", err)
+			}
+			return meta, nil
+		}
+		var rec progress.Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return SessionMetadata{}, fmt.Errorf("parse header record: %w", err)
+		}
+		if rec.Type == progress.RecordHeader && rec.Header != nil {
+			meta = SessionMetadata{PlanPath: rec.Header.Plan, Branch: rec.Header.Branch, Mode: rec.Header.Mode,
+				StartCommit: rec.Header.Commit, StartTime: rec.Timestamp, Host: rec.Header.Host, PID: rec.Header.PID,
+				Label: rec.Header.Label, Pinned: rec.Header.Pinned, Worktree: rec.Header.Worktree}
+		}
+		return meta, nil
+	}
+
 	for scanner.Scan() {
 		line := scanner.Text()
 
@@ -422,8 +967,22 @@ func ParseProgressHeader(path string) (SessionMetadata, error) {
 			meta.Branch = val
 		} else if val, found := strings.CutPrefix(line, "Mode: "); found {
 			meta.Mode = val
+		} else if val, found := strings.CutPrefix(line, "Commit: "); found {
+			meta.StartCommit = val
+		} else if val, found := strings.CutPrefix(line, "Worktree: "); found {
+			meta.Worktree = val
+		} else if val, found := strings.CutPrefix(line, labelPrefix); found {
+			meta.Label = val
+		} else if val, found := strings.CutPrefix(line, pinnedPrefix); found {
+			meta.Pinned = val == pinnedValue
+		} else if val, found := strings.CutPrefix(line, "Host: "); found {
+			meta.Host = val
+		} else if val, found := strings.CutPrefix(line, "PID: "); found {
+			if pid, err := strconv.Atoi(val); err == nil {
+				meta.PID = pid
+			}
 		} else if val, found := strings.CutPrefix(line, "Started: "); found {
-			t, err := time.Parse("2006-01-02 15:04:05", val)
+			t, err := progress.ParseTimestamp(val)
 			if err == nil {
 				meta.StartTime = t
 			}
@@ -439,21 +998,131 @@ func ParseProgressHeader(path string) (SessionMetadata, error) {
 
 // loadProgressFileIntoSession reads a progress file and publishes events to the session's SSE server.
 // used for completed sessions that were discovered after they finished.
-// errors are silently ignored since this is best-effort loading.
+// files larger than largeProgressFileSize are loaded partially: only the last progressTailSize bytes
+// are published, and the session's history offset is set so earlier content can be fetched on demand.
+// parts rotated out of the file are published first, unless the file itself is loaded partially.
+// errors are logged but otherwise ignored since this is best-effort loading.
 func loadProgressFileIntoSession(path string, session *Session) {
-	f, err := os.Open(path) //nolint:gosec // path from user-controlled glob pattern, acceptable for session discovery
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	var start int64
+	if info.Size() > largeProgressFileSize && !progress.IsCompressed(path) { // offsets of compressed files can't be seeked to
+		start = info.Size() - progressTailSize
+	} else {
+		loadRotatedParts(path, session.Publish)
+	}
+
+	loadedFrom, err := readProgressEvents(path, start, 0, session.Publish)
 	if err != nil {
+		log.Printf("[WARN] failed to load progress file %s: %v", path, err)
 		return
 	}
+	session.SetHistoryOffset(loadedFrom)
+}
+
+// ReadProgressEvents parses a whole progress file, text or jsonl, and returns its events in file order,
+// preceded by the events of parts rotated out of it.
+func ReadProgressEvents(path string) ([]Event, error) {
+	var events []Event
+	collect := func(e Event) error {
+		events = append(events, e)
+		return nil
+	}
+	for _, part := range append(progress.RotatedParts(path), path) {
+		if _, err := readProgressEvents(part, 0, 0, collect); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// loadRotatedParts publishes the events of parts rotated out of a progress file, oldest first.
+// errors are logged and the remaining parts are still loaded.
+func loadRotatedParts(path string, publish func(Event) error) {
+	for _, part := range progress.RotatedParts(path) {
+		if _, err := readProgressEvents(part, 0, 0, publish); err != nil {
+			log.Printf("[WARN] failed to load rotated progress file %s: %v", part, err)
+		}
+	}
+}
+
+// readProgressEvents parses progress file content between byte offsets start and end and passes
+// the resulting events to publish. end <= 0 reads to the end of the file. a start offset inside a line
+// is moved forward to the beginning of the next line, so partial lines are never parsed.
+// returns the effective start offset.
+func readProgressEvents(path string, start, end int64, publish func(Event) error) (int64, error) {
+	jsonl := isJSONLProgress(path)
+	publishRendered := func(e Event) error { return publish(renderANSI(e)) }
+
+	f, err := progress.OpenFile(path)
+	if err != nil {
+		return 0, err //nolint:wrapcheck // already wrapped by OpenFile
+	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	var r io.Reader = f
+	if start > 0 {
+		// read from the byte before start, if it's a newline start is already a line boundary
+		if err = skipTo(f, start-1); err != nil {
+			return 0, err
+		}
+		br := bufio.NewReader(f)
+		skipped, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("read: %w", err)
+		}
+		start += int64(len(skipped)) - 1
+		r = br
+	}
+	if end > 0 {
+		if end <= start {
+			return start, nil
+		}
+		r = io.LimitReader(r, end-start)
+	}
+	if start == 0 {
+		r = progress.SkipBOM(r) // a byte order mark can only be at the start of the file
+	}
+
+	if jsonl {
+		scanJSONLProgress(r, publishRendered)
+		return start, nil
+	}
+	// content after the first line can't be header, and a bounded chunk keeps its trailing section
+	scanTextProgress(r, start == 0, end > 0, publishRendered)
+	return start, nil
+}
+
+// skipTo moves r to offset, seeking if r supports it and reading up to offset otherwise,
+// e.g. for decompressed content.
+func skipTo(r io.Reader, offset int64) error {
+	if s, ok := r.(io.Seeker); ok {
+		if _, err := s.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("seek: %w", err)
+		}
+		return nil
+	}
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		return fmt.Errorf("skip: %w", err)
+	}
+	return nil
+}
+
+// scanTextProgress parses text progress content and passes events to publish.
+// inHeader is true when r starts at the beginning of the file.
+// if flushSection is set, a trailing section header without events is still published.
+func scanTextProgress(r io.Reader, inHeader, flushSection bool, publish func(Event) error) {
+	scanner := bufio.NewScanner(r)
 	// increase buffer size for large lines (matching executor)
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxScannerBuffer)
-	inHeader := true
 	phase := processor.PhaseTask
+	var iteration int         // iteration number of the current section
 	var pendingSection string // section header waiting for first timestamped event
+	var lastTS time.Time
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -478,6 +1147,7 @@ func loadProgressFileIntoSession(path string, session *Session) {
 		if matches := sectionRegex.FindStringSubmatch(line); matches != nil {
 			sectionName := matches[1]
 			phase = phaseFromSection(sectionName)
+			iteration = sectionIteration(sectionName)
 			// defer emitting section until we see a timestamped event
 			pendingSection = sectionName
 			continue
@@ -488,14 +1158,15 @@ func loadProgressFileIntoSession(path string, session *Session) {
 			text := matches[2]
 
 			// parse timestamp
-			ts, err := time.Parse("06-01-02 15:04:05", matches[1])
+			ts, err := progress.ParseTimestamp(matches[1])
 			if err != nil {
 				ts = time.Now()
 			}
+			lastTS = ts
 
 			// emit pending section with this event's timestamp (for accurate durations)
 			if pendingSection != "" {
-				emitPendingSection(session, pendingSection, phase, ts)
+				emitPendingSection(publish, pendingSection, phase, ts)
 				pendingSection = ""
 			}
 
@@ -503,27 +1174,67 @@ func loadProgressFileIntoSession(path string, session *Session) {
 			event := Event{
 				Type:      eventType,
 				Phase:     phase,
+				Iteration: iteration,
 				Text:      text,
 				Timestamp: ts,
 			}
 
+			if eventType == EventTypeHumanAction {
+				event.Actor = extractActor(text)
+			}
+			if eventType == EventTypePlanReady {
+				event.PlanFile = strings.TrimPrefix(text, progress.PlanReadyPrefix)
+			}
+			markRecoverable(&event)
+
 			if sig := extractSignalFromText(text); sig != "" {
 				event.Signal = sig
 				event.Type = EventTypeSignal
 			}
 
-			_ = session.Publish(event)
+			_ = publish(event)
 			continue
 		}
 
 		// plain line (no timestamp)
-		_ = session.Publish(Event{
+		_ = publish(Event{
 			Type:      EventTypeOutput,
 			Phase:     phase,
+			Iteration: iteration,
 			Text:      line,
 			Timestamp: time.Now(),
 		})
 	}
+
+	if flushSection && pendingSection != "" {
+		if lastTS.IsZero() {
+			lastTS = time.Now()
+		}
+		emitPendingSection(publish, pendingSection, phase, lastTS)
+	}
+}
+
+// scanJSONLProgress parses jsonl progress content and passes events to publish.
+// records are read until the first malformed line, which is logged and ends scanning.
+func scanJSONLProgress(r io.Reader, publish func(Event) error) {
+	records, err := progress.ParseProgressJSONL(r)
+	if err != nil {
+		log.Printf("[WARN] failed to parse progress records: %v", err)
+	}
+	var iteration int // iteration number of the current section
+	for _, rec := range records {
+		event, ok := eventFromRecord(rec)
+		if !ok {
+			continue
+		}
+		if event.Type == EventTypeSection {
+			iteration = sectionIteration(event.Section)
+			emitPendingSection(publish, event.Section, event.Phase, event.Timestamp)
+			continue
+		}
+		event.Iteration = iteration
+		_ = publish(event)
+	}
 }
 
 // phaseFromSection determines the phase from a section name.
@@ -545,8 +1256,9 @@ func phaseFromSection(name string) processor.Phase {
 }
 
 // emitPendingSection publishes section and task_start events for a pending section.
-// task_start is emitted before section for task iteration sections.
-func emitPendingSection(session *Session, sectionName string, phase processor.Phase, ts time.Time) {
+// task_start is emitted before section for task iteration sections, both carry the section's iteration number.
+func emitPendingSection(publish func(Event) error, sectionName string, phase processor.Phase, ts time.Time) {
+	iteration := sectionIteration(sectionName)
 	// emit task_start event for task iteration sections
 	if matches := taskIterationRegex.FindStringSubmatch(sectionName); matches != nil {
 		taskNum, err := strconv.Atoi(matches[1])
@@ -554,10 +1266,11 @@ func emitPendingSection(session *Session, sectionName string, phase processor.Ph
 			// log parse error but continue - section will still be emitted
 			log.Printf("[WARN] failed to parse task number from section %q: %v", sectionName, err)
 		} else {
-			if err := session.Publish(Event{
+			if err := publish(Event{
 				Type:      EventTypeTaskStart,
 				Phase:     phase,
 				TaskNum:   taskNum,
+				Iteration: iteration,
 				Text:      sectionName,
 				Timestamp: ts,
 			}); err != nil {
@@ -566,9 +1279,10 @@ func emitPendingSection(session *Session, sectionName string, phase processor.Ph
 		}
 	}
 
-	if err := session.Publish(Event{
+	if err := publish(Event{
 		Type:      EventTypeSection,
 		Phase:     phase,
+		Iteration: iteration,
 		Section:   sectionName,
 		Text:      sectionName,
 		Timestamp: ts,
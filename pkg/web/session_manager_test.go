@@ -1,17 +1,24 @@
 package web
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
 
 	"github.com/umputun/ralphex/pkg/config"
+	"github.com/umputun/ralphex/pkg/processor"
 	"github.com/umputun/ralphex/pkg/progress"
 )
 
@@ -52,6 +59,49 @@ func TestSessionManager_Discover(t *testing.T) {
 		assert.Equal(t, "docs/plan2.md", s2.GetMetadata().PlanPath)
 	})
 
+	t.Run("same file name in two dirs", func(t *testing.T) {
+		dir1, dir2 := t.TempDir(), t.TempDir()
+		path1 := filepath.Join(dir1, "progress-plan-foo.txt")
+		path2 := filepath.Join(dir2, "progress-plan-foo.txt")
+		createProgressFile(t, path1, "docs/foo.md", "main", "full")
+		createProgressFile(t, path2, "docs/foo.md", "feature", "full")
+
+		m := NewSessionManager()
+		_, err := m.Discover(dir1)
+		require.NoError(t, err)
+		_, err = m.Discover(dir2)
+		require.NoError(t, err)
+		require.Len(t, m.All(), 2)
+
+		id1, id2 := sessionIDFromPath(path1), sessionIDFromPath(path2)
+		require.NotEqual(t, id1, id2)
+		require.NotNil(t, m.Get(id1))
+		require.NotNil(t, m.Get(id2))
+		assert.Equal(t, "main", m.Get(id1).GetMetadata().Branch)
+		assert.Equal(t, "feature", m.Get(id2).GetMetadata().Branch)
+
+		m.Remove(id1)
+		assert.Nil(t, m.Get(id1))
+		assert.NotNil(t, m.Get(id2))
+	})
+
+	t.Run("applies configured poll interval", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-plan1.txt")
+		createProgressFile(t, path, "docs/plan1.md", "main", "full")
+
+		m := NewSessionManager()
+		m.SetPollInterval(750 * time.Millisecond)
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+
+		s := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, s)
+		require.NoError(t, s.StartTailing(true))
+		defer s.StopTailing()
+		assert.Equal(t, 750*time.Millisecond, s.Tailer.config.PollInterval)
+	})
+
 	t.Run("returns empty for no matches", func(t *testing.T) {
 		dir := t.TempDir()
 
@@ -107,6 +157,75 @@ func TestSessionManager_Discover(t *testing.T) {
 		// should update metadata
 		assert.Equal(t, "feature", s.GetMetadata().Branch)
 	})
+
+	t.Run("finds files named by template", func(t *testing.T) {
+		dir := t.TempDir()
+		oldWd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+		logger, err := progress.NewLogger(progress.Config{PlanFile: "docs/plans/add-cache.md", Mode: "review",
+			Branch: "feat/cache", NoColor: true, NameTemplate: "{date}-{branch}-{slug}-{mode}"}, testColors())
+		require.NoError(t, err)
+		require.NoError(t, logger.Close())
+		assert.Regexp(t, `^progress-\d{4}-\d{2}-\d{2}-feat-cache-add-cache-review\.txt$`, logger.Path())
+
+		m := NewSessionManager()
+		defer m.Close()
+		ids, err := m.Discover(dir)
+		require.NoError(t, err)
+		require.Equal(t, []string{sessionIDFromPath(filepath.Join(dir, logger.Path()))}, ids)
+		assert.True(t, strings.HasPrefix(ids[0], strings.TrimSuffix(strings.TrimPrefix(logger.Path(), "progress-"), ".txt")))
+		assert.Equal(t, "feat/cache", m.Get(ids[0]).GetMetadata().Branch)
+	})
+
+	t.Run("concurrent rediscovery and reads", func(t *testing.T) {
+		// meaningful with -race, rediscovery must update sessions only through the locked accessors
+		dir := t.TempDir()
+		paths := []string{filepath.Join(dir, "progress-one.txt"), filepath.Join(dir, "progress-two.txt")}
+		for _, path := range paths {
+			createProgressFile(t, path, "plan.md", "main", "full")
+		}
+
+		m := NewSessionManager()
+		defer m.Close()
+
+		var writers sync.WaitGroup
+		for range 2 {
+			writers.Add(1)
+			go func() {
+				defer writers.Done()
+				for i := range 20 {
+					_, err := m.Discover(dir)
+					assert.NoError(t, err)
+					if i%5 == 0 {
+						createProgressFile(t, paths[0], "plan.md", "branch-"+strconv.Itoa(i), "full")
+					}
+				}
+			}()
+		}
+		done := make(chan struct{})
+		go func() {
+			writers.Wait()
+			close(done)
+		}()
+		for reading := true; reading; {
+			select {
+			case <-done:
+				reading = false
+			default:
+				for _, s := range m.All() {
+					_, _, _ = s.GetMetadata().Branch, s.GetState(), s.GetLastModified()
+				}
+			}
+		}
+
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+		assert.Len(t, m.All(), len(paths), "concurrent discoveries don't duplicate sessions")
+		assert.True(t, strings.HasPrefix(m.Get(sessionIDFromPath(paths[0])).GetMetadata().Branch, "branch-"))
+	})
 }
 
 func TestSessionManager_Get(t *testing.T) {
@@ -213,30 +332,106 @@ func TestSessionManager_Register(t *testing.T) {
 		assert.True(t, strings.HasPrefix(session.ID, "my-feature-"), "ID should start with plan name")
 	})
 
-	t.Run("existing session is NOT overwritten", func(t *testing.T) {
+	t.Run("re-registering updates the existing session without replacing it", func(t *testing.T) {
 		m := NewSessionManager()
 		defer m.Close()
 
-		path := "/tmp/progress-idempotent.txt"
+		path := filepath.Join(t.TempDir(), "progress-idempotent.txt")
+		createProgressFile(t, path, "plan.md", "first", "full")
 		session1 := NewSession("id1", path)
 		defer session1.Close()
 		session1.SetMetadata(SessionMetadata{Branch: "first"})
+		session1.SetState(SessionStateActive)
+		session1.Publish(NewOutputEvent(processor.PhaseTask, "live output"))
+		require.NoError(t, session1.StartTailing(false))
+		tailer := session1.Tailer
 
 		session2 := NewSession("id2", path)
 		defer session2.Close()
 		session2.SetMetadata(SessionMetadata{Branch: "second"})
+		session2.SetState(SessionStateCompleted)
 
-		// register first session
-		m.Register(session1)
-
-		// try to register second session with same path
-		m.Register(session2)
+		assert.Same(t, session1, m.Register(session1))
+		assert.Same(t, session1, m.Register(session2), "existing session should be returned")
 
-		// first session should still be there
-		expectedID := sessionIDFromPath(path)
-		got := m.Get(expectedID)
+		got := m.Get(sessionIDFromPath(path))
 		require.NotNil(t, got)
-		assert.Equal(t, "first", got.GetMetadata().Branch, "original session should not be overwritten")
+		assert.Same(t, session1, got, "original session should not be replaced")
+		assert.Same(t, tailer, got.Tailer, "tailer should be preserved")
+		assert.True(t, got.IsTailing())
+		assert.Same(t, session1.SSE, got.SSE, "SSE server should be preserved")
+		assert.Equal(t, "second", got.GetMetadata().Branch, "metadata should be updated")
+		assert.Equal(t, SessionStateCompleted, got.GetState(), "state should be updated")
+
+		// live history published before the update is still replayed
+		writer := &mockMessageWriter{}
+		err := session1.SSE.Provider.(*sse.Joe).Replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}})
+		require.NoError(t, err)
+		require.Len(t, writer.messages, 1)
+		assert.Contains(t, writer.messages[0], "live output")
+	})
+
+	t.Run("re-registering with empty metadata keeps existing metadata", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+
+		path := "/tmp/progress-keep-meta.txt"
+		session := NewSession("id", path)
+		defer session.Close()
+		session.SetMetadata(SessionMetadata{Branch: "feature", Mode: "full"})
+		m.Register(session)
+
+		dup := NewSession("dup", path)
+		defer dup.Close()
+		dup.SetState(SessionStateActive)
+		assert.Same(t, session, m.Register(dup))
+		assert.Equal(t, SessionMetadata{Branch: "feature", Mode: "full"}, session.GetMetadata())
+		assert.Equal(t, SessionStateActive, session.GetState())
+	})
+
+	t.Run("re-registering with partial metadata keeps other fields", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+
+		path := "/tmp/progress-partial-meta.txt"
+		session := NewSession("id", path)
+		defer session.Close()
+		timings := map[processor.Phase]time.Duration{processor.PhaseTask: time.Minute}
+		tokens := map[processor.Phase]int64{processor.PhaseTask: 1200}
+		session.SetMetadata(SessionMetadata{Branch: "feature", Mode: "full", PhaseTimings: timings, Tokens: tokens})
+		m.Register(session)
+
+		dup := NewSession("dup", path)
+		defer dup.Close()
+		dup.SetMetadata(SessionMetadata{Branch: "other", Label: "nightly"})
+		assert.Same(t, session, m.Register(dup))
+		assert.Equal(t, SessionMetadata{Branch: "other", Mode: "full", Label: "nightly", PhaseTimings: timings, Tokens: tokens},
+			session.GetMetadata())
+	})
+
+	t.Run("re-registering keeps a single entry in All", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+
+		first := NewSession("a", "/tmp/progress-first.txt")
+		defer first.Close()
+		second := NewSession("b", "/tmp/progress-second.txt")
+		defer second.Close()
+		dup := NewSession("c", "/tmp/progress-first.txt")
+		defer dup.Close()
+
+		m.Register(first)
+		m.Register(second)
+		m.Register(dup)
+		m.Register(first) // same session again is a no-op
+
+		all := m.All()
+		require.Len(t, all, 2)
+		assert.ElementsMatch(t, []*Session{first, second}, all)
+		assert.Equal(t, 2, m.Count())
+		assert.Same(t, first, m.Get(first.ID))
+		assert.Same(t, second, m.Get(second.ID))
+		assert.Equal(t, first.ID, dup.ID, "passed session gets the derived ID even when not stored")
 	})
 
 	t.Run("registered session is retrievable via Get", func(t *testing.T) {
@@ -291,6 +486,11 @@ func TestSessionIDFromPath(t *testing.T) {
 		id2 := sessionIDFromPath(path)
 		assert.Equal(t, id1, id2)
 	})
+
+	t.Run("compressed file keeps the ID", func(t *testing.T) {
+		path := "/tmp/progress-simple.txt"
+		assert.Equal(t, sessionIDFromPath(path), sessionIDFromPath(path+progress.CompressedSuffix))
+	})
 }
 
 func TestIsActive(t *testing.T) {
@@ -345,7 +545,11 @@ func TestParseProgressHeader(t *testing.T) {
 		content := `# Ralphex Progress Log
 Plan: docs/plans/my-plan.md
 Branch: feature-branch
+Worktree: /src/proj-worktrees/feature-branch
+Commit: 4b825dc642cb6eb9a060e54bf8d69288fbee4904
 Mode: full
+Host: build-box
+PID: 4242
 Started: 2026-01-22 10:30:00
 ------------------------------------------------------------
 
@@ -360,6 +564,9 @@ Started: 2026-01-22 10:30:00
 		assert.Equal(t, "feature-branch", meta.Branch)
 		assert.Equal(t, "full", meta.Mode)
 		assert.Equal(t, time.Date(2026, 1, 22, 10, 30, 0, 0, time.UTC), meta.StartTime)
+		assert.Equal(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904", meta.StartCommit)
+		assert.Equal(t, "build-box", meta.Host)
+		assert.Equal(t, 4242, meta.PID)
 	})
 
 	t.Run("handles review-only mode", func(t *testing.T) {
@@ -399,6 +606,64 @@ Branch: main
 		assert.Equal(t, "main", meta.Branch)
 		assert.Empty(t, meta.Mode)
 		assert.True(t, meta.StartTime.IsZero())
+		assert.Empty(t, meta.StartCommit, "headers written before Commit: was recorded have no start commit")
+		assert.Empty(t, meta.Host)
+		assert.Zero(t, meta.PID, "headers written before the owner was recorded have no pid")
+	})
+
+	t.Run("ignores commit line after separator", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+
+		content := `# Ralphex Progress Log
+Branch: main
+Mode: full
+------------------------------------------------------------
+
+Commit: deadbeef
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Empty(t, meta.StartCommit)
+	})
+
+	t.Run("parses jsonl header record", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+
+		content := `{"timestamp":"2026-01-22T10:30:00Z","type":"header","header":{"plan":"docs/plans/my-plan.md",` +
+			`"branch":"feature-branch","commit":"abc123","mode":"review","host":"build-box","pid":4242}}
+{"timestamp":"2026-01-22T10:30:05Z","phase":"task","type":"output","text":"Branch: other"}
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Equal(t, SessionMetadata{PlanPath: "docs/plans/my-plan.md", Branch: "feature-branch", Mode: "review",
+			StartCommit: "abc123", StartTime: time.Date(2026, 1, 22, 10, 30, 0, 0, time.UTC), Host: "build-box", PID: 4242}, meta)
+	})
+
+	t.Run("jsonl without header record", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+		content := `{"timestamp":"2026-01-22T10:30:05Z","phase":"task","type":"output","text":"hi"}` + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		assert.Equal(t, SessionMetadata{}, meta)
+	})
+
+	t.Run("returns error for malformed jsonl header", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+		require.NoError(t, os.WriteFile(path, []byte("{not json\n"), 0o600))
+
+		_, err := ParseProgressHeader(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parse header record")
 	})
 
 	t.Run("returns error for missing file", func(t *testing.T) {
@@ -466,6 +731,209 @@ Started: 2026-01-22 10:00:00
 	})
 }
 
+func TestLoadProgressFileIntoSession_HistoryTruncated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-test.txt")
+	createProgressFile(t, path, "plan.md", "main", "full")
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+	require.NoError(t, err)
+	for i := range DefaultReplayerSize + 7 {
+		_, err = f.WriteString("[26-01-22 10:00:01] line " + strconv.Itoa(i) + "\n")
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	session := NewSession("test", path)
+	defer session.Close()
+	loadProgressFileIntoSession(path, session)
+
+	replayer, ok := session.SSE.Provider.(*sse.Joe).Replayer.(*allEventsReplayer)
+	require.True(t, ok)
+	assert.Equal(t, int64(7), replayer.Dropped())
+
+	writer := &mockMessageWriter{}
+	require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	require.Len(t, writer.messages, DefaultReplayerSize+1)
+	assert.Contains(t, writer.messages[0], "history truncated: 7 earlier events dropped")
+	assert.Contains(t, writer.messages[1], "line 7")
+}
+
+func TestSessionManager_MaxEventTextBytes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-blob.txt")
+	createProgressFile(t, path, "plan.md", "main", "full")
+	// 4000 bytes of base64 on one line
+	blob := strings.Repeat("QUJD", 1000)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+	require.NoError(t, err)
+	_, err = f.WriteString("[26-01-22 10:00:01] first\n[26-01-22 10:00:02] " + blob + "\n[26-01-22 10:00:03] after\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	m := NewSessionManager()
+	defer m.Close()
+	m.SetMaxEventTextBytes(100)
+	_, err = m.Discover(dir)
+	require.NoError(t, err)
+	session := m.Get(sessionIDFromPath(path))
+	require.NotNil(t, session)
+
+	writer := &mockMessageWriter{}
+	joe, ok := session.SSE.Provider.(*sse.Joe)
+	require.True(t, ok)
+	require.NoError(t, joe.Replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	var texts []string
+	for _, msg := range writer.messages {
+		for line := range strings.SplitSeq(msg, "\n") {
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var e Event
+				require.NoError(t, json.Unmarshal([]byte(data), &e))
+				texts = append(texts, e.Text)
+			}
+		}
+	}
+	assert.Contains(t, texts, blob[:100]+"… truncated 3900 bytes")
+	assert.Contains(t, texts, "after", "short lines are kept")
+
+	// the progress file keeps the full line
+	content, err := os.ReadFile(path) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Contains(t, string(content), blob)
+}
+
+func TestLoadProgressFileIntoSession_JSONL(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	logger, err := progress.NewLogger(progress.Config{PlanFile: "plan.md", Mode: "full", Branch: "main", NoColor: true,
+		Format: progress.FormatJSONL}, testColors())
+	require.NoError(t, err)
+	logger.Print("starting")
+	logger.PrintSection(processor.NewTaskIterationSection(2))
+	logger.PrintAligned("<<<RALPHEX:ALL_TASKS_DONE>>>")
+	logger.LogHumanAction("web", "paused")
+	require.NoError(t, logger.Close())
+	path := filepath.Join(dir, logger.Path())
+
+	session := NewSession("test-jsonl", path)
+	defer session.Close()
+	loadProgressFileIntoSession(path, session)
+
+	writer := &mockMessageWriter{}
+	replayer := session.SSE.Provider.(*sse.Joe).Replayer
+	require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	replayed := strings.Join(writer.messages, "\n")
+
+	assert.Contains(t, replayed, `"type":"task_start"`)
+	assert.Contains(t, replayed, `"task_num":2`)
+	assert.Contains(t, replayed, `"type":"section","phase":"task","section":"task iteration 2"`)
+	assert.Contains(t, replayed, `"signal":"COMPLETED"`)
+	assert.Contains(t, replayed, `"actor":"web"`)
+	assert.Contains(t, replayed, `"text":"Completed: `)
+	assert.NotContains(t, replayed, `"type":"header"`)
+}
+
+func TestLoadProgressFileIntoSession_Rotated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-plan.txt")
+
+	// two parts rotated out by the logger, then the current file with the footer, three lines each
+	header := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\n%sStarted: 2026-01-22 10:00:00\n" +
+		strings.Repeat("-", 60) + "\n\n"
+	for i, file := range []string{progress.RotatedPath(path, 1), progress.RotatedPath(path, 2), path} {
+		var content strings.Builder
+		if i == 0 {
+			fmt.Fprintf(&content, header, "")
+			content.WriteString("--- task iteration 1 ---\n")
+		} else {
+			fmt.Fprintf(&content, header, fmt.Sprintf("Part: %d\n", i+1))
+		}
+		for j := range 3 {
+			fmt.Fprintf(&content, "[26-01-22 10:00:%02d] line %d\n", i*3+j, i*3+j)
+		}
+		if file == path {
+			content.WriteString("\n" + strings.Repeat("-", 60) + "\nCompleted: 2026-01-22 10:01:00 (1m0s)\n")
+		}
+		require.NoError(t, os.WriteFile(file, []byte(content.String()), 0o600))
+	}
+
+	// wantLines checks that the event texts hold all lines in order
+	wantLines := func(t *testing.T, texts []string) {
+		t.Helper()
+		var lines []string
+		for _, text := range texts {
+			if strings.HasPrefix(text, "line ") {
+				lines = append(lines, text)
+			}
+		}
+		assert.Equal(t, []string{"line 0", "line 1", "line 2", "line 3", "line 4", "line 5", "line 6", "line 7", "line 8"}, lines)
+	}
+
+	t.Run("discovered as one session", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+		ids, err := m.Discover(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{sessionIDFromPath(path)}, ids)
+		assert.False(t, isProgressFile(progress.RotatedPath(path, 1)))
+	})
+
+	t.Run("read events", func(t *testing.T) {
+		events, err := ReadProgressEvents(path)
+		require.NoError(t, err)
+		texts := make([]string, 0, len(events))
+		for _, e := range events {
+			texts = append(texts, e.Text)
+		}
+		wantLines(t, texts)
+	})
+
+	t.Run("load into session", func(t *testing.T) {
+		session := NewSession("test-rotated", path)
+		defer session.Close()
+		loadProgressFileIntoSession(path, session)
+
+		writer := &mockMessageWriter{}
+		replayer := session.SSE.Provider.(*sse.Joe).Replayer
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+		texts := make([]string, 0, len(writer.messages))
+		for _, msg := range writer.messages {
+			_, data, ok := strings.Cut(msg, "data: ")
+			require.True(t, ok, msg)
+			var e Event
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(data)), &e))
+			texts = append(texts, e.Text)
+		}
+		wantLines(t, texts)
+	})
+
+	t.Run("plan name with a number", func(t *testing.T) {
+		versioned := filepath.Join(t.TempDir(), "progress-v1.2.txt")
+		createProgressFile(t, versioned, "v1.2.md", "main", "full")
+		assert.True(t, isProgressFile(versioned), "not a rotated part without progress-v1.txt")
+	})
+
+	// runs last, compresses the current file in place
+	t.Run("discovered as one session once compressed", func(t *testing.T) {
+		data, err := os.ReadFile(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path+progress.CompressedSuffix, gzipBytes(t, data), 0o600))
+		require.NoError(t, os.Remove(path))
+
+		assert.False(t, isProgressFile(progress.RotatedPath(path, 1)))
+		assert.False(t, isProgressFile(progress.RotatedPath(path, 2)))
+		m := NewSessionManager()
+		defer m.Close()
+		ids, err := m.Discover(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{sessionIDFromPath(path + progress.CompressedSuffix)}, ids)
+	})
+}
+
 func TestEmitPendingSection(t *testing.T) {
 	t.Run("task iteration section emits task_start event", func(t *testing.T) {
 		dir := t.TempDir()
@@ -613,6 +1081,69 @@ Started: 2026-01-22 10:00:00
 	assert.True(t, session.IsLoaded(), "completed session should be marked as loaded")
 }
 
+func TestSessionManager_LazyLoadCompleted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-completed.txt")
+	content := `# Ralphex Progress Log
+Plan: docs/plan.md
+Branch: main
+Mode: full
+Started: 2026-01-22 10:00:00
+------------------------------------------------------------
+
+--- Task 1 ---
+[26-01-22 10:00:01] task output
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	replayed := func(t *testing.T, session *Session) []string {
+		t.Helper()
+		writer := &mockMessageWriter{}
+		replayer := session.SSE.Provider.(*sse.Joe).Replayer
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+		return writer.messages
+	}
+
+	t.Run("buffer is populated on first access", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+		m.SetLazyLoadCompleted(true)
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+
+		session := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, session)
+		assert.Equal(t, SessionStateCompleted, session.GetState())
+		assert.False(t, session.IsLoaded(), "content should not be loaded before first access")
+		assert.Empty(t, replayed(t, session))
+
+		session.EnsureLoaded()
+		assert.True(t, session.IsLoaded())
+		messages := replayed(t, session)
+		assert.Contains(t, strings.Join(messages, "\n"), "task output")
+
+		// repeated access doesn't load the content again
+		session.EnsureLoaded()
+		assert.Len(t, replayed(t, session), len(messages))
+	})
+
+	t.Run("disabled loads on discovery", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+
+		session := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, session)
+		assert.True(t, session.IsLoaded())
+		messages := replayed(t, session)
+		assert.Contains(t, strings.Join(messages, "\n"), "task output")
+
+		session.EnsureLoaded()
+		assert.Len(t, replayed(t, session), len(messages))
+	})
+}
+
 func TestSessionManager_EvictOldCompleted(t *testing.T) {
 	t.Run("evicts oldest completed sessions when limit exceeded", func(t *testing.T) {
 		dir := t.TempDir()
@@ -666,6 +1197,76 @@ Started: ` + startTime.Format("2006-01-02 15:04:05") + `
 	})
 }
 
+func TestSessionManager_PruneOlderThan(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-10 * 24 * time.Hour)
+
+	// old completed session, should be pruned
+	oldPath := filepath.Join(dir, "progress-old.txt")
+	createProgressFile(t, oldPath, "old.md", "main", "full")
+	require.NoError(t, os.Chtimes(oldPath, old, old))
+
+	// old completed session with rotated parts, all of its files should be pruned
+	rotatedPath := filepath.Join(dir, "progress-rotated.txt")
+	for _, part := range []string{progress.RotatedPath(rotatedPath, 1), progress.RotatedPath(rotatedPath, 2), rotatedPath} {
+		createProgressFile(t, part, "rotated.md", "main", "full")
+		require.NoError(t, os.Chtimes(part, old, old))
+	}
+
+	// old header but recently modified, should be kept
+	recentPath := filepath.Join(dir, "progress-recent.txt")
+	createProgressFile(t, recentPath, "recent.md", "main", "full")
+
+	// old but pinned, should be kept
+	pinnedPath := filepath.Join(dir, "progress-pinned.txt")
+	createProgressFile(t, pinnedPath, "pinned.md", "main", "full")
+	require.NoError(t, NewSession(sessionIDFromPath(pinnedPath), pinnedPath).SetPinned(true))
+	require.NoError(t, os.Chtimes(pinnedPath, old, old))
+
+	// old but held by a running progress logger, should be kept
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+	logger, err := progress.NewLogger(progress.Config{PlanFile: filepath.Join(dir, "locked.md"), Mode: "full",
+		Branch: "main"}, testColors())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+	lockedPath := logger.Path()
+	if !filepath.IsAbs(lockedPath) {
+		lockedPath = filepath.Join(dir, lockedPath)
+	}
+	createProgressFile(t, lockedPath, "locked.md", "main", "full")
+	require.NoError(t, os.Chtimes(lockedPath, old, old))
+
+	m := NewSessionManager()
+	t.Cleanup(m.Close)
+	_, err = m.Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, m.All(), 5)
+
+	pruned := m.PruneOlderThan(7 * 24 * time.Hour)
+	assert.ElementsMatch(t, []string{sessionIDFromPath(oldPath), sessionIDFromPath(rotatedPath)}, pruned)
+
+	assert.NoFileExists(t, oldPath)
+	assert.NoFileExists(t, rotatedPath)
+	assert.NoFileExists(t, progress.RotatedPath(rotatedPath, 1))
+	assert.NoFileExists(t, progress.RotatedPath(rotatedPath, 2))
+	assert.Nil(t, m.Get(sessionIDFromPath(oldPath)))
+	assert.FileExists(t, recentPath)
+	assert.NotNil(t, m.Get(sessionIDFromPath(recentPath)))
+	assert.FileExists(t, lockedPath)
+	assert.NotNil(t, m.Get(sessionIDFromPath(lockedPath)))
+	assert.FileExists(t, pinnedPath)
+	assert.NotNil(t, m.Get(sessionIDFromPath(pinnedPath)))
+
+	// nothing left to prune on second pass, and no part turned into a session of its own
+	assert.Empty(t, m.PruneOlderThan(7*24*time.Hour))
+	_, err = m.Discover(dir)
+	require.NoError(t, err)
+	assert.Len(t, m.All(), 3)
+}
+
 func TestSessionManager_RefreshStates(t *testing.T) {
 	t.Run("skips non-tailing sessions", func(t *testing.T) {
 		dir := t.TempDir()
@@ -717,6 +1318,253 @@ func TestSessionManager_RefreshStates(t *testing.T) {
 		assert.Equal(t, SessionStateCompleted, session.GetState())
 		assert.False(t, session.IsTailing())
 	})
+
+	t.Run("marks unlocked tailing session with canceled footer as canceled", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+		createProgressFile(t, path, "plan.md", "main", "full")
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+		require.NoError(t, err)
+		_, err = f.WriteString("\n" + strings.Repeat("-", 60) + "\nCanceled: 2026-01-22 10:05:00 (5 minutes)\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		m := NewSessionManager()
+		_, err = m.Discover(dir)
+		require.NoError(t, err)
+
+		session := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, session)
+		assert.Equal(t, SessionStateCanceled, session.GetState(), "discovery reads the footer")
+
+		session.SetState(SessionStateActive)
+		require.NoError(t, session.StartTailing(true))
+
+		m.RefreshStates()
+		time.Sleep(50 * time.Millisecond) // give goroutine time to stop
+		assert.Equal(t, SessionStateCanceled, session.GetState())
+		assert.False(t, session.IsTailing())
+	})
+}
+
+func TestSessionManager_FollowCompleted(t *testing.T) {
+	// finishedSession discovers a progress file, tails it like a live session and lets RefreshStates
+	// see it finished, returning the manager and the session
+	finishedSession := func(t *testing.T, follow bool) (*SessionManager, *Session) {
+		t.Helper()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "progress-test.txt")
+		createProgressFile(t, path, "plan.md", "main", "full")
+
+		m := NewSessionManager()
+		t.Cleanup(m.Close)
+		m.SetPollInterval(10 * time.Millisecond)
+		m.SetFollowCompleted(follow)
+		_, err := m.Discover(dir)
+		require.NoError(t, err)
+
+		session := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, session)
+		session.SetState(SessionStateActive)
+		require.NoError(t, session.StartTailing(true))
+		m.RefreshStates()
+		require.Equal(t, SessionStateCompleted, session.GetState())
+		return m, session
+	}
+
+	appendLine := func(t *testing.T, path, line string) {
+		t.Helper()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+		require.NoError(t, err)
+		_, err = f.WriteString(line + "\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	replayed := func(t *testing.T, session *Session) string {
+		t.Helper()
+		writer := &mockMessageWriter{}
+		joe, ok := session.SSE.Provider.(*sse.Joe)
+		require.True(t, ok)
+		require.NoError(t, joe.Replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+		return strings.Join(writer.messages, "\n")
+	}
+
+	t.Run("appended content reactivates session", func(t *testing.T) {
+		m, session := finishedSession(t, true)
+		assert.True(t, session.IsTailing(), "finished session keeps tailing")
+
+		events, unsubscribe := m.Hub().Subscribe()
+		defer unsubscribe()
+		appendLine(t, session.Path, "[26-01-22 10:10:00] resumed work")
+
+		e := nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionStateChanged, e.Type)
+		assert.Equal(t, SessionStateCompleted, e.PrevState)
+		assert.Equal(t, SessionStateActive, session.GetState())
+		assert.Contains(t, replayed(t, session), "resumed work")
+
+		// no lock is held, so the next refresh finishes the session again and it is still followed
+		m.RefreshStates()
+		assert.Equal(t, SessionStateCompleted, session.GetState())
+		assert.True(t, session.IsTailing())
+
+		appendLine(t, session.Path, "[26-01-22 10:20:00] resumed again")
+		require.Eventually(t, func() bool { return session.GetState() == SessionStateActive }, time.Second, 10*time.Millisecond)
+		assert.Contains(t, replayed(t, session), "resumed again")
+	})
+
+	t.Run("rotated file is read from the start", func(t *testing.T) {
+		_, session := finishedSession(t, true)
+
+		content := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\n" + strings.Repeat("-", 60) +
+			"\n\n[26-01-22 11:00:00] fresh run\n"
+		rotated := session.Path + ".new"
+		require.NoError(t, os.WriteFile(rotated, []byte(content), 0o600))
+		require.NoError(t, os.Rename(rotated, session.Path))
+
+		require.Eventually(t, func() bool { return session.GetState() == SessionStateActive }, time.Second, 10*time.Millisecond)
+		assert.Contains(t, replayed(t, session), "fresh run")
+	})
+
+	t.Run("disabled stops tailing", func(t *testing.T) {
+		_, session := finishedSession(t, false)
+		time.Sleep(50 * time.Millisecond) // give goroutine time to stop
+		assert.False(t, session.IsTailing())
+
+		appendLine(t, session.Path, "[26-01-22 10:10:00] resumed work")
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, SessionStateCompleted, session.GetState())
+	})
+}
+
+func TestFinishedState(t *testing.T) {
+	dir := t.TempDir()
+	rule := strings.Repeat("-", 60)
+	tests := []struct {
+		name, content string
+		want          SessionState
+	}{
+		{name: "completed text footer", content: "output\n" + rule + "\nCompleted: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: SessionStateCompleted},
+		{name: "canceled text footer", content: "output\n" + rule + "\nCanceled: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: SessionStateCanceled},
+		{name: "failed text footer", content: "output\n" + rule + "\nFailed: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: SessionStateFailed},
+		{name: "no footer", content: "output\n", want: SessionStateCompleted},
+		{name: "empty file", content: "", want: SessionStateCompleted},
+		{name: "jsonl canceled footer", content: `{"type":"output","text":"x"}` + "\n" +
+			`{"type":"footer","text":"5 minutes","outcome":"canceled"}` + "\n", want: SessionStateCanceled},
+		{name: "jsonl failed footer", content: `{"type":"footer","text":"5 minutes","outcome":"failed"}` + "\n",
+			want: SessionStateFailed},
+		{name: "jsonl footer without outcome", content: `{"type":"footer","text":"5 minutes"}` + "\n",
+			want: SessionStateCompleted},
+		{name: "jsonl output last", content: `{"type":"output","text":"Canceled: not a footer"}` + "\n",
+			want: SessionStateCompleted},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "progress-"+strconv.Itoa(i)+".txt")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+			assert.Equal(t, tc.want, finishedState(path))
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		assert.Equal(t, SessionStateCompleted, finishedState(filepath.Join(dir, "missing.txt")))
+	})
+}
+
+func TestParseProgressFooter(t *testing.T) {
+	dir := t.TempDir()
+	rule := strings.Repeat("-", 60)
+	tests := []struct {
+		name, content string
+		want          ProgressFooter
+		wantErr       string
+	}{
+		{name: "text without timings", content: "output\n" + rule + "\nCompleted: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted}},
+		{name: "text with timings", content: "output\n" + rule + "\nPhase-Timings: task=3m0s review=2m0s\n" +
+			"Canceled: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCanceled, PhaseTimings: map[processor.Phase]time.Duration{
+				processor.PhaseTask: 3 * time.Minute, processor.PhaseReview: 2 * time.Minute}}},
+		{name: "timings line not before footer", content: "Phase-Timings: task=3m0s\n" + rule +
+			"\nCompleted: 2026-01-22 10:05:00 (5 minutes)\n", want: ProgressFooter{Outcome: progress.OutcomeCompleted}},
+		{name: "jsonl with timings", content: `{"type":"output","text":"x"}` + "\n" +
+			`{"type":"footer","text":"5 minutes","outcome":"failed","phase_timings":"task=5m0s"}` + "\n",
+			want: ProgressFooter{Outcome: progress.OutcomeFailed, PhaseTimings: map[processor.Phase]time.Duration{
+				processor.PhaseTask: 5 * time.Minute}}},
+		{name: "text with timings and tokens", content: rule + "\nPhase-Timings: task=3m0s\nTokens: task=1200 codex=30\n" +
+			"Completed: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted,
+				PhaseTimings: map[processor.Phase]time.Duration{processor.PhaseTask: 3 * time.Minute},
+				Tokens:       map[processor.Phase]int64{processor.PhaseTask: 1200, processor.PhaseCodex: 30}}},
+		{name: "text with push", content: rule + "\nPhase-Timings: task=3m0s\nTokens: task=1200\nPush: pushed feature to origin\n" +
+			"Completed: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted,
+				PhaseTimings: map[processor.Phase]time.Duration{processor.PhaseTask: 3 * time.Minute},
+				Tokens:       map[processor.Phase]int64{processor.PhaseTask: 1200}, Push: "pushed feature to origin"}},
+		{name: "jsonl with push", content: `{"type":"footer","text":"5 minutes","outcome":"completed","push":"failed: rejected"}` + "\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted, Push: "failed: rejected"}},
+		{name: "text with plan file", content: rule + "\nPlan-File: docs/plans/feature.md\n" +
+			"Completed: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted, PlanFile: "docs/plans/feature.md"}},
+		{name: "jsonl with plan file", content: `{"type":"footer","text":"5 minutes","plan_file":"docs/plans/x.md"}` + "\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted, PlanFile: "docs/plans/x.md"}},
+		{name: "text with unavailable tokens", content: rule + "\nTokens: unavailable\nFailed: 2026-01-22 10:05:00 (5 minutes)\n",
+			want: ProgressFooter{Outcome: progress.OutcomeFailed, Tokens: map[processor.Phase]int64{}}},
+		{name: "jsonl with tokens", content: `{"type":"footer","text":"5 minutes","outcome":"completed","tokens":"review=77"}` + "\n",
+			want: ProgressFooter{Outcome: progress.OutcomeCompleted, Tokens: map[processor.Phase]int64{processor.PhaseReview: 77}}},
+		{name: "invalid tokens keep outcome", content: rule + "\nTokens: task=lots\n" +
+			"Completed: 2026-01-22 10:05:00 (5 minutes)\n", want: ProgressFooter{Outcome: progress.OutcomeCompleted},
+			wantErr: "parse token usage"},
+		{name: "no footer", content: "output\n", want: ProgressFooter{}},
+		{name: "timings without footer", content: "Phase-Timings: task=3m0s\n", want: ProgressFooter{}},
+		{name: "invalid timings keep outcome", content: rule + "\nPhase-Timings: task=soon\n" +
+			"Completed: 2026-01-22 10:05:00 (5 minutes)\n", want: ProgressFooter{Outcome: progress.OutcomeCompleted},
+			wantErr: "parse phase timings"},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "progress-"+strconv.Itoa(i)+".txt")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+			footer, err := ParseProgressFooter(path)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, footer)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ParseProgressFooter(filepath.Join(dir, "missing.txt"))
+		require.Error(t, err)
+	})
+}
+
+func TestSessionManager_Discover_PhaseTimings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-done.txt")
+	content := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\nStarted: 2026-01-22 10:00:00\n" +
+		strings.Repeat("-", 60) + "\n\n[26-01-22 10:00:01] working\n\n" + strings.Repeat("-", 60) +
+		"\nPhase-Timings: task=4m0s codex=1m0s\nCompleted: 2026-01-22 10:05:00 (5m0s)\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	m := NewSessionManager()
+	defer m.Close()
+	_, err := m.Discover(dir)
+	require.NoError(t, err)
+
+	session := m.Get(sessionIDFromPath(path))
+	require.NotNil(t, session)
+	assert.Equal(t, map[processor.Phase]time.Duration{processor.PhaseTask: 4 * time.Minute, processor.PhaseCodex: time.Minute},
+		session.GetMetadata().PhaseTimings)
 }
 
 func testColors() *progress.Colors {
@@ -733,6 +1581,99 @@ func testColors() *progress.Colors {
 	})
 }
 
+func TestSessionManager_CompressedProgressFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-done.txt")
+	createProgressFile(t, path, "docs/plans/done.md", "feature", "full")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+	require.NoError(t, err)
+	_, err = f.WriteString("--- task iteration 1 ---\n[26-01-22 10:00:05] task output\n\n" +
+		"------------------------------------------------------------\nFailed: 26-01-22 10:01:00 (1m0s)\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	plainEvents, err := ReadProgressEvents(path)
+	require.NoError(t, err)
+
+	gzPath, err := progress.CompressFile(path)
+	require.NoError(t, err)
+
+	active, err := IsActive(gzPath)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	m := NewSessionManager()
+	ids, err := m.Discover(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{sessionIDFromPath(gzPath)}, ids)
+	assert.True(t, strings.HasPrefix(ids[0], "done-"))
+
+	s := m.Get(ids[0])
+	require.NotNil(t, s)
+	assert.Equal(t, SessionStateFailed, s.GetState())
+	assert.Equal(t, "feature", s.GetMetadata().Branch)
+	assert.Equal(t, "docs/plans/done.md", s.GetMetadata().PlanPath)
+
+	events, err := ReadProgressEvents(gzPath)
+	require.NoError(t, err)
+	require.Len(t, events, len(plainEvents))
+	for i := range events {
+		assert.Equal(t, plainEvents[i].Type, events[i].Type)
+		assert.Equal(t, plainEvents[i].Text, events[i].Text)
+	}
+	assert.Equal(t, "task output", events[2].Text)
+
+	// header rewrites keep the file compressed
+	require.NoError(t, s.SetLabel("archived"))
+	meta, err := ParseProgressHeader(gzPath)
+	require.NoError(t, err)
+	assert.Equal(t, "archived", meta.Label)
+	assert.NoFileExists(t, path)
+}
+
+func TestSessionManager_CompressionKeepsSession(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress-done.txt")
+	createProgressFile(t, path, "docs/plans/done.md", "feature", "full")
+
+	m := NewSessionManager()
+	defer m.Close()
+	ids, err := m.Discover(dir)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	s := m.Get(ids[0])
+	require.NotNil(t, s)
+
+	// both variants exist while the file is compressed, the plain one stays in use
+	gzPath := path + progress.CompressedSuffix
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(gzPath, gzipBytes(t, data), 0o600))
+	ids, err = m.Discover(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{s.ID}, ids)
+	assert.Equal(t, path, s.GetPath())
+
+	// once the plain file is gone the same session follows the compressed one
+	require.NoError(t, os.Remove(path))
+	ids, err = m.Discover(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{s.ID}, ids)
+	assert.Same(t, s, m.Get(s.ID))
+	assert.Equal(t, gzPath, s.GetPath())
+	assert.Equal(t, "feature", s.GetMetadata().Branch)
+}
+
+// helper to gzip data in memory
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
 // helper to create a progress file with standard header
 func createProgressFile(t *testing.T, path, plan, branch, mode string) {
 	t.Helper()
@@ -747,6 +1688,94 @@ Started: 2026-01-22 10:00:00
 	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
 }
 
+func TestProgressLineEndingsAndBOM(t *testing.T) {
+	text := `# Ralphex Progress Log
+Plan: docs/plans/my-plan.md
+Branch: feature-branch
+Mode: full
+Started: 2026-01-22 10:30:00
+------------------------------------------------------------
+
+--- task iteration 1 ---
+[26-01-22 10:30:05] Some output
+[26-01-22 10:30:06] <<<RALPHEX:ALL_TASKS_DONE>>>
+`
+	jsonl := `{"type":"header","timestamp":"2026-01-22T10:30:00Z","header":{"plan":"docs/plans/my-plan.md","branch":"feature-branch","mode":"full"}}
+{"type":"section","timestamp":"2026-01-22T10:30:01Z","section":"task iteration 1"}
+{"type":"output","timestamp":"2026-01-22T10:30:05Z","phase":"task","text":"Some output"}
+`
+	variants := map[string]func(string) string{
+		"crlf":     func(s string) string { return strings.ReplaceAll(s, "\n", "\r\n") },
+		"bom":      func(s string) string { return progress.BOM + s },
+		"bom crlf": func(s string) string { return progress.BOM + strings.ReplaceAll(s, "\n", "\r\n") },
+	}
+
+	// parse returns the header metadata and events of content written to a progress file with ext
+	parse := func(t *testing.T, content, ext string) (SessionMetadata, []Event) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "progress-test"+ext)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		meta, err := ParseProgressHeader(path)
+		require.NoError(t, err)
+		events, err := ReadProgressEvents(path)
+		require.NoError(t, err)
+		return meta, events
+	}
+
+	for _, format := range []struct{ ext, content string }{{".txt", text}, {".jsonl", jsonl}} {
+		wantMeta, wantEvents := parse(t, format.content, format.ext)
+		require.Equal(t, "feature-branch", wantMeta.Branch)
+		require.NotEmpty(t, wantEvents)
+		for name, variant := range variants {
+			t.Run(format.ext+" "+name, func(t *testing.T) {
+				meta, events := parse(t, variant(format.content), format.ext)
+				assert.Equal(t, wantMeta, meta)
+				assert.Equal(t, wantEvents, events)
+			})
+		}
+	}
+}
+
+func TestProgressTimestampFormats(t *testing.T) {
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	ts := time.Date(2026, 1, 22, 10, 30, 5, 0, time.UTC)
+	for _, format := range []string{progress.TimestampLegacy, progress.TimestampISO8601} {
+		t.Run(format, func(t *testing.T) {
+			logger, err := progress.NewLogger(progress.Config{PlanFile: format + ".md", Mode: "full", Branch: "main",
+				NoColor: true, TimestampFormat: format}, testColors())
+			require.NoError(t, err)
+			logger.SetClock(func() time.Time { return ts })
+			logger.PrintSection(processor.NewTaskIterationSection(1))
+			logger.Print("working")
+			require.NoError(t, logger.Close())
+			path := filepath.Join(dir, logger.Path())
+
+			meta, err := ParseProgressHeader(path)
+			require.NoError(t, err)
+			assert.Equal(t, "main", meta.Branch)
+			assert.False(t, meta.StartTime.IsZero(), "start time is parsed")
+			assert.WithinDuration(t, time.Now(), meta.StartTime, 24*time.Hour)
+
+			events, err := ReadProgressEvents(path)
+			require.NoError(t, err)
+			var output *Event
+			for i := range events {
+				if events[i].Text == "working" {
+					output = &events[i]
+				}
+			}
+			require.NotNil(t, output, "timestamped line is parsed as an event")
+			assert.Equal(t, EventTypeOutput, output.Type)
+			assert.True(t, ts.Equal(output.Timestamp), "got %v", output.Timestamp)
+		})
+	}
+}
+
 func TestParseProgressHeaderLargeBuffer(t *testing.T) {
 	// test that lines larger than 64KB (default bufio.Scanner limit) are handled
 	t.Run("handles lines larger than default scanner buffer", func(t *testing.T) {
@@ -805,3 +1834,259 @@ Started: 2026-01-22 10:00:00
 		loadProgressFileIntoSession(path, session)
 	})
 }
+
+func TestLoadProgressFileIntoSession_LargeFile(t *testing.T) {
+	writeProgress := func(t *testing.T, lines int) (path string, content []byte) {
+		t.Helper()
+		var sb strings.Builder
+		sb.WriteString("# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\n" +
+			"Started: 2026-01-22 10:00:00\n" + strings.Repeat("-", 60) + "\n\n--- task iteration 1 ---\n")
+		for i := range lines {
+			sb.WriteString("[26-01-22 10:00:01] synthetic output line " + strconv.Itoa(i) + " " + strings.Repeat("x", 64) + "\n")
+		}
+		path = filepath.Join(t.TempDir(), "progress-large.txt")
+		require.NoError(t, os.WriteFile(path, []byte(sb.String()), 0o600))
+		return path, []byte(sb.String())
+	}
+
+	replayed := func(t *testing.T, session *Session) string {
+		t.Helper()
+		writer := &mockMessageWriter{}
+		replayer := session.SSE.Provider.(*sse.Joe).Replayer
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+		return strings.Join(writer.messages, "\n")
+	}
+
+	t.Run("large file loads only the tail", func(t *testing.T) {
+		path, content := writeProgress(t, 100_000) // ~10MB
+		require.Greater(t, int64(len(content)), int64(largeProgressFileSize))
+
+		session := NewSession("test-large-tail", path)
+		defer session.Close()
+		loadProgressFileIntoSession(path, session)
+
+		offset := session.GetHistoryOffset()
+		assert.GreaterOrEqual(t, offset, int64(len(content))-progressTailSize)
+		assert.Equal(t, byte('\n'), content[offset-1], "history starts at a line boundary")
+
+		events := replayed(t, session)
+		assert.Contains(t, events, "synthetic output line 99999 ")
+		assert.NotContains(t, events, "synthetic output line 0 ")
+		assert.NotContains(t, events, "task iteration 1", "section before the tail is not loaded")
+	})
+
+	t.Run("small file loads fully", func(t *testing.T) {
+		path, _ := writeProgress(t, 100)
+
+		session := NewSession("test-small", path)
+		defer session.Close()
+		loadProgressFileIntoSession(path, session)
+
+		assert.Zero(t, session.GetHistoryOffset())
+		events := replayed(t, session)
+		assert.Contains(t, events, "task iteration 1")
+		assert.Contains(t, events, "synthetic output line 99 ")
+	})
+}
+
+func TestReadProgressEvents_Errors(t *testing.T) {
+	content := "# Ralphex Progress Log\nPlan: plan.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n" +
+		"[26-01-22 10:00:01] ERROR: finalize step failed: boom (recoverable)\n" +
+		"[26-01-22 10:00:02] ERROR: codex loop: codex exited\n"
+	path := filepath.Join(t.TempDir(), "progress-errors.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	session := NewSession("errors", path)
+	defer session.Close()
+	_, err := readProgressEvents(path, 0, 0, session.Publish)
+	require.NoError(t, err)
+
+	last := session.LastError()
+	require.NotNil(t, last)
+	assert.Equal(t, "ERROR: codex loop: codex exited", last.Text)
+	assert.False(t, last.Recoverable)
+
+	events, err := ReadProgressEvents(path)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "ERROR: finalize step failed: boom", events[0].Text)
+	assert.True(t, events[0].Recoverable)
+}
+
+func TestReadProgressEvents(t *testing.T) {
+	content := "# Ralphex Progress Log\nPlan: plan.md\nMode: full\n" + strings.Repeat("-", 60) + "\n\n" +
+		"--- task iteration 1 ---\n" +
+		"[26-01-22 10:00:01] first\n" +
+		"[26-01-22 10:00:02] second\n" +
+		"--- task iteration 2 ---\n" +
+		"[26-01-22 10:00:03] third\n"
+	path := filepath.Join(t.TempDir(), "progress-range.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	offsetOf := func(s string) int64 { return int64(strings.Index(content, s)) }
+
+	read := func(t *testing.T, start, end int64) (int64, []Event) {
+		t.Helper()
+		var events []Event
+		got, err := readProgressEvents(path, start, end, func(e Event) error {
+			events = append(events, e)
+			return nil
+		})
+		require.NoError(t, err)
+		return got, events
+	}
+	texts := func(events []Event) []string {
+		res := make([]string, 0, len(events))
+		for _, e := range events {
+			res = append(res, string(e.Type)+":"+e.Text)
+		}
+		return res
+	}
+
+	t.Run("whole file", func(t *testing.T) {
+		start, events := read(t, 0, 0)
+		assert.Zero(t, start)
+		assert.Equal(t, []string{"task_start:task iteration 1", "section:task iteration 1", "output:first", "output:second",
+			"task_start:task iteration 2", "section:task iteration 2", "output:third"}, texts(events))
+	})
+
+	t.Run("start inside a line moves to the next line", func(t *testing.T) {
+		start, events := read(t, offsetOf("first")+2, 0)
+		assert.Equal(t, offsetOf("[26-01-22 10:00:02]"), start)
+		assert.Equal(t, []string{"output:second", "task_start:task iteration 2", "section:task iteration 2",
+			"output:third"}, texts(events))
+	})
+
+	t.Run("start at a line boundary is kept", func(t *testing.T) {
+		start, events := read(t, offsetOf("[26-01-22 10:00:03]"), 0)
+		assert.Equal(t, offsetOf("[26-01-22 10:00:03]"), start)
+		assert.Equal(t, []string{"output:third"}, texts(events))
+	})
+
+	t.Run("bounded range keeps trailing section", func(t *testing.T) {
+		start, events := read(t, offsetOf("[26-01-22 10:00:02]"), offsetOf("[26-01-22 10:00:03]"))
+		assert.Equal(t, offsetOf("[26-01-22 10:00:02]"), start)
+		assert.Equal(t, []string{"output:second", "task_start:task iteration 2", "section:task iteration 2"}, texts(events))
+		assert.Equal(t, 2, events[2].Timestamp.Second(), "flushed section uses last seen timestamp")
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		_, events := read(t, offsetOf("third"), offsetOf("third")+2)
+		assert.Empty(t, events)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readProgressEvents("/nonexistent/progress.txt", 0, 0, func(Event) error { return nil })
+		require.Error(t, err)
+	})
+}
+
+func TestSessionManager_LifecycleEvents(t *testing.T) {
+	t.Run("register and remove", func(t *testing.T) {
+		m := NewSessionManager()
+		defer m.Close()
+		events, unsubscribe := m.Hub().Subscribe()
+		defer unsubscribe()
+
+		path := filepath.Join(t.TempDir(), "progress-live.txt")
+		createProgressFile(t, path, "plan.md", "main", "full")
+		session := NewSession("ignored", path)
+		m.Register(session)
+		m.Register(NewSession("dup", path)) // already registered, no event
+
+		e := nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionAdded, e.Type)
+		assert.Equal(t, session.ID, e.SessionID)
+		require.NotNil(t, e.Session)
+		assert.Equal(t, session.ID, e.Session.ID)
+
+		m.Remove(session.ID)
+		m.Remove(session.ID) // already removed, no event
+		e = nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionRemoved, e.Type)
+		assert.Equal(t, session.ID, e.SessionID)
+		assert.Nil(t, e.Session)
+		assert.Empty(t, events)
+	})
+
+	t.Run("discover, update and state transition", func(t *testing.T) {
+		dir := t.TempDir()
+		oldWd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+		logger, err := progress.NewLogger(progress.Config{PlanFile: "plan.md", Mode: "full", Branch: "main",
+			NoColor: true}, testColors())
+		require.NoError(t, err)
+		loggerClosed := false
+		t.Cleanup(func() {
+			if !loggerClosed {
+				_ = logger.Close()
+			}
+		})
+
+		m := NewSessionManager()
+		defer m.Close()
+		events, unsubscribe := m.Hub().Subscribe()
+		defer unsubscribe()
+
+		_, err = m.Discover(dir)
+		require.NoError(t, err)
+		e := nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionAdded, e.Type)
+		require.NotNil(t, e.Session)
+		assert.Equal(t, SessionStateActive, e.Session.State)
+		id := e.SessionID
+
+		// unchanged file, no events
+		_, err = m.Discover(dir)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		// modified file
+		path := filepath.Join(dir, logger.Path())
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, later, later))
+		_, err = m.Discover(dir)
+		require.NoError(t, err)
+		e = nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionUpdated, e.Type)
+		assert.Equal(t, id, e.SessionID)
+
+		// run finished, lock released
+		require.NoError(t, logger.Close())
+		loggerClosed = true
+		m.RefreshStates()
+		e = nextLifecycleEvent(t, events)
+		assert.Equal(t, LifecycleSessionStateChanged, e.Type)
+		assert.Equal(t, id, e.SessionID)
+		assert.Equal(t, SessionStateActive, e.PrevState)
+		require.NotNil(t, e.Session)
+		assert.Equal(t, SessionStateCompleted, e.Session.State)
+	})
+
+	t.Run("close ends subscriptions", func(t *testing.T) {
+		m := NewSessionManager()
+		events, _ := m.Hub().Subscribe()
+		m.Close()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
+
+func TestSessionManager_Discovered(t *testing.T) {
+	m := NewSessionManager()
+	defer m.Close()
+	assert.False(t, m.Discovered())
+	assert.Zero(t, m.Count())
+
+	path := filepath.Join(t.TempDir(), "progress-one.txt")
+	createProgressFile(t, path, "one.md", "main", "full")
+	_, err := m.Discover(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.False(t, m.Discovered(), "only the watcher marks initial discovery done")
+	assert.Equal(t, 1, m.Count())
+
+	m.MarkDiscovered()
+	assert.True(t, m.Discovered())
+}
@@ -0,0 +1,118 @@
+//go:build !windows
+
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsActive_OwnerPID(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+
+	// lockedProgressFile writes a progress header with the given owner and holds a lock on it
+	// through a separate file description, like a ralphex process running in another process would
+	lockedProgressFile := func(t *testing.T, ownerHost string, ownerPID int) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "progress-test.txt")
+		content := "# Ralphex Progress Log\nPlan: plan.md\nBranch: main\nMode: full\nHost: " + ownerHost +
+			"\nPID: " + strconv.Itoa(ownerPID) + "\nStarted: 2026-01-22 10:00:00\n" +
+			"------------------------------------------------------------\n\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		f, err := os.Open(path) //nolint:gosec // test file
+		require.NoError(t, err)
+		require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB))
+		t.Cleanup(func() { _ = f.Close() })
+		return path
+	}
+
+	t.Run("live owner keeps session active", func(t *testing.T) {
+		path := lockedProgressFile(t, host, os.Getpid())
+		active, err := IsActive(path)
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("dead owner on this host makes lock stale", func(t *testing.T) {
+		path := lockedProgressFile(t, host, 1<<30)
+		active, err := IsActive(path)
+		require.NoError(t, err)
+		assert.False(t, active)
+	})
+
+	t.Run("owner on another host is trusted", func(t *testing.T) {
+		path := lockedProgressFile(t, "other-box", 1<<30)
+		active, err := IsActive(path)
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("stale lock is reported as finished session", func(t *testing.T) {
+		path := lockedProgressFile(t, host, 1<<30)
+		m := NewSessionManager()
+		defer m.Close()
+		_, err := m.Discover(filepath.Dir(path))
+		require.NoError(t, err)
+
+		session := m.Get(sessionIDFromPath(path))
+		require.NotNil(t, session)
+		assert.Equal(t, SessionStateCompleted, session.GetState())
+	})
+}
+
+func TestSessionManager_UnreadableWatchDir(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("directory permissions don't apply to root")
+	}
+	root := t.TempDir()
+	readable := filepath.Join(root, "readable")
+	locked := filepath.Join(root, "locked")
+	require.NoError(t, os.MkdirAll(readable, 0o750))
+	require.NoError(t, os.MkdirAll(locked, 0o750))
+	okPath, hiddenPath := filepath.Join(readable, "progress-ok.txt"), filepath.Join(locked, "progress-hidden.txt")
+	createProgressFile(t, okPath, "ok.md", "main", "full")
+	createProgressFile(t, hiddenPath, "hidden.md", "main", "full")
+	require.NoError(t, os.Chmod(locked, 0o000))
+	t.Cleanup(func() { _ = os.Chmod(locked, 0o750) }) //nolint:gosec // test cleanup
+
+	t.Run("discover reports the directory", func(t *testing.T) {
+		m := NewSessionManager()
+		t.Cleanup(m.Close)
+		_, err := m.Discover(locked)
+		require.ErrorIs(t, err, os.ErrPermission)
+
+		ids, err := m.Discover(readable)
+		require.NoError(t, err)
+		assert.Equal(t, []string{sessionIDFromPath(okPath)}, ids, "other directories still scan")
+
+		warnings := m.Warnings()
+		require.Len(t, warnings, 1)
+		assert.Equal(t, locked, warnings[0].Dir)
+		assert.Contains(t, warnings[0].Error, "permission denied")
+	})
+
+	t.Run("recursive discovery keeps scanning", func(t *testing.T) {
+		m := NewSessionManager()
+		t.Cleanup(m.Close)
+		ids, err := m.DiscoverRecursive(root, 3)
+		require.ErrorIs(t, err, os.ErrPermission)
+		assert.Equal(t, []string{sessionIDFromPath(okPath)}, ids)
+		require.Len(t, m.Warnings(), 1)
+		assert.Equal(t, locked, m.Warnings()[0].Dir)
+
+		// the warning is cleared once the directory becomes readable
+		require.NoError(t, os.Chmod(locked, 0o750))
+		ids, err = m.DiscoverRecursive(root, 3)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{sessionIDFromPath(okPath), sessionIDFromPath(hiddenPath)}, ids)
+		assert.Empty(t, m.Warnings())
+	})
+}
@@ -1,13 +1,18 @@
 package web
 
 import (
+	"context"
+	"math"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
 )
 
 func TestNewSession(t *testing.T) {
@@ -91,6 +96,58 @@ func TestSession_Publish(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSession_PauseResume(t *testing.T) {
+	s := NewSession("test", "/tmp/test.txt")
+	defer s.Close()
+
+	// the replayer never replays the first stored event, keep it out of the assertions below
+	require.NoError(t, s.Publish(NewOutputEvent("task", "before pause")))
+
+	assert.False(t, s.IsPaused())
+	require.NoError(t, s.WaitResume(context.Background()), "not paused, returns immediately")
+
+	assert.True(t, s.Pause("web"))
+	assert.False(t, s.Pause("web"), "already paused")
+	assert.True(t, s.IsPaused())
+
+	// canceled wait returns context error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, s.WaitResume(ctx), context.Canceled)
+
+	done := make(chan error, 1)
+	go func() { done <- s.WaitResume(context.Background()) }()
+	assert.True(t, s.Resume("web"))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("wait did not return after resume")
+	}
+	assert.False(t, s.IsPaused())
+	assert.False(t, s.Resume("web"), "not paused")
+
+	// accepted requests are kept for the runner until collected, rejected ones are not recorded
+	actions := s.PauseActions()
+	require.Len(t, actions, 2)
+	assert.Equal(t, "web", actions[0].Actor)
+	assert.Equal(t, "paused run", actions[0].Action)
+	assert.Equal(t, "resumed run", actions[1].Action)
+	assert.False(t, actions[0].Time.IsZero())
+	assert.False(t, actions[1].Time.Before(actions[0].Time))
+	assert.Empty(t, s.PauseActions(), "collected actions are cleared")
+
+	// paused and resumed status events are published for SSE clients
+	writer := &mockMessageWriter{}
+	joe, ok := s.SSE.Provider.(*sse.Joe)
+	require.True(t, ok)
+	require.NoError(t, joe.Replayer.Replay(sse.Subscription{Client: writer, Topics: []string{defaultTopic}}))
+	require.Len(t, writer.messages, 2)
+	assert.Contains(t, writer.messages[0], `"type":"status"`)
+	assert.Contains(t, writer.messages[0], `"text":"paused"`)
+	assert.Contains(t, writer.messages[1], `"text":"resumed"`)
+}
+
 func TestSession_MarkLoadedIfNot(t *testing.T) {
 	t.Run("returns true on first call", func(t *testing.T) {
 		s := NewSession("test", "/tmp/test.txt")
@@ -274,13 +331,48 @@ Started: 2026-01-22 10:30:00
 	})
 }
 
+func TestSession_Heartbeat(t *testing.T) {
+	s := NewSession("test", "/tmp/test.txt")
+	defer s.Close()
+
+	start := time.Date(2026, 1, 22, 10, 0, 0, 0, time.UTC)
+	hb, err := s.Heartbeat(start)
+	require.NoError(t, err)
+	assert.Equal(t, Heartbeat{Timestamp: start}, hb, "idle session still sends a heartbeat")
+
+	require.NoError(t, s.Publish(NewTaskStartEvent(processor.PhaseTask, 3, "task 3")))
+	require.NoError(t, s.Publish(NewOutputEvent(processor.PhaseTask, "working")))
+	require.NoError(t, s.Publish(NewOutputEvent(processor.PhaseTask, "still working")))
+	require.NoError(t, s.Publish(NewStatusEvent("paused")))
+
+	hb, err = s.Heartbeat(start.Add(30 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, processor.PhaseTask, hb.Phase)
+	assert.Equal(t, 3, hb.Iteration)
+	assert.InDelta(t, (1-math.Exp(-1))*3/30, hb.Rate, 1e-9, "3 events in 30s")
+	assert.InDelta(t, hb.Rate, s.EventRate(), 1e-9)
+
+	require.NoError(t, s.Publish(NewIterationStartEvent(processor.PhaseReview, 2, "review iteration 2")))
+	hb, err = s.Heartbeat(start.Add(45 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, processor.PhaseReview, hb.Phase)
+	assert.Equal(t, 2, hb.Iteration)
+
+	t.Run("closed session", func(t *testing.T) {
+		closed := NewSession("closed", "/tmp/closed.txt")
+		closed.Close()
+		_, err := closed.Heartbeat(start)
+		require.ErrorIs(t, err, sse.ErrProviderClosed)
+	})
+}
+
 func TestAllEventsReplayer_Replay(t *testing.T) {
 	t.Run("empty LastEventID is replaced with 0", func(t *testing.T) {
 		// create a FiniteReplayer and wrap it in allEventsReplayer
 		finiteReplayer, err := sse.NewFiniteReplayer(100, true)
 		require.NoError(t, err)
 
-		replayer := &allEventsReplayer{inner: finiteReplayer}
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 100}
 
 		// create a mock message writer to capture replayed messages
 		writer := &mockMessageWriter{}
@@ -301,7 +393,7 @@ func TestAllEventsReplayer_Replay(t *testing.T) {
 		finiteReplayer, err := sse.NewFiniteReplayer(100, true)
 		require.NoError(t, err)
 
-		replayer := &allEventsReplayer{inner: finiteReplayer}
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 100}
 
 		// store some events first
 		msg := &sse.Message{}
@@ -325,7 +417,7 @@ func TestAllEventsReplayer_Replay(t *testing.T) {
 		finiteReplayer, err := sse.NewFiniteReplayer(100, true)
 		require.NoError(t, err)
 
-		replayer := &allEventsReplayer{inner: finiteReplayer}
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 100}
 
 		// put should delegate to inner replayer
 		msg := &sse.Message{}
@@ -339,7 +431,7 @@ func TestAllEventsReplayer_Replay(t *testing.T) {
 		finiteReplayer, err := sse.NewFiniteReplayer(100, true)
 		require.NoError(t, err)
 
-		replayer := &allEventsReplayer{inner: finiteReplayer}
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 100}
 
 		// store multiple events
 		for i := 1; i <= 3; i++ {
@@ -363,15 +455,84 @@ func TestAllEventsReplayer_Replay(t *testing.T) {
 		// verify events were replayed
 		assert.GreaterOrEqual(t, writer.messageCount, 0, "messages should be replayed")
 	})
+
+	t.Run("heartbeats are not stored", func(t *testing.T) {
+		finiteReplayer, err := sse.NewFiniteReplayer(5, true)
+		require.NoError(t, err)
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 5}
+
+		hb := &sse.Message{Type: sse.Type(heartbeatEventType)}
+		hb.AppendData(`{"rate":0}`)
+		putMsg, err := replayer.Put(hb, []string{"events"})
+		require.NoError(t, err)
+		assert.Same(t, hb, putMsg, "heartbeat is passed through for live delivery")
+		assert.Equal(t, int64(0), replayer.puts.Load())
+
+		writer := &mockMessageWriter{}
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{"events"}}))
+		assert.Zero(t, writer.messageCount)
+	})
+
+	t.Run("full replay after eviction starts with truncation marker", func(t *testing.T) {
+		finiteReplayer, err := sse.NewFiniteReplayer(5, true)
+		require.NoError(t, err)
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 5}
+
+		for i := range 8 {
+			_, putErr := replayer.Put(NewOutputEvent(processor.PhaseTask, "event "+strconv.Itoa(i)).ToSSEMessage(),
+				[]string{"events"})
+			require.NoError(t, putErr)
+		}
+		assert.Equal(t, int64(3), replayer.Dropped())
+
+		writer := &mockMessageWriter{}
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{"events"}}))
+
+		require.Len(t, writer.messages, 6, "marker plus the 5 retained events")
+		assert.Contains(t, writer.messages[0], `"type":"warn"`)
+		assert.Contains(t, writer.messages[0], "history truncated: 3 earlier events dropped")
+		assert.Contains(t, writer.messages[1], "event 3", "oldest retained event follows the marker")
+		assert.Contains(t, writer.messages[5], "event 7")
+	})
+
+	t.Run("no marker without eviction or on resume", func(t *testing.T) {
+		finiteReplayer, err := sse.NewFiniteReplayer(5, true)
+		require.NoError(t, err)
+		replayer := &allEventsReplayer{inner: finiteReplayer, size: 5}
+
+		for i := range 5 {
+			_, putErr := replayer.Put(NewOutputEvent(processor.PhaseTask, "event "+strconv.Itoa(i)).ToSSEMessage(),
+				[]string{"events"})
+			require.NoError(t, putErr)
+		}
+		assert.Zero(t, replayer.Dropped())
+
+		writer := &mockMessageWriter{}
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, Topics: []string{"events"}}))
+		for _, m := range writer.messages {
+			assert.NotContains(t, m, "history truncated")
+		}
+
+		// evict, then resume from a known id: the client already has the earlier history
+		_, err = replayer.Put(NewOutputEvent(processor.PhaseTask, "event 5").ToSSEMessage(), []string{"events"})
+		require.NoError(t, err)
+		writer = &mockMessageWriter{}
+		require.NoError(t, replayer.Replay(sse.Subscription{Client: writer, LastEventID: sse.ID("4"),
+			Topics: []string{"events"}}))
+		require.Len(t, writer.messages, 1)
+		assert.Contains(t, writer.messages[0], "event 5")
+	})
 }
 
 // mockMessageWriter implements sse.MessageWriter for testing
 type mockMessageWriter struct {
 	messageCount int
+	messages     []string
 }
 
 func (m *mockMessageWriter) Send(msg *sse.Message) error {
 	m.messageCount++
+	m.messages = append(m.messages, msg.String())
 	return nil
 }
 
@@ -31,6 +31,7 @@
     // session sidebar elements
     const sessionSidebar = document.getElementById('session-sidebar');
     const sessionList = document.getElementById('session-list');
+    const watchWarnings = document.getElementById('watch-warnings');
     const sidebarToggle = document.getElementById('sidebar-toggle');
     const viewToggle = document.getElementById('view-toggle');
     const mainWrapper = document.getElementById('main-wrapper');
@@ -39,6 +40,10 @@
     const projectCopyBtn = document.getElementById('project-copy');
     const planNameEl = document.getElementById('plan-name');
     const branchNameEl = document.getElementById('branch-name');
+    const errorBanner = document.getElementById('error-banner');
+    const errorBannerText = document.getElementById('error-banner-text');
+    const errorBannerClose = document.getElementById('error-banner-close');
+    const heartbeatEl = document.getElementById('heartbeat');
 
     // SSE reconnection constants
     var SSE_INITIAL_RECONNECT_MS = 1000;
@@ -78,6 +83,9 @@
         sessions: [],
         currentSessionId: null,
         sessionPollInterval: null,
+        sessionHistoryOffset: 0, // file offset where the server-loaded output of the session starts
+        historyOffset: 0, // file offset of the earliest rendered output, >0 means earlier output can be loaded
+        renderedEvents: [], // events received for the current session, kept to re-render after loading earlier output
 
         // timing state
         executionStartTime: null,
@@ -98,6 +106,7 @@
         currentEventSource: null,
         isFirstConnect: true,
         resetOnNextEvent: false,
+        readOnly: false, // server rejects state changes, set by the config event
 
         // event batching state for performance
         eventQueue: [],
@@ -237,6 +246,10 @@
         element.textContent = '';
 
         if (!term) {
+            if (element.ansiSpans) {
+                renderAnsiSpans(element, element.ansiSpans);
+                return;
+            }
             element.textContent = text;
             return;
         }
@@ -260,6 +273,31 @@
         }
     }
 
+    // allowed css class of ANSI styled spans, classes are produced by the server from SGR sequences
+    var ANSI_CLASS_PATTERN = /^ansi-[a-z-]+$/;
+
+    /**
+     * Render output text styled with ANSI colors as spans.
+     * XSS-safe: span text is set via textContent, only known ansi-* classes are applied.
+     * @param {Element} element - The DOM element to fill
+     * @param {Array<{text: string, class: string}>} spans - Styled text runs from the server
+     */
+    function renderAnsiSpans(element, spans) {
+        spans.forEach(function(span) {
+            var classes = (span.class || '').split(' ').filter(function(c) {
+                return ANSI_CLASS_PATTERN.test(c);
+            });
+            if (classes.length === 0) {
+                element.appendChild(document.createTextNode(span.text));
+                return;
+            }
+            var el = document.createElement('span');
+            el.className = classes.join(' ');
+            el.textContent = span.text;
+            element.appendChild(el);
+        });
+    }
+
     // check if text matches search term
     function matchesSearch(text, term) {
         if (!term) return true;
@@ -334,6 +372,9 @@
         const content = document.createElement('span');
         content.className = 'content';
         content.dataset.originalText = event.text;
+        if (event.spans && event.spans.length) {
+            content.ansiSpans = event.spans; // styled rendering, search highlighting falls back to plain text
+        }
         setContentWithHighlight(content, event.text, state.searchTerm);
 
         line.appendChild(timestamp);
@@ -660,6 +701,10 @@
         // update status badge
         updateStatusBadge(event);
 
+        if (event.type === 'error') {
+            showErrorBanner(event);
+        }
+
         // handle task boundary events
         if (event.type === 'task_start') {
             handleTaskStart(event);
@@ -742,6 +787,26 @@
             state.isFirstConnect = false;
         };
 
+        // config event arrives first on every connection, before any output
+        source.addEventListener('config', function(e) {
+            try {
+                var config = JSON.parse(e.data);
+                state.readOnly = !!config.readOnly;
+                document.body.classList.toggle('read-only', state.readOnly);
+            } catch (err) {
+                console.error('config parse error:', err);
+            }
+        });
+
+        // heartbeats arrive periodically while connected, even when the session is idle
+        source.addEventListener('heartbeat', function(e) {
+            try {
+                showHeartbeat(JSON.parse(e.data));
+            } catch (err) {
+                console.error('heartbeat parse error:', err);
+            }
+        });
+
         source.onmessage = function(e) {
             try {
                 var event = JSON.parse(e.data);
@@ -750,6 +815,7 @@
                     state.resetOnNextEvent = false;
                 }
                 // queue event for batch processing to avoid layout thrashing
+                state.renderedEvents.push(event);
                 state.eventQueue.push(event);
                 processEventQueue();
             } catch (err) {
@@ -1006,6 +1072,7 @@
             .then(function(sessions) {
                 state.sessions = sessions;
                 renderSessionList(sessions);
+                fetchWarnings();
                 // auto-select first session if none is currently selected
                 if (!state.currentSessionId && sessions.length > 0) {
                     selectSession(sessions[0].id);
@@ -1021,6 +1088,69 @@
             });
     }
 
+    // fetch watched directories that couldn't be scanned, their sessions are missing from the list
+    function fetchWarnings() {
+        fetch('/api/warnings')
+            .then(function(response) {
+                if (!response.ok) {
+                    throw new Error('Warnings not available');
+                }
+                return response.json();
+            })
+            .then(renderWarnings)
+            .catch(function(err) {
+                console.log('Warnings fetch:', err.message);
+            });
+    }
+
+    // fetch the phases offered as filters, tabs of phases the server doesn't run are hidden
+    function fetchPhases() {
+        fetch('/api/phases')
+            .then(function(response) {
+                if (!response.ok) {
+                    throw new Error('Phases not available');
+                }
+                return response.json();
+            })
+            .then(renderPhaseTabs)
+            .catch(function(err) {
+                console.log('Phases fetch:', err.message);
+            });
+    }
+
+    // show tabs of the advertised phases with their display names, hide the rest
+    function renderPhaseTabs(phases) {
+        var names = {};
+        (phases || []).forEach(function(p) {
+            names[p.id] = p.name;
+        });
+        phaseTabs.forEach(function(tab) {
+            var phase = tab.dataset.phase;
+            if (phase === 'all') return;
+            tab.hidden = !(phase in names);
+            if (names[phase]) {
+                tab.textContent = names[phase];
+            }
+            if (tab.hidden && state.currentPhase === phase) {
+                setPhaseFilter('all');
+            }
+        });
+    }
+
+    // render the unreadable watch directories above the session list
+    function renderWarnings(warnings) {
+        if (!watchWarnings) return;
+        clearElement(watchWarnings);
+        (warnings || []).forEach(function(w) {
+            var item = document.createElement('div');
+            item.className = 'watch-warning';
+            item.textContent = 'watch dir ' + w.dir + ' unreadable';
+            item.title = w.error;
+            watchWarnings.appendChild(item);
+        });
+        watchWarnings.hidden = !warnings || warnings.length === 0;
+    }
+
     // format relative time for display
     function formatRelativeTime(date) {
         var now = Date.now();
@@ -1198,7 +1328,15 @@
         indicator.className = 'session-indicator';
         if (session.state === 'active') {
             indicator.classList.add('active');
-            indicator.title = 'Active session';
+            indicator.title = session.ownerPid
+                ? 'Active on host ' + (session.ownerHost || 'unknown') + ' (pid ' + session.ownerPid + ')'
+                : 'Active session';
+        } else if (session.state === 'canceled') {
+            indicator.classList.add('canceled');
+            indicator.title = 'Canceled session';
+        } else if (session.state === 'failed') {
+            indicator.classList.add('failed');
+            indicator.title = 'Failed session';
         } else {
             indicator.classList.add('completed');
             indicator.title = 'Completed session';
@@ -1206,7 +1344,7 @@
 
         var name = document.createElement('div');
         name.className = 'session-name';
-        name.textContent = extractPlanName(session.planPath);
+        name.textContent = session.label || extractPlanName(session.planPath);
 
         topRow.appendChild(indicator);
         topRow.appendChild(name);
@@ -1300,13 +1438,16 @@
                 }
             }
             if (planNameEl) {
-                planNameEl.textContent = extractPlanName(session.planPath);
+                planNameEl.textContent = session.label || extractPlanName(session.planPath);
             }
             if (branchNameEl) {
                 branchNameEl.textContent = session.branch || '';
             }
         }
 
+        // large completed sessions are loaded partially, earlier output is fetched on demand
+        state.sessionHistoryOffset = (session && session.historyOffset) || 0;
+
         // reconnect SSE to new session
         reconnectToSession(sessionId);
 
@@ -1387,6 +1528,22 @@
         state.sessionPollInterval = setInterval(fetchSessions, SESSION_POLL_INTERVAL_MS);
     }
 
+    // subscribe to session lifecycle events to refresh the session list as soon as sessions change.
+    // polling keeps running for relative times and as a fallback while the stream reconnects.
+    // in single-session mode the endpoint responds with 404 and the browser doesn't retry.
+    function connectManagementStream() {
+        var refreshTimeout = null;
+        var source = new EventSource('/api/events');
+        source.onmessage = function() {
+            // coalesce bursts (e.g. discovery of many sessions) into a single refresh
+            if (refreshTimeout) return;
+            refreshTimeout = setTimeout(function() {
+                refreshTimeout = null;
+                fetchSessions();
+            }, 200);
+        };
+    }
+
     // stop polling for session updates
     function stopSessionPolling() {
         if (state.sessionPollInterval) {
@@ -1402,8 +1559,39 @@
         }
     }
 
+    // show the latest error above the output, recoverable errors are styled as warnings
+    function showErrorBanner(event) {
+        errorBannerText.textContent = event.text;
+        errorBanner.classList.toggle('recoverable', !!event.recoverable);
+        errorBanner.hidden = false;
+    }
+
+    function hideErrorBanner() {
+        errorBanner.hidden = true;
+        errorBannerText.textContent = '';
+    }
+
+    // show phase, iteration and event rate from the latest heartbeat next to the elapsed time
+    function showHeartbeat(hb) {
+        var parts = [];
+        if (hb.phase) {
+            parts.push(hb.iteration ? hb.phase + ' #' + hb.iteration : hb.phase);
+        }
+        parts.push((hb.rate || 0).toFixed(1) + ' ev/s');
+        heartbeatEl.textContent = parts.join(' · ');
+        heartbeatEl.title = 'Last heartbeat ' + new Date(hb.timestamp).toLocaleTimeString();
+        heartbeatEl.hidden = false;
+    }
+
+    function hideHeartbeat() {
+        heartbeatEl.hidden = true;
+        heartbeatEl.textContent = '';
+    }
+
     function resetOutputState() {
         clearElement(output);
+        hideErrorBanner();
+        hideHeartbeat();
         state.currentSection = null;
         state.sectionStartTimes = {};
         state.sectionCounter = 0;
@@ -1422,6 +1610,51 @@
             state.elapsedTimerInterval = null;
         }
         elapsedTimeEl.textContent = '';
+        state.renderedEvents = [];
+        state.historyOffset = state.sessionHistoryOffset;
+        renderLoadEarlierButton();
+    }
+
+    // render "load earlier output" control at the top of output for partially loaded sessions
+    function renderLoadEarlierButton() {
+        var existing = output.querySelector('.load-earlier');
+        if (existing) {
+            existing.remove();
+        }
+        if (!state.historyOffset || !state.currentSessionId) return;
+
+        var btn = document.createElement('button');
+        btn.type = 'button';
+        btn.className = 'load-earlier';
+        btn.textContent = 'Load earlier output';
+        btn.addEventListener('click', loadEarlierOutput);
+        output.insertBefore(btn, output.firstChild);
+    }
+
+    // fetch the output preceding the rendered events and re-render everything in order
+    function loadEarlierOutput() {
+        var sessionId = state.currentSessionId;
+        var url = '/api/sessions/' + encodeURIComponent(sessionId) + '/history?before=' + state.historyOffset;
+        fetch(url)
+            .then(function(response) {
+                if (!response.ok) {
+                    throw new Error('History not available');
+                }
+                return response.json();
+            })
+            .then(function(chunk) {
+                if (sessionId !== state.currentSessionId) return; // switched sessions while loading
+                var events = chunk.events.concat(state.renderedEvents);
+                resetOutputState();
+                state.historyOffset = chunk.start;
+                renderLoadEarlierButton();
+                state.renderedEvents = events;
+                state.eventQueue = events.slice();
+                processEventQueue();
+            })
+            .catch(function(err) {
+                console.log('History fetch:', err.message);
+            });
     }
 
     // create plan loading/error message element
@@ -1717,6 +1950,9 @@
         outputClone.querySelectorAll('.hidden').forEach(function(el) {
             el.classList.remove('hidden');
         });
+        outputClone.querySelectorAll('.load-earlier').forEach(function(el) {
+            el.remove();
+        });
         return {
             output: outputClone,
             plan: planContent.cloneNode(true)
@@ -1833,6 +2069,7 @@
     }
 
     exportBtn.addEventListener('click', exportSession);
+    errorBannerClose.addEventListener('click', hideErrorBanner);
 
     // expand/collapse all sections (user-initiated, so track preferences)
     function expandAllSections() {
@@ -1904,8 +2141,10 @@
 
 
     // start
+    fetchPhases();
     fetchSessions();
     startSessionPolling();
+    connectManagementStream();
 
     // if we have a session ID, fetch its plan; otherwise use server default
     if (state.currentSessionId) {
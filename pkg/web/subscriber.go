@@ -0,0 +1,169 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// subscriberQueueSize is how many live messages can wait for a slow session stream client
+// before its output lines are coalesced into "… N lines skipped …" markers.
+const subscriberQueueSize = 256
+
+// subscriberQueueLimit caps the messages waiting for a client. sections, signals and other events driving
+// the UI are never coalesced, a client falling this far behind on them is disconnected and catches up
+// from the replay buffer when the browser reconnects with its last event ID.
+const subscriberQueueLimit = 4 * subscriberQueueSize
+
+// errSubscriberTooSlow ends the subscription of a client whose queue reached subscriberQueueLimit.
+var errSubscriberTooSlow = errors.New("client is too slow, queue limit reached")
+
+// subscriberQueue sits between the session's provider and one SSE client, so a slow client doesn't hold up
+// the provider and the other clients. live messages are queued and written by run, once more than
+// subscriberQueueSize are waiting, output lines for this client are coalesced into a skip marker while
+// all other events are kept. replayed history is written to the client directly, see allEventsReplayer.
+type subscriberQueue struct {
+	mu      sync.Mutex
+	client  sse.MessageWriter
+	queue   []queuedMessage
+	skipped int           // output lines coalesced for this client so far
+	err     error         // error that ended the subscription, returned by later sends
+	wake    chan struct{} // signals run that messages were queued
+}
+
+// queuedMessage is a message waiting in a subscriberQueue.
+// entries with skipped > 0 are skip markers standing in for coalesced output lines, event holds the last of them.
+type queuedMessage struct {
+	msg     *sse.Message
+	event   Event
+	skipped int
+}
+
+// newSubscriberQueue creates a queue writing to client.
+func newSubscriberQueue(client sse.MessageWriter) *subscriberQueue {
+	return &subscriberQueue{client: client, wake: make(chan struct{}, 1)}
+}
+
+// Send queues the message for the client without blocking.
+func (q *subscriberQueue) Send(m *sse.Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return q.err
+	}
+
+	if len(q.queue) >= subscriberQueueSize {
+		if e, ok := messageEvent(m); ok && e.Type == EventTypeOutput {
+			q.skipped++
+			if n := len(q.queue); q.queue[n-1].skipped > 0 {
+				q.queue[n-1].skipped++
+				q.queue[n-1].event = e
+			} else {
+				q.queue = append(q.queue, queuedMessage{event: e, skipped: 1})
+			}
+			return nil
+		}
+		if len(q.queue) >= subscriberQueueLimit {
+			q.err = errSubscriberTooSlow
+			return q.err
+		}
+	}
+
+	q.queue = append(q.queue, queuedMessage{msg: m})
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Flush is a no-op, queued messages are flushed by run. returns the error that ended the subscription, if any.
+func (q *subscriberQueue) Flush() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.err
+}
+
+// Skipped returns how many output lines were coalesced for this client.
+func (q *subscriberQueue) Skipped() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.skipped
+}
+
+// run writes queued messages to the client until ctx is canceled or writing fails.
+// returns the write error, nil when ctx is canceled.
+func (q *subscriberQueue) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-q.wake:
+		}
+
+		q.mu.Lock()
+		batch := q.queue
+		q.queue = nil
+		q.mu.Unlock()
+
+		if err := q.write(batch); err != nil {
+			q.mu.Lock()
+			q.err = err
+			q.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// write sends the messages to the client and flushes them.
+func (q *subscriberQueue) write(batch []queuedMessage) error {
+	for _, qm := range batch {
+		msg := qm.msg
+		if qm.skipped > 0 {
+			msg = newSkippedEvent(qm.event.Phase, qm.event.Iteration, qm.skipped, qm.event.Timestamp).ToSSEMessage()
+		}
+		if err := q.client.Send(msg); err != nil {
+			return fmt.Errorf("send message: %w", err)
+		}
+	}
+	if err := q.client.Flush(); err != nil {
+		return fmt.Errorf("flush messages: %w", err)
+	}
+	return nil
+}
+
+// newSkippedEvent creates the marker standing in for output lines coalesced for a slow client.
+func newSkippedEvent(phase processor.Phase, iteration, skipped int, ts time.Time) Event {
+	e := NewOutputEvent(phase, fmt.Sprintf("… %d lines skipped …", skipped))
+	e.Iteration, e.Timestamp = iteration, ts
+	return e
+}
+
+// messageEvent decodes the session event carried in the data of an SSE message.
+// returns false for messages without one, e.g. heartbeats.
+func messageEvent(m *sse.Message) (Event, bool) {
+	text, err := m.MarshalText()
+	if err != nil {
+		return Event{}, false
+	}
+	for line := range strings.SplitSeq(string(text), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(data), &e); err != nil || e.Type == "" {
+			return Event{}, false
+		}
+		return e, true
+	}
+	return Event{}, false
+}
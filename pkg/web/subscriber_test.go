@@ -0,0 +1,151 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
+
+	"github.com/umputun/ralphex/pkg/processor"
+)
+
+// syncMessageWriter records sent messages, safe for concurrent use. Send blocks while release is open
+// once blockAfter messages were sent, blockAfter 0 never blocks.
+type syncMessageWriter struct {
+	mu         sync.Mutex
+	messages   []string
+	blockAfter int
+	release    chan struct{}
+}
+
+func (w *syncMessageWriter) Send(msg *sse.Message) error {
+	w.mu.Lock()
+	n := len(w.messages)
+	w.mu.Unlock()
+	if w.blockAfter > 0 && n >= w.blockAfter {
+		<-w.release
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, msg.String())
+	return nil
+}
+
+func (w *syncMessageWriter) Flush() error { return nil }
+
+func (w *syncMessageWriter) events(t *testing.T) []Event {
+	t.Helper()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make([]Event, 0, len(w.messages))
+	for _, msg := range w.messages {
+		m := &sse.Message{}
+		require.NoError(t, m.UnmarshalText([]byte(msg)))
+		e, ok := messageEvent(m)
+		require.True(t, ok, msg)
+		res = append(res, e)
+	}
+	return res
+}
+
+func TestSubscriberQueue_CoalescesOutputForSlowClient(t *testing.T) {
+	writer := &syncMessageWriter{}
+	q := newSubscriberQueue(writer)
+
+	// nothing drains the queue, as for a stalled client
+	for i := range subscriberQueueSize {
+		require.NoError(t, q.Send(NewOutputEvent(processor.PhaseTask, fmt.Sprintf("line %d", i)).ToSSEMessage()))
+	}
+	for range 5 {
+		require.NoError(t, q.Send(NewOutputEvent(processor.PhaseTask, "overflow before").ToSSEMessage()))
+	}
+	require.NoError(t, q.Send(NewSignalEvent(processor.PhaseTask, "COMPLETED").ToSSEMessage()))
+	for range 3 {
+		require.NoError(t, q.Send(NewOutputEvent(processor.PhaseReview, "overflow after").ToSSEMessage()))
+	}
+	assert.Equal(t, 8, q.Skipped())
+
+	require.NoError(t, q.write(q.queue))
+	events := writer.events(t)
+	require.Len(t, events, subscriberQueueSize+3)
+	assert.Equal(t, "line 0", events[0].Text)
+	tail := events[subscriberQueueSize:]
+	assert.Equal(t, "… 5 lines skipped …", tail[0].Text)
+	assert.Equal(t, EventTypeSignal, tail[1].Type, "signal must survive backpressure")
+	assert.Equal(t, "COMPLETED", tail[1].Signal)
+	assert.Equal(t, "… 3 lines skipped …", tail[2].Text)
+	assert.Equal(t, processor.PhaseReview, tail[2].Phase)
+}
+
+func TestSubscriberQueue_LimitEndsSubscription(t *testing.T) {
+	q := newSubscriberQueue(&syncMessageWriter{})
+	for range subscriberQueueLimit {
+		require.NoError(t, q.Send(NewSectionEvent(processor.PhaseTask, "section").ToSSEMessage()))
+	}
+	require.NoError(t, q.Send(NewOutputEvent(processor.PhaseTask, "coalesced").ToSSEMessage()))
+	require.ErrorIs(t, q.Send(NewSectionEvent(processor.PhaseTask, "too many").ToSSEMessage()), errSubscriberTooSlow)
+	require.ErrorIs(t, q.Flush(), errSubscriberTooSlow)
+	assert.Len(t, q.queue, subscriberQueueLimit+1)
+}
+
+func TestSubscriberQueue_SlowClientDoesNotAffectOthers(t *testing.T) {
+	session := NewSession("test", "/tmp/test.txt")
+	defer session.Close()
+	require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, "history")))
+
+	fast := &syncMessageWriter{}
+	slow := &syncMessageWriter{blockAfter: 2, release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, w := range []*syncMessageWriter{fast, slow} {
+		q := newSubscriberQueue(w)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = q.run(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = session.SSE.Provider.Subscribe(ctx, sse.Subscription{Client: q, Topics: []string{defaultTopic}})
+		}()
+	}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	// history is replayed to both clients directly
+	require.Eventually(t, func() bool { return len(fast.events(t)) == 1 && len(slow.events(t)) == 1 },
+		time.Second, 5*time.Millisecond)
+
+	const lines = 2 * subscriberQueueSize
+	for i := range lines {
+		require.NoError(t, session.Publish(NewOutputEvent(processor.PhaseTask, fmt.Sprintf("line %d", i))))
+	}
+	require.NoError(t, session.Publish(NewSignalEvent(processor.PhaseTask, "COMPLETED")))
+
+	require.Eventually(t, func() bool { return len(fast.events(t)) == lines+2 }, time.Second, 5*time.Millisecond)
+	for _, e := range fast.events(t) {
+		assert.NotContains(t, e.Text, "skipped", "fast client gets every line")
+	}
+
+	close(slow.release)
+	require.Eventually(t, func() bool {
+		events := slow.events(t)
+		return len(events) > 0 && events[len(events)-1].Type == EventTypeSignal
+	}, time.Second, 5*time.Millisecond)
+	events := slow.events(t)
+	assert.Less(t, len(events), lines+2, "slow client output is coalesced")
+	var markers int
+	for _, e := range events {
+		if strings.HasSuffix(e.Text, "lines skipped …") {
+			markers++
+		}
+	}
+	assert.Equal(t, 1, markers)
+}
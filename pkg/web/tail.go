@@ -2,34 +2,55 @@ package web
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
+// tailerBufferSize is the capacity of the tailer's event channel.
+const tailerBufferSize = 256
+
+// tailer defaults, used for zero values in TailerConfig
+const (
+	defaultTailPollInterval  = 100 * time.Millisecond
+	defaultTailReadChunkSize = 64 * 1024
+	defaultTailMaxLineLength = 1024 * 1024
+)
+
+// truncatedLineMarker is appended to lines cut at TailerConfig.MaxLineLength.
+const truncatedLineMarker = " … (line truncated)"
+
 // TailerConfig holds configuration for the Tailer.
 type TailerConfig struct {
-	PollInterval time.Duration   // how often to check for new content (default: 100ms)
-	InitialPhase processor.Phase // phase to use for events (default: PhaseTask)
+	PollInterval  time.Duration   // how often to check for new content (default: 100ms)
+	InitialPhase  processor.Phase // phase to use for events (default: PhaseTask)
+	ReadChunkSize int             // size of the file read buffer in bytes (default: 64KB)
+	MaxLineLength int             // longer lines are truncated to this many bytes (default: 1MB)
 }
 
 // DefaultTailerConfig returns default configuration.
 func DefaultTailerConfig() TailerConfig {
 	return TailerConfig{
-		PollInterval: 100 * time.Millisecond,
-		InitialPhase: processor.PhaseTask,
+		PollInterval:  defaultTailPollInterval,
+		InitialPhase:  processor.PhaseTask,
+		ReadChunkSize: defaultTailReadChunkSize,
+		MaxLineLength: defaultTailMaxLineLength,
 	}
 }
 
 // Tailer watches a progress file and emits events for new lines.
-// it parses progress file format (timestamps, sections) into Event structs.
+// it parses progress file format (timestamps, sections, or jsonl records) into Event structs.
 type Tailer struct {
 	mu       sync.Mutex
 	path     string
@@ -43,25 +64,39 @@ type Tailer struct {
 	doneCh   chan struct{}
 	eventCh  chan Event
 	phase    processor.Phase
+	iter     int  // iteration number of the current section, see sectionIteration
 	inHeader bool // true until we pass the header separator
+	jsonl    bool // progress file is in jsonl format, detected on Start
+
+	// pending is a parsed event waiting for room in eventCh, reading pauses until it's delivered, see emit
+	pending *Event
+
+	readOffset atomic.Int64 // copy of offset readable without t.mu
+	resets     atomic.Int64 // number of times the file was truncated or replaced, see reopenIfReplaced
 }
 
 // NewTailer creates a new Tailer for the given progress file.
 // the tailer starts in stopped state; call Start() to begin tailing.
 func NewTailer(path string, config TailerConfig) *Tailer {
 	if config.PollInterval <= 0 {
-		config.PollInterval = 100 * time.Millisecond
+		config.PollInterval = defaultTailPollInterval
 	}
 	if config.InitialPhase == "" {
 		config.InitialPhase = processor.PhaseTask
 	}
+	if config.ReadChunkSize <= 0 {
+		config.ReadChunkSize = defaultTailReadChunkSize
+	}
+	if config.MaxLineLength <= 0 {
+		config.MaxLineLength = defaultTailMaxLineLength
+	}
 
 	return &Tailer{
 		path:     path,
 		config:   config,
 		stopCh:   make(chan struct{}),
 		doneCh:   make(chan struct{}),
-		eventCh:  make(chan Event, 256),
+		eventCh:  make(chan Event, tailerBufferSize),
 		phase:    config.InitialPhase,
 		inHeader: true,
 	}
@@ -73,6 +108,16 @@ func (t *Tailer) Events() <-chan Event {
 	return t.eventCh
 }
 
+// Offset returns the number of bytes of the file read so far.
+func (t *Tailer) Offset() int64 {
+	return t.readOffset.Load()
+}
+
+// Resets returns how many times the file was truncated or replaced while tailing.
+func (t *Tailer) Resets() int64 {
+	return t.resets.Load()
+}
+
 // Start begins tailing the file from the current position.
 // if fromStart is true, reads from the beginning; otherwise reads from current end.
 // note: Tailer is not reusable after Stop() - create a new instance instead.
@@ -88,6 +133,7 @@ func (t *Tailer) Start(fromStart bool) error {
 	if err != nil {
 		return fmt.Errorf("open file: %w", err)
 	}
+	t.jsonl = isJSONLProgress(t.path)
 
 	if !fromStart {
 		// seek to end
@@ -97,18 +143,72 @@ func (t *Tailer) Start(fromStart bool) error {
 			return fmt.Errorf("seek to end: %w", err)
 		}
 		t.offset = offset
+		t.readOffset.Store(offset)
 		t.inHeader = false // assume we're past header if starting from end
 	}
 
+	t.run(f)
+	return nil
+}
+
+// StartAt begins tailing the file from a byte offset recorded earlier, e.g. Offset of a previous tailer.
+// an offset inside a complete line starts at the next line. if the file is now shorter than offset, it was truncated
+// or replaced since the offset was recorded and is read from the beginning, after a reset marker event.
+func (t *Tailer) StartAt(offset int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running {
+		return nil
+	}
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat file: %w", err)
+	}
+	t.jsonl = isJSONLProgress(t.path)
+
+	switch {
+	case offset > info.Size():
+		t.resets.Add(1)
+		t.emit(t.resetEvent("truncated"))
+	case offset > 0:
+		// read from the byte before offset, if it's a newline offset is already a line boundary
+		if _, err := f.Seek(offset-1, io.SeekStart); err != nil {
+			f.Close()
+			return fmt.Errorf("seek to offset: %w", err)
+		}
+		skipped, err := bufio.NewReader(f).ReadBytes('\n')
+		if err == nil {
+			offset += int64(len(skipped)) - 1
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return fmt.Errorf("seek to offset: %w", err)
+		}
+		t.offset = offset
+		t.readOffset.Store(offset)
+		t.inHeader = false
+	}
+
+	t.run(f)
+	return nil
+}
+
+// run starts the tail loop reading f from its current position. must be called with t.mu held.
+func (t *Tailer) run(f *os.File) {
 	t.file = f
-	t.reader = bufio.NewReader(f)
+	t.reader = bufio.NewReaderSize(f, t.config.ReadChunkSize)
 	t.running = true
 	t.stopCh = make(chan struct{})
 	t.doneCh = make(chan struct{})
 
 	go t.tailLoop()
-
-	return nil
 }
 
 // Stop stops the tailer and closes resources.
@@ -182,23 +282,37 @@ func (t *Tailer) readNewLines() {
 	}
 
 	for {
-		line, err := t.reader.ReadString('\n')
+		// the consumer is behind, the rest of the file waits for the next poll
+		if !t.flushPending() {
+			return
+		}
+
+		line, n, err := t.readLine()
 		if err != nil {
 			if err == io.EOF {
 				// no more data, wait for next poll
-				// seek back to where we were (ReadString may have read partial line)
-				if line != "" {
+				// seek back to where we were (readLine may have consumed a partial line)
+				if n > 0 {
 					_, _ = t.file.Seek(t.offset, io.SeekStart)
 					t.reader.Reset(t.file)
 				}
+				if t.reopenIfReplaced(n) {
+					continue // more to read in the old file, or it was truncated or rotated and is read from the start
+				}
 				return
 			}
 			// real error, stop tailing
 			return
 		}
 
+		// the first line of the file may start with a byte order mark
+		if t.offset == 0 {
+			line = strings.TrimPrefix(line, progress.BOM)
+		}
+
 		// update offset
-		t.offset += int64(len(line))
+		t.offset += n
+		t.readOffset.Store(t.offset)
 
 		// trim newline
 		line = strings.TrimSuffix(line, "\n")
@@ -209,19 +323,123 @@ func (t *Tailer) readNewLines() {
 		}
 
 		// parse line and emit event
-		event := t.parseLine(line)
-		if event != nil {
-			select {
-			case t.eventCh <- *event:
-			default:
-				// channel full, drop event
-			}
+		if event := t.parseLine(line); event != nil {
+			t.emit(*event)
 		}
 	}
 }
 
-// timestamp regex: [YY-MM-DD HH:MM:SS]
-var timestampRegex = regexp.MustCompile(`^\[(\d{2}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})\] (.*)$`)
+// reopenIfReplaced checks whether the file was truncated below the read offset or replaced by a new file
+// at the same path (e.g. rotated, detected by comparing file identity), and if so switches to reading
+// the current file from its start after emitting a reset marker event. a replaced file is read to its end first,
+// the logger may have written to it between the last read and the rename. partial is the size of the incomplete
+// line at the read offset. a removed file is kept open until a new one appears.
+// returns true if there is more to read, in the old file or from the reset position. must be called with t.mu held.
+func (t *Tailer) reopenIfReplaced(partial int64) bool {
+	cur, err := t.file.Stat()
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return false
+	}
+
+	reason := "truncated"
+	if !os.SameFile(cur, info) {
+		if cur.Size() > t.offset+partial {
+			return true // the old file grew since the last read, drain it before switching
+		}
+		f, err := os.Open(t.path)
+		if err != nil {
+			return false
+		}
+		t.file.Close()
+		t.file = f
+		reason = "replaced"
+	} else if info.Size() >= t.offset {
+		return false
+	}
+
+	if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+		return false
+	}
+	t.reader.Reset(t.file)
+	t.offset = 0
+	t.readOffset.Store(0)
+	t.inHeader = true
+	t.jsonl = isJSONLProgress(t.path)
+	t.resets.Add(1)
+	t.emit(t.resetEvent(reason))
+	return true
+}
+
+// resetEvent returns the marker event emitted when tailing restarts from the beginning of the file.
+func (t *Tailer) resetEvent(reason string) Event {
+	e := NewStatusEvent("progress file " + reason + ", reading from the start")
+	e.Phase = t.phase
+	e.Iteration = t.iter
+	return e
+}
+
+// readLine reads the next line including its newline, keeping at most MaxLineLength bytes of it in memory.
+// longer lines are cut and marked with truncatedLineMarker, the rest of the line is consumed and discarded.
+// returns the line, number of bytes consumed from the file, and io.EOF if no complete line is available yet.
+// must be called with t.mu held.
+func (t *Tailer) readLine() (string, int64, error) {
+	var buf []byte
+	var n int64
+	for {
+		chunk, err := t.reader.ReadSlice('\n')
+		n += int64(len(chunk))
+		if room := t.config.MaxLineLength - len(buf); room > 0 {
+			buf = append(buf, chunk[:min(room, len(chunk))]...)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue // line is longer than the read buffer, keep reading
+		}
+		if err != nil {
+			return "", n, err //nolint:wrapcheck // io.EOF is checked by the caller
+		}
+		break
+	}
+
+	if lineLen := n - 1; lineLen > int64(t.config.MaxLineLength) {
+		return strings.TrimSuffix(string(buf), "\n") + truncatedLineMarker + "\n", n, nil
+	}
+	return string(buf), n, nil
+}
+
+// emit sends an event to the consumer without blocking.
+// when the channel is full the event is kept as pending and reading pauses until it's delivered,
+// so no event is dropped and the backlog stays in the file. slow SSE clients don't stall the consumer,
+// their output is coalesced per client, see subscriberQueue. must be called with t.mu held.
+func (t *Tailer) emit(e Event) {
+	select {
+	case t.eventCh <- e:
+	default:
+		t.pending = &e
+	}
+}
+
+// flushPending delivers the pending event if the channel has room.
+// returns false if the event is still pending. must be called with t.mu held.
+func (t *Tailer) flushPending() bool {
+	if t.pending == nil {
+		return true
+	}
+	select {
+	case t.eventCh <- *t.pending:
+		t.pending = nil
+		return true
+	default:
+		return false
+	}
+}
+
+// timestamp regex: [YY-MM-DD HH:MM:SS] or ISO-8601 [YYYY-MM-DDTHH:MM:SS+HH:MM], see progress.TimestampISO8601
+var timestampRegex = regexp.MustCompile(
+	`^\[(\d{2}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}|\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2}))\] (.*)$`)
 
 // section header regex: --- section name ---
 var sectionRegex = regexp.MustCompile(`^--- (.+) ---$`)
@@ -229,9 +447,19 @@ var sectionRegex = regexp.MustCompile(`^--- (.+) ---$`)
 // task iteration regex: task iteration N (extracts the number)
 var taskIterationRegex = regexp.MustCompile(`(?i)^task iteration (\d+)$`)
 
+// numbered section regex: sections of processor.Section types with an iteration (extracts the number)
+var sectionIterationRegex = regexp.MustCompile(`(?i)^(?:task iteration|claude review|codex iteration|plan iteration) (\d+)\b`)
+
+// human action regex: HUMAN ACTION (actor): action (extracts the actor)
+var humanActionRegex = regexp.MustCompile(`^HUMAN ACTION \(([^)]*)\): `)
+
 // parseLine parses a progress file line and returns an Event.
 // returns nil for lines that should be skipped (header lines).
 func (t *Tailer) parseLine(line string) *Event {
+	if t.jsonl {
+		return t.parseRecordLine(line)
+	}
+
 	// check for header separator
 	if strings.HasPrefix(line, "---") && strings.Count(line, "-") > 20 && !strings.Contains(line, " ") {
 		t.inHeader = false
@@ -247,9 +475,11 @@ func (t *Tailer) parseLine(line string) *Event {
 	if matches := sectionRegex.FindStringSubmatch(line); matches != nil {
 		sectionName := matches[1]
 		t.updatePhaseFromSection(sectionName)
+		t.iter = sectionIteration(sectionName)
 		return &Event{
 			Type:      EventTypeSection,
 			Phase:     t.phase,
+			Iteration: t.iter,
 			Section:   sectionName,
 			Text:      sectionName,
 			Timestamp: time.Now(),
@@ -261,7 +491,7 @@ func (t *Tailer) parseLine(line string) *Event {
 		text := matches[2]
 
 		// parse timestamp
-		ts, err := time.Parse("06-01-02 15:04:05", matches[1])
+		ts, err := progress.ParseTimestamp(matches[1])
 		if err != nil {
 			ts = time.Now()
 		}
@@ -271,10 +501,19 @@ func (t *Tailer) parseLine(line string) *Event {
 		event := Event{
 			Type:      eventType,
 			Phase:     t.phase,
+			Iteration: t.iter,
 			Text:      text,
 			Timestamp: ts,
 		}
 
+		if eventType == EventTypeHumanAction {
+			event.Actor = extractActor(text)
+		}
+		if eventType == EventTypePlanReady {
+			event.PlanFile = strings.TrimPrefix(text, progress.PlanReadyPrefix)
+		}
+		markRecoverable(&event)
+
 		// extract signal if present
 		if sig := extractSignalFromText(text); sig != "" {
 			event.Signal = sig
@@ -288,27 +527,133 @@ func (t *Tailer) parseLine(line string) *Event {
 	return &Event{
 		Type:      EventTypeOutput,
 		Phase:     t.phase,
+		Iteration: t.iter,
 		Text:      line,
 		Timestamp: time.Now(),
 	}
 }
 
+// parseRecordLine parses a jsonl progress record and returns an Event.
+// returns nil for the header record. lines that are not valid JSON (e.g. truncated) are emitted as output.
+func (t *Tailer) parseRecordLine(line string) *Event {
+	var rec progress.Record
+	if err := json.Unmarshal([]byte(line), &rec); err != nil {
+		return &Event{Type: EventTypeOutput, Phase: t.phase, Iteration: t.iter, Text: line, Timestamp: time.Now()}
+	}
+	event, ok := eventFromRecord(rec)
+	if !ok {
+		return nil
+	}
+	t.phase = event.Phase
+	if event.Type == EventTypeSection {
+		t.iter = sectionIteration(event.Section)
+	}
+	event.Iteration = t.iter
+	return &event
+}
+
+// eventFromRecord converts a jsonl progress record into an Event.
+// returns false for records that don't map to an event (header).
+func eventFromRecord(rec progress.Record) (Event, bool) {
+	event := Event{Type: EventTypeOutput, Phase: rec.Phase, Text: rec.Text, Timestamp: rec.Timestamp}
+	switch rec.Type {
+	case progress.RecordHeader:
+		return Event{}, false
+	case progress.RecordSection:
+		event.Type = EventTypeSection
+		event.Section = rec.Section
+		if event.Phase == "" {
+			event.Phase = phaseFromSection(rec.Section)
+		}
+	case progress.RecordError:
+		event.Type = EventTypeError
+		event.Recoverable = rec.Recoverable
+	case progress.RecordWarn:
+		event.Type = EventTypeWarn
+	case progress.RecordSignal:
+		event.Type = EventTypeSignal
+		event.Signal = normalizeTokenSignal(rec.Signal)
+	case progress.RecordHumanAction:
+		event.Type = EventTypeHumanAction
+		event.Actor = rec.Actor
+	case progress.RecordPlanReady:
+		event.Type = EventTypePlanReady
+		event.PlanFile = rec.PlanFile
+	case progress.RecordFooter:
+		event.Text = fmt.Sprintf("%s: %s (%s)", progress.FooterLabel(rec.Outcome), rec.Timestamp.Format("2006-01-02 15:04:05"), rec.Text)
+	}
+	if event.Phase == "" {
+		event.Phase = processor.PhaseTask
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return event, true
+}
+
+// isJSONLProgress reports whether the progress file at path is in jsonl format.
+// text progress files start with a "# Ralphex Progress Log" line, jsonl files with a header record.
+func isJSONLProgress(path string) bool {
+	f, err := progress.OpenFile(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	buf := make([]byte, len(progress.BOM)+1)
+	n, _ := io.ReadFull(f, buf)
+	content := strings.TrimPrefix(string(buf[:n]), progress.BOM)
+	return content != "" && content[0] == '{'
+}
+
 // updatePhaseFromSection updates the current phase based on section name.
 // uses the shared phaseFromSection helper to avoid duplicate logic.
 func (t *Tailer) updatePhaseFromSection(name string) {
 	t.phase = phaseFromSection(name)
 }
 
+// sectionIteration returns the iteration number of a numbered section such as "task iteration 3"
+// or "claude review 1: all findings", 0 for other sections.
+func sectionIteration(name string) int {
+	matches := sectionIterationRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return 0
+	}
+	n, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// markRecoverable flags error events of text progress files recorded with progress.RecoverableSuffix,
+// removing the suffix from the text.
+func markRecoverable(e *Event) {
+	if e.Type == EventTypeError && strings.HasSuffix(e.Text, progress.RecoverableSuffix) {
+		e.Text = strings.TrimSuffix(e.Text, progress.RecoverableSuffix)
+		e.Recoverable = true
+	}
+}
+
 // detectEventType determines the event type from line content.
 func detectEventType(text string) EventType {
 	textLower := strings.ToLower(text)
 
+	if humanActionRegex.MatchString(text) {
+		return EventTypeHumanAction
+	}
+	if strings.HasPrefix(text, progress.PlanReadyPrefix) {
+		return EventTypePlanReady
+	}
 	if strings.HasPrefix(textLower, "error:") || strings.HasPrefix(text, "ERROR:") {
 		return EventTypeError
 	}
 	if strings.HasPrefix(textLower, "warn:") || strings.HasPrefix(text, "WARN:") {
 		return EventTypeWarn
 	}
+	if strings.HasPrefix(text, debugPrefix) {
+		return EventTypeDebug
+	}
 	if extractSignalFromText(text) != "" {
 		return EventTypeSignal
 	}
@@ -316,6 +661,14 @@ func detectEventType(text string) EventType {
 	return EventTypeOutput
 }
 
+// extractActor returns the actor of a "HUMAN ACTION (actor): ..." line, or empty string if not a human action.
+func extractActor(text string) string {
+	if matches := humanActionRegex.FindStringSubmatch(text); matches != nil {
+		return matches[1]
+	}
+	return ""
+}
+
 // extractSignalFromText extracts normalized signal name from <<<RALPHEX:SIGNAL>>> format
 // or plain signal markers like ALL_TASKS_DONE, TASK_FAILED, REVIEW_DONE.
 // returns "COMPLETED" for ALL_TASKS_DONE, "FAILED" for TASK_FAILED, or raw signal for unknown tokens.
@@ -1,8 +1,11 @@
 package web
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/umputun/ralphex/pkg/processor"
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 func TestNewTailer(t *testing.T) {
@@ -19,6 +23,8 @@ func TestNewTailer(t *testing.T) {
 		assert.Equal(t, "/tmp/test.txt", tailer.path)
 		assert.Equal(t, 100*time.Millisecond, tailer.config.PollInterval)
 		assert.Equal(t, processor.PhaseTask, tailer.config.InitialPhase)
+		assert.Equal(t, 64*1024, tailer.config.ReadChunkSize)
+		assert.Equal(t, 1024*1024, tailer.config.MaxLineLength)
 		assert.False(t, tailer.running)
 	})
 
@@ -67,6 +73,20 @@ func TestTailer_ParseLine(t *testing.T) {
 		assert.Equal(t, "ERROR: something went wrong", event.Text)
 	})
 
+	t.Run("detects recoverable error lines", func(t *testing.T) {
+		event := tailer.parseLine("[26-01-22 10:30:45] ERROR: finalize step failed: boom (recoverable)")
+
+		require.NotNil(t, event)
+		assert.Equal(t, EventTypeError, event.Type)
+		assert.Equal(t, "ERROR: finalize step failed: boom", event.Text)
+		assert.True(t, event.Recoverable)
+
+		event = tailer.parseLine("[26-01-22 10:30:45] output mentioning (recoverable)")
+		require.NotNil(t, event)
+		assert.False(t, event.Recoverable, "only error lines are marked")
+		assert.Equal(t, "output mentioning (recoverable)", event.Text)
+	})
+
 	t.Run("detects warning lines", func(t *testing.T) {
 		event := tailer.parseLine("[26-01-22 10:30:45] WARN: be careful")
 
@@ -83,6 +103,16 @@ func TestTailer_ParseLine(t *testing.T) {
 		assert.Equal(t, "COMPLETED", event.Signal)
 	})
 
+	t.Run("detects human action lines", func(t *testing.T) {
+		event := tailer.parseLine("[26-01-22 10:30:45] HUMAN ACTION (user): answered question: Redis")
+
+		require.NotNil(t, event)
+		assert.Equal(t, EventTypeHumanAction, event.Type)
+		assert.Equal(t, "user", event.Actor)
+		assert.Equal(t, "HUMAN ACTION (user): answered question: Redis", event.Text)
+		assert.Equal(t, 45, event.Timestamp.Second())
+	})
+
 	t.Run("handles plain line without timestamp", func(t *testing.T) {
 		event := tailer.parseLine("plain text line")
 
@@ -142,6 +172,52 @@ func TestTailer_UpdatePhaseFromSection(t *testing.T) {
 	}
 }
 
+func TestSectionIteration(t *testing.T) {
+	tests := []struct {
+		section  string
+		expected int
+	}{
+		{"task iteration 3", 3},
+		{"Task Iteration 12", 12},
+		{"claude review 2: critical/major", 2},
+		{"codex iteration 5", 5},
+		{"plan iteration 1", 1},
+		{"claude-eval", 0},
+		{"finalize", 0},
+		{"task iteration", 0},
+		{"task iteration 3x", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sectionIteration(tt.section))
+		})
+	}
+}
+
+func TestTailer_ParseLine_Iteration(t *testing.T) {
+	tailer := NewTailer("/tmp/test.txt", DefaultTailerConfig())
+	tailer.inHeader = false
+
+	lines := []struct {
+		line      string
+		iteration int
+	}{
+		{"[26-01-22 10:00:00] before sections", 0},
+		{"--- task iteration 2 ---", 2},
+		{"[26-01-22 10:00:01] working", 2},
+		{"plain output", 2},
+		{"--- codex iteration 4 ---", 4},
+		{"[26-01-22 10:00:02] reviewing", 4},
+		{"--- finalize ---", 0},
+		{"[26-01-22 10:00:03] done", 0},
+	}
+	for _, l := range lines {
+		event := tailer.parseLine(l.line)
+		require.NotNil(t, event, l.line)
+		assert.Equal(t, l.iteration, event.Iteration, l.line)
+	}
+}
+
 func TestTailer_StartStop(t *testing.T) {
 	t.Run("starts and stops tailing", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -325,6 +401,360 @@ Started: 2026-01-22 10:30:00
 	})
 }
 
+func TestTailer_PollInterval(t *testing.T) {
+	// firstEventDelay starts tailing at the end of the file, appends a line and measures when its event arrives
+	firstEventDelay := func(t *testing.T, interval time.Duration) time.Duration {
+		t.Helper()
+		progressFile := filepath.Join(t.TempDir(), "progress-test.txt")
+		require.NoError(t, os.WriteFile(progressFile, []byte("header\n"+strings.Repeat("-", 60)+"\n"), 0o600))
+
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: interval})
+		require.NoError(t, tailer.Start(false))
+		defer tailer.Stop()
+
+		start := time.Now()
+		f, err := os.OpenFile(progressFile, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+		require.NoError(t, err)
+		_, err = f.WriteString("[26-01-22 10:30:01] new line\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		select {
+		case e := <-tailer.Events():
+			assert.Equal(t, "new line", e.Text)
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event received")
+		}
+		return time.Since(start)
+	}
+
+	assert.Less(t, firstEventDelay(t, 10*time.Millisecond), 200*time.Millisecond, "fast poll delivers quickly")
+	assert.GreaterOrEqual(t, firstEventDelay(t, 400*time.Millisecond), 300*time.Millisecond,
+		"slow poll waits for the next tick")
+}
+
+func TestTailer_TruncateAndRotate(t *testing.T) {
+	header := "# Ralphex Progress Log\nPlan: test.md\nBranch: main\nMode: full\n" + strings.Repeat("-", 60) + "\n\n"
+
+	// startTailer tails a progress file with a few lines and drains the events of the initial content
+	startTailer := func(t *testing.T) (*Tailer, string) {
+		t.Helper()
+		progressFile := filepath.Join(t.TempDir(), "progress-test.txt")
+		content := header + strings.Repeat("[26-01-22 10:30:01] some earlier output line\n", 10) +
+			"[26-01-22 10:30:02] last old line\n"
+		require.NoError(t, os.WriteFile(progressFile, []byte(content), 0o600))
+
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: 10 * time.Millisecond})
+		require.NoError(t, tailer.Start(true))
+		t.Cleanup(tailer.Stop)
+		waitForEventText(t, tailer, "last old line")
+		return tailer, progressFile
+	}
+
+	t.Run("truncated file", func(t *testing.T) {
+		tailer, progressFile := startTailer(t)
+		require.NoError(t, os.WriteFile(progressFile, []byte(header+"[26-01-22 11:00:00] new run\n"), 0o600))
+
+		marker := waitForEventText(t, tailer, "progress file truncated, reading from the start")
+		assert.Equal(t, EventTypeStatus, marker.Type)
+		e := waitForEventText(t, tailer, "new run")
+		assert.Equal(t, EventTypeOutput, e.Type, "header of the new content is skipped")
+		assert.Equal(t, int64(1), tailer.Resets())
+	})
+
+	t.Run("rotated file", func(t *testing.T) {
+		tailer, progressFile := startTailer(t)
+		require.NoError(t, os.Rename(progressFile, progressFile+".1"))
+		require.NoError(t, os.WriteFile(progressFile, []byte(header+"[26-01-22 11:00:00] new file\n"), 0o600))
+
+		waitForEventText(t, tailer, "progress file replaced, reading from the start")
+		waitForEventText(t, tailer, "new file")
+		assert.Equal(t, int64(1), tailer.Resets())
+
+		// appends to the new file keep flowing
+		f, err := os.OpenFile(progressFile, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+		require.NoError(t, err)
+		_, err = f.WriteString("[26-01-22 11:00:01] appended later\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		waitForEventText(t, tailer, "appended later")
+	})
+
+	t.Run("write racing the rotation", func(t *testing.T) {
+		progressFile := filepath.Join(t.TempDir(), "progress-test.txt")
+		require.NoError(t, os.WriteFile(progressFile, []byte(header+"[26-01-22 10:30:01] first line\n"), 0o600))
+
+		// the poll loop never ticks, reads are driven by the test
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: time.Hour})
+		require.NoError(t, tailer.Start(true))
+		t.Cleanup(tailer.Stop)
+		tailer.readNewLines()
+		waitForEventText(t, tailer, "first line")
+
+		// the logger writes to the old file after the tailer's read hit its end, then rotates it
+		// before the tailer checks the file identity
+		f, err := os.OpenFile(progressFile, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // test file
+		require.NoError(t, err)
+		_, err = f.WriteString("[26-01-22 10:30:02] written before rename\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.NoError(t, os.Rename(progressFile, progress.RotatedPath(progressFile, 1)))
+		require.NoError(t, os.WriteFile(progressFile, []byte(header+"[26-01-22 11:00:00] new file\n"), 0o600))
+		tailer.mu.Lock()
+		assert.True(t, tailer.reopenIfReplaced(0), "old file has more to read")
+		tailer.mu.Unlock()
+
+		tailer.readNewLines()
+		var texts []string
+		for len(tailer.Events()) > 0 {
+			if e := <-tailer.Events(); e.Type != EventTypeSection {
+				texts = append(texts, e.Text)
+			}
+		}
+		assert.Equal(t, []string{"written before rename", "progress file replaced, reading from the start", "new file"}, texts)
+	})
+
+	t.Run("removed file keeps tailer running", func(t *testing.T) {
+		tailer, progressFile := startTailer(t)
+		require.NoError(t, os.Remove(progressFile))
+		time.Sleep(50 * time.Millisecond)
+		assert.True(t, tailer.IsRunning())
+		assert.Zero(t, tailer.Resets())
+	})
+}
+
+func TestTailer_StartAt(t *testing.T) {
+	header := "# Ralphex Progress Log\nPlan: test.md\n" + strings.Repeat("-", 60) + "\n\n"
+	content := header + "[26-01-22 10:30:01] first line\n[26-01-22 10:30:02] second line\n"
+	progressFile := filepath.Join(t.TempDir(), "progress-test.txt")
+	require.NoError(t, os.WriteFile(progressFile, []byte(content), 0o600))
+	secondLine := int64(strings.Index(content, "[26-01-22 10:30:02]"))
+
+	// nextEvent returns the next event of the tailer
+	nextEvent := func(t *testing.T, tailer *Tailer) Event {
+		t.Helper()
+		select {
+		case e := <-tailer.Events():
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event")
+			return Event{}
+		}
+	}
+
+	t.Run("line boundary", func(t *testing.T) {
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: 10 * time.Millisecond})
+		require.NoError(t, tailer.StartAt(secondLine))
+		defer tailer.Stop()
+		assert.Equal(t, "second line", nextEvent(t, tailer).Text)
+		assert.Equal(t, int64(len(content)), waitForOffset(t, tailer, int64(len(content))))
+	})
+
+	t.Run("inside a line starts at the next one", func(t *testing.T) {
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: 10 * time.Millisecond})
+		require.NoError(t, tailer.StartAt(secondLine-5))
+		defer tailer.Stop()
+		assert.Equal(t, "second line", nextEvent(t, tailer).Text)
+	})
+
+	t.Run("beyond the end reads from the start", func(t *testing.T) {
+		tailer := NewTailer(progressFile, TailerConfig{PollInterval: 10 * time.Millisecond})
+		require.NoError(t, tailer.StartAt(int64(len(content))+100))
+		defer tailer.Stop()
+		marker := nextEvent(t, tailer)
+		assert.Equal(t, EventTypeStatus, marker.Type)
+		assert.Equal(t, "progress file truncated, reading from the start", marker.Text)
+		assert.Equal(t, "first line", nextEvent(t, tailer).Text, "header is skipped")
+		assert.Equal(t, int64(1), tailer.Resets())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		tailer := NewTailer(filepath.Join(t.TempDir(), "missing.txt"), TailerConfig{})
+		require.Error(t, tailer.StartAt(10))
+	})
+}
+
+// waitForOffset waits until the tailer has read up to offset and returns the offset reached.
+func waitForOffset(t *testing.T, tailer *Tailer, offset int64) int64 {
+	t.Helper()
+	require.Eventually(t, func() bool { return tailer.Offset() >= offset }, 2*time.Second, 5*time.Millisecond)
+	return tailer.Offset()
+}
+
+// waitForEventText reads tailer events until one with the given text arrives.
+func waitForEventText(t *testing.T, tailer *Tailer, text string) Event {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case e := <-tailer.Events():
+			if e.Text == text {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("no event with text %q", text)
+			return Event{}
+		}
+	}
+}
+
+func TestTailer_MaxLineLength(t *testing.T) {
+	progressFile := filepath.Join(t.TempDir(), "progress-test.txt")
+	long := strings.Repeat("x", 1000)
+	content := "header\n" + strings.Repeat("-", 60) + "\n" + long + "\n[26-01-22 10:30:01] after long line\n"
+	require.NoError(t, os.WriteFile(progressFile, []byte(content), 0o600))
+
+	tailer := NewTailer(progressFile, TailerConfig{PollInterval: 10 * time.Millisecond, ReadChunkSize: 16, MaxLineLength: 100})
+	require.NoError(t, tailer.Start(true))
+	defer tailer.Stop()
+
+	var events []Event
+	timeout := time.After(time.Second)
+	for len(events) < 2 {
+		select {
+		case e := <-tailer.Events():
+			events = append(events, e)
+		case <-timeout:
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+	}
+
+	assert.Equal(t, strings.Repeat("x", 100)+truncatedLineMarker, events[0].Text)
+	assert.Equal(t, "after long line", events[1].Text, "reading continues after a truncated line")
+}
+
+func TestTailer_JSONL(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	logger, err := progress.NewLogger(progress.Config{PlanFile: "plan.md", Mode: "full", Branch: "main", NoColor: true,
+		Format: progress.FormatJSONL}, testColors())
+	require.NoError(t, err)
+	logger.PrintSection(processor.NewTaskIterationSection(1))
+	logger.PrintAligned("working\n<<<RALPHEX:ALL_TASKS_DONE>>>")
+	logger.SetPhase(processor.PhaseReview)
+	logger.Error("oops")
+	logger.LogError(errors.New("finalize step failed"), true)
+	require.NoError(t, logger.Close())
+
+	tailer := NewTailer(filepath.Join(dir, logger.Path()), TailerConfig{PollInterval: 10 * time.Millisecond})
+	require.NoError(t, tailer.Start(true))
+	defer tailer.Stop()
+	assert.True(t, tailer.jsonl)
+
+	var events []Event
+	timeout := time.After(time.Second)
+	for len(events) < 6 {
+		select {
+		case e := <-tailer.Events():
+			events = append(events, e)
+		case <-timeout:
+			t.Fatalf("expected 6 events, got %d", len(events))
+		}
+	}
+
+	assert.Equal(t, EventTypeSection, events[0].Type)
+	assert.Equal(t, "task iteration 1", events[0].Section)
+	assert.Equal(t, EventTypeOutput, events[1].Type)
+	assert.Equal(t, "working", events[1].Text)
+	assert.Equal(t, EventTypeSignal, events[2].Type)
+	assert.Equal(t, "COMPLETED", events[2].Signal)
+	assert.Equal(t, EventTypeError, events[3].Type)
+	assert.Equal(t, processor.PhaseReview, events[3].Phase)
+	assert.Equal(t, "ERROR: oops", events[3].Text)
+	assert.False(t, events[3].Recoverable)
+	assert.Equal(t, EventTypeError, events[4].Type)
+	assert.Equal(t, "ERROR: finalize step failed", events[4].Text)
+	assert.True(t, events[4].Recoverable)
+	assert.Equal(t, EventTypeOutput, events[5].Type)
+	assert.True(t, strings.HasPrefix(events[5].Text, "Completed: "), "footer becomes completion line")
+}
+
+func TestTailer_BOMAndCRLF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress-test.jsonl")
+	content := progress.BOM + `{"type":"header","timestamp":"2026-01-22T10:30:00Z","header":{"plan":"plan.md","mode":"full"}}` +
+		"\r\n" + `{"type":"output","timestamp":"2026-01-22T10:30:05Z","phase":"task","text":"working"}` + "\r\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	tailer := NewTailer(path, TailerConfig{PollInterval: 10 * time.Millisecond})
+	require.NoError(t, tailer.Start(true))
+	defer tailer.Stop()
+	assert.True(t, tailer.jsonl, "byte order mark doesn't hide the jsonl format")
+
+	select {
+	case e := <-tailer.Events():
+		assert.Equal(t, EventTypeOutput, e.Type)
+		assert.Equal(t, "working", e.Text, "header record is skipped, not shown as malformed output")
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestEventFromRecord(t *testing.T) {
+	ts := time.Date(2026, 1, 22, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		rec    progress.Record
+		want   Event
+		wantOK bool
+	}{
+		{name: "header skipped", rec: progress.Record{Type: progress.RecordHeader, Header: &progress.Header{Mode: "full"}}},
+		{name: "output", rec: progress.Record{Type: progress.RecordOutput, Phase: processor.PhaseCodex, Text: "hi", Timestamp: ts},
+			want: Event{Type: EventTypeOutput, Phase: processor.PhaseCodex, Text: "hi", Timestamp: ts}, wantOK: true},
+		{name: "section without phase", rec: progress.Record{Type: progress.RecordSection, Section: "codex iteration 1",
+			Text: "codex iteration 1", Timestamp: ts},
+			want: Event{Type: EventTypeSection, Phase: processor.PhaseCodex, Section: "codex iteration 1",
+				Text: "codex iteration 1", Timestamp: ts}, wantOK: true},
+		{name: "warn", rec: progress.Record{Type: progress.RecordWarn, Phase: processor.PhaseTask, Text: "WARN: x", Timestamp: ts},
+			want: Event{Type: EventTypeWarn, Phase: processor.PhaseTask, Text: "WARN: x", Timestamp: ts}, wantOK: true},
+		{name: "signal normalized", rec: progress.Record{Type: progress.RecordSignal, Phase: processor.PhaseTask,
+			Signal: "TASK_FAILED", Text: "<<<RALPHEX:TASK_FAILED>>>", Timestamp: ts},
+			want: Event{Type: EventTypeSignal, Phase: processor.PhaseTask, Signal: "FAILED",
+				Text: "<<<RALPHEX:TASK_FAILED>>>", Timestamp: ts}, wantOK: true},
+		{name: "human action", rec: progress.Record{Type: progress.RecordHumanAction, Phase: processor.PhaseTask,
+			Actor: "web", Text: "HUMAN ACTION (web): paused", Timestamp: ts},
+			want: Event{Type: EventTypeHumanAction, Phase: processor.PhaseTask, Actor: "web",
+				Text: "HUMAN ACTION (web): paused", Timestamp: ts}, wantOK: true},
+		{name: "plan ready", rec: progress.Record{Type: progress.RecordPlanReady, Phase: processor.PhasePlan,
+			PlanFile: "docs/plans/x.md", Text: "PLAN READY: docs/plans/x.md", Timestamp: ts},
+			want: Event{Type: EventTypePlanReady, Phase: processor.PhasePlan, PlanFile: "docs/plans/x.md",
+				Text: "PLAN READY: docs/plans/x.md", Timestamp: ts}, wantOK: true},
+		{name: "footer", rec: progress.Record{Type: progress.RecordFooter, Text: "5 minutes", Timestamp: ts},
+			want: Event{Type: EventTypeOutput, Phase: processor.PhaseTask, Text: "Completed: 2026-01-22 10:30:00 (5 minutes)",
+				Timestamp: ts}, wantOK: true},
+		{name: "canceled footer", rec: progress.Record{Type: progress.RecordFooter, Text: "5 minutes",
+			Outcome: progress.OutcomeCanceled, Timestamp: ts},
+			want: Event{Type: EventTypeOutput, Phase: processor.PhaseTask, Text: "Canceled: 2026-01-22 10:30:00 (5 minutes)",
+				Timestamp: ts}, wantOK: true},
+		{name: "unknown type is output", rec: progress.Record{Type: "future", Text: "x", Timestamp: ts},
+			want: Event{Type: EventTypeOutput, Phase: processor.PhaseTask, Text: "x", Timestamp: ts}, wantOK: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := eventFromRecord(tc.rec)
+			assert.Equal(t, tc.wantOK, ok)
+			if tc.wantOK {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTailer_ParseRecordLine_Malformed(t *testing.T) {
+	tailer := NewTailer("/tmp/test.txt", DefaultTailerConfig())
+	tailer.jsonl = true
+
+	event := tailer.parseLine(`{"type":"output","text":"cut` + truncatedLineMarker)
+	require.NotNil(t, event)
+	assert.Equal(t, EventTypeOutput, event.Type)
+	assert.Equal(t, `{"type":"output","text":"cut`+truncatedLineMarker, event.Text)
+}
+
 func TestTailer_Stop(t *testing.T) {
 	t.Run("stop before start is safe", func(t *testing.T) {
 		tailer := NewTailer("/nonexistent", DefaultTailerConfig())
@@ -389,6 +819,39 @@ func TestTailer_Stop(t *testing.T) {
 	})
 }
 
+func TestTailer_PausesWhileConsumerIsBehind(t *testing.T) {
+	var content strings.Builder
+	content.WriteString("# Ralphex Progress Log\n" + strings.Repeat("-", 60) + "\n")
+	for i := range tailerBufferSize + 50 {
+		fmt.Fprintf(&content, "[26-01-22 10:30:45] line %d\n", i)
+	}
+	content.WriteString("--- claude review 1 ---\n")
+	path := filepath.Join(t.TempDir(), "progress-test.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content.String()), 0o600))
+
+	tailer := NewTailer(path, TailerConfig{PollInterval: 5 * time.Millisecond})
+	require.NoError(t, tailer.Start(true))
+	defer tailer.Stop()
+
+	// nothing is consumed for a few polls, the channel fills up and reading pauses
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, tailer.Events(), tailerBufferSize)
+
+	var events []Event
+	for len(events) < tailerBufferSize+51 {
+		select {
+		case e := <-tailer.Events():
+			events = append(events, e)
+		case <-time.After(time.Second):
+			require.Fail(t, "no more events", "got %d events", len(events))
+		}
+	}
+	for i := range tailerBufferSize + 50 {
+		assert.Equal(t, fmt.Sprintf("line %d", i), events[i].Text, "no output line is dropped")
+	}
+	assert.Equal(t, EventTypeSection, events[len(events)-1].Type, "section must survive backpressure")
+}
+
 func TestDetectEventType(t *testing.T) {
 	tests := []struct {
 		text     string
@@ -398,8 +861,12 @@ func TestDetectEventType(t *testing.T) {
 		{"error: lowercase", EventTypeError},
 		{"WARN: be careful", EventTypeWarn},
 		{"warn: lowercase", EventTypeWarn},
+		{"[debug] non-JSON line: x", EventTypeDebug},
 		{"<<<RALPHEX:COMPLETED>>>", EventTypeSignal},
 		{"ALL_TASKS_DONE", EventTypeSignal},
+		{"HUMAN ACTION (user): canceled run", EventTypeHumanAction},
+		{"HUMAN ACTION without actor", EventTypeOutput},
+		{"PLAN READY: docs/plans/feature.md", EventTypePlanReady},
 		{"normal output", EventTypeOutput},
 	}
 
@@ -0,0 +1,42 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+)
+
+// BuildInfo identifies the running binary, set by the main package from its -ldflags variables.
+type BuildInfo struct {
+	Version   string // release version or revision
+	Commit    string // git commit the binary was built from
+	BuildDate string // build timestamp
+}
+
+// versionInfo is the response body of the version endpoint.
+type versionInfo struct {
+	Version           string   `json:"version"`
+	Commit            string   `json:"commit"`
+	BuildDate         string   `json:"build_date"`
+	GoVersion         string   `json:"go_version"`
+	ConfigSourcePaths []string `json:"config_source_paths"`
+}
+
+// handleVersion reports the build info of the running server and the config files it loaded,
+// so support triage doesn't depend on what the user remembers installing.
+func (s *Server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	info := versionInfo{
+		Version:           s.cfg.BuildInfo.Version,
+		Commit:            s.cfg.BuildInfo.Commit,
+		BuildDate:         s.cfg.BuildInfo.BuildDate,
+		GoVersion:         runtime.Version(),
+		ConfigSourcePaths: s.cfg.ConfigSources,
+	}
+	if info.ConfigSourcePaths == nil {
+		info.ConfigSourcePaths = []string{} // encode as empty list, embedded defaults only
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(info)
+}
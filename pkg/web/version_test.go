@@ -0,0 +1,47 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HandleVersion(t *testing.T) {
+	t.Run("build info and config sources", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080,
+			BuildInfo:     BuildInfo{Version: "v1.2.3", Commit: "abc1234", BuildDate: "20260122T103000"},
+			ConfigSources: []string{"/home/user/.config/ralphex/config", "/repo/.ralphex/config.toml"},
+		}, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		srv.handleVersion(w, httptest.NewRequest(http.MethodGet, "/api/version", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, map[string]any{
+			"version":             "v1.2.3",
+			"commit":              "abc1234",
+			"build_date":          "20260122T103000",
+			"go_version":          runtime.Version(),
+			"config_source_paths": []any{"/home/user/.config/ralphex/config", "/repo/.ralphex/config.toml"},
+		}, body)
+	})
+
+	t.Run("embedded defaults only", func(t *testing.T) {
+		srv, err := NewServer(ServerConfig{Port: 8080}, nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		srv.handleVersion(w, httptest.NewRequest(http.MethodGet, "/api/version", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"config_source_paths":[]`)
+	})
+}
@@ -6,13 +6,23 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
+// sessionPruneInterval is how often the watcher prunes stale completed sessions.
+const sessionPruneInterval = time.Hour
+
+// maxWatchDepth is how many directory levels below each watch directory are scanned
+// for progress files in recursive mode, it keeps huge trees from being walked entirely.
+const maxWatchDepth = 10
+
 // Watcher monitors directories for progress file changes.
 // it uses fsnotify for efficient file system event detection
 // and notifies the SessionManager when new progress files appear.
@@ -21,12 +31,16 @@ type Watcher struct {
 	sm      *SessionManager
 	watcher *fsnotify.Watcher
 
+	retention     time.Duration // prune completed sessions idle longer than this, 0 disables pruning
+	pruneInterval time.Duration // how often stale sessions are pruned
+	depth         int           // directory levels below each watch dir to scan, 0 scans only the dirs themselves
+
 	mu      sync.Mutex
 	started bool
 }
 
 // NewWatcher creates a watcher for the specified directories.
-// directories are watched recursively for progress-*.txt files.
+// directories are watched recursively for progress-*.txt files, up to maxWatchDepth levels deep.
 func NewWatcher(dirs []string, sm *SessionManager) (*Watcher, error) {
 	w, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -34,9 +48,11 @@ func NewWatcher(dirs []string, sm *SessionManager) (*Watcher, error) {
 	}
 
 	return &Watcher{
-		dirs:    dirs,
-		sm:      sm,
-		watcher: w,
+		dirs:          dirs,
+		sm:            sm,
+		watcher:       w,
+		pruneInterval: sessionPruneInterval,
+		depth:         maxWatchDepth,
 	}, nil
 }
 
@@ -61,14 +77,18 @@ func (w *Watcher) Start(ctx context.Context) error {
 
 	// initial discovery (recursive to find existing progress files in subdirectories)
 	for _, dir := range w.dirs {
-		if _, err := w.sm.DiscoverRecursive(dir); err != nil {
-			log.Printf("[WARN] initial discovery failed for %s: %v", dir, err)
+		if _, err := w.sm.DiscoverRecursive(dir, w.depth); err != nil {
+			log.Printf("[WARN] initial discovery of %s incomplete: %v", dir, err)
 		}
 	}
+	w.sm.MarkDiscovered()
 
 	// start tailing for active sessions
 	w.sm.StartTailingActive()
 
+	// drop sessions that went stale while the dashboard wasn't running
+	w.pruneStale()
+
 	// start periodic state refresh to detect completed sessions
 	go w.refreshLoop(ctx)
 
@@ -88,6 +108,9 @@ func (w *Watcher) addRecursive(dir string) error {
 		}
 
 		if d.IsDir() {
+			if w.watchDepth(path) > w.depth {
+				return filepath.SkipDir
+			}
 			name := d.Name()
 			// skip hidden directories
 			if strings.HasPrefix(name, ".") && path != dir {
@@ -147,8 +170,18 @@ func (w *Watcher) handleEvent(event fsnotify.Event) {
 		w.handleProgressFileChange(event.Name)
 	}
 
-	// handle remove events
+	// handle remove events, a file replaced at the same path, e.g. on rotation, keeps its session,
+	// and so does a file replaced by its compressed variant
 	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		if _, err := os.Stat(event.Name); err == nil {
+			return
+		}
+		if compressed := event.Name + progress.CompressedSuffix; !progress.IsCompressed(event.Name) {
+			if _, err := os.Stat(compressed); err == nil {
+				w.handleProgressFileChange(compressed)
+				return
+			}
+		}
 		id := sessionIDFromPath(event.Name)
 		w.sm.Remove(id)
 	}
@@ -160,7 +193,7 @@ func (w *Watcher) handleNonProgressEvent(event fsnotify.Event) {
 		return
 	}
 	info, err := os.Stat(event.Name)
-	if err != nil || !info.IsDir() {
+	if err != nil || !info.IsDir() || w.watchDepth(event.Name) > w.depth {
 		return
 	}
 	if err := w.addRecursive(event.Name); err != nil {
@@ -168,6 +201,25 @@ func (w *Watcher) handleNonProgressEvent(event fsnotify.Event) {
 	}
 }
 
+// watchDepth returns how many directory levels path is below the closest watch directory containing it.
+func (w *Watcher) watchDepth(path string) int {
+	depth := -1
+	for _, dir := range w.dirs {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		levels := 0
+		if rel != "." {
+			levels = strings.Count(rel, string(filepath.Separator)) + 1
+		}
+		if depth < 0 || levels < depth {
+			depth = levels
+		}
+	}
+	return max(depth, 0)
+}
+
 // handleProgressFileChange handles create/write events for progress files.
 func (w *Watcher) handleProgressFileChange(path string) {
 	dir := filepath.Dir(path)
@@ -197,22 +249,41 @@ func (w *Watcher) startTailingIfNeeded(id string) {
 	}
 }
 
-// refreshLoop periodically checks for session state changes (active->completed).
+// refreshLoop periodically checks for session state changes (active->completed)
+// and prunes stale completed sessions if retention is set.
 // runs until context is canceled.
 func (w *Watcher) refreshLoop(ctx context.Context) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
+	pruneTicker := time.NewTicker(w.pruneInterval)
+	defer pruneTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			w.sm.RefreshStates()
+		case <-pruneTicker.C:
+			w.pruneStale()
 		}
 	}
 }
 
+// pruneStale removes completed sessions older than the retention period.
+// does nothing if retention is not set.
+func (w *Watcher) pruneStale() []string {
+	if w.retention <= 0 {
+		return nil
+	}
+	pruned := w.sm.PruneOlderThan(w.retention)
+	if len(pruned) > 0 {
+		log.Printf("[INFO] pruned %d stale sessions: %s", len(pruned), strings.Join(pruned, ", "))
+	}
+	return pruned
+}
+
 // Close stops the watcher and releases resources.
 func (w *Watcher) Close() error {
 	if err := w.watcher.Close(); err != nil {
@@ -221,10 +292,30 @@ func (w *Watcher) Close() error {
 	return nil
 }
 
-// isProgressFile returns true if the path matches progress-*.txt pattern.
+// isProgressFile returns true if the path matches progress-*.txt pattern, or progress-*.txt.gz once compressed,
+// and is not a rotated part of another file.
 func isProgressFile(path string) bool {
-	name := filepath.Base(path)
-	return strings.HasPrefix(name, "progress-") && strings.HasSuffix(name, ".txt")
+	name := strings.TrimSuffix(filepath.Base(path), progress.CompressedSuffix)
+	return strings.HasPrefix(name, "progress-") && strings.HasSuffix(name, ".txt") && !isRotatedPart(path)
+}
+
+// rotatedPartRe matches progress file parts rotated out by the logger, see progress.RotatedPath
+var rotatedPartRe = regexp.MustCompile(`^(.+)\.\d+(\.txt)$`)
+
+// isRotatedPart returns true if the path is a part rotated out of a progress file, e.g. progress-plan.1.txt.
+// the name alone is ambiguous, a plan can be named v1.2, so the file it was rotated from must exist,
+// either as is or compressed. parts keep their uncompressed names when the file is compressed.
+func isRotatedPart(path string) bool {
+	m := rotatedPartRe.FindStringSubmatch(path)
+	if m == nil {
+		return false
+	}
+	for _, base := range []string{m[1] + m[2], m[1] + m[2] + progress.CompressedSuffix} {
+		if _, err := os.Stat(base); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
 // ResolveWatchDirs determines the directories to watch based on precedence:
@@ -249,11 +340,16 @@ func ResolveWatchDirs(cliDirs, configDirs []string) []string {
 	return []string{cwd}
 }
 
-// normalizeDirs converts relative paths to absolute and removes duplicates.
+// normalizeDirs expands globs, converts relative paths to absolute and removes duplicates.
 // logs warnings for invalid directories to help users debug configuration issues.
-func normalizeDirs(dirs []string) []string {
+func normalizeDirs(entries []string) []string {
 	seen := make(map[string]bool)
-	result := make([]string, 0, len(dirs))
+	result := make([]string, 0, len(entries))
+
+	var dirs []string
+	for _, entry := range entries {
+		dirs = append(dirs, expandWatchDir(entry)...)
+	}
 
 	for _, dir := range dirs {
 		// convert to absolute path
@@ -299,3 +395,32 @@ func normalizeDirs(dirs []string) []string {
 
 	return result
 }
+
+// expandWatchDir expands a leading ~ to the home directory and a glob pattern to the matching directories,
+// e.g. ~/projects/* to every project directory. entries without glob characters are returned as is.
+func expandWatchDir(entry string) []string {
+	if entry == "~" || strings.HasPrefix(entry, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			entry = filepath.Join(home, entry[1:])
+		}
+	}
+	if !strings.ContainsAny(entry, "*?[") {
+		return []string{entry}
+	}
+
+	matches, err := filepath.Glob(entry)
+	if err != nil {
+		log.Printf("[WARN] invalid watch directory pattern %q: %v", entry, err)
+		return nil
+	}
+	dirs := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if info, statErr := os.Stat(m); statErr == nil && info.IsDir() {
+			dirs = append(dirs, m)
+		}
+	}
+	if len(dirs) == 0 {
+		log.Printf("[WARN] watch directory pattern %q matches no directories", entry)
+	}
+	return dirs
+}
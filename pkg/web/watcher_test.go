@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/ralphex/pkg/progress"
 )
 
 // resolveSymlinks resolves symlinks in the given path for test comparison.
@@ -29,6 +31,8 @@ func TestIsProgressFile(t *testing.T) {
 		{"progress-my-plan.txt", true},
 		{"/some/path/progress-test.txt", true},
 		{"/some/path/progress-.txt", true},
+		{"progress-test.txt.gz", true},
+		{"progress-test.gz", false},
 		{"test.txt", false},
 		{"progress.txt", false},
 		{"progress-test.log", false},
@@ -136,6 +140,99 @@ func TestNormalizeDirs_RelativePaths(t *testing.T) {
 	assert.Equal(t, resolveSymlinks(t, subDir), result[0])
 }
 
+func TestNormalizeDirs_Globs(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"app1", "app2", "other"} {
+		require.NoError(t, os.Mkdir(filepath.Join(tmpDir, name), 0o750))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.txt"), nil, 0o600)) // not a directory
+
+	t.Run("expands pattern to directories", func(t *testing.T) {
+		result := normalizeDirs([]string{filepath.Join(tmpDir, "app*")})
+		assert.Equal(t, []string{resolveSymlinks(t, filepath.Join(tmpDir, "app1")),
+			resolveSymlinks(t, filepath.Join(tmpDir, "app2"))}, result)
+	})
+
+	t.Run("deduplicates overlapping entries", func(t *testing.T) {
+		result := normalizeDirs([]string{filepath.Join(tmpDir, "app1"), filepath.Join(tmpDir, "*"),
+			filepath.Join(tmpDir, "app?")})
+		assert.Equal(t, []string{resolveSymlinks(t, filepath.Join(tmpDir, "app1")),
+			resolveSymlinks(t, filepath.Join(tmpDir, "app2")), resolveSymlinks(t, filepath.Join(tmpDir, "other"))}, result)
+	})
+
+	t.Run("expands home directory", func(t *testing.T) {
+		t.Setenv("HOME", tmpDir)
+		result := normalizeDirs([]string{"~/other", "~/app*"})
+		assert.Equal(t, []string{resolveSymlinks(t, filepath.Join(tmpDir, "other")),
+			resolveSymlinks(t, filepath.Join(tmpDir, "app1")), resolveSymlinks(t, filepath.Join(tmpDir, "app2"))}, result)
+	})
+
+	t.Run("pattern without matches falls back to cwd", func(t *testing.T) {
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, []string{cwd}, normalizeDirs([]string{filepath.Join(tmpDir, "missing*")}))
+		assert.Equal(t, []string{cwd}, normalizeDirs([]string{filepath.Join(tmpDir, "[")}))
+	})
+}
+
+func TestWatcher_DiscoveryDepth(t *testing.T) {
+	tmpDir := t.TempDir()
+	deepDir := tmpDir
+	for range maxWatchDepth + 1 {
+		deepDir = filepath.Join(deepDir, "d")
+	}
+	paths := map[string]string{
+		"top":    filepath.Join(tmpDir, "progress-top.txt"),
+		"nested": filepath.Join(tmpDir, "a", "b", "progress-nested.txt"),
+		"deep":   filepath.Join(deepDir, "progress-deep.txt"),
+	}
+	for name, path := range paths {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		createProgressFile(t, path, name+".md", "main", "full")
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		want      []string
+	}{
+		{name: "recursive stops at depth limit", recursive: true, want: []string{"top", "nested"}},
+		{name: "non-recursive scans only the watch dir", recursive: false, want: []string{"top"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := NewSessionManager()
+			defer sm.Close()
+			w, err := NewWatcher([]string{tmpDir}, sm)
+			require.NoError(t, err)
+			if !tc.recursive {
+				w.depth = 0
+			}
+			go func() {
+				_ = w.Start(t.Context())
+			}()
+			require.Eventually(t, sm.Discovered, time.Second, 10*time.Millisecond)
+
+			var got []string
+			for _, name := range []string{"top", "nested", "deep"} {
+				if sm.Get(sessionIDFromPath(paths[name])) != nil {
+					got = append(got, name)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWatcher_WatchDepth(t *testing.T) {
+	w := &Watcher{dirs: []string{"/work", "/work/app"}}
+	assert.Equal(t, 0, w.watchDepth("/work"))
+	assert.Equal(t, 1, w.watchDepth("/work/lib"))
+	assert.Equal(t, 1, w.watchDepth("/work/app/src"), "closest watch dir wins")
+	assert.Equal(t, 2, w.watchDepth("/work/lib/x"))
+	assert.Equal(t, 0, w.watchDepth("/elsewhere"))
+}
+
 func TestWatcher_NewWatcher(t *testing.T) {
 	tmpDir := t.TempDir()
 	sm := NewSessionManager()
@@ -217,6 +314,32 @@ Started: 2026-01-22 10:00:00
 	assert.Equal(t, expectedID, session.ID)
 }
 
+func TestWatcher_PrunesStaleSessionsOnStart(t *testing.T) {
+	tmpDir := t.TempDir()
+	stale := filepath.Join(tmpDir, "progress-stale.txt")
+	fresh := filepath.Join(tmpDir, "progress-fresh.txt")
+	createProgressFile(t, stale, "stale.md", "main", "full")
+	createProgressFile(t, fresh, "fresh.md", "main", "full")
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	sm := NewSessionManager()
+	defer sm.Close()
+	w, err := NewWatcher([]string{tmpDir}, sm)
+	require.NoError(t, err)
+	w.retention = 24 * time.Hour
+
+	go func() {
+		_ = w.Start(t.Context())
+	}()
+
+	require.Eventually(t, func() bool {
+		_, statErr := os.Stat(stale)
+		return os.IsNotExist(statErr)
+	}, time.Second, 10*time.Millisecond, "stale progress file should be pruned")
+	assert.FileExists(t, fresh)
+}
+
 func TestWatcher_IgnoresNonProgressFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 	sm := NewSessionManager()
@@ -286,6 +409,32 @@ Started: 2026-01-22 10:00:00
 	require.NotNil(t, session, "session in subdirectory should be discovered")
 }
 
+func TestWatcher_NonRecursiveIgnoresNewSubdirectories(t *testing.T) {
+	tmpDir := t.TempDir()
+	sm := NewSessionManager()
+	defer sm.Close()
+	w, err := NewWatcher([]string{tmpDir}, sm)
+	require.NoError(t, err)
+	w.depth = 0
+
+	go func() {
+		_ = w.Start(t.Context())
+	}()
+	require.Eventually(t, sm.Discovered, time.Second, 10*time.Millisecond)
+
+	subDir := filepath.Join(tmpDir, "sub")
+	require.NoError(t, os.Mkdir(subDir, 0o750))
+	time.Sleep(100 * time.Millisecond) // let the watcher see the new directory
+
+	nested := filepath.Join(subDir, "progress-nested.txt")
+	createProgressFile(t, nested, "nested.md", "main", "full")
+	top := filepath.Join(tmpDir, "progress-top.txt")
+	createProgressFile(t, top, "top.md", "main", "full")
+
+	require.Eventually(t, func() bool { return sm.Get(sessionIDFromPath(top)) != nil }, time.Second, 10*time.Millisecond)
+	assert.Nil(t, sm.Get(sessionIDFromPath(nested)), "subdirectory should not be watched")
+}
+
 func TestWatcher_HandlesDeletedProgressFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	sm := NewSessionManager()
@@ -332,6 +481,31 @@ Started: 2026-01-22 10:00:00
 	assert.Nil(t, session, "session should be removed after file deletion")
 }
 
+func TestWatcher_KeepsSessionOfCompressedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	sm := NewSessionManager()
+	defer sm.Close()
+
+	progressFile := filepath.Join(tmpDir, "progress-compress-test.txt")
+	createProgressFile(t, progressFile, "compress-plan.md", "compress-branch", "full")
+	sessionID := sessionIDFromPath(progressFile)
+
+	w, err := NewWatcher([]string{tmpDir}, sm)
+	require.NoError(t, err)
+	go func() {
+		_ = w.Start(t.Context())
+	}()
+	require.Eventually(t, func() bool { return sm.Get(sessionID) != nil }, time.Second, 10*time.Millisecond)
+	session := sm.Get(sessionID)
+
+	gzPath, err := progress.CompressFile(progressFile)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return session.GetPath() == gzPath }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond) // let the watcher process the remaining events
+	assert.Same(t, session, sm.Get(sessionID), "session should survive compression under the same ID")
+}
+
 func TestWatcher_SkipsHiddenDirectories(t *testing.T) {
 	tmpDir := t.TempDir()
 	hiddenDir := filepath.Join(tmpDir, ".hidden")